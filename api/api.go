@@ -34,6 +34,7 @@ const (
 	SpecAggregationLevel     = "aggregation_level"
 	SpecDedupe               = "dedupe"
 	SpecPassphrase           = "secret_key"
+	SpecSecretName           = "secret_name"
 	SpecAutoAggregationValue = "auto"
 	SpecGroup                = "group"
 	SpecGroupEnforce         = "fg"
@@ -99,6 +100,23 @@ const (
 	OptBkupOpState = "OpState"
 	// OptBackupSchedUUID is the UUID of the backup-schedule
 	OptBackupSchedUUID = "BkupSchedUUID"
+	// OptAsync query parameter used to run an operation as a job
+	OptAsync = "Async"
+)
+
+// Credential types accepted in OptCredType.
+const (
+	// CredTypeS3 is an S3 compatible object store
+	CredTypeS3 = "s3"
+	// CredTypeGoogle is Google cloud storage
+	CredTypeGoogle = "google"
+	// CredTypeAzure is Azure blob storage
+	CredTypeAzure = "azure"
+)
+
+const (
+	// LabelGroupSnapID links the snapshots of a group snapshot
+	LabelGroupSnapID = "group_snap_id"
 )
 
 // Api clientserver Constants
@@ -107,9 +125,36 @@ const (
 	OsdSnapshotPath = "osd-snapshot"
 	OsdCredsPath    = "osd-creds"
 	OsdBackupPath   = "osd-backup"
+	OsdJobPath      = "osd-jobs"
+	OsdWatchPath    = "osd-watch"
+	OsdQuotaPath    = "osd-quotas"
 	TimeLayout      = "Jan 2 15:04:05 UTC 2006"
 )
 
+// Cloud backup operation types, statuses and requested states.
+const (
+	// BackupOpBackup is the OpType of a backup operation
+	BackupOpBackup = "Backup"
+	// BackupOpRestore is the OpType of a restore operation
+	BackupOpRestore = "Restore"
+	// BackupStatusActive indicates the operation is in progress
+	BackupStatusActive = "Active"
+	// BackupStatusPaused indicates the operation was paused
+	BackupStatusPaused = "Paused"
+	// BackupStatusStopped indicates the operation was stopped
+	BackupStatusStopped = "Stopped"
+	// BackupStatusDone indicates the operation completed successfully
+	BackupStatusDone = "Done"
+	// BackupStatusFailed indicates the operation failed
+	BackupStatusFailed = "Failed"
+	// BackupStatePause requests an active operation to pause
+	BackupStatePause = "pause"
+	// BackupStateResume requests a paused operation to resume
+	BackupStateResume = "resume"
+	// BackupStateStop requests an operation to stop
+	BackupStateStop = "stop"
+)
+
 const (
 	// AutoAggregation value indicates driver to select aggregation level.
 	AutoAggregation = math.MaxUint32
@@ -410,6 +455,30 @@ type BackupSchedEnumerateResponse struct {
 	SchedEnumerateErr string
 }
 
+// GroupSnapCreateRequest requests snapshots of several volumes taken at
+// the same point in time.
+type GroupSnapCreateRequest struct {
+	// Group whose volumes are snapshotted, if Ids is empty
+	Group string
+	// Ids of the volumes to snapshot
+	Ids []string
+	// Labels added to every snapshot
+	Labels map[string]string
+	// Readonly snapshots are taken if set
+	Readonly bool
+	// QuiesceTimeoutSec bounds how long volumes stay quiesced
+	QuiesceTimeoutSec uint64
+}
+
+type GroupSnapCreateResponse struct {
+	// Id of the group snapshot, the LabelGroupSnapID of its snapshots
+	Id string
+	// Snapshots maps the volume IDs to the IDs of their snapshots
+	Snapshots map[string]string
+	// GroupSnapCreateErr is set if no snapshot was taken
+	GroupSnapCreateErr string
+}
+
 // DriverTypeSimpleValueOf returns the string format of DriverType
 func DriverTypeSimpleValueOf(s string) (DriverType, error) {
 	obj, err := simpleValueOf("driver_type", DriverType_value, s)
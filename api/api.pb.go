@@ -33,6 +33,58 @@ It has these top-level messages:
 	ClusterResponse
 	ActiveRequest
 	ActiveRequests
+	StorageNode
+	StorageCluster
+	SdkVolumeCreateRequest
+	SdkVolumeCreateResponse
+	SdkVolumeInspectRequest
+	SdkVolumeInspectResponse
+	SdkVolumeEnumerateRequest
+	SdkVolumeEnumerateResponse
+	SdkVolumeUpdateRequest
+	SdkVolumeUpdateResponse
+	SdkVolumeDeleteRequest
+	SdkVolumeDeleteResponse
+	SdkVolumeStatsRequest
+	SdkVolumeStatsResponse
+	SdkSnapshotCreateRequest
+	SdkSnapshotCreateResponse
+	SdkSnapshotEnumerateRequest
+	SdkSnapshotEnumerateResponse
+	SdkSnapshotRestoreRequest
+	SdkSnapshotRestoreResponse
+	SdkCredentialCreateRequest
+	SdkCredentialCreateResponse
+	SdkCredentialEnumerateRequest
+	SdkCredentialEnumerateResponse
+	SdkCredentialDeleteRequest
+	SdkCredentialDeleteResponse
+	SdkCredentialValidateRequest
+	SdkCredentialValidateResponse
+	SdkCloudBackupCreateRequest
+	SdkCloudBackupCreateResponse
+	SdkCloudBackupRestoreRequest
+	SdkCloudBackupRestoreResponse
+	SdkCloudBackupEnumerateRequest
+	SdkCloudBackupInfo
+	SdkCloudBackupEnumerateResponse
+	SdkCloudBackupDeleteRequest
+	SdkCloudBackupDeleteResponse
+	SdkCloudBackupStatusRequest
+	SdkCloudBackupStatus
+	SdkCloudBackupStatusResponse
+	SdkClusterEnumerateRequest
+	SdkClusterEnumerateResponse
+	SdkClusterInspectRequest
+	SdkClusterInspectResponse
+	SdkClusterAlertEnumerateRequest
+	SdkClusterAlertEnumerateResponse
+	SdkClusterAlertClearRequest
+	SdkClusterAlertClearResponse
+	SdkClusterAlertEraseRequest
+	SdkClusterAlertEraseResponse
+	SdkWatchRequest
+	SdkWatchEvent
 */
 package api
 
@@ -40,6 +92,12 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
+import _ "google.golang.org/genproto/googleapis/api/annotations"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -889,6 +947,9 @@ type VolumeSpec struct {
 	Journal bool `protobuf:"varint,25,opt,name=journal" json:"journal,omitempty"`
 	// Nfs is true if this volume can be accessed via nfs.
 	Nfs bool `protobuf:"varint,26,opt,name=nfs" json:"nfs,omitempty"`
+	// SecretName names the secret in the secrets store an encrypted volume
+	// is secured with.
+	SecretName string `protobuf:"bytes,27,opt,name=secret_name,json=secretName" json:"secret_name,omitempty"`
 }
 
 func (m *VolumeSpec) Reset()                    { *m = VolumeSpec{} }
@@ -1064,6 +1125,13 @@ func (m *VolumeSpec) GetNfs() bool {
 	return false
 }
 
+func (m *VolumeSpec) GetSecretName() string {
+	if m != nil {
+		return m.SecretName
+	}
+	return ""
+}
+
 // ReplicaSet set of machine IDs (nodes) to which part of this volume is erasure
 // coded - for clustered storage arrays
 // swagger:model
@@ -1563,7 +1631,6 @@ type VolumeResponse struct {
 	// Error message
 	//
 	// in: body
-	// Required: true
 	Error string `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
 }
 
@@ -1579,18 +1646,15 @@ func (m *VolumeResponse) GetError() string {
 	return ""
 }
 
-// VolumeCreateResponse
-// swagger:response volumeCreateResponse
+// swagger:response
 type VolumeCreateResponse struct {
 	// ID of the newly created volume
 	//
 	// in: body
-	// Required: true
 	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
 	// Volume Response
 	//
 	// in: body
-	// Required: true
 	VolumeResponse *VolumeResponse `protobuf:"bytes,2,opt,name=volume_response,json=volumeResponse" json:"volume_response,omitempty"`
 }
 
@@ -1711,13 +1775,11 @@ func (m *VolumeSetRequest) GetOptions() map[string]string {
 type VolumeSetResponse struct {
 	// Volume
 	//
-	// in:body
-	// Required: true
+	// in: body
 	Volume *Volume `protobuf:"bytes,1,opt,name=volume" json:"volume,omitempty"`
-	// Volume Response
+	// VolumeResponse
 	//
 	// in: body
-	// Required: true
 	VolumeResponse *VolumeResponse `protobuf:"bytes,2,opt,name=volume_response,json=volumeResponse" json:"volume_response,omitempty"`
 }
 
@@ -1775,9 +1837,11 @@ func (m *SnapCreateRequest) GetReadonly() bool {
 	return false
 }
 
-// SnapCreateResponse
+// SnapCreateRequest specifies a response that get's returned when creating a snapshot.
 // swagger:response snapCreateResponse
 type SnapCreateResponse struct {
+	// VolumeCreateResponse
+	//
 	// in: body
 	VolumeCreateResponse *VolumeCreateResponse `protobuf:"bytes,1,opt,name=volume_create_response,json=volumeCreateResponse" json:"volume_create_response,omitempty"`
 }
@@ -1794,7 +1858,7 @@ func (m *SnapCreateResponse) GetVolumeCreateResponse() *VolumeCreateResponse {
 	return nil
 }
 
-// Volume Info
+// VolumeInfo
 // swagger:model
 type VolumeInfo struct {
 	VolumeId string      `protobuf:"bytes,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
@@ -1857,9 +1921,12 @@ func (m *GraphDriverChanges) GetKind() GraphDriverChangeType {
 	return GraphDriverChangeType_GRAPH_DRIVER_CHANGE_TYPE_NONE
 }
 
-// ClusterResponse
+// ClusterResponse specifies a response that gets returned when requesting the cluster
 // swagger:response clusterResponse
 type ClusterResponse struct {
+	// Error code
+	//
+	// in: body
 	Error string `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
 }
 
@@ -1875,6 +1942,7 @@ func (m *ClusterResponse) GetError() string {
 	return ""
 }
 
+// Active Request
 // swagger:model
 type ActiveRequest struct {
 	ReqestKV map[int64]string `protobuf:"bytes,1,rep,name=ReqestKV" json:"ReqestKV,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
@@ -1892,6 +1960,7 @@ func (m *ActiveRequest) GetReqestKV() map[int64]string {
 	return nil
 }
 
+// Active Requests
 // swagger:model
 type ActiveRequests struct {
 	RequestCount  int64            `protobuf:"varint,1,opt,name=RequestCount" json:"RequestCount,omitempty"`
@@ -1917,241 +1986,2690 @@ func (m *ActiveRequests) GetActiveRequest() []*ActiveRequest {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*StorageResource)(nil), "openstorage.api.StorageResource")
-	proto.RegisterType((*StoragePool)(nil), "openstorage.api.StoragePool")
-	proto.RegisterType((*VolumeLocator)(nil), "openstorage.api.VolumeLocator")
-	proto.RegisterType((*Source)(nil), "openstorage.api.Source")
-	proto.RegisterType((*Group)(nil), "openstorage.api.Group")
-	proto.RegisterType((*VolumeSpec)(nil), "openstorage.api.VolumeSpec")
-	proto.RegisterType((*ReplicaSet)(nil), "openstorage.api.ReplicaSet")
-	proto.RegisterType((*RuntimeStateMap)(nil), "openstorage.api.RuntimeStateMap")
-	proto.RegisterType((*Volume)(nil), "openstorage.api.Volume")
-	proto.RegisterType((*Stats)(nil), "openstorage.api.Stats")
-	proto.RegisterType((*Alert)(nil), "openstorage.api.Alert")
-	proto.RegisterType((*Alerts)(nil), "openstorage.api.Alerts")
-	proto.RegisterType((*VolumeCreateRequest)(nil), "openstorage.api.VolumeCreateRequest")
-	proto.RegisterType((*VolumeResponse)(nil), "openstorage.api.VolumeResponse")
-	proto.RegisterType((*VolumeCreateResponse)(nil), "openstorage.api.VolumeCreateResponse")
-	proto.RegisterType((*VolumeStateAction)(nil), "openstorage.api.VolumeStateAction")
-	proto.RegisterType((*VolumeSetRequest)(nil), "openstorage.api.VolumeSetRequest")
-	proto.RegisterType((*VolumeSetResponse)(nil), "openstorage.api.VolumeSetResponse")
-	proto.RegisterType((*SnapCreateRequest)(nil), "openstorage.api.SnapCreateRequest")
-	proto.RegisterType((*SnapCreateResponse)(nil), "openstorage.api.SnapCreateResponse")
-	proto.RegisterType((*VolumeInfo)(nil), "openstorage.api.VolumeInfo")
-	proto.RegisterType((*GraphDriverChanges)(nil), "openstorage.api.GraphDriverChanges")
-	proto.RegisterType((*ClusterResponse)(nil), "openstorage.api.ClusterResponse")
-	proto.RegisterType((*ActiveRequest)(nil), "openstorage.api.ActiveRequest")
-	proto.RegisterType((*ActiveRequests)(nil), "openstorage.api.ActiveRequests")
-	proto.RegisterEnum("openstorage.api.Status", Status_name, Status_value)
-	proto.RegisterEnum("openstorage.api.DriverType", DriverType_name, DriverType_value)
-	proto.RegisterEnum("openstorage.api.FSType", FSType_name, FSType_value)
-	proto.RegisterEnum("openstorage.api.GraphDriverChangeType", GraphDriverChangeType_name, GraphDriverChangeType_value)
-	proto.RegisterEnum("openstorage.api.SeverityType", SeverityType_name, SeverityType_value)
-	proto.RegisterEnum("openstorage.api.ResourceType", ResourceType_name, ResourceType_value)
-	proto.RegisterEnum("openstorage.api.AlertActionType", AlertActionType_name, AlertActionType_value)
-	proto.RegisterEnum("openstorage.api.VolumeActionParam", VolumeActionParam_name, VolumeActionParam_value)
-	proto.RegisterEnum("openstorage.api.CosType", CosType_name, CosType_value)
-	proto.RegisterEnum("openstorage.api.IoProfile", IoProfile_name, IoProfile_value)
-	proto.RegisterEnum("openstorage.api.VolumeState", VolumeState_name, VolumeState_value)
-	proto.RegisterEnum("openstorage.api.VolumeStatus", VolumeStatus_name, VolumeStatus_value)
-	proto.RegisterEnum("openstorage.api.StorageMedium", StorageMedium_name, StorageMedium_value)
-	proto.RegisterEnum("openstorage.api.ClusterNotify", ClusterNotify_name, ClusterNotify_value)
-	proto.RegisterEnum("openstorage.api.AttachState", AttachState_name, AttachState_value)
-	proto.RegisterEnum("openstorage.api.OperationFlags", OperationFlags_name, OperationFlags_value)
+// StorageNode describes a node of the cluster as returned by the gRPC API.
+type StorageNode struct {
+	// Id of the node
+	Id string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	// Cpu usage of the node in percent
+	Cpu float64 `protobuf:"fixed64,2,opt,name=cpu" json:"cpu,omitempty"`
+	// Total memory of the node
+	MemTotal uint64 `protobuf:"varint,3,opt,name=mem_total,json=memTotal" json:"mem_total,omitempty"`
+	// Used memory of the node
+	MemUsed uint64 `protobuf:"varint,4,opt,name=mem_used,json=memUsed" json:"mem_used,omitempty"`
+	// Free memory of the node
+	MemFree uint64 `protobuf:"varint,5,opt,name=mem_free,json=memFree" json:"mem_free,omitempty"`
+	// Average load in percent
+	AvgLoad int64 `protobuf:"varint,6,opt,name=avg_load,json=avgLoad" json:"avg_load,omitempty"`
+	// Status of the node
+	Status Status `protobuf:"varint,7,opt,name=status,enum=openstorage.api.Status" json:"status,omitempty"`
+	// Disks of the node
+	Disks map[string]*StorageResource `protobuf:"bytes,8,rep,name=disks" json:"disks,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Storage pools of the node
+	Pools []*StoragePool `protobuf:"bytes,9,rep,name=pools" json:"pools,omitempty"`
+	// Management IP
+	MgmtIp string `protobuf:"bytes,10,opt,name=mgmt_ip,json=mgmtIp" json:"mgmt_ip,omitempty"`
+	// Data IP
+	DataIp string `protobuf:"bytes,11,opt,name=data_ip,json=dataIp" json:"data_ip,omitempty"`
+	// Hostname of the node
+	Hostname string `protobuf:"bytes,12,opt,name=hostname" json:"hostname,omitempty"`
+	// User defined labels of the node
+	NodeLabels map[string]string `protobuf:"bytes,13,rep,name=node_labels,json=nodeLabels" json:"node_labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Time the node was started
+	StartTime *google_protobuf.Timestamp `protobuf:"bytes,14,opt,name=start_time,json=startTime" json:"start_time,omitempty"`
+}
+
+func (m *StorageNode) Reset()                    { *m = StorageNode{} }
+func (m *StorageNode) String() string            { return proto.CompactTextString(m) }
+func (*StorageNode) ProtoMessage()               {}
+func (*StorageNode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *StorageNode) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *StorageNode) GetCpu() float64 {
+	if m != nil {
+		return m.Cpu
+	}
+	return 0
+}
+
+func (m *StorageNode) GetMemTotal() uint64 {
+	if m != nil {
+		return m.MemTotal
+	}
+	return 0
+}
+
+func (m *StorageNode) GetMemUsed() uint64 {
+	if m != nil {
+		return m.MemUsed
+	}
+	return 0
+}
+
+func (m *StorageNode) GetMemFree() uint64 {
+	if m != nil {
+		return m.MemFree
+	}
+	return 0
+}
+
+func (m *StorageNode) GetAvgLoad() int64 {
+	if m != nil {
+		return m.AvgLoad
+	}
+	return 0
+}
+
+func (m *StorageNode) GetStatus() Status {
+	if m != nil {
+		return m.Status
+	}
+	return Status_STATUS_NONE
+}
+
+func (m *StorageNode) GetDisks() map[string]*StorageResource {
+	if m != nil {
+		return m.Disks
+	}
+	return nil
+}
+
+func (m *StorageNode) GetPools() []*StoragePool {
+	if m != nil {
+		return m.Pools
+	}
+	return nil
+}
+
+func (m *StorageNode) GetMgmtIp() string {
+	if m != nil {
+		return m.MgmtIp
+	}
+	return ""
+}
+
+func (m *StorageNode) GetDataIp() string {
+	if m != nil {
+		return m.DataIp
+	}
+	return ""
+}
+
+func (m *StorageNode) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
+func (m *StorageNode) GetNodeLabels() map[string]string {
+	if m != nil {
+		return m.NodeLabels
+	}
+	return nil
+}
+
+func (m *StorageNode) GetStartTime() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+// StorageCluster describes the cluster as returned by the gRPC API.
+type StorageCluster struct {
+	// Status of the cluster
+	Status Status `protobuf:"varint,1,opt,name=status,enum=openstorage.api.Status" json:"status,omitempty"`
+	// Id of the cluster
+	Id string `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
+	// Id of the node serving the request
+	NodeId string `protobuf:"bytes,3,opt,name=node_id,json=nodeId" json:"node_id,omitempty"`
+	// Nodes of the cluster
+	Nodes []*StorageNode `protobuf:"bytes,4,rep,name=nodes" json:"nodes,omitempty"`
+}
+
+func (m *StorageCluster) Reset()                    { *m = StorageCluster{} }
+func (m *StorageCluster) String() string            { return proto.CompactTextString(m) }
+func (*StorageCluster) ProtoMessage()               {}
+func (*StorageCluster) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *StorageCluster) GetStatus() Status {
+	if m != nil {
+		return m.Status
+	}
+	return Status_STATUS_NONE
+}
+
+func (m *StorageCluster) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *StorageCluster) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+func (m *StorageCluster) GetNodes() []*StorageNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type SdkVolumeCreateRequest struct {
+	// User specified volume name and labels
+	Locator *VolumeLocator `protobuf:"bytes,1,opt,name=locator" json:"locator,omitempty"`
+	// Source to create volume from
+	Source *Source `protobuf:"bytes,2,opt,name=source" json:"source,omitempty"`
+	// The storage spec for the volume
+	Spec *VolumeSpec `protobuf:"bytes,3,opt,name=spec" json:"spec,omitempty"`
+}
+
+func (m *SdkVolumeCreateRequest) Reset()                    { *m = SdkVolumeCreateRequest{} }
+func (m *SdkVolumeCreateRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkVolumeCreateRequest) ProtoMessage()               {}
+func (*SdkVolumeCreateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+
+func (m *SdkVolumeCreateRequest) GetLocator() *VolumeLocator {
+	if m != nil {
+		return m.Locator
+	}
+	return nil
+}
+
+func (m *SdkVolumeCreateRequest) GetSource() *Source {
+	if m != nil {
+		return m.Source
+	}
+	return nil
+}
+
+func (m *SdkVolumeCreateRequest) GetSpec() *VolumeSpec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+type SdkVolumeCreateResponse struct {
+	// Id of the new volume
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+}
+
+func (m *SdkVolumeCreateResponse) Reset()                    { *m = SdkVolumeCreateResponse{} }
+func (m *SdkVolumeCreateResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkVolumeCreateResponse) ProtoMessage()               {}
+func (*SdkVolumeCreateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+
+func (m *SdkVolumeCreateResponse) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
+type SdkVolumeInspectRequest struct {
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+}
+
+func (m *SdkVolumeInspectRequest) Reset()                    { *m = SdkVolumeInspectRequest{} }
+func (m *SdkVolumeInspectRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkVolumeInspectRequest) ProtoMessage()               {}
+func (*SdkVolumeInspectRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+
+func (m *SdkVolumeInspectRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
+type SdkVolumeInspectResponse struct {
+	Volume *Volume `protobuf:"bytes,1,opt,name=volume" json:"volume,omitempty"`
+}
+
+func (m *SdkVolumeInspectResponse) Reset()                    { *m = SdkVolumeInspectResponse{} }
+func (m *SdkVolumeInspectResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkVolumeInspectResponse) ProtoMessage()               {}
+func (*SdkVolumeInspectResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+
+func (m *SdkVolumeInspectResponse) GetVolume() *Volume {
+	if m != nil {
+		return m.Volume
+	}
+	return nil
+}
+
+type SdkVolumeEnumerateRequest struct {
+	// Locator the volumes match, all volumes if not set
+	Locator *VolumeLocator `protobuf:"bytes,1,opt,name=locator" json:"locator,omitempty"`
+	// Labels of the configuration the volumes match
+	Labels map[string]string `protobuf:"bytes,2,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *SdkVolumeEnumerateRequest) Reset()                    { *m = SdkVolumeEnumerateRequest{} }
+func (m *SdkVolumeEnumerateRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkVolumeEnumerateRequest) ProtoMessage()               {}
+func (*SdkVolumeEnumerateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+
+func (m *SdkVolumeEnumerateRequest) GetLocator() *VolumeLocator {
+	if m != nil {
+		return m.Locator
+	}
+	return nil
+}
+
+func (m *SdkVolumeEnumerateRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type SdkVolumeEnumerateResponse struct {
+	Volumes []*Volume `protobuf:"bytes,1,rep,name=volumes" json:"volumes,omitempty"`
+}
+
+func (m *SdkVolumeEnumerateResponse) Reset()                    { *m = SdkVolumeEnumerateResponse{} }
+func (m *SdkVolumeEnumerateResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkVolumeEnumerateResponse) ProtoMessage()               {}
+func (*SdkVolumeEnumerateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *SdkVolumeEnumerateResponse) GetVolumes() []*Volume {
+	if m != nil {
+		return m.Volumes
+	}
+	return nil
+}
+
+type SdkVolumeUpdateRequest struct {
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	// New name and labels of the volume, unchanged if not set
+	Locator *VolumeLocator `protobuf:"bytes,2,opt,name=locator" json:"locator,omitempty"`
+	// New spec of the volume, unchanged if not set
+	Spec *VolumeSpec `protobuf:"bytes,3,opt,name=spec" json:"spec,omitempty"`
+}
+
+func (m *SdkVolumeUpdateRequest) Reset()                    { *m = SdkVolumeUpdateRequest{} }
+func (m *SdkVolumeUpdateRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkVolumeUpdateRequest) ProtoMessage()               {}
+func (*SdkVolumeUpdateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+func (m *SdkVolumeUpdateRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
+func (m *SdkVolumeUpdateRequest) GetLocator() *VolumeLocator {
+	if m != nil {
+		return m.Locator
+	}
+	return nil
+}
+
+func (m *SdkVolumeUpdateRequest) GetSpec() *VolumeSpec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+type SdkVolumeUpdateResponse struct {
+}
+
+func (m *SdkVolumeUpdateResponse) Reset()                    { *m = SdkVolumeUpdateResponse{} }
+func (m *SdkVolumeUpdateResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkVolumeUpdateResponse) ProtoMessage()               {}
+func (*SdkVolumeUpdateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+type SdkVolumeDeleteRequest struct {
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+}
+
+func (m *SdkVolumeDeleteRequest) Reset()                    { *m = SdkVolumeDeleteRequest{} }
+func (m *SdkVolumeDeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkVolumeDeleteRequest) ProtoMessage()               {}
+func (*SdkVolumeDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *SdkVolumeDeleteRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
+type SdkVolumeDeleteResponse struct {
+}
+
+func (m *SdkVolumeDeleteResponse) Reset()                    { *m = SdkVolumeDeleteResponse{} }
+func (m *SdkVolumeDeleteResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkVolumeDeleteResponse) ProtoMessage()               {}
+func (*SdkVolumeDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+type SdkVolumeStatsRequest struct {
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	// Stats of the last interval instead of since the volume was created
+	NotCumulative bool `protobuf:"varint,2,opt,name=not_cumulative,json=notCumulative" json:"not_cumulative,omitempty"`
+}
+
+func (m *SdkVolumeStatsRequest) Reset()                    { *m = SdkVolumeStatsRequest{} }
+func (m *SdkVolumeStatsRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkVolumeStatsRequest) ProtoMessage()               {}
+func (*SdkVolumeStatsRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *SdkVolumeStatsRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
+func (m *SdkVolumeStatsRequest) GetNotCumulative() bool {
+	if m != nil {
+		return m.NotCumulative
+	}
+	return false
+}
+
+type SdkVolumeStatsResponse struct {
+	Stats *Stats `protobuf:"bytes,1,opt,name=stats" json:"stats,omitempty"`
+}
+
+func (m *SdkVolumeStatsResponse) Reset()                    { *m = SdkVolumeStatsResponse{} }
+func (m *SdkVolumeStatsResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkVolumeStatsResponse) ProtoMessage()               {}
+func (*SdkVolumeStatsResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *SdkVolumeStatsResponse) GetStats() *Stats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type SdkSnapshotCreateRequest struct {
+	// Id of the volume to snapshot
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	// Name and labels of the snapshot
+	Locator  *VolumeLocator `protobuf:"bytes,2,opt,name=locator" json:"locator,omitempty"`
+	Readonly bool           `protobuf:"varint,3,opt,name=readonly" json:"readonly,omitempty"`
+}
+
+func (m *SdkSnapshotCreateRequest) Reset()                    { *m = SdkSnapshotCreateRequest{} }
+func (m *SdkSnapshotCreateRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkSnapshotCreateRequest) ProtoMessage()               {}
+func (*SdkSnapshotCreateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *SdkSnapshotCreateRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
+func (m *SdkSnapshotCreateRequest) GetLocator() *VolumeLocator {
+	if m != nil {
+		return m.Locator
+	}
+	return nil
+}
+
+func (m *SdkSnapshotCreateRequest) GetReadonly() bool {
+	if m != nil {
+		return m.Readonly
+	}
+	return false
+}
+
+type SdkSnapshotCreateResponse struct {
+	SnapshotId string `protobuf:"bytes,1,opt,name=snapshot_id,json=snapshotId" json:"snapshot_id,omitempty"`
+}
+
+func (m *SdkSnapshotCreateResponse) Reset()                    { *m = SdkSnapshotCreateResponse{} }
+func (m *SdkSnapshotCreateResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkSnapshotCreateResponse) ProtoMessage()               {}
+func (*SdkSnapshotCreateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *SdkSnapshotCreateResponse) GetSnapshotId() string {
+	if m != nil {
+		return m.SnapshotId
+	}
+	return ""
+}
+
+type SdkSnapshotEnumerateRequest struct {
+	// Id of the volume whose snapshots are listed, all snapshots if empty
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	// Labels the snapshots match
+	Labels map[string]string `protobuf:"bytes,2,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *SdkSnapshotEnumerateRequest) Reset()                    { *m = SdkSnapshotEnumerateRequest{} }
+func (m *SdkSnapshotEnumerateRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkSnapshotEnumerateRequest) ProtoMessage()               {}
+func (*SdkSnapshotEnumerateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *SdkSnapshotEnumerateRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
+func (m *SdkSnapshotEnumerateRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+type SdkSnapshotEnumerateResponse struct {
+	Snapshots []*Volume `protobuf:"bytes,1,rep,name=snapshots" json:"snapshots,omitempty"`
+}
+
+func (m *SdkSnapshotEnumerateResponse) Reset()                    { *m = SdkSnapshotEnumerateResponse{} }
+func (m *SdkSnapshotEnumerateResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkSnapshotEnumerateResponse) ProtoMessage()               {}
+func (*SdkSnapshotEnumerateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *SdkSnapshotEnumerateResponse) GetSnapshots() []*Volume {
+	if m != nil {
+		return m.Snapshots
+	}
+	return nil
+}
+
+type SdkSnapshotRestoreRequest struct {
+	VolumeId   string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	SnapshotId string `protobuf:"bytes,2,opt,name=snapshot_id,json=snapshotId" json:"snapshot_id,omitempty"`
+}
+
+func (m *SdkSnapshotRestoreRequest) Reset()                    { *m = SdkSnapshotRestoreRequest{} }
+func (m *SdkSnapshotRestoreRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkSnapshotRestoreRequest) ProtoMessage()               {}
+func (*SdkSnapshotRestoreRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+func (m *SdkSnapshotRestoreRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
+func (m *SdkSnapshotRestoreRequest) GetSnapshotId() string {
+	if m != nil {
+		return m.SnapshotId
+	}
+	return ""
+}
+
+type SdkSnapshotRestoreResponse struct {
+}
+
+func (m *SdkSnapshotRestoreResponse) Reset()                    { *m = SdkSnapshotRestoreResponse{} }
+func (m *SdkSnapshotRestoreResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkSnapshotRestoreResponse) ProtoMessage()               {}
+func (*SdkSnapshotRestoreResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+type SdkCredentialCreateRequest struct {
+	// Parameters of the credential, see the OptCred options
+	InputParams map[string]string `protobuf:"bytes,1,rep,name=input_params,json=inputParams" json:"input_params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *SdkCredentialCreateRequest) Reset()                    { *m = SdkCredentialCreateRequest{} }
+func (m *SdkCredentialCreateRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkCredentialCreateRequest) ProtoMessage()               {}
+func (*SdkCredentialCreateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *SdkCredentialCreateRequest) GetInputParams() map[string]string {
+	if m != nil {
+		return m.InputParams
+	}
+	return nil
+}
+
+type SdkCredentialCreateResponse struct {
+	CredentialId string `protobuf:"bytes,1,opt,name=credential_id,json=credentialId" json:"credential_id,omitempty"`
+}
+
+func (m *SdkCredentialCreateResponse) Reset()                    { *m = SdkCredentialCreateResponse{} }
+func (m *SdkCredentialCreateResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkCredentialCreateResponse) ProtoMessage()               {}
+func (*SdkCredentialCreateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
+func (m *SdkCredentialCreateResponse) GetCredentialId() string {
+	if m != nil {
+		return m.CredentialId
+	}
+	return ""
+}
+
+type SdkCredentialEnumerateRequest struct {
+}
+
+func (m *SdkCredentialEnumerateRequest) Reset()                    { *m = SdkCredentialEnumerateRequest{} }
+func (m *SdkCredentialEnumerateRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkCredentialEnumerateRequest) ProtoMessage()               {}
+func (*SdkCredentialEnumerateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{47} }
+
+type SdkCredentialEnumerateResponse struct {
+	// Credentials maps the ids of the credentials to their JSON encoded
+	// parameters
+	Credentials map[string]string `protobuf:"bytes,1,rep,name=credentials" json:"credentials,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *SdkCredentialEnumerateResponse) Reset()         { *m = SdkCredentialEnumerateResponse{} }
+func (m *SdkCredentialEnumerateResponse) String() string { return proto.CompactTextString(m) }
+func (*SdkCredentialEnumerateResponse) ProtoMessage()    {}
+func (*SdkCredentialEnumerateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{48}
+}
+
+func (m *SdkCredentialEnumerateResponse) GetCredentials() map[string]string {
+	if m != nil {
+		return m.Credentials
+	}
+	return nil
+}
+
+type SdkCredentialDeleteRequest struct {
+	CredentialId string `protobuf:"bytes,1,opt,name=credential_id,json=credentialId" json:"credential_id,omitempty"`
+}
+
+func (m *SdkCredentialDeleteRequest) Reset()                    { *m = SdkCredentialDeleteRequest{} }
+func (m *SdkCredentialDeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkCredentialDeleteRequest) ProtoMessage()               {}
+func (*SdkCredentialDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{49} }
+
+func (m *SdkCredentialDeleteRequest) GetCredentialId() string {
+	if m != nil {
+		return m.CredentialId
+	}
+	return ""
+}
+
+type SdkCredentialDeleteResponse struct {
+}
+
+func (m *SdkCredentialDeleteResponse) Reset()                    { *m = SdkCredentialDeleteResponse{} }
+func (m *SdkCredentialDeleteResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkCredentialDeleteResponse) ProtoMessage()               {}
+func (*SdkCredentialDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{50} }
+
+type SdkCredentialValidateRequest struct {
+	CredentialId string `protobuf:"bytes,1,opt,name=credential_id,json=credentialId" json:"credential_id,omitempty"`
+}
+
+func (m *SdkCredentialValidateRequest) Reset()                    { *m = SdkCredentialValidateRequest{} }
+func (m *SdkCredentialValidateRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkCredentialValidateRequest) ProtoMessage()               {}
+func (*SdkCredentialValidateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{51} }
+
+func (m *SdkCredentialValidateRequest) GetCredentialId() string {
+	if m != nil {
+		return m.CredentialId
+	}
+	return ""
+}
+
+type SdkCredentialValidateResponse struct {
+}
+
+func (m *SdkCredentialValidateResponse) Reset()                    { *m = SdkCredentialValidateResponse{} }
+func (m *SdkCredentialValidateResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkCredentialValidateResponse) ProtoMessage()               {}
+func (*SdkCredentialValidateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{52} }
+
+type SdkCloudBackupCreateRequest struct {
+	// Id of the volume to back up
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId" json:"volume_id,omitempty"`
+	// Credential of the cloud the backup is uploaded to
+	CredentialId string `protobuf:"bytes,2,opt,name=credential_id,json=credentialId" json:"credential_id,omitempty"`
+	// Full backup even if an incremental one is possible
+	Full bool `protobuf:"varint,3,opt,name=full" json:"full,omitempty"`
+}
+
+func (m *SdkCloudBackupCreateRequest) Reset()                    { *m = SdkCloudBackupCreateRequest{} }
+func (m *SdkCloudBackupCreateRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkCloudBackupCreateRequest) ProtoMessage()               {}
+func (*SdkCloudBackupCreateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{53} }
+
+func (m *SdkCloudBackupCreateRequest) GetVolumeId() string {
+	if m != nil {
+		return m.VolumeId
+	}
+	return ""
+}
+
+func (m *SdkCloudBackupCreateRequest) GetCredentialId() string {
+	if m != nil {
+		return m.CredentialId
+	}
+	return ""
+}
+
+func (m *SdkCloudBackupCreateRequest) GetFull() bool {
+	if m != nil {
+		return m.Full
+	}
+	return false
+}
+
+type SdkCloudBackupCreateResponse struct {
+}
+
+func (m *SdkCloudBackupCreateResponse) Reset()                    { *m = SdkCloudBackupCreateResponse{} }
+func (m *SdkCloudBackupCreateResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkCloudBackupCreateResponse) ProtoMessage()               {}
+func (*SdkCloudBackupCreateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{54} }
+
+type SdkCloudBackupRestoreRequest struct {
+	BackupId string `protobuf:"bytes,1,opt,name=backup_id,json=backupId" json:"backup_id,omitempty"`
+	// Name of the volume restored to, optional
+	RestoreVolumeName string `protobuf:"bytes,2,opt,name=restore_volume_name,json=restoreVolumeName" json:"restore_volume_name,omitempty"`
+	CredentialId      string `protobuf:"bytes,3,opt,name=credential_id,json=credentialId" json:"credential_id,omitempty"`
+	// Node the volume is provisioned on, optional
+	NodeId string `protobuf:"bytes,4,opt,name=node_id,json=nodeId" json:"node_id,omitempty"`
+}
+
+func (m *SdkCloudBackupRestoreRequest) Reset()                    { *m = SdkCloudBackupRestoreRequest{} }
+func (m *SdkCloudBackupRestoreRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkCloudBackupRestoreRequest) ProtoMessage()               {}
+func (*SdkCloudBackupRestoreRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{55} }
+
+func (m *SdkCloudBackupRestoreRequest) GetBackupId() string {
+	if m != nil {
+		return m.BackupId
+	}
+	return ""
+}
+
+func (m *SdkCloudBackupRestoreRequest) GetRestoreVolumeName() string {
+	if m != nil {
+		return m.RestoreVolumeName
+	}
+	return ""
+}
+
+func (m *SdkCloudBackupRestoreRequest) GetCredentialId() string {
+	if m != nil {
+		return m.CredentialId
+	}
+	return ""
+}
+
+func (m *SdkCloudBackupRestoreRequest) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+type SdkCloudBackupRestoreResponse struct {
+	RestoreVolumeId string `protobuf:"bytes,1,opt,name=restore_volume_id,json=restoreVolumeId" json:"restore_volume_id,omitempty"`
+}
+
+func (m *SdkCloudBackupRestoreResponse) Reset()                    { *m = SdkCloudBackupRestoreResponse{} }
+func (m *SdkCloudBackupRestoreResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkCloudBackupRestoreResponse) ProtoMessage()               {}
+func (*SdkCloudBackupRestoreResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{56} }
+
+func (m *SdkCloudBackupRestoreResponse) GetRestoreVolumeId() string {
+	if m != nil {
+		return m.RestoreVolumeId
+	}
+	return ""
+}
+
+type SdkCloudBackupEnumerateRequest struct {
+	// Id of the volume whose backups are listed, optional
+	SrcVolumeId string `protobuf:"bytes,1,opt,name=src_volume_id,json=srcVolumeId" json:"src_volume_id,omitempty"`
+	// Id of the cluster whose backups are listed, optional
+	ClusterId string `protobuf:"bytes,2,opt,name=cluster_id,json=clusterId" json:"cluster_id,omitempty"`
+	// Backups of all the clusters are listed if set
+	All          bool   `protobuf:"varint,3,opt,name=all" json:"all,omitempty"`
+	CredentialId string `protobuf:"bytes,4,opt,name=credential_id,json=credentialId" json:"credential_id,omitempty"`
+}
+
+func (m *SdkCloudBackupEnumerateRequest) Reset()         { *m = SdkCloudBackupEnumerateRequest{} }
+func (m *SdkCloudBackupEnumerateRequest) String() string { return proto.CompactTextString(m) }
+func (*SdkCloudBackupEnumerateRequest) ProtoMessage()    {}
+func (*SdkCloudBackupEnumerateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{57}
+}
+
+func (m *SdkCloudBackupEnumerateRequest) GetSrcVolumeId() string {
+	if m != nil {
+		return m.SrcVolumeId
+	}
+	return ""
+}
+
+func (m *SdkCloudBackupEnumerateRequest) GetClusterId() string {
+	if m != nil {
+		return m.ClusterId
+	}
+	return ""
+}
+
+func (m *SdkCloudBackupEnumerateRequest) GetAll() bool {
+	if m != nil {
+		return m.All
+	}
+	return false
+}
+
+func (m *SdkCloudBackupEnumerateRequest) GetCredentialId() string {
+	if m != nil {
+		return m.CredentialId
+	}
+	return ""
+}
+
+type SdkCloudBackupInfo struct {
+	SrcVolumeId   string `protobuf:"bytes,1,opt,name=src_volume_id,json=srcVolumeId" json:"src_volume_id,omitempty"`
+	SrcVolumeName string `protobuf:"bytes,2,opt,name=src_volume_name,json=srcVolumeName" json:"src_volume_name,omitempty"`
+	BackupId      string `protobuf:"bytes,3,opt,name=backup_id,json=backupId" json:"backup_id,omitempty"`
+	// Time the volume was backed up
+	Timestamp *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=timestamp" json:"timestamp,omitempty"`
+	Status    string                     `protobuf:"bytes,5,opt,name=status" json:"status,omitempty"`
+}
+
+func (m *SdkCloudBackupInfo) Reset()                    { *m = SdkCloudBackupInfo{} }
+func (m *SdkCloudBackupInfo) String() string            { return proto.CompactTextString(m) }
+func (*SdkCloudBackupInfo) ProtoMessage()               {}
+func (*SdkCloudBackupInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{58} }
+
+func (m *SdkCloudBackupInfo) GetSrcVolumeId() string {
+	if m != nil {
+		return m.SrcVolumeId
+	}
+	return ""
+}
+
+func (m *SdkCloudBackupInfo) GetSrcVolumeName() string {
+	if m != nil {
+		return m.SrcVolumeName
+	}
+	return ""
+}
+
+func (m *SdkCloudBackupInfo) GetBackupId() string {
+	if m != nil {
+		return m.BackupId
+	}
+	return ""
+}
+
+func (m *SdkCloudBackupInfo) GetTimestamp() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+func (m *SdkCloudBackupInfo) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+type SdkCloudBackupEnumerateResponse struct {
+	Backups []*SdkCloudBackupInfo `protobuf:"bytes,1,rep,name=backups" json:"backups,omitempty"`
+}
+
+func (m *SdkCloudBackupEnumerateResponse) Reset()         { *m = SdkCloudBackupEnumerateResponse{} }
+func (m *SdkCloudBackupEnumerateResponse) String() string { return proto.CompactTextString(m) }
+func (*SdkCloudBackupEnumerateResponse) ProtoMessage()    {}
+func (*SdkCloudBackupEnumerateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{59}
+}
+
+func (m *SdkCloudBackupEnumerateResponse) GetBackups() []*SdkCloudBackupInfo {
+	if m != nil {
+		return m.Backups
+	}
+	return nil
+}
+
+type SdkCloudBackupDeleteRequest struct {
+	// Id of the volume whose backups are deleted, optional
+	SrcVolumeId string `protobuf:"bytes,1,opt,name=src_volume_id,json=srcVolumeId" json:"src_volume_id,omitempty"`
+	// Id of the cluster whose backups are deleted, optional
+	ClusterId string `protobuf:"bytes,2,opt,name=cluster_id,json=clusterId" json:"cluster_id,omitempty"`
+	// Backups of all the clusters are deleted if set
+	All          bool   `protobuf:"varint,3,opt,name=all" json:"all,omitempty"`
+	CredentialId string `protobuf:"bytes,4,opt,name=credential_id,json=credentialId" json:"credential_id,omitempty"`
+}
+
+func (m *SdkCloudBackupDeleteRequest) Reset()                    { *m = SdkCloudBackupDeleteRequest{} }
+func (m *SdkCloudBackupDeleteRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkCloudBackupDeleteRequest) ProtoMessage()               {}
+func (*SdkCloudBackupDeleteRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{60} }
+
+func (m *SdkCloudBackupDeleteRequest) GetSrcVolumeId() string {
+	if m != nil {
+		return m.SrcVolumeId
+	}
+	return ""
+}
+
+func (m *SdkCloudBackupDeleteRequest) GetClusterId() string {
+	if m != nil {
+		return m.ClusterId
+	}
+	return ""
+}
+
+func (m *SdkCloudBackupDeleteRequest) GetAll() bool {
+	if m != nil {
+		return m.All
+	}
+	return false
+}
+
+func (m *SdkCloudBackupDeleteRequest) GetCredentialId() string {
+	if m != nil {
+		return m.CredentialId
+	}
+	return ""
+}
+
+type SdkCloudBackupDeleteResponse struct {
+}
+
+func (m *SdkCloudBackupDeleteResponse) Reset()                    { *m = SdkCloudBackupDeleteResponse{} }
+func (m *SdkCloudBackupDeleteResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkCloudBackupDeleteResponse) ProtoMessage()               {}
+func (*SdkCloudBackupDeleteResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{61} }
+
+type SdkCloudBackupStatusRequest struct {
+	// Id of the volume whose operations are listed, optional
+	SrcVolumeId string `protobuf:"bytes,1,opt,name=src_volume_id,json=srcVolumeId" json:"src_volume_id,omitempty"`
+	// Only the operations of this node are listed if set
+	Local bool `protobuf:"varint,2,opt,name=local" json:"local,omitempty"`
+}
+
+func (m *SdkCloudBackupStatusRequest) Reset()                    { *m = SdkCloudBackupStatusRequest{} }
+func (m *SdkCloudBackupStatusRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkCloudBackupStatusRequest) ProtoMessage()               {}
+func (*SdkCloudBackupStatusRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{62} }
+
+func (m *SdkCloudBackupStatusRequest) GetSrcVolumeId() string {
+	if m != nil {
+		return m.SrcVolumeId
+	}
+	return ""
+}
+
+func (m *SdkCloudBackupStatusRequest) GetLocal() bool {
+	if m != nil {
+		return m.Local
+	}
+	return false
+}
+
+type SdkCloudBackupStatus struct {
+	// Backup or Restore
+	OpType        string                     `protobuf:"bytes,1,opt,name=op_type,json=opType" json:"op_type,omitempty"`
+	Status        string                     `protobuf:"bytes,2,opt,name=status" json:"status,omitempty"`
+	BytesDone     uint64                     `protobuf:"varint,3,opt,name=bytes_done,json=bytesDone" json:"bytes_done,omitempty"`
+	StartTime     *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime" json:"start_time,omitempty"`
+	CompletedTime *google_protobuf.Timestamp `protobuf:"bytes,5,opt,name=completed_time,json=completedTime" json:"completed_time,omitempty"`
+	BackupId      string                     `protobuf:"bytes,6,opt,name=backup_id,json=backupId" json:"backup_id,omitempty"`
+	NodeId        string                     `protobuf:"bytes,7,opt,name=node_id,json=nodeId" json:"node_id,omitempty"`
+}
+
+func (m *SdkCloudBackupStatus) Reset()                    { *m = SdkCloudBackupStatus{} }
+func (m *SdkCloudBackupStatus) String() string            { return proto.CompactTextString(m) }
+func (*SdkCloudBackupStatus) ProtoMessage()               {}
+func (*SdkCloudBackupStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{63} }
+
+func (m *SdkCloudBackupStatus) GetOpType() string {
+	if m != nil {
+		return m.OpType
+	}
+	return ""
+}
+
+func (m *SdkCloudBackupStatus) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *SdkCloudBackupStatus) GetBytesDone() uint64 {
+	if m != nil {
+		return m.BytesDone
+	}
+	return 0
+}
+
+func (m *SdkCloudBackupStatus) GetStartTime() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *SdkCloudBackupStatus) GetCompletedTime() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.CompletedTime
+	}
+	return nil
+}
+
+func (m *SdkCloudBackupStatus) GetBackupId() string {
+	if m != nil {
+		return m.BackupId
+	}
+	return ""
+}
+
+func (m *SdkCloudBackupStatus) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+type SdkCloudBackupStatusResponse struct {
+	// Statuses maps the volume ids to the status of their last operation
+	Statuses map[string]*SdkCloudBackupStatus `protobuf:"bytes,1,rep,name=statuses" json:"statuses,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *SdkCloudBackupStatusResponse) Reset()                    { *m = SdkCloudBackupStatusResponse{} }
+func (m *SdkCloudBackupStatusResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkCloudBackupStatusResponse) ProtoMessage()               {}
+func (*SdkCloudBackupStatusResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{64} }
+
+func (m *SdkCloudBackupStatusResponse) GetStatuses() map[string]*SdkCloudBackupStatus {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+type SdkClusterEnumerateRequest struct {
+}
+
+func (m *SdkClusterEnumerateRequest) Reset()                    { *m = SdkClusterEnumerateRequest{} }
+func (m *SdkClusterEnumerateRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkClusterEnumerateRequest) ProtoMessage()               {}
+func (*SdkClusterEnumerateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{65} }
+
+type SdkClusterEnumerateResponse struct {
+	Cluster *StorageCluster `protobuf:"bytes,1,opt,name=cluster" json:"cluster,omitempty"`
+}
+
+func (m *SdkClusterEnumerateResponse) Reset()                    { *m = SdkClusterEnumerateResponse{} }
+func (m *SdkClusterEnumerateResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkClusterEnumerateResponse) ProtoMessage()               {}
+func (*SdkClusterEnumerateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{66} }
+
+func (m *SdkClusterEnumerateResponse) GetCluster() *StorageCluster {
+	if m != nil {
+		return m.Cluster
+	}
+	return nil
+}
+
+type SdkClusterInspectRequest struct {
+	NodeId string `protobuf:"bytes,1,opt,name=node_id,json=nodeId" json:"node_id,omitempty"`
+}
+
+func (m *SdkClusterInspectRequest) Reset()                    { *m = SdkClusterInspectRequest{} }
+func (m *SdkClusterInspectRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkClusterInspectRequest) ProtoMessage()               {}
+func (*SdkClusterInspectRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{67} }
+
+func (m *SdkClusterInspectRequest) GetNodeId() string {
+	if m != nil {
+		return m.NodeId
+	}
+	return ""
+}
+
+type SdkClusterInspectResponse struct {
+	Node *StorageNode `protobuf:"bytes,1,opt,name=node" json:"node,omitempty"`
+}
+
+func (m *SdkClusterInspectResponse) Reset()                    { *m = SdkClusterInspectResponse{} }
+func (m *SdkClusterInspectResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkClusterInspectResponse) ProtoMessage()               {}
+func (*SdkClusterInspectResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{68} }
+
+func (m *SdkClusterInspectResponse) GetNode() *StorageNode {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+type SdkClusterAlertEnumerateRequest struct {
+	Resource ResourceType `protobuf:"varint,1,opt,name=resource,enum=openstorage.api.ResourceType" json:"resource,omitempty"`
+	// Alerts raised from, all if not set
+	TimeStart *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=time_start,json=timeStart" json:"time_start,omitempty"`
+	// Alerts raised until, no limit if not set
+	TimeEnd *google_protobuf.Timestamp `protobuf:"bytes,3,opt,name=time_end,json=timeEnd" json:"time_end,omitempty"`
+}
+
+func (m *SdkClusterAlertEnumerateRequest) Reset()         { *m = SdkClusterAlertEnumerateRequest{} }
+func (m *SdkClusterAlertEnumerateRequest) String() string { return proto.CompactTextString(m) }
+func (*SdkClusterAlertEnumerateRequest) ProtoMessage()    {}
+func (*SdkClusterAlertEnumerateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{69}
+}
+
+func (m *SdkClusterAlertEnumerateRequest) GetResource() ResourceType {
+	if m != nil {
+		return m.Resource
+	}
+	return ResourceType_RESOURCE_TYPE_NONE
+}
+
+func (m *SdkClusterAlertEnumerateRequest) GetTimeStart() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.TimeStart
+	}
+	return nil
+}
+
+func (m *SdkClusterAlertEnumerateRequest) GetTimeEnd() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.TimeEnd
+	}
+	return nil
+}
+
+type SdkClusterAlertEnumerateResponse struct {
+	Alerts []*Alert `protobuf:"bytes,1,rep,name=alerts" json:"alerts,omitempty"`
+}
+
+func (m *SdkClusterAlertEnumerateResponse) Reset()         { *m = SdkClusterAlertEnumerateResponse{} }
+func (m *SdkClusterAlertEnumerateResponse) String() string { return proto.CompactTextString(m) }
+func (*SdkClusterAlertEnumerateResponse) ProtoMessage()    {}
+func (*SdkClusterAlertEnumerateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{70}
+}
+
+func (m *SdkClusterAlertEnumerateResponse) GetAlerts() []*Alert {
+	if m != nil {
+		return m.Alerts
+	}
+	return nil
+}
+
+type SdkClusterAlertClearRequest struct {
+	Resource ResourceType `protobuf:"varint,1,opt,name=resource,enum=openstorage.api.ResourceType" json:"resource,omitempty"`
+	AlertId  int64        `protobuf:"varint,2,opt,name=alert_id,json=alertId" json:"alert_id,omitempty"`
+}
+
+func (m *SdkClusterAlertClearRequest) Reset()                    { *m = SdkClusterAlertClearRequest{} }
+func (m *SdkClusterAlertClearRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkClusterAlertClearRequest) ProtoMessage()               {}
+func (*SdkClusterAlertClearRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{71} }
+
+func (m *SdkClusterAlertClearRequest) GetResource() ResourceType {
+	if m != nil {
+		return m.Resource
+	}
+	return ResourceType_RESOURCE_TYPE_NONE
+}
+
+func (m *SdkClusterAlertClearRequest) GetAlertId() int64 {
+	if m != nil {
+		return m.AlertId
+	}
+	return 0
+}
+
+type SdkClusterAlertClearResponse struct {
+}
+
+func (m *SdkClusterAlertClearResponse) Reset()                    { *m = SdkClusterAlertClearResponse{} }
+func (m *SdkClusterAlertClearResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkClusterAlertClearResponse) ProtoMessage()               {}
+func (*SdkClusterAlertClearResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{72} }
+
+type SdkClusterAlertEraseRequest struct {
+	Resource ResourceType `protobuf:"varint,1,opt,name=resource,enum=openstorage.api.ResourceType" json:"resource,omitempty"`
+	AlertId  int64        `protobuf:"varint,2,opt,name=alert_id,json=alertId" json:"alert_id,omitempty"`
+}
+
+func (m *SdkClusterAlertEraseRequest) Reset()                    { *m = SdkClusterAlertEraseRequest{} }
+func (m *SdkClusterAlertEraseRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkClusterAlertEraseRequest) ProtoMessage()               {}
+func (*SdkClusterAlertEraseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{73} }
+
+func (m *SdkClusterAlertEraseRequest) GetResource() ResourceType {
+	if m != nil {
+		return m.Resource
+	}
+	return ResourceType_RESOURCE_TYPE_NONE
+}
+
+func (m *SdkClusterAlertEraseRequest) GetAlertId() int64 {
+	if m != nil {
+		return m.AlertId
+	}
+	return 0
+}
+
+type SdkClusterAlertEraseResponse struct {
+}
+
+func (m *SdkClusterAlertEraseResponse) Reset()                    { *m = SdkClusterAlertEraseResponse{} }
+func (m *SdkClusterAlertEraseResponse) String() string            { return proto.CompactTextString(m) }
+func (*SdkClusterAlertEraseResponse) ProtoMessage()               {}
+func (*SdkClusterAlertEraseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{74} }
+
+type SdkWatchRequest struct {
+	// Revision to resume after, new events only if not set
+	Revision uint64 `protobuf:"varint,1,opt,name=revision" json:"revision,omitempty"`
+	// Types selects events whose type has one of these prefixes, such as
+	// "volume" or "node.down", all events if empty
+	Types []string `protobuf:"bytes,2,rep,name=types" json:"types,omitempty"`
+	// Id selects the events of a volume or node if set
+	Id string `protobuf:"bytes,3,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *SdkWatchRequest) Reset()                    { *m = SdkWatchRequest{} }
+func (m *SdkWatchRequest) String() string            { return proto.CompactTextString(m) }
+func (*SdkWatchRequest) ProtoMessage()               {}
+func (*SdkWatchRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{75} }
+
+func (m *SdkWatchRequest) GetRevision() uint64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *SdkWatchRequest) GetTypes() []string {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
+func (m *SdkWatchRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// SdkWatchEvent is a change of a volume or node.
+type SdkWatchEvent struct {
+	// Revision orders events, and is used to resume watching after it
+	Revision uint64 `protobuf:"varint,1,opt,name=revision" json:"revision,omitempty"`
+	// Type of event, such as "volume.create" or "node.down"
+	Type string `protobuf:"bytes,2,opt,name=type" json:"type,omitempty"`
+	// Time of the event
+	Time *google_protobuf.Timestamp `protobuf:"bytes,3,opt,name=time" json:"time,omitempty"`
+	// Driver of the volume of volume events
+	Driver string `protobuf:"bytes,4,opt,name=driver" json:"driver,omitempty"`
+	// Volume of volume events, as it was after the event
+	Volume *Volume `protobuf:"bytes,5,opt,name=volume" json:"volume,omitempty"`
+	// Node of node events
+	Node *StorageNode `protobuf:"bytes,6,opt,name=node" json:"node,omitempty"`
+}
+
+func (m *SdkWatchEvent) Reset()                    { *m = SdkWatchEvent{} }
+func (m *SdkWatchEvent) String() string            { return proto.CompactTextString(m) }
+func (*SdkWatchEvent) ProtoMessage()               {}
+func (*SdkWatchEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{76} }
+
+func (m *SdkWatchEvent) GetRevision() uint64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *SdkWatchEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *SdkWatchEvent) GetTime() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *SdkWatchEvent) GetDriver() string {
+	if m != nil {
+		return m.Driver
+	}
+	return ""
+}
+
+func (m *SdkWatchEvent) GetVolume() *Volume {
+	if m != nil {
+		return m.Volume
+	}
+	return nil
+}
+
+func (m *SdkWatchEvent) GetNode() *StorageNode {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*StorageResource)(nil), "openstorage.api.StorageResource")
+	proto.RegisterType((*StoragePool)(nil), "openstorage.api.StoragePool")
+	proto.RegisterType((*VolumeLocator)(nil), "openstorage.api.VolumeLocator")
+	proto.RegisterType((*Source)(nil), "openstorage.api.Source")
+	proto.RegisterType((*Group)(nil), "openstorage.api.Group")
+	proto.RegisterType((*VolumeSpec)(nil), "openstorage.api.VolumeSpec")
+	proto.RegisterType((*ReplicaSet)(nil), "openstorage.api.ReplicaSet")
+	proto.RegisterType((*RuntimeStateMap)(nil), "openstorage.api.RuntimeStateMap")
+	proto.RegisterType((*Volume)(nil), "openstorage.api.Volume")
+	proto.RegisterType((*Stats)(nil), "openstorage.api.Stats")
+	proto.RegisterType((*Alert)(nil), "openstorage.api.Alert")
+	proto.RegisterType((*Alerts)(nil), "openstorage.api.Alerts")
+	proto.RegisterType((*VolumeCreateRequest)(nil), "openstorage.api.VolumeCreateRequest")
+	proto.RegisterType((*VolumeResponse)(nil), "openstorage.api.VolumeResponse")
+	proto.RegisterType((*VolumeCreateResponse)(nil), "openstorage.api.VolumeCreateResponse")
+	proto.RegisterType((*VolumeStateAction)(nil), "openstorage.api.VolumeStateAction")
+	proto.RegisterType((*VolumeSetRequest)(nil), "openstorage.api.VolumeSetRequest")
+	proto.RegisterType((*VolumeSetResponse)(nil), "openstorage.api.VolumeSetResponse")
+	proto.RegisterType((*SnapCreateRequest)(nil), "openstorage.api.SnapCreateRequest")
+	proto.RegisterType((*SnapCreateResponse)(nil), "openstorage.api.SnapCreateResponse")
+	proto.RegisterType((*VolumeInfo)(nil), "openstorage.api.VolumeInfo")
+	proto.RegisterType((*GraphDriverChanges)(nil), "openstorage.api.GraphDriverChanges")
+	proto.RegisterType((*ClusterResponse)(nil), "openstorage.api.ClusterResponse")
+	proto.RegisterType((*ActiveRequest)(nil), "openstorage.api.ActiveRequest")
+	proto.RegisterType((*ActiveRequests)(nil), "openstorage.api.ActiveRequests")
+	proto.RegisterType((*StorageNode)(nil), "openstorage.api.StorageNode")
+	proto.RegisterType((*StorageCluster)(nil), "openstorage.api.StorageCluster")
+	proto.RegisterType((*SdkVolumeCreateRequest)(nil), "openstorage.api.SdkVolumeCreateRequest")
+	proto.RegisterType((*SdkVolumeCreateResponse)(nil), "openstorage.api.SdkVolumeCreateResponse")
+	proto.RegisterType((*SdkVolumeInspectRequest)(nil), "openstorage.api.SdkVolumeInspectRequest")
+	proto.RegisterType((*SdkVolumeInspectResponse)(nil), "openstorage.api.SdkVolumeInspectResponse")
+	proto.RegisterType((*SdkVolumeEnumerateRequest)(nil), "openstorage.api.SdkVolumeEnumerateRequest")
+	proto.RegisterType((*SdkVolumeEnumerateResponse)(nil), "openstorage.api.SdkVolumeEnumerateResponse")
+	proto.RegisterType((*SdkVolumeUpdateRequest)(nil), "openstorage.api.SdkVolumeUpdateRequest")
+	proto.RegisterType((*SdkVolumeUpdateResponse)(nil), "openstorage.api.SdkVolumeUpdateResponse")
+	proto.RegisterType((*SdkVolumeDeleteRequest)(nil), "openstorage.api.SdkVolumeDeleteRequest")
+	proto.RegisterType((*SdkVolumeDeleteResponse)(nil), "openstorage.api.SdkVolumeDeleteResponse")
+	proto.RegisterType((*SdkVolumeStatsRequest)(nil), "openstorage.api.SdkVolumeStatsRequest")
+	proto.RegisterType((*SdkVolumeStatsResponse)(nil), "openstorage.api.SdkVolumeStatsResponse")
+	proto.RegisterType((*SdkSnapshotCreateRequest)(nil), "openstorage.api.SdkSnapshotCreateRequest")
+	proto.RegisterType((*SdkSnapshotCreateResponse)(nil), "openstorage.api.SdkSnapshotCreateResponse")
+	proto.RegisterType((*SdkSnapshotEnumerateRequest)(nil), "openstorage.api.SdkSnapshotEnumerateRequest")
+	proto.RegisterType((*SdkSnapshotEnumerateResponse)(nil), "openstorage.api.SdkSnapshotEnumerateResponse")
+	proto.RegisterType((*SdkSnapshotRestoreRequest)(nil), "openstorage.api.SdkSnapshotRestoreRequest")
+	proto.RegisterType((*SdkSnapshotRestoreResponse)(nil), "openstorage.api.SdkSnapshotRestoreResponse")
+	proto.RegisterType((*SdkCredentialCreateRequest)(nil), "openstorage.api.SdkCredentialCreateRequest")
+	proto.RegisterType((*SdkCredentialCreateResponse)(nil), "openstorage.api.SdkCredentialCreateResponse")
+	proto.RegisterType((*SdkCredentialEnumerateRequest)(nil), "openstorage.api.SdkCredentialEnumerateRequest")
+	proto.RegisterType((*SdkCredentialEnumerateResponse)(nil), "openstorage.api.SdkCredentialEnumerateResponse")
+	proto.RegisterType((*SdkCredentialDeleteRequest)(nil), "openstorage.api.SdkCredentialDeleteRequest")
+	proto.RegisterType((*SdkCredentialDeleteResponse)(nil), "openstorage.api.SdkCredentialDeleteResponse")
+	proto.RegisterType((*SdkCredentialValidateRequest)(nil), "openstorage.api.SdkCredentialValidateRequest")
+	proto.RegisterType((*SdkCredentialValidateResponse)(nil), "openstorage.api.SdkCredentialValidateResponse")
+	proto.RegisterType((*SdkCloudBackupCreateRequest)(nil), "openstorage.api.SdkCloudBackupCreateRequest")
+	proto.RegisterType((*SdkCloudBackupCreateResponse)(nil), "openstorage.api.SdkCloudBackupCreateResponse")
+	proto.RegisterType((*SdkCloudBackupRestoreRequest)(nil), "openstorage.api.SdkCloudBackupRestoreRequest")
+	proto.RegisterType((*SdkCloudBackupRestoreResponse)(nil), "openstorage.api.SdkCloudBackupRestoreResponse")
+	proto.RegisterType((*SdkCloudBackupEnumerateRequest)(nil), "openstorage.api.SdkCloudBackupEnumerateRequest")
+	proto.RegisterType((*SdkCloudBackupInfo)(nil), "openstorage.api.SdkCloudBackupInfo")
+	proto.RegisterType((*SdkCloudBackupEnumerateResponse)(nil), "openstorage.api.SdkCloudBackupEnumerateResponse")
+	proto.RegisterType((*SdkCloudBackupDeleteRequest)(nil), "openstorage.api.SdkCloudBackupDeleteRequest")
+	proto.RegisterType((*SdkCloudBackupDeleteResponse)(nil), "openstorage.api.SdkCloudBackupDeleteResponse")
+	proto.RegisterType((*SdkCloudBackupStatusRequest)(nil), "openstorage.api.SdkCloudBackupStatusRequest")
+	proto.RegisterType((*SdkCloudBackupStatus)(nil), "openstorage.api.SdkCloudBackupStatus")
+	proto.RegisterType((*SdkCloudBackupStatusResponse)(nil), "openstorage.api.SdkCloudBackupStatusResponse")
+	proto.RegisterType((*SdkClusterEnumerateRequest)(nil), "openstorage.api.SdkClusterEnumerateRequest")
+	proto.RegisterType((*SdkClusterEnumerateResponse)(nil), "openstorage.api.SdkClusterEnumerateResponse")
+	proto.RegisterType((*SdkClusterInspectRequest)(nil), "openstorage.api.SdkClusterInspectRequest")
+	proto.RegisterType((*SdkClusterInspectResponse)(nil), "openstorage.api.SdkClusterInspectResponse")
+	proto.RegisterType((*SdkClusterAlertEnumerateRequest)(nil), "openstorage.api.SdkClusterAlertEnumerateRequest")
+	proto.RegisterType((*SdkClusterAlertEnumerateResponse)(nil), "openstorage.api.SdkClusterAlertEnumerateResponse")
+	proto.RegisterType((*SdkClusterAlertClearRequest)(nil), "openstorage.api.SdkClusterAlertClearRequest")
+	proto.RegisterType((*SdkClusterAlertClearResponse)(nil), "openstorage.api.SdkClusterAlertClearResponse")
+	proto.RegisterType((*SdkClusterAlertEraseRequest)(nil), "openstorage.api.SdkClusterAlertEraseRequest")
+	proto.RegisterType((*SdkClusterAlertEraseResponse)(nil), "openstorage.api.SdkClusterAlertEraseResponse")
+	proto.RegisterType((*SdkWatchRequest)(nil), "openstorage.api.SdkWatchRequest")
+	proto.RegisterType((*SdkWatchEvent)(nil), "openstorage.api.SdkWatchEvent")
+	proto.RegisterEnum("openstorage.api.Status", Status_name, Status_value)
+	proto.RegisterEnum("openstorage.api.DriverType", DriverType_name, DriverType_value)
+	proto.RegisterEnum("openstorage.api.FSType", FSType_name, FSType_value)
+	proto.RegisterEnum("openstorage.api.GraphDriverChangeType", GraphDriverChangeType_name, GraphDriverChangeType_value)
+	proto.RegisterEnum("openstorage.api.SeverityType", SeverityType_name, SeverityType_value)
+	proto.RegisterEnum("openstorage.api.ResourceType", ResourceType_name, ResourceType_value)
+	proto.RegisterEnum("openstorage.api.AlertActionType", AlertActionType_name, AlertActionType_value)
+	proto.RegisterEnum("openstorage.api.VolumeActionParam", VolumeActionParam_name, VolumeActionParam_value)
+	proto.RegisterEnum("openstorage.api.CosType", CosType_name, CosType_value)
+	proto.RegisterEnum("openstorage.api.IoProfile", IoProfile_name, IoProfile_value)
+	proto.RegisterEnum("openstorage.api.VolumeState", VolumeState_name, VolumeState_value)
+	proto.RegisterEnum("openstorage.api.VolumeStatus", VolumeStatus_name, VolumeStatus_value)
+	proto.RegisterEnum("openstorage.api.StorageMedium", StorageMedium_name, StorageMedium_value)
+	proto.RegisterEnum("openstorage.api.ClusterNotify", ClusterNotify_name, ClusterNotify_value)
+	proto.RegisterEnum("openstorage.api.AttachState", AttachState_name, AttachState_value)
+	proto.RegisterEnum("openstorage.api.OperationFlags", OperationFlags_name, OperationFlags_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for OpenStorageVolume service
+
+type OpenStorageVolumeClient interface {
+	// Create creates a volume
+	Create(ctx context.Context, in *SdkVolumeCreateRequest, opts ...grpc.CallOption) (*SdkVolumeCreateResponse, error)
+	// Inspect returns a volume
+	Inspect(ctx context.Context, in *SdkVolumeInspectRequest, opts ...grpc.CallOption) (*SdkVolumeInspectResponse, error)
+	// Enumerate returns the volumes matching the request
+	Enumerate(ctx context.Context, in *SdkVolumeEnumerateRequest, opts ...grpc.CallOption) (*SdkVolumeEnumerateResponse, error)
+	// Update changes the locator and spec of a volume
+	Update(ctx context.Context, in *SdkVolumeUpdateRequest, opts ...grpc.CallOption) (*SdkVolumeUpdateResponse, error)
+	// Delete deletes a volume
+	Delete(ctx context.Context, in *SdkVolumeDeleteRequest, opts ...grpc.CallOption) (*SdkVolumeDeleteResponse, error)
+	// Stats returns the stats of a volume
+	Stats(ctx context.Context, in *SdkVolumeStatsRequest, opts ...grpc.CallOption) (*SdkVolumeStatsResponse, error)
+	// SnapshotCreate snapshots a volume
+	SnapshotCreate(ctx context.Context, in *SdkSnapshotCreateRequest, opts ...grpc.CallOption) (*SdkSnapshotCreateResponse, error)
+	// SnapshotEnumerate returns the snapshots matching the request
+	SnapshotEnumerate(ctx context.Context, in *SdkSnapshotEnumerateRequest, opts ...grpc.CallOption) (*SdkSnapshotEnumerateResponse, error)
+	// SnapshotRestore restores a volume to one of its snapshots
+	SnapshotRestore(ctx context.Context, in *SdkSnapshotRestoreRequest, opts ...grpc.CallOption) (*SdkSnapshotRestoreResponse, error)
+	// CredentialCreate creates a cloud credential
+	CredentialCreate(ctx context.Context, in *SdkCredentialCreateRequest, opts ...grpc.CallOption) (*SdkCredentialCreateResponse, error)
+	// CredentialEnumerate returns the cloud credentials
+	CredentialEnumerate(ctx context.Context, in *SdkCredentialEnumerateRequest, opts ...grpc.CallOption) (*SdkCredentialEnumerateResponse, error)
+	// CredentialDelete deletes a cloud credential
+	CredentialDelete(ctx context.Context, in *SdkCredentialDeleteRequest, opts ...grpc.CallOption) (*SdkCredentialDeleteResponse, error)
+	// CredentialValidate checks the cloud can be accessed with a credential
+	CredentialValidate(ctx context.Context, in *SdkCredentialValidateRequest, opts ...grpc.CallOption) (*SdkCredentialValidateResponse, error)
+	// CloudBackupCreate backs a volume up to the cloud
+	CloudBackupCreate(ctx context.Context, in *SdkCloudBackupCreateRequest, opts ...grpc.CallOption) (*SdkCloudBackupCreateResponse, error)
+	// CloudBackupRestore restores a cloud backup to a new volume
+	CloudBackupRestore(ctx context.Context, in *SdkCloudBackupRestoreRequest, opts ...grpc.CallOption) (*SdkCloudBackupRestoreResponse, error)
+	// CloudBackupEnumerate returns the cloud backups matching the request
+	CloudBackupEnumerate(ctx context.Context, in *SdkCloudBackupEnumerateRequest, opts ...grpc.CallOption) (*SdkCloudBackupEnumerateResponse, error)
+	// CloudBackupDelete deletes the cloud backups matching the request
+	CloudBackupDelete(ctx context.Context, in *SdkCloudBackupDeleteRequest, opts ...grpc.CallOption) (*SdkCloudBackupDeleteResponse, error)
+	// CloudBackupStatus returns the status of the backups and restores
+	CloudBackupStatus(ctx context.Context, in *SdkCloudBackupStatusRequest, opts ...grpc.CallOption) (*SdkCloudBackupStatusResponse, error)
+}
+
+type openStorageVolumeClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOpenStorageVolumeClient(cc *grpc.ClientConn) OpenStorageVolumeClient {
+	return &openStorageVolumeClient{cc}
+}
+
+func (c *openStorageVolumeClient) Create(ctx context.Context, in *SdkVolumeCreateRequest, opts ...grpc.CallOption) (*SdkVolumeCreateResponse, error) {
+	out := new(SdkVolumeCreateResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageVolume/Create", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageVolumeClient) Inspect(ctx context.Context, in *SdkVolumeInspectRequest, opts ...grpc.CallOption) (*SdkVolumeInspectResponse, error) {
+	out := new(SdkVolumeInspectResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageVolume/Inspect", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageVolumeClient) Enumerate(ctx context.Context, in *SdkVolumeEnumerateRequest, opts ...grpc.CallOption) (*SdkVolumeEnumerateResponse, error) {
+	out := new(SdkVolumeEnumerateResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageVolume/Enumerate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageVolumeClient) Update(ctx context.Context, in *SdkVolumeUpdateRequest, opts ...grpc.CallOption) (*SdkVolumeUpdateResponse, error) {
+	out := new(SdkVolumeUpdateResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageVolume/Update", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageVolumeClient) Delete(ctx context.Context, in *SdkVolumeDeleteRequest, opts ...grpc.CallOption) (*SdkVolumeDeleteResponse, error) {
+	out := new(SdkVolumeDeleteResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageVolume/Delete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageVolumeClient) Stats(ctx context.Context, in *SdkVolumeStatsRequest, opts ...grpc.CallOption) (*SdkVolumeStatsResponse, error) {
+	out := new(SdkVolumeStatsResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageVolume/Stats", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageVolumeClient) SnapshotCreate(ctx context.Context, in *SdkSnapshotCreateRequest, opts ...grpc.CallOption) (*SdkSnapshotCreateResponse, error) {
+	out := new(SdkSnapshotCreateResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageVolume/SnapshotCreate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageVolumeClient) SnapshotEnumerate(ctx context.Context, in *SdkSnapshotEnumerateRequest, opts ...grpc.CallOption) (*SdkSnapshotEnumerateResponse, error) {
+	out := new(SdkSnapshotEnumerateResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageVolume/SnapshotEnumerate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageVolumeClient) SnapshotRestore(ctx context.Context, in *SdkSnapshotRestoreRequest, opts ...grpc.CallOption) (*SdkSnapshotRestoreResponse, error) {
+	out := new(SdkSnapshotRestoreResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageVolume/SnapshotRestore", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageVolumeClient) CredentialCreate(ctx context.Context, in *SdkCredentialCreateRequest, opts ...grpc.CallOption) (*SdkCredentialCreateResponse, error) {
+	out := new(SdkCredentialCreateResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageVolume/CredentialCreate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageVolumeClient) CredentialEnumerate(ctx context.Context, in *SdkCredentialEnumerateRequest, opts ...grpc.CallOption) (*SdkCredentialEnumerateResponse, error) {
+	out := new(SdkCredentialEnumerateResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageVolume/CredentialEnumerate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageVolumeClient) CredentialDelete(ctx context.Context, in *SdkCredentialDeleteRequest, opts ...grpc.CallOption) (*SdkCredentialDeleteResponse, error) {
+	out := new(SdkCredentialDeleteResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageVolume/CredentialDelete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageVolumeClient) CredentialValidate(ctx context.Context, in *SdkCredentialValidateRequest, opts ...grpc.CallOption) (*SdkCredentialValidateResponse, error) {
+	out := new(SdkCredentialValidateResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageVolume/CredentialValidate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageVolumeClient) CloudBackupCreate(ctx context.Context, in *SdkCloudBackupCreateRequest, opts ...grpc.CallOption) (*SdkCloudBackupCreateResponse, error) {
+	out := new(SdkCloudBackupCreateResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageVolume/CloudBackupCreate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageVolumeClient) CloudBackupRestore(ctx context.Context, in *SdkCloudBackupRestoreRequest, opts ...grpc.CallOption) (*SdkCloudBackupRestoreResponse, error) {
+	out := new(SdkCloudBackupRestoreResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageVolume/CloudBackupRestore", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageVolumeClient) CloudBackupEnumerate(ctx context.Context, in *SdkCloudBackupEnumerateRequest, opts ...grpc.CallOption) (*SdkCloudBackupEnumerateResponse, error) {
+	out := new(SdkCloudBackupEnumerateResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageVolume/CloudBackupEnumerate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageVolumeClient) CloudBackupDelete(ctx context.Context, in *SdkCloudBackupDeleteRequest, opts ...grpc.CallOption) (*SdkCloudBackupDeleteResponse, error) {
+	out := new(SdkCloudBackupDeleteResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageVolume/CloudBackupDelete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageVolumeClient) CloudBackupStatus(ctx context.Context, in *SdkCloudBackupStatusRequest, opts ...grpc.CallOption) (*SdkCloudBackupStatusResponse, error) {
+	out := new(SdkCloudBackupStatusResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageVolume/CloudBackupStatus", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for OpenStorageVolume service
+
+type OpenStorageVolumeServer interface {
+	// Create creates a volume
+	Create(context.Context, *SdkVolumeCreateRequest) (*SdkVolumeCreateResponse, error)
+	// Inspect returns a volume
+	Inspect(context.Context, *SdkVolumeInspectRequest) (*SdkVolumeInspectResponse, error)
+	// Enumerate returns the volumes matching the request
+	Enumerate(context.Context, *SdkVolumeEnumerateRequest) (*SdkVolumeEnumerateResponse, error)
+	// Update changes the locator and spec of a volume
+	Update(context.Context, *SdkVolumeUpdateRequest) (*SdkVolumeUpdateResponse, error)
+	// Delete deletes a volume
+	Delete(context.Context, *SdkVolumeDeleteRequest) (*SdkVolumeDeleteResponse, error)
+	// Stats returns the stats of a volume
+	Stats(context.Context, *SdkVolumeStatsRequest) (*SdkVolumeStatsResponse, error)
+	// SnapshotCreate snapshots a volume
+	SnapshotCreate(context.Context, *SdkSnapshotCreateRequest) (*SdkSnapshotCreateResponse, error)
+	// SnapshotEnumerate returns the snapshots matching the request
+	SnapshotEnumerate(context.Context, *SdkSnapshotEnumerateRequest) (*SdkSnapshotEnumerateResponse, error)
+	// SnapshotRestore restores a volume to one of its snapshots
+	SnapshotRestore(context.Context, *SdkSnapshotRestoreRequest) (*SdkSnapshotRestoreResponse, error)
+	// CredentialCreate creates a cloud credential
+	CredentialCreate(context.Context, *SdkCredentialCreateRequest) (*SdkCredentialCreateResponse, error)
+	// CredentialEnumerate returns the cloud credentials
+	CredentialEnumerate(context.Context, *SdkCredentialEnumerateRequest) (*SdkCredentialEnumerateResponse, error)
+	// CredentialDelete deletes a cloud credential
+	CredentialDelete(context.Context, *SdkCredentialDeleteRequest) (*SdkCredentialDeleteResponse, error)
+	// CredentialValidate checks the cloud can be accessed with a credential
+	CredentialValidate(context.Context, *SdkCredentialValidateRequest) (*SdkCredentialValidateResponse, error)
+	// CloudBackupCreate backs a volume up to the cloud
+	CloudBackupCreate(context.Context, *SdkCloudBackupCreateRequest) (*SdkCloudBackupCreateResponse, error)
+	// CloudBackupRestore restores a cloud backup to a new volume
+	CloudBackupRestore(context.Context, *SdkCloudBackupRestoreRequest) (*SdkCloudBackupRestoreResponse, error)
+	// CloudBackupEnumerate returns the cloud backups matching the request
+	CloudBackupEnumerate(context.Context, *SdkCloudBackupEnumerateRequest) (*SdkCloudBackupEnumerateResponse, error)
+	// CloudBackupDelete deletes the cloud backups matching the request
+	CloudBackupDelete(context.Context, *SdkCloudBackupDeleteRequest) (*SdkCloudBackupDeleteResponse, error)
+	// CloudBackupStatus returns the status of the backups and restores
+	CloudBackupStatus(context.Context, *SdkCloudBackupStatusRequest) (*SdkCloudBackupStatusResponse, error)
+}
+
+func RegisterOpenStorageVolumeServer(s *grpc.Server, srv OpenStorageVolumeServer) {
+	s.RegisterService(&_OpenStorageVolume_serviceDesc, srv)
+}
+
+func _OpenStorageVolume_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkVolumeCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageVolumeServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageVolume/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageVolumeServer).Create(ctx, req.(*SdkVolumeCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageVolume_Inspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkVolumeInspectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageVolumeServer).Inspect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageVolume/Inspect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageVolumeServer).Inspect(ctx, req.(*SdkVolumeInspectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageVolume_Enumerate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkVolumeEnumerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageVolumeServer).Enumerate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageVolume/Enumerate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageVolumeServer).Enumerate(ctx, req.(*SdkVolumeEnumerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageVolume_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkVolumeUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageVolumeServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageVolume/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageVolumeServer).Update(ctx, req.(*SdkVolumeUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageVolume_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkVolumeDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageVolumeServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageVolume/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageVolumeServer).Delete(ctx, req.(*SdkVolumeDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageVolume_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkVolumeStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageVolumeServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageVolume/Stats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageVolumeServer).Stats(ctx, req.(*SdkVolumeStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageVolume_SnapshotCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkSnapshotCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageVolumeServer).SnapshotCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageVolume/SnapshotCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageVolumeServer).SnapshotCreate(ctx, req.(*SdkSnapshotCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageVolume_SnapshotEnumerate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkSnapshotEnumerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageVolumeServer).SnapshotEnumerate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageVolume/SnapshotEnumerate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageVolumeServer).SnapshotEnumerate(ctx, req.(*SdkSnapshotEnumerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageVolume_SnapshotRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkSnapshotRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageVolumeServer).SnapshotRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageVolume/SnapshotRestore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageVolumeServer).SnapshotRestore(ctx, req.(*SdkSnapshotRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageVolume_CredentialCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkCredentialCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageVolumeServer).CredentialCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageVolume/CredentialCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageVolumeServer).CredentialCreate(ctx, req.(*SdkCredentialCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageVolume_CredentialEnumerate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkCredentialEnumerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageVolumeServer).CredentialEnumerate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageVolume/CredentialEnumerate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageVolumeServer).CredentialEnumerate(ctx, req.(*SdkCredentialEnumerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageVolume_CredentialDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkCredentialDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageVolumeServer).CredentialDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageVolume/CredentialDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageVolumeServer).CredentialDelete(ctx, req.(*SdkCredentialDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageVolume_CredentialValidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkCredentialValidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageVolumeServer).CredentialValidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageVolume/CredentialValidate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageVolumeServer).CredentialValidate(ctx, req.(*SdkCredentialValidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageVolume_CloudBackupCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkCloudBackupCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageVolumeServer).CloudBackupCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageVolume/CloudBackupCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageVolumeServer).CloudBackupCreate(ctx, req.(*SdkCloudBackupCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageVolume_CloudBackupRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkCloudBackupRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageVolumeServer).CloudBackupRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageVolume/CloudBackupRestore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageVolumeServer).CloudBackupRestore(ctx, req.(*SdkCloudBackupRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageVolume_CloudBackupEnumerate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkCloudBackupEnumerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageVolumeServer).CloudBackupEnumerate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageVolume/CloudBackupEnumerate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageVolumeServer).CloudBackupEnumerate(ctx, req.(*SdkCloudBackupEnumerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageVolume_CloudBackupDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkCloudBackupDeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageVolumeServer).CloudBackupDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageVolume/CloudBackupDelete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageVolumeServer).CloudBackupDelete(ctx, req.(*SdkCloudBackupDeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageVolume_CloudBackupStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkCloudBackupStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageVolumeServer).CloudBackupStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageVolume/CloudBackupStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageVolumeServer).CloudBackupStatus(ctx, req.(*SdkCloudBackupStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _OpenStorageVolume_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "openstorage.api.OpenStorageVolume",
+	HandlerType: (*OpenStorageVolumeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _OpenStorageVolume_Create_Handler,
+		},
+		{
+			MethodName: "Inspect",
+			Handler:    _OpenStorageVolume_Inspect_Handler,
+		},
+		{
+			MethodName: "Enumerate",
+			Handler:    _OpenStorageVolume_Enumerate_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _OpenStorageVolume_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _OpenStorageVolume_Delete_Handler,
+		},
+		{
+			MethodName: "Stats",
+			Handler:    _OpenStorageVolume_Stats_Handler,
+		},
+		{
+			MethodName: "SnapshotCreate",
+			Handler:    _OpenStorageVolume_SnapshotCreate_Handler,
+		},
+		{
+			MethodName: "SnapshotEnumerate",
+			Handler:    _OpenStorageVolume_SnapshotEnumerate_Handler,
+		},
+		{
+			MethodName: "SnapshotRestore",
+			Handler:    _OpenStorageVolume_SnapshotRestore_Handler,
+		},
+		{
+			MethodName: "CredentialCreate",
+			Handler:    _OpenStorageVolume_CredentialCreate_Handler,
+		},
+		{
+			MethodName: "CredentialEnumerate",
+			Handler:    _OpenStorageVolume_CredentialEnumerate_Handler,
+		},
+		{
+			MethodName: "CredentialDelete",
+			Handler:    _OpenStorageVolume_CredentialDelete_Handler,
+		},
+		{
+			MethodName: "CredentialValidate",
+			Handler:    _OpenStorageVolume_CredentialValidate_Handler,
+		},
+		{
+			MethodName: "CloudBackupCreate",
+			Handler:    _OpenStorageVolume_CloudBackupCreate_Handler,
+		},
+		{
+			MethodName: "CloudBackupRestore",
+			Handler:    _OpenStorageVolume_CloudBackupRestore_Handler,
+		},
+		{
+			MethodName: "CloudBackupEnumerate",
+			Handler:    _OpenStorageVolume_CloudBackupEnumerate_Handler,
+		},
+		{
+			MethodName: "CloudBackupDelete",
+			Handler:    _OpenStorageVolume_CloudBackupDelete_Handler,
+		},
+		{
+			MethodName: "CloudBackupStatus",
+			Handler:    _OpenStorageVolume_CloudBackupStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/api.proto",
+}
+
+// Client API for OpenStorageCluster service
+
+type OpenStorageClusterClient interface {
+	// Enumerate returns the cluster and its nodes
+	Enumerate(ctx context.Context, in *SdkClusterEnumerateRequest, opts ...grpc.CallOption) (*SdkClusterEnumerateResponse, error)
+	// Inspect returns a node of the cluster
+	Inspect(ctx context.Context, in *SdkClusterInspectRequest, opts ...grpc.CallOption) (*SdkClusterInspectResponse, error)
+	// AlertEnumerate returns the alerts of a resource type
+	AlertEnumerate(ctx context.Context, in *SdkClusterAlertEnumerateRequest, opts ...grpc.CallOption) (*SdkClusterAlertEnumerateResponse, error)
+	// AlertClear clears an alert
+	AlertClear(ctx context.Context, in *SdkClusterAlertClearRequest, opts ...grpc.CallOption) (*SdkClusterAlertClearResponse, error)
+	// AlertErase erases an alert
+	AlertErase(ctx context.Context, in *SdkClusterAlertEraseRequest, opts ...grpc.CallOption) (*SdkClusterAlertEraseResponse, error)
+}
+
+type openStorageClusterClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOpenStorageClusterClient(cc *grpc.ClientConn) OpenStorageClusterClient {
+	return &openStorageClusterClient{cc}
+}
+
+func (c *openStorageClusterClient) Enumerate(ctx context.Context, in *SdkClusterEnumerateRequest, opts ...grpc.CallOption) (*SdkClusterEnumerateResponse, error) {
+	out := new(SdkClusterEnumerateResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageCluster/Enumerate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageClusterClient) Inspect(ctx context.Context, in *SdkClusterInspectRequest, opts ...grpc.CallOption) (*SdkClusterInspectResponse, error) {
+	out := new(SdkClusterInspectResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageCluster/Inspect", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageClusterClient) AlertEnumerate(ctx context.Context, in *SdkClusterAlertEnumerateRequest, opts ...grpc.CallOption) (*SdkClusterAlertEnumerateResponse, error) {
+	out := new(SdkClusterAlertEnumerateResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageCluster/AlertEnumerate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageClusterClient) AlertClear(ctx context.Context, in *SdkClusterAlertClearRequest, opts ...grpc.CallOption) (*SdkClusterAlertClearResponse, error) {
+	out := new(SdkClusterAlertClearResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageCluster/AlertClear", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *openStorageClusterClient) AlertErase(ctx context.Context, in *SdkClusterAlertEraseRequest, opts ...grpc.CallOption) (*SdkClusterAlertEraseResponse, error) {
+	out := new(SdkClusterAlertEraseResponse)
+	err := grpc.Invoke(ctx, "/openstorage.api.OpenStorageCluster/AlertErase", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for OpenStorageCluster service
+
+type OpenStorageClusterServer interface {
+	// Enumerate returns the cluster and its nodes
+	Enumerate(context.Context, *SdkClusterEnumerateRequest) (*SdkClusterEnumerateResponse, error)
+	// Inspect returns a node of the cluster
+	Inspect(context.Context, *SdkClusterInspectRequest) (*SdkClusterInspectResponse, error)
+	// AlertEnumerate returns the alerts of a resource type
+	AlertEnumerate(context.Context, *SdkClusterAlertEnumerateRequest) (*SdkClusterAlertEnumerateResponse, error)
+	// AlertClear clears an alert
+	AlertClear(context.Context, *SdkClusterAlertClearRequest) (*SdkClusterAlertClearResponse, error)
+	// AlertErase erases an alert
+	AlertErase(context.Context, *SdkClusterAlertEraseRequest) (*SdkClusterAlertEraseResponse, error)
+}
+
+func RegisterOpenStorageClusterServer(s *grpc.Server, srv OpenStorageClusterServer) {
+	s.RegisterService(&_OpenStorageCluster_serviceDesc, srv)
+}
+
+func _OpenStorageCluster_Enumerate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkClusterEnumerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageClusterServer).Enumerate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageCluster/Enumerate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageClusterServer).Enumerate(ctx, req.(*SdkClusterEnumerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageCluster_Inspect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkClusterInspectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageClusterServer).Inspect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageCluster/Inspect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageClusterServer).Inspect(ctx, req.(*SdkClusterInspectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageCluster_AlertEnumerate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkClusterAlertEnumerateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageClusterServer).AlertEnumerate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageCluster/AlertEnumerate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageClusterServer).AlertEnumerate(ctx, req.(*SdkClusterAlertEnumerateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageCluster_AlertClear_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkClusterAlertClearRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageClusterServer).AlertClear(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageCluster/AlertClear",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageClusterServer).AlertClear(ctx, req.(*SdkClusterAlertClearRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OpenStorageCluster_AlertErase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SdkClusterAlertEraseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OpenStorageClusterServer).AlertErase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/openstorage.api.OpenStorageCluster/AlertErase",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OpenStorageClusterServer).AlertErase(ctx, req.(*SdkClusterAlertEraseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _OpenStorageCluster_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "openstorage.api.OpenStorageCluster",
+	HandlerType: (*OpenStorageClusterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Enumerate",
+			Handler:    _OpenStorageCluster_Enumerate_Handler,
+		},
+		{
+			MethodName: "Inspect",
+			Handler:    _OpenStorageCluster_Inspect_Handler,
+		},
+		{
+			MethodName: "AlertEnumerate",
+			Handler:    _OpenStorageCluster_AlertEnumerate_Handler,
+		},
+		{
+			MethodName: "AlertClear",
+			Handler:    _OpenStorageCluster_AlertClear_Handler,
+		},
+		{
+			MethodName: "AlertErase",
+			Handler:    _OpenStorageCluster_AlertErase_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/api.proto",
+}
+
+// Client API for OpenStorageWatch service
+
+type OpenStorageWatchClient interface {
+	// Watch streams the events selected by the request. Watching after a
+	// revision no longer kept fails with OUT_OF_RANGE, clients must then
+	// list the current state and watch again.
+	Watch(ctx context.Context, in *SdkWatchRequest, opts ...grpc.CallOption) (OpenStorageWatch_WatchClient, error)
+}
+
+type openStorageWatchClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOpenStorageWatchClient(cc *grpc.ClientConn) OpenStorageWatchClient {
+	return &openStorageWatchClient{cc}
+}
+
+func (c *openStorageWatchClient) Watch(ctx context.Context, in *SdkWatchRequest, opts ...grpc.CallOption) (OpenStorageWatch_WatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_OpenStorageWatch_serviceDesc.Streams[0], c.cc, "/openstorage.api.OpenStorageWatch/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &openStorageWatchWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type OpenStorageWatch_WatchClient interface {
+	Recv() (*SdkWatchEvent, error)
+	grpc.ClientStream
+}
+
+type openStorageWatchWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *openStorageWatchWatchClient) Recv() (*SdkWatchEvent, error) {
+	m := new(SdkWatchEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for OpenStorageWatch service
+
+type OpenStorageWatchServer interface {
+	// Watch streams the events selected by the request. Watching after a
+	// revision no longer kept fails with OUT_OF_RANGE, clients must then
+	// list the current state and watch again.
+	Watch(*SdkWatchRequest, OpenStorageWatch_WatchServer) error
+}
+
+func RegisterOpenStorageWatchServer(s *grpc.Server, srv OpenStorageWatchServer) {
+	s.RegisterService(&_OpenStorageWatch_serviceDesc, srv)
+}
+
+func _OpenStorageWatch_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SdkWatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OpenStorageWatchServer).Watch(m, &openStorageWatchWatchServer{stream})
+}
+
+type OpenStorageWatch_WatchServer interface {
+	Send(*SdkWatchEvent) error
+	grpc.ServerStream
+}
+
+type openStorageWatchWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *openStorageWatchWatchServer) Send(m *SdkWatchEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _OpenStorageWatch_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "openstorage.api.OpenStorageWatch",
+	HandlerType: (*OpenStorageWatchServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _OpenStorageWatch_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/api.proto",
 }
 
 func init() { proto.RegisterFile("api/api.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 3012 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x59, 0x4b, 0x73, 0xe3, 0xc6,
-	0xb5, 0x1e, 0xf0, 0xcd, 0x43, 0x91, 0x82, 0x7a, 0x34, 0x12, 0x46, 0xf3, 0x92, 0x59, 0xd7, 0xb6,
-	0x8a, 0xd7, 0x57, 0xe3, 0xd2, 0xb5, 0x7d, 0xc7, 0x73, 0xf3, 0xa2, 0x48, 0x50, 0x62, 0xcc, 0x87,
-	0xdc, 0x80, 0x34, 0x33, 0x4e, 0xa5, 0x50, 0x18, 0xb2, 0x25, 0xc1, 0x43, 0x01, 0x18, 0x00, 0x54,
-	0x4a, 0x5e, 0x64, 0x9b, 0x4d, 0x2a, 0x59, 0x25, 0x29, 0xaf, 0xf2, 0x03, 0xbc, 0xca, 0x3a, 0x8b,
-	0x2c, 0xb2, 0xf2, 0x26, 0xbf, 0x24, 0x9b, 0xfc, 0x83, 0xd4, 0xe9, 0x6e, 0x50, 0x00, 0x29, 0xcd,
-	0x8c, 0x2a, 0x5e, 0xa9, 0xfb, 0x3b, 0x8f, 0x3e, 0x7d, 0xfa, 0xbc, 0x40, 0x41, 0xd5, 0xf6, 0x9d,
-	0xc7, 0xb6, 0xef, 0x6c, 0xfb, 0x81, 0x17, 0x79, 0x64, 0xd9, 0xf3, 0x99, 0x1b, 0x46, 0x5e, 0x60,
-	0x9f, 0xb0, 0x6d, 0xdb, 0x77, 0x36, 0x1e, 0x9d, 0x78, 0xde, 0xc9, 0x84, 0x3d, 0xe6, 0xe4, 0x97,
-	0xd3, 0xe3, 0xc7, 0x91, 0x73, 0xc6, 0xc2, 0xc8, 0x3e, 0xf3, 0x85, 0x44, 0xfd, 0x5f, 0x19, 0x58,
-	0x36, 0x84, 0x00, 0x65, 0xa1, 0x37, 0x0d, 0x46, 0x8c, 0xd4, 0x20, 0xe3, 0x8c, 0x35, 0x65, 0x53,
-	0xd9, 0x2a, 0xd3, 0x8c, 0x33, 0x26, 0x04, 0x72, 0xbe, 0x1d, 0x9d, 0x6a, 0x19, 0x8e, 0xf0, 0x35,
-	0xf9, 0x0c, 0x0a, 0x67, 0x6c, 0xec, 0x4c, 0xcf, 0xb4, 0xec, 0xa6, 0xb2, 0x55, 0xdb, 0x79, 0xb8,
-	0x3d, 0x77, 0xf4, 0xb6, 0xd4, 0xda, 0xe7, 0x5c, 0x54, 0x72, 0x93, 0x35, 0x28, 0x78, 0xee, 0xc4,
-	0x71, 0x99, 0x96, 0xdb, 0x54, 0xb6, 0x4a, 0x54, 0xee, 0xf0, 0x0c, 0xc7, 0xf3, 0x43, 0x2d, 0xbf,
-	0xa9, 0x6c, 0xe5, 0x28, 0x5f, 0x93, 0x7b, 0x50, 0x0e, 0xd9, 0x6b, 0xeb, 0x57, 0x81, 0x13, 0x31,
-	0xad, 0xb0, 0xa9, 0x6c, 0x29, 0xb4, 0x14, 0xb2, 0xd7, 0xcf, 0x70, 0x4f, 0xee, 0x02, 0xae, 0xad,
-	0x80, 0xd9, 0x63, 0xad, 0xc8, 0x69, 0xc5, 0x90, 0xbd, 0xa6, 0xcc, 0x1e, 0xe3, 0x19, 0x81, 0xed,
-	0x8e, 0xe9, 0x33, 0xad, 0xc4, 0x09, 0x72, 0x87, 0x67, 0x84, 0xce, 0x37, 0x4c, 0x2b, 0x8b, 0x33,
-	0x70, 0x8d, 0xd8, 0x34, 0x64, 0x63, 0x0d, 0x04, 0x86, 0x6b, 0xf2, 0x3e, 0xd4, 0x02, 0x2f, 0xb2,
-	0x23, 0xc7, 0x73, 0xad, 0xd0, 0x67, 0x6c, 0xac, 0x55, 0xf8, 0xcd, 0xab, 0x31, 0x6a, 0x20, 0x48,
-	0xfe, 0x0f, 0xca, 0x13, 0x3b, 0x8c, 0xac, 0x70, 0x64, 0xbb, 0xda, 0xd2, 0xa6, 0xb2, 0x55, 0xd9,
-	0xd9, 0xd8, 0x16, 0xfe, 0xde, 0x8e, 0xfd, 0xbd, 0x6d, 0xc6, 0xfe, 0xa6, 0x25, 0x64, 0x36, 0x46,
-	0xb6, 0x5b, 0xff, 0x47, 0x06, 0x2a, 0xd2, 0x3b, 0x07, 0x9e, 0x37, 0x41, 0x7f, 0x77, 0xdb, 0xdc,
-	0xdf, 0x79, 0x9a, 0xe9, 0xb6, 0x49, 0x03, 0xb2, 0x2d, 0x2f, 0xe4, 0xee, 0xae, 0xed, 0x68, 0x0b,
-	0x8e, 0x6d, 0x79, 0xa1, 0x79, 0xe1, 0x33, 0x8a, 0x4c, 0xf8, 0x0e, 0xfd, 0x1b, 0xbd, 0x83, 0xf8,
-	0x4b, 0xee, 0x43, 0x99, 0xda, 0xce, 0xb8, 0xc7, 0xce, 0xd9, 0x84, 0x3f, 0x45, 0x99, 0x5e, 0x02,
-	0x48, 0x35, 0xbd, 0xc8, 0x9e, 0x18, 0xe8, 0xae, 0x22, 0x77, 0xcd, 0x25, 0x80, 0x3e, 0x3b, 0x44,
-	0x9f, 0x95, 0x84, 0xcf, 0x70, 0x4d, 0x7e, 0x06, 0x85, 0x89, 0xfd, 0x92, 0x4d, 0x42, 0xad, 0xbc,
-	0x99, 0xdd, 0xaa, 0xec, 0x6c, 0x5d, 0x67, 0x07, 0xde, 0x78, 0xbb, 0xc7, 0x59, 0x75, 0x37, 0x0a,
-	0x2e, 0xa8, 0x94, 0xdb, 0xf8, 0x1c, 0x2a, 0x09, 0x98, 0xa8, 0x90, 0x7d, 0xc5, 0x2e, 0x64, 0x14,
-	0xe2, 0x92, 0xac, 0x42, 0xfe, 0xdc, 0x9e, 0x4c, 0x99, 0x8c, 0x43, 0xb1, 0x79, 0x9a, 0x79, 0xa2,
-	0xd4, 0xff, 0xaa, 0x40, 0xf5, 0xc8, 0x9b, 0x4c, 0xcf, 0x58, 0xcf, 0x1b, 0xd9, 0x91, 0x17, 0xa0,
-	0x89, 0xae, 0x7d, 0xc6, 0xa4, 0x38, 0x5f, 0x93, 0x43, 0xa8, 0x9e, 0x73, 0x26, 0x4b, 0x5a, 0x9a,
-	0xe1, 0x96, 0x7e, 0xbc, 0x60, 0x69, 0x4a, 0x55, 0xbc, 0x4b, 0x58, 0xbc, 0x74, 0x9e, 0x80, 0x36,
-	0x7e, 0x0a, 0x2b, 0x0b, 0x2c, 0x37, 0xb2, 0xfe, 0x13, 0x28, 0x18, 0x22, 0xf1, 0xd6, 0xa0, 0xe0,
-	0xdb, 0x01, 0x73, 0x23, 0x29, 0x28, 0x77, 0x3c, 0x70, 0x31, 0x0c, 0x65, 0x02, 0xe2, 0xba, 0xbe,
-	0x0e, 0xf9, 0xbd, 0xc0, 0x9b, 0xfa, 0xf3, 0xd9, 0x5a, 0xff, 0x7b, 0x11, 0x40, 0x18, 0x64, 0xf8,
-	0x6c, 0x84, 0x4f, 0xc9, 0xfc, 0x53, 0x76, 0xc6, 0x02, 0x7b, 0xc2, 0xb9, 0x4a, 0xf4, 0x12, 0x98,
-	0xa5, 0x44, 0x26, 0x91, 0x12, 0x8f, 0xa1, 0x70, 0xec, 0x05, 0x67, 0x76, 0x24, 0x43, 0x6a, 0x7d,
-	0xc1, 0x41, 0x1d, 0x83, 0x07, 0xa0, 0x64, 0x23, 0x0f, 0x00, 0x5e, 0x4e, 0xbc, 0xd1, 0x2b, 0x8b,
-	0xab, 0xc2, 0x60, 0xca, 0xd2, 0x32, 0x47, 0x78, 0xb8, 0xdc, 0x85, 0xd2, 0xa9, 0x6d, 0x4d, 0x78,
-	0xa4, 0xe5, 0x39, 0xb1, 0x78, 0x6a, 0x8b, 0x38, 0x6b, 0x40, 0x76, 0xe4, 0x85, 0x3c, 0xb7, 0xdf,
-	0x18, 0xe9, 0x23, 0x2f, 0x24, 0x9f, 0x03, 0x38, 0x9e, 0xe5, 0x07, 0xde, 0xb1, 0x33, 0x11, 0x41,
-	0x59, 0xdb, 0xd9, 0x58, 0x10, 0xe9, 0x7a, 0x07, 0x82, 0x83, 0x96, 0x9d, 0x78, 0x89, 0x7e, 0x1d,
-	0xb3, 0xf1, 0xd4, 0x67, 0x3c, 0x64, 0x4b, 0x54, 0xee, 0xc8, 0x7f, 0xc3, 0x4a, 0xe8, 0xda, 0x7e,
-	0x78, 0xea, 0x45, 0x96, 0xe3, 0x46, 0x2c, 0x38, 0xb7, 0x27, 0xbc, 0x3a, 0x54, 0xa9, 0x1a, 0x13,
-	0xba, 0x12, 0x27, 0x74, 0x3e, 0x7c, 0x80, 0x87, 0xcf, 0xff, 0x5c, 0x13, 0x3e, 0xe8, 0xfc, 0xb7,
-	0xc5, 0x0e, 0x1a, 0x16, 0x9e, 0xda, 0x81, 0xac, 0x30, 0x25, 0x2a, 0x77, 0xe4, 0x47, 0x50, 0x09,
-	0x98, 0x3f, 0x71, 0x46, 0xb6, 0x15, 0xb2, 0x48, 0x16, 0x97, 0x7b, 0x0b, 0x27, 0x51, 0xc1, 0x63,
-	0xb0, 0x88, 0x42, 0x30, 0x5b, 0xe3, 0xb5, 0xec, 0x93, 0x93, 0x80, 0x9d, 0x88, 0x12, 0x26, 0x3c,
-	0x5f, 0x15, 0xd7, 0x4a, 0x10, 0x66, 0xa9, 0xce, 0xdc, 0x51, 0x70, 0xe1, 0x47, 0x6c, 0xac, 0xd5,
-	0x64, 0x7c, 0xc4, 0x00, 0x79, 0x08, 0xe0, 0xdb, 0x61, 0xe8, 0x9f, 0x06, 0x76, 0xc8, 0xb4, 0x65,
-	0x1e, 0x64, 0x09, 0x24, 0xe5, 0xc1, 0x70, 0x74, 0xca, 0xc6, 0xd3, 0x09, 0xd3, 0x54, 0xce, 0x36,
-	0xf3, 0xa0, 0x21, 0x71, 0x4c, 0x81, 0x70, 0x64, 0x4f, 0x98, 0xb6, 0xc2, 0x6d, 0x11, 0x1b, 0xee,
-	0x83, 0xc8, 0x19, 0xbd, 0xba, 0xd0, 0x88, 0xf4, 0x01, 0xdf, 0x91, 0x8f, 0x20, 0x7f, 0x82, 0x01,
-	0xae, 0xdd, 0xe1, 0xb7, 0x5f, 0x5b, 0xb8, 0x3d, 0x0f, 0x7f, 0x2a, 0x98, 0xb0, 0x66, 0xf3, 0x85,
-	0xc5, 0xdc, 0x63, 0x2f, 0x18, 0xb1, 0xb1, 0xb6, 0xc6, 0xb5, 0x55, 0x39, 0xaa, 0x4b, 0x10, 0xef,
-	0x33, 0xf2, 0xce, 0xfc, 0x80, 0x85, 0x58, 0xc0, 0xd6, 0x39, 0x4b, 0x02, 0x21, 0x1b, 0x50, 0x1a,
-	0xd9, 0xe1, 0xc8, 0x1e, 0xb3, 0xb1, 0xa6, 0x71, 0xea, 0x6c, 0x4f, 0x34, 0x28, 0x7e, 0xed, 0x4d,
-	0x03, 0xd7, 0x9e, 0x68, 0x77, 0x39, 0x29, 0xde, 0x62, 0xb6, 0xbb, 0xc7, 0xa1, 0xb6, 0xc1, 0x51,
-	0x5c, 0xfe, 0xe7, 0x45, 0xa1, 0x0e, 0x70, 0xf9, 0xba, 0xc8, 0xe7, 0x7a, 0x63, 0x16, 0x6a, 0xca,
-	0x66, 0x16, 0xf9, 0xf8, 0xa6, 0xfe, 0x9d, 0x02, 0xcb, 0x74, 0xea, 0x62, 0x4b, 0x37, 0x22, 0x3b,
-	0x62, 0x7d, 0xdb, 0x27, 0xcf, 0xa0, 0x1a, 0x08, 0xc8, 0x0a, 0x11, 0xe3, 0x12, 0x95, 0x9d, 0x9d,
-	0xc5, 0xd8, 0x49, 0x0b, 0xa6, 0xf6, 0x32, 0x54, 0x83, 0x04, 0x84, 0x37, 0x5a, 0x60, 0xb9, 0xd1,
-	0x8d, 0xfe, 0x54, 0x82, 0x82, 0xf0, 0xc9, 0xc2, 0x80, 0xf1, 0x18, 0x0a, 0x62, 0xf4, 0xe0, 0x52,
-	0x95, 0x2b, 0x2a, 0x8e, 0x28, 0x90, 0x54, 0xb2, 0x5d, 0xc6, 0x46, 0xf6, 0x5d, 0x62, 0x63, 0x03,
-	0x4a, 0x38, 0x26, 0x78, 0xee, 0xe4, 0x42, 0x4e, 0x1d, 0xb3, 0x3d, 0x79, 0x02, 0xc5, 0x89, 0x28,
-	0xf4, 0xbc, 0x36, 0x55, 0xae, 0x68, 0xa0, 0xa9, 0x76, 0x40, 0x63, 0x76, 0xf2, 0x31, 0xe4, 0x47,
-	0xe8, 0x0e, 0x5e, 0xbd, 0xde, 0xdc, 0xfa, 0x05, 0x23, 0x79, 0x0c, 0xb9, 0xd0, 0x67, 0x23, 0x5e,
-	0xbb, 0xae, 0x4a, 0xe7, 0xcb, 0xc2, 0x41, 0x39, 0x23, 0x3a, 0x73, 0x1a, 0xda, 0x27, 0x4c, 0x76,
-	0x5a, 0xb1, 0x49, 0xcf, 0x1d, 0xe5, 0x77, 0x9f, 0x3b, 0x12, 0x85, 0x1d, 0xde, 0xad, 0xb0, 0x7f,
-	0x8a, 0xa9, 0x69, 0x47, 0xd3, 0x90, 0x97, 0xa7, 0xda, 0xce, 0x83, 0xeb, 0x4c, 0xe6, 0x4c, 0x54,
-	0x32, 0x93, 0x1d, 0xc8, 0x8b, 0xd8, 0x5b, 0xe2, 0x52, 0xf7, 0xdf, 0x20, 0xc5, 0xa8, 0x60, 0x25,
-	0x8f, 0xa0, 0x62, 0x47, 0x91, 0x8d, 0xa5, 0xc2, 0xf2, 0x5c, 0x5e, 0xad, 0xca, 0x14, 0x62, 0x68,
-	0xe8, 0x92, 0x16, 0xd4, 0x66, 0x0c, 0x42, 0x7b, 0xed, 0x1a, 0xed, 0x4d, 0xce, 0x26, 0xb4, 0x57,
-	0x63, 0x19, 0x23, 0x3e, 0x65, 0xcc, 0xce, 0x9d, 0x11, 0xb3, 0xf8, 0x40, 0x2b, 0xeb, 0x99, 0x80,
-	0x0e, 0x70, 0xac, 0xfd, 0x08, 0x48, 0xc8, 0x46, 0xd3, 0x80, 0x59, 0x49, 0xbe, 0xb8, 0xa0, 0x71,
-	0x4a, 0xfb, 0x92, 0x7b, 0x66, 0xb4, 0x60, 0x5b, 0xe1, 0xc9, 0x29, 0x8d, 0xe6, 0x0c, 0xfb, 0x33,
-	0x06, 0xc7, 0x3d, 0xf6, 0x34, 0xc2, 0x73, 0xf1, 0xc3, 0x6b, 0xfc, 0x21, 0x0d, 0xef, 0xba, 0xc7,
-	0x9e, 0x48, 0x40, 0xa9, 0x09, 0x01, 0xf2, 0x13, 0x58, 0x4a, 0x74, 0x84, 0x50, 0xbb, 0xcd, 0x55,
-	0xbd, 0xb1, 0x25, 0x54, 0x2e, 0x5b, 0x42, 0x48, 0xf4, 0xf9, 0xba, 0xb0, 0xca, 0x15, 0x6c, 0xbe,
-	0xad, 0x2e, 0xa4, 0xab, 0x00, 0x46, 0x24, 0x0b, 0x02, 0x2f, 0xe0, 0x45, 0xb9, 0x4c, 0xc5, 0x66,
-	0xe3, 0xc7, 0xb0, 0x3c, 0x67, 0xfb, 0x8d, 0x2a, 0xc3, 0x9f, 0x33, 0x90, 0x47, 0xf5, 0x21, 0xf2,
-	0x60, 0x66, 0x86, 0x5c, 0x2e, 0x47, 0xc5, 0x86, 0xac, 0x43, 0x11, 0x17, 0xd6, 0x59, 0x28, 0xe7,
-	0x94, 0x02, 0x6e, 0xfb, 0x21, 0x0e, 0x1e, 0x9c, 0xf0, 0xf2, 0x22, 0x62, 0x21, 0xaf, 0x05, 0x39,
-	0x5a, 0x46, 0x64, 0x17, 0x01, 0xec, 0x2c, 0xfc, 0xdb, 0x21, 0xe4, 0x59, 0x9f, 0xa3, 0x72, 0x87,
-	0x03, 0x09, 0x5f, 0xa1, 0x42, 0xf1, 0xbd, 0x51, 0xe4, 0xfb, 0x7e, 0x88, 0x2f, 0x2a, 0x48, 0x42,
-	0x65, 0x81, 0x53, 0x81, 0x43, 0x42, 0xe7, 0x23, 0xa8, 0x88, 0x29, 0xe4, 0x04, 0x3b, 0x86, 0x9c,
-	0x8d, 0x81, 0x8f, 0x1a, 0x1c, 0x21, 0xb7, 0x21, 0xef, 0x78, 0xa8, 0xb9, 0x14, 0x7f, 0xc9, 0x08,
-	0x43, 0xb9, 0x42, 0x8b, 0x7f, 0x6b, 0x88, 0xef, 0x8f, 0x32, 0x47, 0xf8, 0xf0, 0x8c, 0x4a, 0xe5,
-	0x98, 0x81, 0x92, 0x20, 0x95, 0x4a, 0xa8, 0x1f, 0xd6, 0xff, 0x99, 0x81, 0x7c, 0x73, 0xc2, 0x82,
-	0x28, 0x51, 0x3a, 0xb3, 0xbc, 0x74, 0x7e, 0x8e, 0x9f, 0x41, 0xe7, 0x2c, 0x70, 0xa2, 0x0b, 0xf9,
-	0xc1, 0xb0, 0x98, 0xa4, 0x86, 0x64, 0xe0, 0xb9, 0x3d, 0x63, 0x47, 0xa3, 0x6c, 0xd4, 0x69, 0x45,
-	0x17, 0x3e, 0xe3, 0xde, 0xcb, 0xd2, 0x32, 0x47, 0x90, 0x11, 0xdb, 0xdd, 0x19, 0x0b, 0x79, 0xf9,
-	0x11, 0xdf, 0x07, 0xf1, 0x96, 0x3c, 0x81, 0xf2, 0xec, 0x33, 0x52, 0x56, 0xcd, 0x37, 0x15, 0xa0,
-	0x4b, 0x66, 0xbc, 0x68, 0x20, 0xbf, 0x32, 0x2d, 0x67, 0xcc, 0xdd, 0x5b, 0xc6, 0xd1, 0x45, 0x40,
-	0x5d, 0x7e, 0x9d, 0x78, 0x27, 0x47, 0xbc, 0x07, 0x57, 0x84, 0xb8, 0x60, 0x10, 0xd7, 0x89, 0xd9,
-	0xd1, 0xde, 0xd1, 0x84, 0xf1, 0x61, 0x4a, 0x4c, 0x79, 0xf1, 0x16, 0x63, 0x31, 0x8a, 0x26, 0xd2,
-	0xed, 0xb8, 0xc4, 0xab, 0x4f, 0x5d, 0xe7, 0xf5, 0x94, 0x59, 0x91, 0x7d, 0xc2, 0xfd, 0x5d, 0xa6,
-	0x65, 0x81, 0x98, 0xf6, 0x49, 0xfd, 0x33, 0x28, 0x70, 0x6f, 0x87, 0xd8, 0x68, 0xb8, 0x47, 0x64,
-	0x1b, 0x5d, 0x6c, 0x34, 0x9c, 0x8f, 0x0a, 0xa6, 0xfa, 0x5f, 0x14, 0xb8, 0x2d, 0x72, 0xb9, 0x15,
-	0x30, 0x2c, 0x3f, 0xec, 0xf5, 0x94, 0x85, 0x51, 0xb2, 0xc9, 0x28, 0x37, 0x6b, 0x32, 0x37, 0xee,
-	0x8c, 0x71, 0x8f, 0xc9, 0xbe, 0x63, 0x8f, 0xa9, 0x7f, 0x00, 0x35, 0x81, 0x51, 0x16, 0xfa, 0x9e,
-	0x1b, 0x26, 0x72, 0x5c, 0x49, 0xe4, 0x78, 0xdd, 0x87, 0xd5, 0xf4, 0xd5, 0x24, 0xf7, 0x7c, 0x2f,
-	0xdf, 0x87, 0x65, 0x39, 0x26, 0x07, 0x92, 0x45, 0x9a, 0xfe, 0xe8, 0x1a, 0x5b, 0x62, 0x4d, 0xb4,
-	0x76, 0x9e, 0xda, 0xd7, 0xbf, 0x57, 0xe2, 0x21, 0x8a, 0xd7, 0x9e, 0xe6, 0x08, 0x87, 0x56, 0xf2,
-	0x14, 0x0a, 0xa2, 0x2c, 0xf2, 0x33, 0x6b, 0x3b, 0xf5, 0x6b, 0xd4, 0x0a, 0xf6, 0x03, 0x3b, 0xb0,
-	0xcf, 0xa8, 0x94, 0x20, 0x4f, 0x20, 0x7f, 0xe6, 0x4d, 0xdd, 0x48, 0x66, 0xca, 0xbb, 0x88, 0x0a,
-	0x01, 0x0c, 0x18, 0xbe, 0x10, 0x85, 0x3e, 0x2b, 0x02, 0x86, 0x23, 0x71, 0x23, 0x48, 0xf6, 0x8b,
-	0xdc, 0x7c, 0x5f, 0xa9, 0xff, 0x2d, 0x03, 0xaa, 0xbc, 0x0b, 0x8b, 0x7e, 0x88, 0xb0, 0x10, 0xaf,
-	0x9c, 0x79, 0xd7, 0x49, 0x02, 0xbd, 0xc6, 0x6f, 0x25, 0x03, 0xa3, 0xfe, 0xa6, 0x9e, 0x2c, 0xee,
-	0x4f, 0xa5, 0x04, 0xd9, 0x87, 0xa2, 0xe7, 0xe3, 0x0a, 0xeb, 0x28, 0x66, 0xc1, 0xf6, 0x75, 0xc2,
-	0xb3, 0xab, 0x6d, 0x0f, 0x85, 0x80, 0xe8, 0x63, 0xb1, 0xf8, 0xc6, 0x53, 0x58, 0x4a, 0x12, 0x6e,
-	0xd4, 0x24, 0x7e, 0x77, 0x19, 0x0d, 0x78, 0x8c, 0x8c, 0xbe, 0xc7, 0x50, 0x10, 0x51, 0x23, 0x3d,
-	0xb8, 0x7e, 0x5d, 0x90, 0x49, 0xb6, 0x1f, 0x30, 0x3c, 0x2f, 0x60, 0xc5, 0x70, 0x6d, 0x3f, 0x9d,
-	0xe9, 0xf3, 0xd9, 0x90, 0x78, 0xe2, 0xcc, 0xcd, 0x9e, 0x38, 0x39, 0xb4, 0x66, 0xd3, 0x43, 0x6b,
-	0xfd, 0x35, 0x90, 0xe4, 0xd1, 0xd2, 0x17, 0xbf, 0x80, 0x35, 0x79, 0xb5, 0x11, 0x27, 0x5c, 0xde,
-	0x50, 0xf8, 0xe6, 0xfd, 0x6b, 0x8e, 0x4e, 0xab, 0xa1, 0xab, 0xe7, 0x57, 0xa0, 0xf5, 0x28, 0xfe,
-	0x51, 0x81, 0x4f, 0x23, 0xf7, 0xa0, 0x2c, 0x8f, 0x9a, 0xdd, 0xb6, 0x24, 0x80, 0xee, 0xd5, 0x3f,
-	0x17, 0x7e, 0x0a, 0x45, 0x79, 0xf0, 0xbb, 0x54, 0xa6, 0x98, 0xb7, 0x3e, 0x06, 0xb2, 0x17, 0xd8,
-	0xfe, 0x69, 0x3b, 0x70, 0xce, 0x59, 0xd0, 0x3a, 0xb5, 0xdd, 0x13, 0x16, 0xce, 0x0e, 0x50, 0x12,
-	0x07, 0x3c, 0x85, 0xdc, 0x2b, 0xc7, 0x1d, 0xcb, 0xcc, 0xfe, 0xe0, 0x8a, 0x0f, 0x82, 0x39, 0x35,
-	0xbc, 0x7b, 0x70, 0x99, 0xfa, 0x87, 0xb0, 0xdc, 0x9a, 0x4c, 0xc3, 0x88, 0x05, 0x6f, 0xa9, 0x81,
-	0x7f, 0x54, 0xa0, 0x8a, 0xc9, 0x71, 0x3e, 0x7b, 0xef, 0x7d, 0x28, 0x51, 0xf6, 0x9a, 0x85, 0xd1,
-	0x17, 0x47, 0xb2, 0x45, 0x7c, 0xb4, 0xd8, 0x22, 0x92, 0x12, 0xdb, 0x31, 0xbb, 0x48, 0x8d, 0x99,
-	0xf4, 0xc6, 0xff, 0x43, 0x35, 0x45, 0x4a, 0x26, 0x47, 0xf6, 0x6d, 0xc9, 0xf1, 0x0d, 0xd4, 0x52,
-	0xa7, 0x84, 0xa4, 0x0e, 0x4b, 0x72, 0xdd, 0xe2, 0x15, 0x4f, 0xa8, 0x49, 0x61, 0xa4, 0x3d, 0x77,
-	0x1b, 0xf9, 0x83, 0xd8, 0xc3, 0x37, 0xdf, 0x80, 0xa6, 0x85, 0x1a, 0xdf, 0x67, 0xa0, 0x20, 0x3e,
-	0x00, 0xc8, 0x32, 0x54, 0x0c, 0xb3, 0x69, 0x1e, 0x1a, 0xd6, 0x60, 0x38, 0xd0, 0xd5, 0x5b, 0x09,
-	0xa0, 0x3b, 0xe8, 0x9a, 0xaa, 0x42, 0xaa, 0x50, 0x96, 0xc0, 0xf0, 0x0b, 0x35, 0x43, 0x08, 0xd4,
-	0xe2, 0x6d, 0xa7, 0xd3, 0xeb, 0x0e, 0x74, 0x35, 0x4b, 0x54, 0x58, 0x92, 0x98, 0x4e, 0xe9, 0x90,
-	0xaa, 0x39, 0xa2, 0xc1, 0xea, 0x4c, 0xad, 0x69, 0x75, 0x07, 0xd6, 0x97, 0x87, 0x43, 0x7a, 0xd8,
-	0x57, 0xf3, 0x64, 0x1d, 0x6e, 0x4b, 0x4a, 0x5b, 0x6f, 0x0d, 0xfb, 0xfd, 0xae, 0x61, 0x74, 0x87,
-	0x03, 0xb5, 0x40, 0xd6, 0x80, 0x48, 0x42, 0xbf, 0xd9, 0x1d, 0x98, 0xfa, 0xa0, 0x39, 0x68, 0xe9,
-	0x6a, 0x31, 0x21, 0x60, 0x98, 0x43, 0xda, 0xdc, 0xd3, 0xad, 0xf6, 0xf0, 0xd9, 0x40, 0x2d, 0x91,
-	0x7b, 0xb0, 0x3e, 0x4f, 0xd0, 0xf7, 0x68, 0xb3, 0xad, 0xb7, 0xd5, 0x72, 0x42, 0x6a, 0xa0, 0xeb,
-	0x6d, 0xc3, 0xa2, 0xfa, 0xee, 0x70, 0x68, 0xaa, 0x40, 0xee, 0x83, 0x36, 0x27, 0x45, 0xf5, 0xdd,
-	0x66, 0x8f, 0x1f, 0x56, 0x21, 0x9b, 0x70, 0x7f, 0x5e, 0x27, 0xed, 0x1e, 0x21, 0xcf, 0x41, 0xaf,
-	0xd9, 0xd2, 0xd5, 0x25, 0x52, 0x03, 0x98, 0x99, 0xf9, 0x5c, 0xad, 0x36, 0xbe, 0x55, 0x00, 0x44,
-	0x90, 0xf2, 0x11, 0x6c, 0x15, 0x54, 0x2e, 0x41, 0x2d, 0xf3, 0xc5, 0x81, 0x1e, 0x3b, 0x75, 0x0e,
-	0xed, 0x74, 0x7b, 0xba, 0xaa, 0x90, 0x3b, 0xb0, 0x92, 0x44, 0x77, 0x7b, 0xc3, 0x16, 0x7a, 0x78,
-	0x0d, 0x48, 0x12, 0x1e, 0xee, 0xfe, 0x5c, 0x6f, 0x99, 0x6a, 0x96, 0xdc, 0x85, 0x3b, 0x49, 0xbc,
-	0xd5, 0x3b, 0x34, 0x4c, 0x9d, 0xea, 0x6d, 0x35, 0x37, 0xaf, 0x69, 0x8f, 0x36, 0x0f, 0xf6, 0xd5,
-	0x7c, 0xe3, 0x0f, 0x0a, 0x14, 0xc4, 0xf7, 0x21, 0x3e, 0x51, 0xc7, 0x48, 0xd9, 0xb4, 0x02, 0xd5,
-	0x18, 0xd9, 0x35, 0x69, 0xc7, 0x50, 0x95, 0x24, 0x93, 0xfe, 0xdc, 0xfc, 0x44, 0xcd, 0x24, 0x91,
-	0xce, 0xa1, 0x81, 0x6f, 0xbd, 0x0c, 0x95, 0x99, 0xa2, 0x8e, 0xa1, 0xe6, 0x92, 0xc0, 0x51, 0xc7,
-	0x50, 0xf3, 0x49, 0xe0, 0x79, 0xc7, 0x50, 0x0b, 0x49, 0xe0, 0xab, 0x8e, 0xa1, 0x16, 0x1b, 0xdf,
-	0x29, 0x70, 0xe7, 0xca, 0xec, 0x26, 0xef, 0xc1, 0x03, 0x6e, 0xbc, 0x25, 0xaf, 0xd3, 0xda, 0x6f,
-	0x0e, 0xf6, 0xf4, 0x94, 0xdd, 0xef, 0xc3, 0x7b, 0xd7, 0xb2, 0xf4, 0x87, 0xed, 0x6e, 0xa7, 0xab,
-	0xb7, 0x55, 0x85, 0xd4, 0xe1, 0xe1, 0xb5, 0x6c, 0xcd, 0x36, 0x06, 0x49, 0x86, 0xfc, 0x17, 0x6c,
-	0x5e, 0xcb, 0xd3, 0xd6, 0x7b, 0xba, 0xa9, 0xb7, 0xd5, 0x6c, 0x23, 0x82, 0xa5, 0xe4, 0x38, 0xce,
-	0x03, 0x55, 0x3f, 0xd2, 0x69, 0xd7, 0x7c, 0x91, 0x32, 0x0c, 0x43, 0x2e, 0x85, 0x37, 0x7b, 0x4d,
-	0xda, 0x57, 0x15, 0x7c, 0xb8, 0x34, 0xe1, 0x59, 0x93, 0x0e, 0xba, 0x83, 0x3d, 0x35, 0xc3, 0xf3,
-	0x64, 0x4e, 0x97, 0xd9, 0xed, 0xbc, 0x50, 0xb3, 0x8d, 0xdf, 0x2a, 0x58, 0x0e, 0x2e, 0xc7, 0x66,
-	0x3c, 0x96, 0xea, 0xc6, 0xf0, 0x90, 0xb6, 0xd2, 0xfe, 0xd0, 0x60, 0x35, 0x8d, 0x1f, 0x0d, 0x7b,
-	0x87, 0x7d, 0x8c, 0xaf, 0x2b, 0x24, 0xda, 0xba, 0x9a, 0x41, 0x7b, 0xd2, 0xb8, 0x0c, 0x25, 0x35,
-	0x8b, 0x77, 0x48, 0x93, 0xb8, 0x67, 0xd4, 0x5c, 0xe3, 0x37, 0x0a, 0x2c, 0xf3, 0xc1, 0x59, 0x4c,
-	0x1a, 0xdc, 0xa2, 0x0d, 0x58, 0x6b, 0xf6, 0x74, 0x6a, 0x5a, 0xcd, 0x96, 0xd9, 0x1d, 0x0e, 0x52,
-	0x56, 0xdd, 0x07, 0x6d, 0x91, 0x26, 0x7c, 0xaa, 0x2a, 0x57, 0x53, 0x5b, 0x54, 0x6f, 0x9a, 0x68,
-	0xdf, 0x95, 0xd4, 0xc3, 0x83, 0x36, 0x52, 0xb3, 0x8d, 0xaf, 0xe3, 0xa1, 0x22, 0x31, 0xf3, 0xa1,
-	0x88, 0xb8, 0x76, 0x2c, 0x73, 0xd0, 0xa4, 0xcd, 0x7e, 0x6c, 0xcc, 0x3d, 0x58, 0xbf, 0x8a, 0x3a,
-	0xec, 0x74, 0x54, 0x05, 0x6f, 0x71, 0x25, 0x71, 0xa0, 0x66, 0x1a, 0x3b, 0x50, 0x94, 0x3f, 0x68,
-	0x93, 0x12, 0xe4, 0xa4, 0xb6, 0x22, 0x64, 0x7b, 0xc3, 0x67, 0xaa, 0x42, 0x00, 0x0a, 0x7d, 0xbd,
-	0xdd, 0x3d, 0xec, 0xab, 0x19, 0x24, 0xef, 0x77, 0xf7, 0xf6, 0xd5, 0x6c, 0xe3, 0xd7, 0x50, 0x9e,
-	0xfd, 0xa2, 0x8d, 0xae, 0xee, 0x0e, 0xad, 0x03, 0x3a, 0xc4, 0x94, 0xb7, 0x0c, 0xfd, 0xcb, 0x43,
-	0x7d, 0x60, 0x76, 0x9b, 0x3d, 0xf5, 0x16, 0xe6, 0x6c, 0x82, 0x44, 0x9b, 0x83, 0xf6, 0x10, 0x83,
-	0x65, 0x05, 0xaa, 0x09, 0xb8, 0xbd, 0x2b, 0x82, 0x24, 0x05, 0x59, 0x54, 0xef, 0x0f, 0xd1, 0x17,
-	0x58, 0x8c, 0x13, 0x94, 0x56, 0xdf, 0x50, 0x73, 0x8d, 0x6f, 0x33, 0x50, 0x49, 0x4c, 0x86, 0x78,
-	0x8e, 0xbc, 0x1f, 0xd6, 0xad, 0x64, 0xd8, 0xa4, 0xe0, 0x03, 0x7d, 0xd0, 0xc6, 0x98, 0x4c, 0x3a,
-	0x44, 0x50, 0x9a, 0x47, 0xcd, 0x6e, 0xaf, 0xb9, 0xdb, 0x93, 0xa1, 0x93, 0xa6, 0x99, 0x66, 0xb3,
-	0xb5, 0x8f, 0x69, 0xb2, 0x40, 0x6a, 0xeb, 0x92, 0x94, 0x4b, 0xf8, 0xff, 0x92, 0x64, 0xb6, 0xf6,
-	0xf1, 0xb8, 0x3c, 0x46, 0x69, 0x8a, 0x28, 0x5a, 0x48, 0x61, 0xc1, 0xc0, 0x38, 0x21, 0x8b, 0xe4,
-	0x21, 0x6c, 0xa4, 0x28, 0x26, 0x7d, 0x21, 0x4f, 0x43, 0x8d, 0xa5, 0x05, 0x49, 0xaa, 0x63, 0x31,
-	0xd7, 0xd5, 0x72, 0xe3, 0xf7, 0x0a, 0x2c, 0x25, 0x7f, 0xff, 0x9a, 0x3b, 0xfc, 0xb2, 0x0b, 0x3e,
-	0x80, 0xbb, 0xf3, 0xb8, 0x69, 0x1d, 0x50, 0xdd, 0xd0, 0x07, 0xd8, 0x13, 0x57, 0x41, 0x4d, 0x93,
-	0x0f, 0x0f, 0x44, 0xe1, 0x4e, 0xa3, 0xbc, 0x51, 0x65, 0xe7, 0x1c, 0xca, 0x3b, 0x9f, 0xec, 0x53,
-	0xb9, 0xc6, 0x2f, 0xa1, 0x9a, 0xfa, 0x6f, 0x9f, 0xe8, 0x6a, 0xa2, 0xf5, 0x88, 0xe0, 0xb2, 0xfa,
-	0xcd, 0xbd, 0x81, 0x6e, 0x76, 0x5b, 0xea, 0x2d, 0xd1, 0x23, 0x53, 0x44, 0xc3, 0xc0, 0x62, 0xc7,
-	0xbb, 0x5d, 0x0a, 0x1f, 0x1c, 0xf5, 0x75, 0x35, 0xd3, 0xd8, 0x82, 0xaa, 0x9c, 0x93, 0x06, 0x5e,
-	0xe4, 0x1c, 0x5f, 0x20, 0xa7, 0xcc, 0x76, 0x59, 0x6a, 0x84, 0x91, 0xb7, 0x1a, 0x0c, 0x2a, 0x89,
-	0x5f, 0xe1, 0xf0, 0x35, 0xc5, 0xdb, 0xc6, 0xaf, 0xf2, 0xdc, 0xd4, 0xe9, 0x80, 0x07, 0xee, 0x3c,
-	0x09, 0x9b, 0x35, 0x27, 0x29, 0xd8, 0x3e, 0xaf, 0x24, 0x59, 0xc6, 0xb3, 0xae, 0xd9, 0xda, 0x57,
-	0x33, 0x0d, 0x13, 0x6a, 0x43, 0x9f, 0x05, 0xfc, 0xbf, 0x19, 0x9d, 0x89, 0x7d, 0x12, 0xa2, 0x2f,
-	0x87, 0x07, 0x56, 0xa7, 0xd7, 0xdc, 0x33, 0xac, 0xc3, 0xc1, 0x17, 0x03, 0x6e, 0x0e, 0xa6, 0xc1,
-	0x0c, 0xe5, 0x6f, 0xc2, 0xcb, 0xe8, 0x0c, 0x12, 0xcf, 0x6d, 0x75, 0x86, 0xb4, 0xa5, 0xab, 0x99,
-	0xdd, 0xfb, 0x70, 0x7b, 0xe4, 0x9d, 0xcd, 0x0f, 0x41, 0x07, 0xca, 0x57, 0x59, 0xdb, 0x77, 0x5e,
-	0x16, 0xf8, 0x2f, 0x1c, 0xff, 0xfb, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x50, 0xea, 0xd0, 0xb2,
-	0x7a, 0x1f, 0x00, 0x00,
+	// 5154 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x5b, 0xcd, 0x6f, 0x1b, 0x49,
+	0x76, 0x9f, 0xe6, 0xa7, 0xf8, 0x24, 0x4a, 0xad, 0xb2, 0x2c, 0xb5, 0x29, 0xc9, 0xd6, 0xf4, 0xc4,
+	0x1e, 0x0d, 0xc7, 0x96, 0x3c, 0xda, 0x9d, 0xc9, 0x8c, 0x77, 0x66, 0xb3, 0x34, 0xd9, 0xb2, 0x99,
+	0x91, 0x48, 0x6d, 0x93, 0xb2, 0x67, 0x66, 0x91, 0x74, 0xda, 0x64, 0x59, 0xe2, 0x9a, 0xec, 0xa6,
+	0xbb, 0x9b, 0x1a, 0x78, 0x07, 0x13, 0x04, 0x59, 0x2c, 0x26, 0x48, 0xb2, 0xf9, 0xce, 0x2e, 0x76,
+	0x11, 0x24, 0x39, 0x05, 0x08, 0xf6, 0x14, 0x60, 0x6f, 0x41, 0x90, 0x4b, 0x80, 0x20, 0x97, 0xcd,
+	0x21, 0xc7, 0x5c, 0xf6, 0xb0, 0xa7, 0x20, 0x08, 0x90, 0xff, 0x20, 0x78, 0x55, 0xd5, 0xcd, 0xee,
+	0xe6, 0xa7, 0x36, 0x4e, 0x82, 0x5c, 0xec, 0xae, 0x57, 0xaf, 0x5e, 0xfd, 0xea, 0xd5, 0x7b, 0xaf,
+	0x5e, 0xbd, 0xa2, 0x20, 0x6f, 0xf6, 0x3b, 0xfb, 0x66, 0xbf, 0xb3, 0xd7, 0x77, 0x6c, 0xcf, 0x26,
+	0x2b, 0x76, 0x9f, 0x5a, 0xae, 0x67, 0x3b, 0xe6, 0x19, 0xdd, 0x33, 0xfb, 0x9d, 0xc2, 0x8d, 0x33,
+	0xdb, 0x3e, 0xeb, 0xd2, 0x7d, 0xd6, 0xfd, 0x64, 0xf0, 0x74, 0xdf, 0xeb, 0xf4, 0xa8, 0xeb, 0x99,
+	0xbd, 0x3e, 0x1f, 0x51, 0xd8, 0x12, 0x0c, 0x4c, 0x8e, 0x65, 0xd9, 0x9e, 0xe9, 0x75, 0x6c, 0xcb,
+	0xe5, 0xbd, 0xea, 0x7f, 0x26, 0x60, 0xa5, 0xc1, 0xc5, 0xe9, 0xd4, 0xb5, 0x07, 0x4e, 0x8b, 0x92,
+	0x65, 0x48, 0x74, 0xda, 0x8a, 0xb4, 0x23, 0xed, 0xe6, 0xf4, 0x44, 0xa7, 0x4d, 0x08, 0xa4, 0xfa,
+	0xa6, 0x77, 0xae, 0x24, 0x18, 0x85, 0x7d, 0x93, 0x77, 0x20, 0xd3, 0xa3, 0xed, 0xce, 0xa0, 0xa7,
+	0x24, 0x77, 0xa4, 0xdd, 0xe5, 0x83, 0xeb, 0x7b, 0x31, 0x60, 0x7b, 0x42, 0xea, 0x31, 0xe3, 0xd2,
+	0x05, 0x37, 0x59, 0x87, 0x8c, 0x6d, 0x75, 0x3b, 0x16, 0x55, 0x52, 0x3b, 0xd2, 0xee, 0x82, 0x2e,
+	0x5a, 0x38, 0x47, 0xc7, 0xee, 0xbb, 0x4a, 0x7a, 0x47, 0xda, 0x4d, 0xe9, 0xec, 0x9b, 0x6c, 0x42,
+	0xce, 0xa5, 0xcf, 0x8d, 0x4f, 0x9d, 0x8e, 0x47, 0x95, 0xcc, 0x8e, 0xb4, 0x2b, 0xe9, 0x0b, 0x2e,
+	0x7d, 0xfe, 0x18, 0xdb, 0xe4, 0x1a, 0xe0, 0xb7, 0xe1, 0x50, 0xb3, 0xad, 0x64, 0x59, 0x5f, 0xd6,
+	0xa5, 0xcf, 0x75, 0x6a, 0xb6, 0x71, 0x0e, 0xc7, 0xb4, 0xda, 0xfa, 0x63, 0x65, 0x81, 0x75, 0x88,
+	0x16, 0xce, 0xe1, 0x76, 0xbe, 0x45, 0x95, 0x1c, 0x9f, 0x03, 0xbf, 0x91, 0x36, 0x70, 0x69, 0x5b,
+	0x01, 0x4e, 0xc3, 0x6f, 0x72, 0x13, 0x96, 0x1d, 0xa1, 0x26, 0xc3, 0xed, 0x53, 0xda, 0x56, 0x16,
+	0xd9, 0xca, 0xf3, 0x3e, 0xb5, 0x81, 0x44, 0xf2, 0x8b, 0x90, 0xeb, 0x9a, 0xae, 0x67, 0xb8, 0x2d,
+	0xd3, 0x52, 0x96, 0x76, 0xa4, 0xdd, 0xc5, 0x83, 0xc2, 0x1e, 0x57, 0xf6, 0x9e, 0xbf, 0x1b, 0x7b,
+	0x4d, 0x7f, 0x37, 0xf4, 0x05, 0x64, 0x6e, 0xb4, 0x4c, 0x4b, 0xfd, 0x49, 0x02, 0x16, 0x85, 0x76,
+	0x4e, 0x6c, 0xbb, 0x8b, 0xfa, 0xae, 0x56, 0x98, 0xbe, 0xd3, 0x7a, 0xa2, 0x5a, 0x21, 0x45, 0x48,
+	0x96, 0x6d, 0x97, 0xa9, 0x7b, 0xf9, 0x40, 0x19, 0x51, 0x6c, 0xd9, 0x76, 0x9b, 0x2f, 0xfa, 0x54,
+	0x47, 0x26, 0xdc, 0x87, 0xe3, 0x4b, 0xed, 0x03, 0xff, 0x9f, 0x6c, 0x41, 0x4e, 0x37, 0x3b, 0xed,
+	0x23, 0x7a, 0x41, 0xbb, 0x6c, 0x2b, 0x72, 0xfa, 0x90, 0x80, 0xbd, 0x4d, 0xdb, 0x33, 0xbb, 0x0d,
+	0x54, 0x57, 0x96, 0xa9, 0x66, 0x48, 0x40, 0x9d, 0x9d, 0xa2, 0xce, 0x16, 0xb8, 0xce, 0xf0, 0x9b,
+	0x7c, 0x0d, 0x32, 0x5d, 0xf3, 0x09, 0xed, 0xba, 0x4a, 0x6e, 0x27, 0xb9, 0xbb, 0x78, 0xb0, 0x3b,
+	0x09, 0x07, 0xae, 0x78, 0xef, 0x88, 0xb1, 0x6a, 0x96, 0xe7, 0xbc, 0xd0, 0xc5, 0xb8, 0xc2, 0x7b,
+	0xb0, 0x18, 0x22, 0x13, 0x19, 0x92, 0xcf, 0xe8, 0x0b, 0x61, 0x85, 0xf8, 0x49, 0xd6, 0x20, 0x7d,
+	0x61, 0x76, 0x07, 0x54, 0xd8, 0x21, 0x6f, 0xdc, 0x4b, 0xbc, 0x2b, 0xa9, 0x7f, 0x2b, 0x41, 0xfe,
+	0x91, 0xdd, 0x1d, 0xf4, 0xe8, 0x91, 0xdd, 0x32, 0x3d, 0xdb, 0x41, 0x88, 0x96, 0xd9, 0xa3, 0x62,
+	0x38, 0xfb, 0x26, 0xa7, 0x90, 0xbf, 0x60, 0x4c, 0x86, 0x40, 0x9a, 0x60, 0x48, 0xef, 0x8e, 0x20,
+	0x8d, 0x88, 0xf2, 0x5b, 0x21, 0xc4, 0x4b, 0x17, 0x21, 0x52, 0xe1, 0x97, 0x60, 0x75, 0x84, 0xe5,
+	0x52, 0xe8, 0xbf, 0x0c, 0x99, 0x06, 0x77, 0xbc, 0x75, 0xc8, 0xf4, 0x4d, 0x87, 0x5a, 0x9e, 0x18,
+	0x28, 0x5a, 0xcc, 0x70, 0xd1, 0x0c, 0x85, 0x03, 0xe2, 0xb7, 0xba, 0x01, 0xe9, 0x07, 0x8e, 0x3d,
+	0xe8, 0xc7, 0xbd, 0x55, 0xfd, 0x59, 0x16, 0x80, 0x03, 0x6a, 0xf4, 0x69, 0x0b, 0xb7, 0x92, 0xf6,
+	0xcf, 0x69, 0x8f, 0x3a, 0x66, 0x97, 0x71, 0x2d, 0xe8, 0x43, 0x42, 0xe0, 0x12, 0x89, 0x90, 0x4b,
+	0xec, 0x43, 0xe6, 0xa9, 0xed, 0xf4, 0x4c, 0x4f, 0x98, 0xd4, 0xc6, 0x88, 0x82, 0x0e, 0x1b, 0xcc,
+	0x00, 0x05, 0x1b, 0xd9, 0x06, 0x78, 0xd2, 0xb5, 0x5b, 0xcf, 0x0c, 0x26, 0x0a, 0x8d, 0x29, 0xa9,
+	0xe7, 0x18, 0x85, 0x99, 0xcb, 0x35, 0x58, 0x38, 0x37, 0x8d, 0x2e, 0xb3, 0xb4, 0x34, 0xeb, 0xcc,
+	0x9e, 0x9b, 0xdc, 0xce, 0x8a, 0x90, 0x6c, 0xd9, 0xae, 0x92, 0x99, 0x65, 0xe9, 0x2d, 0xdb, 0x25,
+	0xef, 0x01, 0x74, 0x6c, 0xa3, 0xef, 0xd8, 0x4f, 0x3b, 0x5d, 0x6e, 0x94, 0xcb, 0x07, 0x85, 0x91,
+	0x21, 0x55, 0xfb, 0x84, 0x73, 0xe8, 0xb9, 0x8e, 0xff, 0x89, 0x7a, 0x6d, 0xd3, 0xf6, 0xa0, 0x4f,
+	0x99, 0xc9, 0x2e, 0xe8, 0xa2, 0x45, 0xde, 0x84, 0x55, 0xd7, 0x32, 0xfb, 0xee, 0xb9, 0xed, 0x19,
+	0x1d, 0xcb, 0xa3, 0xce, 0x85, 0xd9, 0x65, 0xd1, 0x21, 0xaf, 0xcb, 0x7e, 0x47, 0x55, 0xd0, 0x89,
+	0x1e, 0x37, 0x1f, 0x60, 0xe6, 0x73, 0x67, 0x82, 0xf9, 0xa0, 0xf2, 0x67, 0xd9, 0x0e, 0x02, 0x73,
+	0xcf, 0x4d, 0x47, 0x44, 0x98, 0x05, 0x5d, 0xb4, 0xc8, 0xfb, 0xb0, 0xe8, 0xd0, 0x7e, 0xb7, 0xd3,
+	0x32, 0x0d, 0x97, 0x7a, 0x22, 0xb8, 0x6c, 0x8e, 0xcc, 0xa4, 0x73, 0x9e, 0x06, 0xf5, 0x74, 0x70,
+	0x82, 0x6f, 0x5c, 0x96, 0x79, 0x76, 0xe6, 0xd0, 0x33, 0x1e, 0xc2, 0xb8, 0xe6, 0xf3, 0x7c, 0x59,
+	0xa1, 0x8e, 0xc0, 0xd5, 0xa9, 0xd5, 0x72, 0x5e, 0xf4, 0x3d, 0xda, 0x56, 0x96, 0x85, 0x7d, 0xf8,
+	0x04, 0x72, 0x1d, 0xa0, 0x6f, 0xba, 0x6e, 0xff, 0xdc, 0x31, 0x5d, 0xaa, 0xac, 0x30, 0x23, 0x0b,
+	0x51, 0x22, 0x1a, 0x74, 0x5b, 0xe7, 0xb4, 0x3d, 0xe8, 0x52, 0x45, 0x66, 0x6c, 0x81, 0x06, 0x1b,
+	0x82, 0x8e, 0x2e, 0xe0, 0xb6, 0xcc, 0x2e, 0x55, 0x56, 0x19, 0x16, 0xde, 0x60, 0x3a, 0xf0, 0x3a,
+	0xad, 0x67, 0x2f, 0x14, 0x22, 0x74, 0xc0, 0x5a, 0xe4, 0x36, 0xa4, 0xcf, 0xd0, 0xc0, 0x95, 0xab,
+	0x6c, 0xf5, 0xeb, 0x23, 0xab, 0x67, 0xe6, 0xaf, 0x73, 0x26, 0x8c, 0xd9, 0xec, 0xc3, 0xa0, 0xd6,
+	0x53, 0xdb, 0x69, 0xd1, 0xb6, 0xb2, 0xce, 0xa4, 0xe5, 0x19, 0x55, 0x13, 0x44, 0x5c, 0x4f, 0xcb,
+	0xee, 0xf5, 0x1d, 0xea, 0x62, 0x00, 0xdb, 0x60, 0x2c, 0x21, 0x0a, 0x29, 0xc0, 0x42, 0xcb, 0x74,
+	0x5b, 0x66, 0x9b, 0xb6, 0x15, 0x85, 0xf5, 0x06, 0x6d, 0xa2, 0x40, 0xf6, 0x9b, 0xf6, 0xc0, 0xb1,
+	0xcc, 0xae, 0x72, 0x8d, 0x75, 0xf9, 0x4d, 0xf4, 0x76, 0xeb, 0xa9, 0xab, 0x14, 0x18, 0x15, 0x3f,
+	0xc9, 0x0d, 0x58, 0x74, 0x69, 0xcb, 0xa1, 0x9e, 0xc1, 0xc2, 0xd0, 0x26, 0x57, 0x1c, 0x27, 0xd5,
+	0xcc, 0x1e, 0xfd, 0xef, 0x47, 0x0d, 0x15, 0x60, 0xb8, 0xfd, 0xc8, 0x67, 0xd9, 0x6d, 0xea, 0x2a,
+	0xd2, 0x4e, 0x12, 0xf9, 0x58, 0x43, 0xfd, 0x91, 0x04, 0x2b, 0xfa, 0xc0, 0xc2, 0x8c, 0xa0, 0xe1,
+	0x99, 0x1e, 0x3d, 0x36, 0xfb, 0xe4, 0x31, 0xe4, 0x1d, 0x4e, 0x32, 0x5c, 0xa4, 0xb1, 0x11, 0x8b,
+	0x07, 0x07, 0xa3, 0xc6, 0x15, 0x1d, 0x18, 0x69, 0x0b, 0x5b, 0x76, 0x42, 0x24, 0x5c, 0xd1, 0x08,
+	0xcb, 0xa5, 0x56, 0xf4, 0xfd, 0x05, 0xc8, 0x70, 0x9d, 0x8c, 0x64, 0x20, 0xfb, 0x90, 0xe1, 0xb9,
+	0x09, 0x1b, 0xb5, 0x38, 0x26, 0x24, 0xf1, 0x08, 0xaa, 0x0b, 0xb6, 0xa1, 0xf1, 0x24, 0xe7, 0x31,
+	0x9e, 0x02, 0x2c, 0x60, 0x1e, 0x61, 0x5b, 0xdd, 0x17, 0x22, 0x2d, 0x09, 0xda, 0xe4, 0x5d, 0xc8,
+	0x76, 0xf9, 0x49, 0xc0, 0x82, 0xd7, 0xe2, 0x98, 0x13, 0x36, 0x72, 0x5e, 0xe8, 0x3e, 0x3b, 0xb9,
+	0x0b, 0xe9, 0x16, 0xaa, 0x43, 0xc9, 0xcc, 0xcc, 0x0d, 0x38, 0x23, 0xd9, 0x87, 0x94, 0xdb, 0xa7,
+	0x2d, 0x25, 0x3b, 0xc1, 0xdf, 0x87, 0x91, 0x45, 0x67, 0x8c, 0xa8, 0xcc, 0x81, 0x6b, 0x9e, 0x51,
+	0x71, 0x14, 0xf3, 0x46, 0x34, 0x31, 0xc9, 0xcd, 0x9f, 0x98, 0x84, 0x22, 0x3f, 0xcc, 0x17, 0xf9,
+	0xdf, 0x46, 0xdf, 0x35, 0xbd, 0x81, 0xcb, 0xe2, 0xd7, 0xf2, 0xc1, 0xf6, 0x24, 0xc8, 0x8c, 0x49,
+	0x17, 0xcc, 0xe4, 0x00, 0xd2, 0xdc, 0xf6, 0x96, 0xd8, 0xa8, 0xad, 0x29, 0xa3, 0xa8, 0xce, 0x59,
+	0xd1, 0xa3, 0x4c, 0xcf, 0x33, 0x31, 0x96, 0x18, 0xb6, 0xc5, 0xc2, 0x59, 0x4e, 0x07, 0x9f, 0x54,
+	0xb7, 0x48, 0x19, 0x96, 0x03, 0x06, 0x2e, 0x7d, 0x79, 0x82, 0xf4, 0x12, 0x63, 0xe3, 0xd2, 0xf3,
+	0xfe, 0x98, 0x86, 0x3f, 0x4b, 0x9b, 0x5e, 0x74, 0x5a, 0xd4, 0x60, 0x19, 0xaf, 0x08, 0x78, 0x9c,
+	0x74, 0x82, 0x79, 0xef, 0x6d, 0x20, 0x2e, 0x6d, 0x0d, 0x1c, 0x6a, 0x84, 0xf9, 0xfc, 0x88, 0xc7,
+	0x7a, 0x2a, 0x43, 0xee, 0x00, 0x34, 0x67, 0x5b, 0xdd, 0x49, 0x0e, 0x41, 0x33, 0x86, 0x87, 0x01,
+	0x43, 0xc7, 0x7a, 0x6a, 0x2b, 0x84, 0xf9, 0xe2, 0xeb, 0x13, 0xf4, 0x21, 0x80, 0x57, 0xad, 0xa7,
+	0x36, 0x77, 0x40, 0x30, 0x03, 0x02, 0xf9, 0x2a, 0x2c, 0x85, 0x8e, 0x0c, 0x57, 0xb9, 0xb2, 0x93,
+	0x1c, 0x6b, 0x43, 0xa1, 0x33, 0x63, 0x71, 0x78, 0x66, 0xb8, 0x44, 0x8b, 0xc7, 0x85, 0x35, 0x26,
+	0x60, 0x67, 0x56, 0x5c, 0x88, 0x46, 0x01, 0xb4, 0x48, 0xea, 0x38, 0xb6, 0xc3, 0xa2, 0x76, 0x4e,
+	0xe7, 0x8d, 0xc2, 0x07, 0xb0, 0x12, 0xc3, 0x7e, 0xa9, 0xc8, 0xf0, 0x17, 0x09, 0x48, 0xa3, 0x78,
+	0x17, 0x79, 0xd0, 0x33, 0x5d, 0x36, 0x2e, 0xa5, 0xf3, 0x06, 0xd9, 0x80, 0x2c, 0x7e, 0x18, 0x3d,
+	0x57, 0x24, 0x32, 0x19, 0x6c, 0x1e, 0xbb, 0x98, 0x99, 0xb0, 0x8e, 0x27, 0x2f, 0x3c, 0xea, 0xb2,
+	0x58, 0x90, 0xd2, 0x73, 0x48, 0xb9, 0x8f, 0x04, 0x3c, 0x7a, 0xd8, 0xe5, 0xc2, 0x65, 0x5e, 0x9f,
+	0xd2, 0x45, 0x0b, 0x33, 0x16, 0xf6, 0x85, 0x02, 0xf9, 0x85, 0x24, 0xcb, 0xda, 0xc7, 0x2c, 0xb0,
+	0xf3, 0x2e, 0x2e, 0x32, 0xc3, 0x7a, 0x81, 0x91, 0xb8, 0xcc, 0x1b, 0xb0, 0xc8, 0xd3, 0x94, 0x33,
+	0x3c, 0x52, 0x44, 0xf2, 0x0c, 0x2c, 0x17, 0x61, 0x14, 0x72, 0x05, 0xd2, 0x1d, 0x1b, 0x25, 0x2f,
+	0xf8, 0x57, 0x1d, 0x0e, 0x94, 0x09, 0x34, 0xd8, 0x65, 0x84, 0x5f, 0x50, 0x72, 0x8c, 0xc2, 0xb2,
+	0x6b, 0x14, 0x2a, 0xf2, 0x10, 0x1c, 0x09, 0x42, 0xa8, 0x20, 0x1d, 0xbb, 0xea, 0xbf, 0x27, 0x20,
+	0x5d, 0xea, 0x52, 0xc7, 0x0b, 0x85, 0xce, 0x24, 0x0b, 0x9d, 0xef, 0xe1, 0x3d, 0xe9, 0x82, 0x3a,
+	0x1d, 0xef, 0x85, 0x92, 0x98, 0xe0, 0xa4, 0x0d, 0xc1, 0xc0, 0x7c, 0x3b, 0x60, 0x47, 0x50, 0x26,
+	0xca, 0x34, 0xbc, 0x17, 0x7d, 0xca, 0xb4, 0x97, 0xd4, 0x73, 0x8c, 0x82, 0x8c, 0x78, 0x1e, 0xf6,
+	0xa8, 0xcb, 0xc2, 0x0f, 0xbf, 0x40, 0xf8, 0x4d, 0xf2, 0x2e, 0xe4, 0x82, 0x5b, 0xa8, 0x92, 0x9e,
+	0x19, 0x80, 0x86, 0xcc, 0xb8, 0x50, 0x47, 0x5c, 0x43, 0x8d, 0x4e, 0x9b, 0xa9, 0x37, 0xa7, 0x83,
+	0x4f, 0xaa, 0xb2, 0xe5, 0xf8, 0x2d, 0x25, 0x3b, 0x61, 0x39, 0xfe, 0x45, 0x96, 0x2f, 0xc7, 0x67,
+	0x47, 0xbc, 0xad, 0x2e, 0x65, 0xd9, 0x16, 0x4f, 0x03, 0xfd, 0x26, 0xda, 0xa2, 0xe7, 0x75, 0x85,
+	0xda, 0xf1, 0x13, 0x97, 0x3e, 0xb0, 0x3a, 0xcf, 0x07, 0xd4, 0xf0, 0xcc, 0x33, 0xa6, 0xef, 0x9c,
+	0x9e, 0xe3, 0x94, 0xa6, 0x79, 0xa6, 0xbe, 0x03, 0x19, 0xa6, 0x6d, 0x17, 0x0f, 0x1a, 0xa6, 0x11,
+	0x71, 0x8c, 0x8e, 0x1e, 0x34, 0x8c, 0x4f, 0xe7, 0x4c, 0xea, 0xdf, 0x48, 0x70, 0x85, 0xfb, 0x72,
+	0xd9, 0xa1, 0x18, 0x7e, 0xe8, 0xf3, 0x01, 0x75, 0xbd, 0xf0, 0x21, 0x23, 0x5d, 0xee, 0x90, 0xb9,
+	0xf4, 0xc9, 0xe8, 0x9f, 0x31, 0xc9, 0x39, 0xcf, 0x18, 0xf5, 0x16, 0x2c, 0x73, 0x9a, 0x4e, 0xdd,
+	0xbe, 0x6d, 0xb9, 0x21, 0x1f, 0x97, 0x42, 0x3e, 0xae, 0xf6, 0x61, 0x2d, 0xba, 0x34, 0xc1, 0x1d,
+	0x3f, 0xcb, 0x1f, 0xc2, 0x8a, 0xc8, 0xa3, 0x1d, 0xc1, 0x22, 0xa0, 0xdf, 0x98, 0x80, 0xc5, 0x97,
+	0xa4, 0x2f, 0x5f, 0x44, 0xda, 0xea, 0x3f, 0x49, 0x7e, 0x12, 0xc5, 0x62, 0x4f, 0xa9, 0x85, 0x59,
+	0x2d, 0xb9, 0x07, 0x19, 0x1e, 0x16, 0xd9, 0x9c, 0xcb, 0x07, 0xea, 0x04, 0xb1, 0x9c, 0xfd, 0xc4,
+	0x74, 0xcc, 0x9e, 0x2e, 0x46, 0x90, 0x77, 0x21, 0xdd, 0xb3, 0x07, 0x96, 0xa7, 0x24, 0xe6, 0x1e,
+	0xca, 0x07, 0xa0, 0xc1, 0xb0, 0x0f, 0x1e, 0xe8, 0x93, 0xdc, 0x60, 0x18, 0xc5, 0x3f, 0x08, 0xc2,
+	0xe7, 0x45, 0x2a, 0x7e, 0xae, 0xa8, 0x7f, 0x9f, 0x00, 0x59, 0xac, 0x85, 0x7a, 0x2f, 0xc3, 0x2c,
+	0xf8, 0x2e, 0x27, 0xe6, 0xcd, 0x24, 0x50, 0x6b, 0x6c, 0x55, 0xc2, 0x30, 0xd4, 0x69, 0x67, 0x32,
+	0x5f, 0xbf, 0x2e, 0x46, 0x90, 0x87, 0x90, 0xb5, 0xfb, 0xf8, 0x85, 0x71, 0x14, 0xbd, 0x60, 0x6f,
+	0xd2, 0xe0, 0x60, 0x69, 0x7b, 0x75, 0x3e, 0x80, 0x9f, 0x63, 0xfe, 0xf0, 0xc2, 0x3d, 0x58, 0x0a,
+	0x77, 0x5c, 0xea, 0x90, 0xf8, 0xbd, 0xa1, 0x35, 0x50, 0xcf, 0xb7, 0x11, 0xf4, 0x0f, 0x6e, 0x35,
+	0x8a, 0x34, 0xc1, 0x3f, 0x84, 0x91, 0x09, 0xb6, 0x97, 0x68, 0x9e, 0x2f, 0x60, 0xb5, 0x61, 0x99,
+	0xfd, 0xa8, 0xa7, 0xc7, 0xbd, 0x21, 0xb4, 0xc5, 0x89, 0xcb, 0x6d, 0x71, 0x38, 0x69, 0x4d, 0x46,
+	0x93, 0x56, 0xf5, 0x39, 0x90, 0xf0, 0xd4, 0x42, 0x17, 0xdf, 0x80, 0x75, 0xb1, 0xb4, 0x16, 0xeb,
+	0x18, 0xae, 0x90, 0xeb, 0xe6, 0xe6, 0x84, 0xa9, 0xa3, 0x62, 0xf4, 0xb5, 0x8b, 0x31, 0x54, 0xd5,
+	0xf3, 0xab, 0x0e, 0x2c, 0x1b, 0xd9, 0x84, 0x9c, 0x98, 0x2a, 0x58, 0xed, 0x02, 0x27, 0x54, 0xc7,
+	0xd7, 0x13, 0xdf, 0x86, 0xac, 0x98, 0x78, 0x9e, 0xc8, 0xe4, 0xf3, 0xaa, 0x6d, 0x20, 0x0f, 0x1c,
+	0xb3, 0x7f, 0x5e, 0x71, 0x3a, 0x17, 0xd4, 0x29, 0x9f, 0x9b, 0xd6, 0x19, 0x75, 0x83, 0x09, 0xa4,
+	0xd0, 0x04, 0xf7, 0x20, 0xf5, 0xac, 0x63, 0xb5, 0x85, 0x67, 0xdf, 0x1a, 0x73, 0x21, 0x88, 0x89,
+	0x61, 0xa7, 0x07, 0x1b, 0xa3, 0xbe, 0x0e, 0x2b, 0xe5, 0xee, 0xc0, 0xf5, 0xa8, 0x33, 0x23, 0x06,
+	0x7e, 0x4f, 0x82, 0x3c, 0x3a, 0xc7, 0x45, 0xb0, 0xdf, 0x0f, 0x61, 0x41, 0xa7, 0xcf, 0xa9, 0xeb,
+	0x7d, 0xf8, 0x48, 0x1c, 0x11, 0xb7, 0x47, 0x8f, 0x88, 0xf0, 0x88, 0x3d, 0x9f, 0x9d, 0xbb, 0x46,
+	0x30, 0xba, 0xf0, 0x15, 0xc8, 0x47, 0xba, 0xc2, 0xce, 0x91, 0x9c, 0xe5, 0x1c, 0xdf, 0x82, 0xe5,
+	0xc8, 0x2c, 0x2e, 0x51, 0x61, 0x49, 0x7c, 0x97, 0x59, 0xc4, 0xe3, 0x62, 0x22, 0x34, 0x52, 0x89,
+	0xad, 0x46, 0x54, 0xcc, 0xae, 0x4f, 0x5f, 0x81, 0x1e, 0x1d, 0xa4, 0xfe, 0x38, 0x1d, 0x94, 0x3b,
+	0x6b, 0x76, 0x7b, 0xf4, 0x40, 0x90, 0x21, 0xd9, 0xea, 0x0f, 0x18, 0x66, 0x49, 0xc7, 0x4f, 0xb4,
+	0x9e, 0x1e, 0xed, 0x19, 0x9e, 0xed, 0x99, 0x5d, 0x91, 0xb5, 0x2d, 0xf4, 0x68, 0x8f, 0x55, 0x20,
+	0x31, 0x39, 0xc3, 0x4e, 0x96, 0x28, 0xf1, 0xb4, 0x2d, 0xdb, 0xa3, 0x3d, 0x96, 0x26, 0x89, 0xae,
+	0xa7, 0x0e, 0xa5, 0x7e, 0xde, 0xd6, 0xa3, 0xbd, 0x43, 0x87, 0xb2, 0x22, 0x94, 0x79, 0x71, 0x66,
+	0x74, 0x6d, 0x93, 0x67, 0x15, 0x49, 0x3d, 0x6b, 0x5e, 0x9c, 0x1d, 0xd9, 0x26, 0xbf, 0x5c, 0xf2,
+	0x4b, 0x4c, 0x76, 0xc2, 0xad, 0x27, 0x76, 0x7d, 0xf9, 0x00, 0xd2, 0xed, 0x8e, 0xfb, 0x0c, 0x33,
+	0xb8, 0xf1, 0xe9, 0x7a, 0x68, 0xb5, 0x7b, 0x15, 0xe4, 0xe4, 0x7b, 0xc9, 0x47, 0xe1, 0xed, 0xa7,
+	0x6f, 0xdb, 0x41, 0xa5, 0x74, 0x6b, 0x5a, 0xa5, 0x54, 0xe7, 0xac, 0x98, 0xe1, 0xf6, 0xce, 0x7a,
+	0x9e, 0xd1, 0xe9, 0x8b, 0x64, 0x24, 0x83, 0xcd, 0x6a, 0x1f, 0x3b, 0xda, 0xa6, 0x67, 0x62, 0x07,
+	0x2f, 0x52, 0x67, 0xb0, 0x59, 0x65, 0x77, 0xda, 0x73, 0xdb, 0xf5, 0x58, 0xf9, 0x61, 0x89, 0x3b,
+	0xa0, 0xdf, 0x26, 0xc7, 0xb0, 0x68, 0xd9, 0xed, 0xa0, 0x90, 0x95, 0x9f, 0x60, 0x97, 0xe1, 0x65,
+	0xe0, 0x3f, 0xe1, 0x3a, 0x16, 0x58, 0x01, 0x01, 0x2b, 0x73, 0xae, 0x67, 0x62, 0x9e, 0xd8, 0xe9,
+	0xf1, 0x5b, 0xd7, 0x8c, 0x7c, 0x8f, 0x71, 0x63, 0xbb, 0xf0, 0x09, 0xc0, 0x50, 0x41, 0x63, 0xc2,
+	0xfd, 0x3b, 0x61, 0x8b, 0x1e, 0x77, 0x1b, 0x89, 0xbd, 0x5d, 0x84, 0x6c, 0x1e, 0x2f, 0x1d, 0x31,
+	0xd4, 0x97, 0x3a, 0x4f, 0xfe, 0x5c, 0x82, 0x65, 0x21, 0x5d, 0x38, 0x7f, 0xc8, 0x52, 0xa4, 0xf9,
+	0x2c, 0x85, 0x9b, 0x7a, 0x22, 0x30, 0xf5, 0x0d, 0xc8, 0x32, 0xc5, 0x77, 0xda, 0x22, 0x45, 0xc8,
+	0x60, 0xb3, 0xda, 0x46, 0x9b, 0xe0, 0xf5, 0x9b, 0xd4, 0x74, 0x9b, 0xc0, 0x05, 0xf9, 0xd5, 0x9d,
+	0x1f, 0x4b, 0xb0, 0xde, 0x68, 0x3f, 0xfb, 0xff, 0x96, 0x4f, 0xbe, 0x03, 0x1b, 0x23, 0xa8, 0x45,
+	0x50, 0x9d, 0x76, 0x6a, 0x44, 0xc6, 0x55, 0x2d, 0x14, 0x15, 0xe4, 0x49, 0x53, 0xc7, 0x7d, 0x08,
+	0xca, 0xe8, 0xb8, 0x9f, 0x33, 0x3b, 0x50, 0x7f, 0x2a, 0xc1, 0xb5, 0x40, 0x9a, 0x66, 0x0d, 0xb0,
+	0x8a, 0xfe, 0x32, 0xd4, 0x5e, 0x0b, 0x9e, 0x4f, 0x78, 0x88, 0x7d, 0x67, 0x54, 0xed, 0x93, 0x66,
+	0x7d, 0xd9, 0x8f, 0x29, 0x75, 0x28, 0x8c, 0x9b, 0x4b, 0x68, 0xec, 0x2d, 0xc8, 0x72, 0x55, 0xb8,
+	0xe2, 0x38, 0x9b, 0xa8, 0x32, 0x9f, 0x4f, 0xfd, 0xcb, 0xb0, 0x9d, 0x9e, 0xf6, 0xdb, 0x21, 0x85,
+	0x4d, 0x4d, 0x13, 0x7e, 0xfe, 0xd4, 0xe8, 0xd2, 0x36, 0x79, 0x0d, 0x36, 0x46, 0x10, 0x8a, 0xbc,
+	0xe6, 0xed, 0x10, 0xf8, 0x0a, 0xed, 0xd2, 0xf9, 0xc0, 0x47, 0x24, 0xfa, 0xc3, 0x84, 0xc4, 0x6f,
+	0xc0, 0xd5, 0xa0, 0x8b, 0x55, 0x35, 0xe6, 0xd2, 0xc6, 0x4d, 0x58, 0xb6, 0x6c, 0xcf, 0x68, 0x0d,
+	0x7a, 0x83, 0xae, 0x89, 0xc7, 0x2b, 0x53, 0xca, 0x82, 0x9e, 0xb7, 0x6c, 0xaf, 0x1c, 0x10, 0xd5,
+	0x43, 0x58, 0x8f, 0x0b, 0x17, 0x3b, 0x77, 0x9b, 0x17, 0xdd, 0x5c, 0x61, 0x9a, 0xeb, 0x63, 0x63,
+	0x97, 0xcb, 0xcb, 0x6d, 0xae, 0xfa, 0x5d, 0x89, 0xb9, 0x4d, 0x43, 0xd4, 0xf0, 0xa3, 0xe1, 0xe5,
+	0x7f, 0x68, 0xdb, 0xa6, 0x65, 0xb4, 0xef, 0xc3, 0xb5, 0x31, 0x70, 0xc4, 0xd2, 0xb0, 0xda, 0x1e,
+	0xbc, 0xe3, 0xf8, 0x88, 0xc0, 0x27, 0x55, 0xdb, 0xea, 0x3f, 0x4a, 0xb0, 0x19, 0x1a, 0x3e, 0xe2,
+	0xb8, 0x53, 0x17, 0x74, 0x12, 0xf3, 0xcd, 0x77, 0xc7, 0xf9, 0xe6, 0x24, 0xd1, 0x2f, 0xdb, 0x3b,
+	0x4f, 0x61, 0x6b, 0xfc, 0x6c, 0x42, 0x15, 0x6f, 0x43, 0xce, 0x5f, 0xf7, 0x4c, 0x0f, 0x1d, 0x72,
+	0xaa, 0x1f, 0x47, 0xd4, 0xab, 0x53, 0xe4, 0x9e, 0x4f, 0x3b, 0x31, 0xdd, 0x27, 0x46, 0x74, 0xbf,
+	0x05, 0x85, 0x71, 0xa2, 0x85, 0x33, 0xfc, 0x9d, 0xc4, 0xba, 0xcb, 0x0e, 0x6d, 0x53, 0xcb, 0xeb,
+	0x98, 0xdd, 0xa8, 0xa5, 0x19, 0xb0, 0xd4, 0xb1, 0xfa, 0x03, 0xbc, 0x56, 0x3b, 0x66, 0xcf, 0x5f,
+	0xd1, 0xfb, 0xe3, 0x76, 0x60, 0x82, 0x88, 0xbd, 0x2a, 0x8e, 0x67, 0x77, 0x75, 0xb1, 0x0b, 0x8b,
+	0x9d, 0x21, 0xa5, 0xf0, 0x55, 0x90, 0xe3, 0x0c, 0x97, 0xda, 0x8f, 0xfb, 0xb0, 0x39, 0x76, 0x6e,
+	0xb1, 0x1d, 0xaf, 0x41, 0xbe, 0x15, 0xf4, 0x0d, 0xd5, 0xb7, 0x34, 0x24, 0x56, 0xdb, 0xea, 0x0d,
+	0xd8, 0x8e, 0xc8, 0x88, 0xdb, 0x90, 0xfa, 0x0f, 0x12, 0x5c, 0x9f, 0xc4, 0x21, 0x26, 0x7a, 0x02,
+	0x8b, 0x43, 0x99, 0xbe, 0x9e, 0xbe, 0x36, 0x5d, 0x4f, 0x23, 0x52, 0xf6, 0x86, 0x7d, 0xbe, 0xae,
+	0x42, 0x42, 0x51, 0x57, 0x71, 0x86, 0x4b, 0xe9, 0xaa, 0x14, 0xdb, 0xea, 0x68, 0x38, 0x9d, 0x4b,
+	0x55, 0xdb, 0xb0, 0x39, 0x56, 0x84, 0xb0, 0xa6, 0x32, 0x6c, 0x45, 0xba, 0x1f, 0x99, 0xdd, 0x4e,
+	0xdb, 0xbc, 0xe4, 0x1c, 0xf1, 0xed, 0x18, 0x0a, 0x11, 0xb3, 0xb8, 0x1c, 0x44, 0xd7, 0x1e, 0xb4,
+	0xef, 0x9b, 0xad, 0x67, 0x83, 0xfe, 0x25, 0xa2, 0xe3, 0x08, 0x82, 0xc4, 0x28, 0x02, 0xbc, 0xbf,
+	0x3e, 0x1d, 0x74, 0xbb, 0x22, 0x08, 0xb2, 0x6f, 0xf5, 0x3a, 0x6c, 0x8d, 0x9f, 0x54, 0x80, 0xfa,
+	0x6b, 0x29, 0xce, 0x30, 0xea, 0xc5, 0x4f, 0x18, 0x3d, 0x04, 0x8b, 0x13, 0xaa, 0x6d, 0xb2, 0x07,
+	0x57, 0x1c, 0xce, 0x6e, 0x08, 0xec, 0xec, 0xe2, 0xc0, 0xc1, 0xad, 0x8a, 0x2e, 0x1e, 0x36, 0xf0,
+	0xf9, 0x72, 0x74, 0x19, 0xc9, 0x31, 0xcb, 0x08, 0x65, 0xbb, 0xa9, 0x70, 0xb6, 0xab, 0x7e, 0x08,
+	0xdb, 0x13, 0xa0, 0x0a, 0x6b, 0x2e, 0xc2, 0x6a, 0x0c, 0x4e, 0x80, 0x79, 0x25, 0x02, 0xa6, 0xda,
+	0x56, 0x7f, 0x28, 0x9c, 0x63, 0x28, 0x6d, 0x24, 0xbc, 0xab, 0x90, 0x77, 0x9d, 0xd6, 0x88, 0xa8,
+	0x45, 0xd7, 0x69, 0xf9, 0x62, 0xb0, 0x80, 0xd7, 0xe2, 0x69, 0xfe, 0x70, 0x57, 0x72, 0x82, 0x52,
+	0x65, 0x97, 0x54, 0x33, 0xd8, 0x11, 0xfc, 0x1c, 0x55, 0x41, 0x6a, 0x8c, 0x2d, 0xfd, 0xb3, 0x04,
+	0x24, 0x0a, 0x8e, 0x95, 0x47, 0xe6, 0x01, 0x74, 0x0b, 0x56, 0x42, 0x3c, 0xa1, 0xed, 0xc8, 0x07,
+	0x5c, 0x6c, 0x2b, 0x22, 0xfb, 0x9a, 0x8c, 0xed, 0x6b, 0xa4, 0x12, 0x9f, 0xba, 0x4c, 0x25, 0x7e,
+	0x3d, 0xb8, 0xeb, 0xa4, 0xf9, 0xde, 0xf1, 0x96, 0xfa, 0x6b, 0x70, 0x63, 0xa2, 0xb6, 0xc5, 0xee,
+	0x7d, 0x00, 0x59, 0x0e, 0xc0, 0x8f, 0x43, 0xaf, 0x8d, 0x8d, 0x43, 0x51, 0x9d, 0xe8, 0xfe, 0x18,
+	0xf5, 0xfb, 0x52, 0xdc, 0xbf, 0xa2, 0x81, 0xe2, 0xff, 0x6e, 0x37, 0x47, 0x7c, 0x30, 0x16, 0x7e,
+	0x1e, 0xc7, 0x81, 0x8b, 0xeb, 0xe0, 0x25, 0x80, 0xaf, 0x41, 0x1a, 0xd3, 0xa1, 0xae, 0xc8, 0xee,
+	0x78, 0x43, 0xfd, 0x61, 0x02, 0xd6, 0xc6, 0x49, 0x46, 0x17, 0xb3, 0xfb, 0xfc, 0x7d, 0x46, 0xfc,
+	0x64, 0xc8, 0xee, 0xb3, 0xc7, 0x99, 0xe1, 0xf6, 0x25, 0xc2, 0xdb, 0x37, 0x7c, 0x68, 0x6a, 0xdb,
+	0x16, 0xf5, 0x5f, 0xc4, 0x18, 0xa5, 0x62, 0x5b, 0x34, 0x76, 0x95, 0x4f, 0x5d, 0xe2, 0x2a, 0x4f,
+	0x4a, 0xb0, 0x8c, 0x3f, 0xa4, 0x40, 0x85, 0xb4, 0xf9, 0xf0, 0xd9, 0x2f, 0x3f, 0xf9, 0x60, 0x44,
+	0xb3, 0x13, 0x37, 0xe5, 0x4c, 0xcc, 0x94, 0x43, 0xd1, 0x24, 0x1b, 0x89, 0x26, 0xff, 0x3a, 0x12,
+	0xf9, 0x7c, 0xb5, 0x0b, 0x7b, 0x7c, 0x0c, 0x0b, 0x7c, 0xf5, 0xc1, 0xa5, 0xe5, 0x2b, 0x33, 0x0c,
+	0x32, 0x2a, 0x40, 0xdc, 0xea, 0xa9, 0x38, 0x13, 0x03, 0x61, 0x85, 0x27, 0x90, 0x8f, 0x74, 0x8d,
+	0x39, 0x0d, 0xbf, 0x12, 0x2d, 0x60, 0xdc, 0x9c, 0x6f, 0xe2, 0xd0, 0xa1, 0xc9, 0xd3, 0x27, 0x51,
+	0x81, 0x18, 0xc9, 0x0c, 0x3e, 0x82, 0xcd, 0xb1, 0xbd, 0x62, 0xe5, 0xef, 0xe1, 0x93, 0x17, 0xeb,
+	0x53, 0xa4, 0x09, 0x45, 0xec, 0x68, 0x89, 0x43, 0xf7, 0xf9, 0xd5, 0x2f, 0xb1, 0xfc, 0x5f, 0x90,
+	0x63, 0xf7, 0xed, 0xd0, 0x56, 0x48, 0x91, 0xad, 0x38, 0x86, 0x6b, 0x63, 0x06, 0x09, 0x30, 0x77,
+	0x21, 0x85, 0x6c, 0x02, 0xc9, 0xf4, 0x12, 0x07, 0xe3, 0x54, 0x7f, 0x22, 0xc1, 0x8d, 0xa1, 0x3c,
+	0xf6, 0x92, 0x36, 0x12, 0xdb, 0xc3, 0x0f, 0x82, 0xd2, 0xe5, 0x1e, 0x04, 0xdf, 0x03, 0xf0, 0xdf,
+	0xbb, 0x1d, 0x4f, 0x49, 0xcc, 0xb4, 0xd6, 0x9c, 0x78, 0xe6, 0x76, 0xf0, 0x87, 0x0f, 0x0b, 0x6c,
+	0x28, 0xb5, 0xda, 0x4a, 0x72, 0xe6, 0xc0, 0x2c, 0xf2, 0x6a, 0x56, 0x5b, 0xd5, 0x61, 0x67, 0xf2,
+	0x7a, 0x84, 0x9a, 0xf6, 0x20, 0xc3, 0x1e, 0x0b, 0xdd, 0x19, 0x4f, 0x8a, 0x82, 0x2b, 0xc8, 0x46,
+	0x86, 0x32, 0xcb, 0x5d, 0x6a, 0x3a, 0x2f, 0x41, 0x3f, 0x58, 0x33, 0x45, 0x79, 0x7e, 0x08, 0xc5,
+	0x9a, 0x29, 0xb6, 0x43, 0x91, 0x70, 0x64, 0xd2, 0x58, 0x8a, 0x14, 0x5a, 0xa8, 0x63, 0xba, 0xf4,
+	0x7f, 0x1b, 0x94, 0x98, 0x54, 0x80, 0x6a, 0xc0, 0x4a, 0xa3, 0xfd, 0xec, 0xb1, 0xe9, 0xb5, 0xce,
+	0x7d, 0x20, 0xec, 0xca, 0x79, 0xd1, 0x71, 0xf1, 0xe1, 0x8b, 0xff, 0xa4, 0x20, 0x68, 0x63, 0x28,
+	0xc6, 0xc0, 0xca, 0xaf, 0x7d, 0x39, 0x9d, 0x37, 0x44, 0x49, 0x2f, 0x19, 0xfc, 0xdc, 0xf2, 0xdf,
+	0x24, 0xc8, 0xfb, 0x52, 0xb5, 0x0b, 0x6a, 0x4d, 0x97, 0x49, 0x20, 0xc5, 0x82, 0xb5, 0x78, 0xfa,
+	0xc0, 0x6f, 0xb2, 0x07, 0x29, 0x16, 0x2e, 0x67, 0xdb, 0x11, 0xe3, 0x63, 0xbf, 0x66, 0x64, 0xef,
+	0x14, 0x7e, 0x56, 0xc5, 0x5b, 0xa1, 0x62, 0x56, 0x7a, 0xbe, 0xa7, 0x2e, 0xdf, 0x21, 0x33, 0xf3,
+	0x3a, 0x64, 0xf1, 0x3f, 0x12, 0x90, 0x11, 0x27, 0xcf, 0x0a, 0x2c, 0x36, 0x9a, 0xa5, 0xe6, 0x69,
+	0xc3, 0xa8, 0xd5, 0x6b, 0x9a, 0xfc, 0x4a, 0x88, 0x50, 0xad, 0x55, 0x9b, 0xb2, 0x44, 0xf2, 0x90,
+	0x13, 0x84, 0xfa, 0x87, 0x72, 0x82, 0x10, 0x58, 0xf6, 0x9b, 0x87, 0x87, 0x47, 0xd5, 0x9a, 0x26,
+	0x27, 0x89, 0x0c, 0x4b, 0x82, 0xa6, 0xe9, 0x7a, 0x5d, 0x97, 0x53, 0x44, 0x81, 0xb5, 0x40, 0x6c,
+	0xd3, 0xa8, 0xd6, 0x8c, 0xaf, 0x9f, 0xd6, 0xf5, 0xd3, 0x63, 0x39, 0x4d, 0x36, 0xe0, 0x8a, 0xe8,
+	0xa9, 0x68, 0xe5, 0xfa, 0xf1, 0x71, 0xb5, 0xd1, 0xa8, 0xd6, 0x6b, 0x72, 0x86, 0xac, 0x03, 0x11,
+	0x1d, 0xc7, 0xa5, 0x6a, 0xad, 0xa9, 0xd5, 0x4a, 0xb5, 0xb2, 0x26, 0x67, 0x43, 0x03, 0x1a, 0xcd,
+	0xba, 0x5e, 0x7a, 0xa0, 0x19, 0x95, 0xfa, 0xe3, 0x9a, 0xbc, 0x40, 0x36, 0x61, 0x23, 0xde, 0xa1,
+	0x3d, 0xd0, 0x4b, 0x15, 0xad, 0x22, 0xe7, 0x42, 0xa3, 0x6a, 0x9a, 0x56, 0x69, 0x18, 0xba, 0x76,
+	0xbf, 0x5e, 0x6f, 0xca, 0x40, 0xb6, 0x40, 0x89, 0x8d, 0xd2, 0xb5, 0xfb, 0xa5, 0x23, 0x36, 0xd9,
+	0x22, 0xd9, 0x81, 0xad, 0xb8, 0x4c, 0xbd, 0xfa, 0x08, 0x79, 0x4e, 0x8e, 0x4a, 0x65, 0x4d, 0x5e,
+	0x22, 0xaf, 0xc1, 0x8d, 0x71, 0x2b, 0x33, 0x6a, 0x75, 0x7f, 0x88, 0x9c, 0x27, 0xcb, 0x00, 0xc1,
+	0x5a, 0x3e, 0x92, 0x97, 0x8b, 0x3f, 0x90, 0x00, 0xf8, 0xa3, 0x14, 0x3b, 0xd5, 0xd7, 0x40, 0x66,
+	0x62, 0x75, 0xa3, 0xf9, 0xf1, 0x89, 0xe6, 0x6b, 0x3e, 0x46, 0x3d, 0xac, 0x1e, 0x69, 0xb2, 0x44,
+	0xae, 0xc2, 0x6a, 0x98, 0x7a, 0xff, 0xa8, 0x5e, 0xc6, 0x6d, 0x58, 0x07, 0x12, 0x26, 0xd7, 0xef,
+	0xff, 0xb2, 0x56, 0x6e, 0xca, 0x49, 0x72, 0x0d, 0xae, 0x86, 0xe9, 0xe5, 0xa3, 0xd3, 0x46, 0x53,
+	0xd3, 0xb5, 0x8a, 0x9c, 0x8a, 0x4b, 0x7a, 0xa0, 0x97, 0x4e, 0x1e, 0xca, 0xe9, 0xe2, 0x9f, 0x4a,
+	0x90, 0xe1, 0xbf, 0x07, 0xc3, 0x7d, 0x3c, 0x6c, 0x44, 0x30, 0xad, 0x42, 0xde, 0xa7, 0xdc, 0x6f,
+	0xea, 0x87, 0x0d, 0x59, 0x0a, 0x33, 0x69, 0x1f, 0x35, 0xbf, 0x2c, 0x27, 0xc2, 0x94, 0xc3, 0xd3,
+	0x06, 0x1a, 0xc4, 0x0a, 0x2c, 0x06, 0x82, 0x0e, 0x1b, 0x72, 0x2a, 0x4c, 0x78, 0x74, 0xd8, 0x90,
+	0xd3, 0x61, 0xc2, 0x47, 0x87, 0x0d, 0x39, 0x13, 0x26, 0x7c, 0x72, 0xd8, 0x90, 0xb3, 0xc5, 0x1f,
+	0x49, 0x70, 0x75, 0xec, 0x6b, 0x1e, 0x79, 0x15, 0xb6, 0x19, 0x78, 0x43, 0x2c, 0xa7, 0xfc, 0xb0,
+	0x54, 0x7b, 0xa0, 0x45, 0x70, 0xdf, 0x84, 0x57, 0x27, 0xb2, 0x1c, 0xd7, 0x2b, 0xd5, 0xc3, 0xaa,
+	0x56, 0x91, 0x25, 0xa2, 0xc2, 0xf5, 0x89, 0x6c, 0xa5, 0x0a, 0x5a, 0x52, 0x82, 0xfc, 0x02, 0xec,
+	0x4c, 0xe4, 0xa9, 0x68, 0x47, 0x5a, 0x53, 0xab, 0xc8, 0xc9, 0xa2, 0x07, 0x4b, 0xe1, 0x9f, 0xdf,
+	0x30, 0x6b, 0xd6, 0x1e, 0x69, 0x7a, 0xb5, 0xf9, 0x71, 0x04, 0x18, 0xda, 0x65, 0x84, 0x5e, 0x3a,
+	0x2a, 0xe9, 0xc7, 0xb2, 0x84, 0x1b, 0x17, 0xed, 0x78, 0x5c, 0xd2, 0x6b, 0xd5, 0xda, 0x03, 0x39,
+	0xc1, 0x9c, 0x29, 0x26, 0xab, 0x59, 0x3d, 0xfc, 0x58, 0x4e, 0x16, 0x7f, 0x57, 0xc2, 0xe7, 0xbf,
+	0x61, 0x80, 0xc5, 0x69, 0x75, 0xad, 0x51, 0x3f, 0xd5, 0xcb, 0x51, 0x7d, 0x28, 0xb0, 0x16, 0xa5,
+	0x3f, 0xaa, 0x1f, 0x9d, 0x1e, 0xa3, 0x7d, 0x8d, 0x19, 0x51, 0xd1, 0xe4, 0x04, 0xe2, 0x89, 0xd2,
+	0x85, 0x29, 0xc9, 0x49, 0x5c, 0x43, 0xb4, 0x8b, 0x69, 0x46, 0x4e, 0x15, 0xbf, 0x90, 0x60, 0x85,
+	0x05, 0x6c, 0xfe, 0xcb, 0x02, 0x86, 0xa8, 0x00, 0xeb, 0xa5, 0x23, 0x4d, 0x6f, 0x1a, 0xa5, 0x72,
+	0xb3, 0x5a, 0xaf, 0x45, 0x50, 0x6d, 0x81, 0x32, 0xda, 0xc7, 0x75, 0x2a, 0x4b, 0xe3, 0x7b, 0xcb,
+	0xba, 0x56, 0x6a, 0x22, 0xbe, 0xb1, 0xbd, 0xa7, 0x27, 0x15, 0xec, 0x4d, 0x16, 0xbf, 0xe9, 0xff,
+	0x88, 0x20, 0xf4, 0x1b, 0x0f, 0x1c, 0xc2, 0x97, 0xed, 0x8f, 0x39, 0x29, 0xe9, 0xa5, 0x63, 0x1f,
+	0xcc, 0x26, 0x6c, 0x8c, 0xeb, 0xad, 0x1f, 0x1e, 0xca, 0x12, 0xae, 0x62, 0x6c, 0x67, 0x4d, 0x4e,
+	0x14, 0x0f, 0x20, 0x2b, 0x7e, 0xe1, 0x4e, 0x16, 0x20, 0x25, 0xa4, 0x65, 0x21, 0x79, 0x54, 0x7f,
+	0x2c, 0x4b, 0x04, 0x20, 0x73, 0xac, 0x55, 0xaa, 0xa7, 0xc7, 0x72, 0x02, 0xbb, 0x1f, 0x56, 0x1f,
+	0x3c, 0x94, 0x93, 0xc5, 0x5f, 0x87, 0x5c, 0xf0, 0x13, 0x77, 0x54, 0x75, 0xb5, 0x6e, 0x9c, 0xe8,
+	0x75, 0x74, 0x79, 0xa3, 0xa1, 0x7d, 0xfd, 0x54, 0xab, 0x35, 0xab, 0xa5, 0x23, 0xf9, 0x15, 0xf4,
+	0xd9, 0x50, 0x97, 0x5e, 0xaa, 0x55, 0xea, 0x68, 0x2c, 0xab, 0x90, 0x0f, 0x91, 0x2b, 0xf7, 0xb9,
+	0x91, 0x44, 0x48, 0x86, 0xae, 0x1d, 0xd7, 0x51, 0x17, 0x18, 0xb1, 0x43, 0x3d, 0xe5, 0xe3, 0x86,
+	0x9c, 0x2a, 0xfe, 0x20, 0x01, 0x8b, 0xa1, 0x5f, 0x82, 0xe0, 0x3c, 0x62, 0x7d, 0x18, 0xb7, 0xc2,
+	0x66, 0x13, 0x21, 0x9f, 0x68, 0xb5, 0x0a, 0xda, 0x64, 0x58, 0x21, 0xbc, 0xa7, 0xf4, 0xa8, 0x54,
+	0x3d, 0x2a, 0xdd, 0x3f, 0x12, 0xa6, 0x13, 0xed, 0x6b, 0x36, 0x4b, 0xe5, 0x87, 0xe8, 0x26, 0x23,
+	0x5d, 0x15, 0x4d, 0x74, 0xa5, 0x42, 0xfa, 0x1f, 0x76, 0x35, 0xcb, 0x0f, 0x71, 0xba, 0x34, 0x5a,
+	0x69, 0xa4, 0x93, 0x9f, 0x33, 0x99, 0x11, 0x80, 0xbe, 0x43, 0x66, 0xc9, 0x75, 0x28, 0x44, 0x7a,
+	0x9a, 0xfa, 0xc7, 0x62, 0x36, 0x94, 0xb8, 0x30, 0x32, 0x52, 0xd7, 0x30, 0x7c, 0x6b, 0x72, 0xae,
+	0xf8, 0xfb, 0x12, 0x2c, 0x85, 0x7f, 0xef, 0x1a, 0x9b, 0x7c, 0x78, 0x54, 0x6e, 0xc3, 0xb5, 0x38,
+	0xbd, 0x69, 0x9c, 0xe8, 0x5a, 0x43, 0xab, 0xe1, 0xc1, 0xb9, 0x06, 0x72, 0xb4, 0xfb, 0xf4, 0x84,
+	0x07, 0xee, 0x28, 0x95, 0x9d, 0x66, 0xc9, 0x98, 0x42, 0x4f, 0x1b, 0xc3, 0xc3, 0x2c, 0x55, 0xfc,
+	0x15, 0xc8, 0x8b, 0x43, 0x5c, 0xfc, 0xd9, 0x0f, 0x3b, 0xfa, 0xf8, 0xf9, 0xc4, 0x8d, 0xcb, 0x38,
+	0x2e, 0x3d, 0xa8, 0x69, 0xcd, 0x6a, 0x59, 0x7e, 0x85, 0x1f, 0xa4, 0x91, 0xce, 0x46, 0x03, 0x83,
+	0x1d, 0x3b, 0x12, 0x23, 0xf4, 0xda, 0xa3, 0x63, 0x4d, 0x4e, 0x14, 0x77, 0x21, 0x2f, 0xb2, 0xad,
+	0x9a, 0xed, 0x75, 0x9e, 0xbe, 0x40, 0x4e, 0xe1, 0xed, 0x22, 0xd4, 0x70, 0x90, 0xaf, 0x14, 0x29,
+	0x2c, 0x86, 0x7e, 0x75, 0x8b, 0xbb, 0xc9, 0xf7, 0xd6, 0xdf, 0x95, 0x8f, 0x9a, 0x9a, 0x5e, 0x63,
+	0x86, 0x1b, 0xef, 0xaa, 0xd6, 0x44, 0x97, 0x84, 0x67, 0xec, 0xd8, 0x2e, 0xa3, 0xf1, 0xb8, 0xda,
+	0x2c, 0x3f, 0x94, 0x13, 0xc5, 0x26, 0x2c, 0xd7, 0xfb, 0xd4, 0x61, 0x7f, 0xde, 0x70, 0xd8, 0x35,
+	0xcf, 0xf0, 0x07, 0xa3, 0x72, 0xfd, 0xc4, 0x38, 0x3c, 0x2a, 0x3d, 0x68, 0x18, 0xa7, 0xb5, 0x0f,
+	0x6b, 0x0c, 0x0e, 0xba, 0x41, 0x40, 0x65, 0x7b, 0xc2, 0xc2, 0x68, 0x40, 0xe2, 0xdb, 0x6d, 0x1c,
+	0xd6, 0xf5, 0xb2, 0x26, 0x27, 0x0e, 0x7e, 0x7b, 0x0d, 0x56, 0xeb, 0x7d, 0x6a, 0x09, 0x55, 0xf2,
+	0x2d, 0x26, 0x9f, 0x42, 0x86, 0x97, 0xe0, 0xc8, 0xeb, 0x93, 0x1f, 0xeb, 0x22, 0x95, 0xc1, 0xc2,
+	0xee, 0x6c, 0x46, 0x91, 0xaa, 0x16, 0x7e, 0xf3, 0x5f, 0x7e, 0xf6, 0xc7, 0x89, 0xb5, 0x7b, 0x52,
+	0x51, 0x5d, 0xd9, 0xbf, 0x78, 0x6b, 0xdf, 0x76, 0xdb, 0x77, 0xc4, 0x7b, 0x1a, 0xf9, 0xb6, 0x04,
+	0x59, 0x71, 0xb7, 0x22, 0x53, 0x24, 0x46, 0xef, 0x6c, 0x85, 0x37, 0xe6, 0xe0, 0x14, 0x93, 0xbf,
+	0xc6, 0x26, 0xdf, 0x26, 0x9b, 0xb1, 0x99, 0xf7, 0x3f, 0x0b, 0x4a, 0x17, 0x9f, 0x93, 0xcf, 0x21,
+	0x17, 0xdc, 0x5d, 0x48, 0x71, 0xfe, 0xe7, 0xca, 0xc2, 0x9b, 0x73, 0xf1, 0x0a, 0x28, 0x1b, 0x0c,
+	0xca, 0x2a, 0x19, 0xa7, 0x84, 0x0c, 0x7f, 0xa9, 0x9b, 0xa6, 0xfe, 0xc8, 0x6b, 0x63, 0x61, 0x77,
+	0x36, 0xa3, 0x98, 0xf6, 0x16, 0x9b, 0x76, 0xe7, 0x9e, 0x54, 0x2c, 0x4c, 0x55, 0xc2, 0x6f, 0x48,
+	0x90, 0xe1, 0x35, 0xa0, 0x69, 0x28, 0x22, 0xe5, 0xab, 0xc2, 0xee, 0x6c, 0xc6, 0xe8, 0x3e, 0x14,
+	0xa7, 0x42, 0xf8, 0xb6, 0xe4, 0xff, 0x36, 0xfa, 0xd6, 0x64, 0xc1, 0xe1, 0x67, 0xc6, 0xc2, 0xeb,
+	0x33, 0xf9, 0xc4, 0xfc, 0x6f, 0xb0, 0xf9, 0x5f, 0x23, 0xaf, 0xc6, 0xe7, 0x67, 0x4f, 0x84, 0x11,
+	0x14, 0xdf, 0xc1, 0x1f, 0x4b, 0x44, 0x1e, 0xe7, 0xc8, 0x1b, 0xd3, 0x9e, 0xc9, 0xa2, 0x7e, 0x51,
+	0x9c, 0x87, 0x55, 0x80, 0xda, 0x62, 0xa0, 0xd6, 0xd1, 0x33, 0x56, 0x7d, 0x5c, 0xc1, 0x3b, 0x16,
+	0xf9, 0x1d, 0x89, 0xff, 0xe8, 0x2e, 0xf2, 0x38, 0x46, 0x6e, 0x5f, 0xe6, 0xc5, 0xae, 0x70, 0x67,
+	0x4e, 0x6e, 0x01, 0xe8, 0x1a, 0x03, 0x74, 0x85, 0x8c, 0x41, 0xf3, 0x67, 0x12, 0xac, 0xc4, 0x1e,
+	0xbe, 0xc8, 0xd4, 0xb5, 0x46, 0x4b, 0xf6, 0x85, 0x37, 0xe7, 0xe2, 0x15, 0x38, 0xee, 0x32, 0x1c,
+	0x45, 0x54, 0xcc, 0xcd, 0x11, 0x28, 0xfb, 0xa2, 0x6a, 0x1e, 0xd9, 0xb4, 0x2f, 0xa4, 0xf0, 0x83,
+	0x8e, 0xd8, 0xb6, 0x37, 0x2f, 0xf1, 0xb6, 0x56, 0xb8, 0x3d, 0x1f, 0xb3, 0x40, 0xa8, 0x30, 0x84,
+	0x04, 0x11, 0xe6, 0x7d, 0x84, 0x58, 0x5f, 0x75, 0xc9, 0x77, 0x25, 0xb8, 0x32, 0xe6, 0x5d, 0x8a,
+	0xec, 0xcd, 0xfd, 0x80, 0xc5, 0xf1, 0xec, 0x5f, 0xf2, 0xc1, 0x4b, 0xbd, 0xca, 0x20, 0xad, 0x90,
+	0x18, 0x9e, 0x3f, 0x89, 0x68, 0x46, 0x78, 0xf8, 0x0c, 0xcd, 0x44, 0xbd, 0xfc, 0xf6, 0x7c, 0xcc,
+	0x02, 0xc6, 0x4d, 0x06, 0xe3, 0x46, 0x71, 0x3b, 0x02, 0x63, 0xff, 0xb3, 0x48, 0x49, 0xfa, 0x73,
+	0xf2, 0x57, 0x12, 0x90, 0xd1, 0x77, 0x29, 0x72, 0x67, 0xfa, 0x5c, 0xb1, 0x47, 0xb0, 0xc2, 0xde,
+	0xbc, 0xec, 0x02, 0xdc, 0x5b, 0x0c, 0xdc, 0x9b, 0xb8, 0x6d, 0xb7, 0xa2, 0xf8, 0x2e, 0x04, 0xeb,
+	0x08, 0x50, 0x74, 0xc3, 0x91, 0xa7, 0xaa, 0xf1, 0x6e, 0x38, 0xe9, 0x19, 0xad, 0x70, 0x67, 0x4e,
+	0xee, 0xa8, 0x1b, 0x22, 0xca, 0x65, 0x1f, 0x25, 0xaf, 0x1d, 0x93, 0xef, 0xa1, 0xda, 0x46, 0x1e,
+	0x9b, 0xc8, 0xac, 0x09, 0x62, 0xce, 0xb8, 0x37, 0x2f, 0xbb, 0x00, 0xf4, 0x2a, 0x03, 0xb4, 0x89,
+	0x80, 0xd6, 0xa3, 0x80, 0x7c, 0x67, 0x24, 0x7f, 0x28, 0xc1, 0xda, 0xb8, 0x97, 0x14, 0xb2, 0x3f,
+	0x63, 0xae, 0x11, 0xc3, 0xbf, 0x3b, 0xff, 0x00, 0x01, 0x6f, 0x9d, 0xc1, 0x93, 0x49, 0x5c, 0x59,
+	0xbf, 0x15, 0xdd, 0x3a, 0x61, 0xfb, 0xb3, 0xb6, 0x2e, 0x6a, 0xfc, 0x77, 0xe6, 0xe4, 0x8e, 0x42,
+	0x29, 0xc6, 0xa1, 0xfc, 0x51, 0x14, 0x8a, 0x48, 0xaa, 0x6f, 0xcf, 0x59, 0xbb, 0x9f, 0x0f, 0x4a,
+	0xb4, 0xd2, 0xaf, 0xee, 0x30, 0x28, 0x05, 0xdc, 0xb4, 0xab, 0xb1, 0x4d, 0xe3, 0x55, 0xff, 0x83,
+	0x9f, 0xa6, 0x81, 0x84, 0x92, 0x41, 0xff, 0xa7, 0x81, 0xdf, 0x91, 0xc2, 0xf9, 0xd0, 0xf8, 0x50,
+	0x31, 0xbe, 0x86, 0x5f, 0xb8, 0x3d, 0x1f, 0xb3, 0x40, 0xb8, 0xcd, 0x10, 0x6e, 0x10, 0x06, 0x4f,
+	0x14, 0xeb, 0xf7, 0x69, 0x30, 0xf3, 0x17, 0xa1, 0xe4, 0xf0, 0x8d, 0x29, 0x82, 0x63, 0xd9, 0x61,
+	0x71, 0x1e, 0xd6, 0x68, 0xb0, 0x22, 0xdb, 0x61, 0x04, 0x1d, 0xce, 0xb4, 0xff, 0x99, 0x78, 0x18,
+	0xf8, 0x1c, 0x8d, 0x7b, 0x39, 0x5a, 0xe2, 0x26, 0x77, 0xa7, 0xcc, 0x32, 0xb6, 0xba, 0x5f, 0x78,
+	0xeb, 0x12, 0x23, 0xa2, 0xa9, 0x33, 0x21, 0x61, 0x78, 0xbc, 0x56, 0x4e, 0xfe, 0x40, 0x02, 0x18,
+	0x56, 0xab, 0xc9, 0xed, 0x59, 0xd2, 0xc3, 0x95, 0xf4, 0xc2, 0x9d, 0x39, 0xb9, 0xa3, 0x6a, 0x2a,
+	0x6c, 0x8f, 0xe2, 0xd8, 0xff, 0xcc, 0x2f, 0x61, 0x7f, 0x3e, 0x84, 0xc4, 0x6a, 0xd5, 0xb3, 0x21,
+	0x85, 0xeb, 0xe8, 0x85, 0x3b, 0x73, 0x72, 0x8f, 0x3b, 0x66, 0x26, 0x42, 0x3a, 0x70, 0x40, 0x0e,
+	0x59, 0x38, 0xab, 0x6c, 0x93, 0x5f, 0x85, 0x34, 0xff, 0xd8, 0x19, 0x37, 0x65, 0xb8, 0xa6, 0x5e,
+	0xb8, 0x3e, 0x91, 0x83, 0xd5, 0xc7, 0xd5, 0x55, 0x86, 0x62, 0x91, 0xe4, 0x10, 0xc5, 0xa7, 0x48,
+	0xbf, 0x2b, 0xdd, 0xdf, 0x82, 0x2b, 0x2d, 0xbb, 0x17, 0x1f, 0x79, 0x22, 0x7d, 0x92, 0x34, 0xfb,
+	0x9d, 0x27, 0x19, 0x56, 0x0c, 0xff, 0xd2, 0x7f, 0x0d, 0x00, 0x79, 0x3a, 0x12, 0x8d, 0x0d, 0x45,
+	0x00, 0x00,
 }
@@ -109,22 +109,30 @@ func (c *Client) Versions(endpoint string) ([]string, error) {
 
 // Get returns a Request object setup for GET call.
 func (c *Client) Get() *Request {
-	return NewRequest(c.httpClient, c.base, "GET", c.version, c.authstring, c.userAgent)
+	return c.newRequest("GET")
 }
 
 // Post returns a Request object setup for POST call.
 func (c *Client) Post() *Request {
-	return NewRequest(c.httpClient, c.base, "POST", c.version, c.authstring, c.userAgent)
+	return c.newRequest("POST")
 }
 
 // Put returns a Request object setup for PUT call.
 func (c *Client) Put() *Request {
-	return NewRequest(c.httpClient, c.base, "PUT", c.version, c.authstring, c.userAgent)
+	return c.newRequest("PUT")
 }
 
 // Delete returns a Request object setup for DELETE call.
 func (c *Client) Delete() *Request {
-	return NewRequest(c.httpClient, c.base, "DELETE", c.version, c.authstring, c.userAgent)
+	return c.newRequest("DELETE")
+}
+
+// newRequest returns a Request object setup for a verb call carrying the
+// access token of the client.
+func (c *Client) newRequest(verb string) *Request {
+	r := NewRequest(c.httpClient, c.base, verb, c.version, c.authstring, c.userAgent)
+	r.accesstoken = c.accesstoken
+	return r
 }
 
 func unix2HTTP(u *url.URL) {
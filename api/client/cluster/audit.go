@@ -0,0 +1,27 @@
+package cluster
+
+import (
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/api/client"
+	"github.com/libopenstorage/openstorage/pkg/audit"
+)
+
+// EnumerateAudit returns the audit records selected by filter, oldest
+// first.
+func EnumerateAudit(c *client.Client, filter *audit.Filter) ([]*audit.Record, error) {
+	records := make([]*audit.Record, 0)
+	request := c.Get().Resource(clusterPath + "/audit")
+	if !filter.Start.IsZero() {
+		request.QueryOption("timestart", filter.Start.UTC().Format(api.TimeLayout))
+	}
+	if !filter.End.IsZero() {
+		request.QueryOption("timeend", filter.End.UTC().Format(api.TimeLayout))
+	}
+	if filter.Resource != "" {
+		request.QueryOption("resource", filter.Resource)
+	}
+	if err := request.Do().Unmarshal(&records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
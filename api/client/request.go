@@ -237,22 +237,14 @@ func parseHTTPStatus(resp *http.Response, body []byte) error {
 	return fmt.Errorf("HTTP error %d", resp.StatusCode)
 }
 
-// Do executes the request and returns a Response.
-func (r *Request) Do() *Response {
-	var (
-		err  error
-		req  *http.Request
-		resp *http.Response
-		url  string
-		body []byte
-	)
+// httpRequest builds the HTTP request to send.
+func (r *Request) httpRequest() (*http.Request, error) {
 	if r.err != nil {
-		return &Response{err: r.err}
+		return nil, r.err
 	}
-	url = r.URL().String()
-	req, err = http.NewRequest(r.verb, url, bytes.NewBuffer(r.body))
+	req, err := http.NewRequest(r.verb, r.URL().String(), bytes.NewBuffer(r.body))
 	if err != nil {
-		return &Response{err: err}
+		return nil, err
 	}
 	if r.headers == nil {
 		r.headers = http.Header{}
@@ -269,6 +261,20 @@ func (r *Request) Do() *Response {
 	if len(r.accesstoken) > 0 {
 		req.Header.Set("Access-Token", r.accesstoken)
 	}
+	return req, nil
+}
+
+// Do executes the request and returns a Response.
+func (r *Request) Do() *Response {
+	var (
+		err  error
+		req  *http.Request
+		resp *http.Response
+		body []byte
+	)
+	if req, err = r.httpRequest(); err != nil {
+		return &Response{err: err}
+	}
 
 	resp, err = r.client.Do(req)
 	if err != nil {
@@ -289,6 +295,30 @@ func (r *Request) Do() *Response {
 	}
 }
 
+// Stream executes the request and returns the response with its body left
+// to be read and closed by the caller, without the response timeout of
+// the client. If the request fails, the response is returned closed along
+// with the error if it was received.
+func (r *Request) Stream() (*http.Response, error) {
+	req, err := r.httpRequest()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := (&http.Client{Transport: r.client.Transport}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode > http.StatusPartialContent {
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return resp, err
+		}
+		return resp, parseHTTPStatus(resp, body)
+	}
+	return resp, nil
+}
+
 // Body return http body, valid only if there is no error
 func (r Response) Body() ([]byte, error) {
 	return r.body, r.err
@@ -0,0 +1,21 @@
+package volume
+
+import (
+	"errors"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/api/client"
+)
+
+// GroupSnapshot takes snapshots of the volumes of req at the same point in
+// time.
+func GroupSnapshot(c *client.Client, req *api.GroupSnapCreateRequest) (*api.GroupSnapCreateResponse, error) {
+	response := &api.GroupSnapCreateResponse{}
+	if err := c.Post().Resource(snapPath + "/group").Body(req).Do().Unmarshal(response); err != nil {
+		return nil, err
+	}
+	if response.GroupSnapCreateErr != "" {
+		return nil, errors.New(response.GroupSnapCreateErr)
+	}
+	return response, nil
+}
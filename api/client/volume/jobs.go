@@ -0,0 +1,104 @@
+package volume
+
+import (
+	"errors"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/api/client"
+	"github.com/libopenstorage/openstorage/pkg/jobs"
+)
+
+const jobPath = "/osd-jobs"
+
+// CreateAsync creates a volume in the background. The ID of the volume is
+// the result of the returned job.
+func CreateAsync(
+	c *client.Client,
+	locator *api.VolumeLocator,
+	source *api.Source,
+	spec *api.VolumeSpec,
+) (*jobs.Job, error) {
+	request := &api.VolumeCreateRequest{
+		Locator: locator,
+		Source:  source,
+		Spec:    spec,
+	}
+	return submitJob(c.Post().Resource(volumePath).Body(request))
+}
+
+// DeleteAsync deletes a volume in the background.
+func DeleteAsync(c *client.Client, volumeID string) (*jobs.Job, error) {
+	return submitJob(c.Delete().Resource(volumePath).Instance(volumeID))
+}
+
+// SnapshotAsync snaps a volume in the background. The ID of the snapshot
+// is the result of the returned job.
+func SnapshotAsync(
+	c *client.Client,
+	volumeID string,
+	readonly bool,
+	locator *api.VolumeLocator,
+) (*jobs.Job, error) {
+	request := &api.SnapCreateRequest{
+		Id:       volumeID,
+		Readonly: readonly,
+		Locator:  locator,
+	}
+	return submitJob(c.Post().Resource(snapPath).Body(request))
+}
+
+// RestoreAsync restores a volume to a snapshot in the background.
+func RestoreAsync(c *client.Client, volumeID string, snapID string) (*jobs.Job, error) {
+	request := c.Post().Resource(snapPath + "/restore").Instance(volumeID)
+	request.QueryOption(api.OptSnapID, snapID)
+	return submitJob(request)
+}
+
+// SetAsync updates a volume in the background.
+func SetAsync(c *client.Client, volumeID string, req *api.VolumeSetRequest) (*jobs.Job, error) {
+	return submitJob(c.Put().Resource(volumePath).Instance(volumeID).Body(req))
+}
+
+func submitJob(request *client.Request) (*jobs.Job, error) {
+	job := &jobs.Job{}
+	request.QueryOption(api.OptAsync, "true")
+	if err := request.Do().Unmarshal(job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// JobEnumerate returns the jobs in state, or all jobs if state is empty,
+// oldest first.
+func JobEnumerate(c *client.Client, state string) ([]*jobs.Job, error) {
+	all := make([]*jobs.Job, 0)
+	request := c.Get().Resource(jobPath)
+	if state != "" {
+		request.QueryOption("state", state)
+	}
+	if err := request.Do().Unmarshal(&all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// JobInspect returns the job with ID id.
+func JobInspect(c *client.Client, id string) (*jobs.Job, error) {
+	job := &jobs.Job{}
+	if err := c.Get().Resource(jobPath).Instance(id).Do().Unmarshal(job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// JobCancel cancels the job with ID id.
+func JobCancel(c *client.Client, id string) error {
+	response := &api.VolumeResponse{}
+	if err := c.Post().Resource(jobPath + "/cancel").Instance(id).Do().Unmarshal(response); err != nil {
+		return err
+	}
+	if response.Error != "" {
+		return errors.New(response.Error)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package volume
+
+import (
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/api/client"
+	"github.com/libopenstorage/openstorage/pkg/placement"
+)
+
+// Placement returns the nodes a volume created with req would be placed
+// on, without creating it.
+func Placement(c *client.Client, req *api.VolumeCreateRequest) (*placement.Decision, error) {
+	decision := &placement.Decision{}
+	if err := c.Post().Resource(volumePath + "/placement").Body(req).Do().Unmarshal(decision); err != nil {
+		return nil, err
+	}
+	return decision, nil
+}
@@ -0,0 +1,52 @@
+package volume
+
+import (
+	"errors"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/api/client"
+	"github.com/libopenstorage/openstorage/pkg/quota"
+)
+
+const quotaPath = "/osd-quotas"
+
+// QuotaEnumerate returns the quotas and the usage of the volumes of the
+// driver they select.
+func QuotaEnumerate(c *client.Client) ([]*quota.Status, error) {
+	status := make([]*quota.Status, 0)
+	if err := c.Get().Resource(quotaPath).Do().Unmarshal(&status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// QuotaInspect returns the quota with name and the usage of the volumes of
+// the driver it selects.
+func QuotaInspect(c *client.Client, name string) (*quota.Status, error) {
+	status := &quota.Status{}
+	if err := c.Get().Resource(quotaPath).Instance(name).Do().Unmarshal(status); err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+// QuotaSet creates or replaces quota q.
+func QuotaSet(c *client.Client, q *quota.Quota) error {
+	return quotaResponse(c.Post().Resource(quotaPath).Body(q))
+}
+
+// QuotaDelete deletes the quota with name.
+func QuotaDelete(c *client.Client, name string) error {
+	return quotaResponse(c.Delete().Resource(quotaPath).Instance(name))
+}
+
+func quotaResponse(request *client.Request) error {
+	response := &api.VolumeResponse{}
+	if err := request.Do().Unmarshal(response); err != nil {
+		return err
+	}
+	if response.Error != "" {
+		return errors.New(response.Error)
+	}
+	return nil
+}
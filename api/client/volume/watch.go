@@ -0,0 +1,73 @@
+package volume
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/libopenstorage/openstorage/api/client"
+	"github.com/libopenstorage/openstorage/pkg/watch"
+)
+
+const watchPath = "/osd-watch"
+
+// maxEventSize bounds the size of an event received by Watch.
+const maxEventSize = 4 * 1024 * 1024
+
+// Watch calls fn with the changes of the volumes of the driver and of the
+// nodes after revision, or from now on if revision is zero. Only events
+// whose type starts with one of types are sent, if any. Watch returns when
+// the stream ends or when fn returns an error, which Watch returns. Events
+// after revision may no longer be available, in which case
+// watch.ErrCompacted is returned and the current state must be listed
+// before watching again.
+func Watch(
+	c *client.Client,
+	revision uint64,
+	types []string,
+	fn func(*watch.Event) error,
+) error {
+	request := c.Get().Resource(watchPath)
+	if revision != 0 {
+		request.QueryOption("revision", strconv.FormatUint(revision, 10))
+	}
+	if len(types) > 0 {
+		request.QueryOption("type", strings.Join(types, ","))
+	}
+	resp, err := request.Stream()
+	if resp != nil && resp.StatusCode == http.StatusGone {
+		return watch.ErrCompacted
+	} else if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), maxEventSize)
+	var eventType, data string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			eventType = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		case line == "" && data != "":
+			if eventType == "error" {
+				return errors.New(data)
+			}
+			e := &watch.Event{}
+			if err := json.Unmarshal([]byte(data), e); err != nil {
+				return err
+			}
+			if err := fn(e); err != nil {
+				return err
+			}
+			eventType, data = "", ""
+		}
+	}
+	return scanner.Err()
+}
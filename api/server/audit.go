@@ -0,0 +1,214 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/audit"
+)
+
+// maxAuditError is the length of error responses kept in audit records.
+const maxAuditError = 256
+
+// auditRecorder records the status code and error of a response.
+type auditRecorder struct {
+	statusRecorder
+	err bytes.Buffer
+	// resource set by the handler, for requests whose path has no ID
+	resource string
+}
+
+// auditResource sets the resource of the audit record of a request to id,
+// for handlers of requests creating a resource or naming it in their body.
+func auditResource(w http.ResponseWriter, id string) {
+	if rec, ok := w.(*auditRecorder); ok {
+		rec.resource = id
+	}
+}
+
+func (a *auditRecorder) Write(data []byte) (int, error) {
+	if a.code >= http.StatusBadRequest && a.err.Len() < maxAuditError {
+		n := maxAuditError - a.err.Len()
+		if n > len(data) {
+			n = len(data)
+		}
+		a.err.Write(data[:n])
+	}
+	return a.statusRecorder.Write(data)
+}
+
+func (a *auditRecorder) result() string {
+	if a.code < http.StatusBadRequest {
+		return audit.ResultOK
+	}
+	if msg := strings.TrimSpace(a.err.String()); msg != "" {
+		return fmt.Sprintf("%d %s", a.code, msg)
+	}
+	return fmt.Sprintf("%d %s", a.code, http.StatusText(a.code))
+}
+
+// auditRoutes returns routes with the requests changing state audited.
+func auditRoutes(service string, routes []*Route) []*Route {
+	audited := make([]*Route, len(routes))
+	for i, route := range routes {
+		audited[i] = route
+		if route.verb != "GET" {
+			audited[i] = &Route{
+				verb: route.verb,
+				path: route.path,
+				fn:   auditRequest(service, route),
+			}
+		}
+	}
+	return audited
+}
+
+// auditRequest logs an audit record of every request to route.
+func auditRequest(service string, route *Route) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !audit.Enabled() {
+			route.fn(w, r)
+			return
+		}
+		start := time.Now()
+		body, err := ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		rec := &auditRecorder{statusRecorder: statusRecorder{ResponseWriter: w, code: http.StatusOK}}
+		route.fn(rec, r)
+
+		resource := rec.resource
+		if resource == "" {
+			resource = requestResource(r)
+		}
+		audit.Log(&audit.Record{
+			Time:        start,
+			Caller:      requestCaller(r),
+			Service:     service,
+			Verb:        route.verb + " " + route.path,
+			Resource:    resource,
+			RequestHash: audit.Hash(body),
+			Result:      rec.result(),
+			Latency:     time.Since(start),
+		})
+	}
+}
+
+// auditDenied logs an audit record of a request to route of caller denied
+// by the auth middleware with code, unless it is a GET, as auditRoutes.
+func auditDenied(
+	service string,
+	route *Route,
+	r *http.Request,
+	caller string,
+	start time.Time,
+	code int,
+	msg string,
+) {
+	if !audit.Enabled() || route.verb == "GET" {
+		return
+	}
+	audit.Log(&audit.Record{
+		Time:     start,
+		Caller:   caller,
+		Service:  service,
+		Verb:     route.verb + " " + route.path,
+		Resource: requestResource(r),
+		Result:   fmt.Sprintf("%d %s", code, msg),
+		Latency:  time.Since(start),
+	})
+}
+
+// requestResource returns the ID in the path of a request, if any.
+func requestResource(r *http.Request) string {
+	vars := mux.Vars(r)
+	if id := vars["id"]; id != "" {
+		return id
+	}
+	return vars["uuid"]
+}
+
+// requestCaller returns the identity of the caller of a request.
+func requestCaller(r *http.Request) string {
+	if claims, ok := requestClaims(r); ok {
+		return claims.Subject
+	}
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		return r.TLS.PeerCertificates[0].Subject.CommonName
+	}
+	if _, _, err := net.SplitHostPort(r.RemoteAddr); err != nil {
+		// Requests on unix sockets have no remote address.
+		return audit.CallerLocal
+	}
+	return r.RemoteAddr
+}
+
+// swagger:operation GET /cluster/audit cluster audit enumerateAudit
+//
+// This will return the audit records of the requests changing state
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - name: timestart
+//   in: query
+//   description: Only records at or after this time
+//   type: string
+// - name: timeend
+//   in: query
+//   description: Only records before this time
+//   type: string
+// - name: resource
+//   in: query
+//   description: Only records of the volume, node or other resource with this ID
+//   type: string
+// responses:
+//   '200':
+//      description: Audit records, oldest first
+func (c *clusterApi) enumerateAudit(w http.ResponseWriter, r *http.Request) {
+	method := "enumerateAudit"
+
+	l := audit.Instance()
+	if l == nil {
+		c.sendError(c.name, method, w, "Auditing is not enabled", http.StatusNotFound)
+		return
+	}
+
+	params := r.URL.Query()
+	filter := &audit.Filter{Resource: params.Get("resource")}
+	var err error
+	if v := params.Get("timestart"); v != "" {
+		if filter.Start, err = time.Parse(api.TimeLayout, v); err != nil {
+			c.sendError(c.name, method, w, "Invalid timestart param", http.StatusBadRequest)
+			return
+		}
+	}
+	if v := params.Get("timeend"); v != "" {
+		if filter.End, err = time.Parse(api.TimeLayout, v); err != nil {
+			c.sendError(c.name, method, w, "Invalid timeend param", http.StatusBadRequest)
+			return
+		}
+	}
+
+	records, err := l.Query(filter)
+	if err != nil {
+		c.sendError(c.name, method, w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(records)
+}
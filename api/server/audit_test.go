@@ -0,0 +1,143 @@
+package server
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api/client"
+	clusterclient "github.com/libopenstorage/openstorage/api/client/cluster"
+	"github.com/libopenstorage/openstorage/cluster"
+	"github.com/libopenstorage/openstorage/pkg/audit"
+	"github.com/libopenstorage/openstorage/pkg/auth"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+func TestAudit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	l, err := audit.NewFileLogger(filepath.Join(dir, "audit.log"), 0, 0)
+	require.NoError(t, err)
+	audit.SetInstance(l)
+	defer audit.SetInstance(nil)
+
+	routes := []*Route{
+		{verb: "GET", path: volPath("/{id}", volume.APIVersion), fn: func(w http.ResponseWriter, r *http.Request) {}},
+		{verb: "DELETE", path: volPath("/{id}", volume.APIVersion), fn: func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "volume is mounted", http.StatusInternalServerError)
+		}},
+		{verb: "PUT", path: volPath("/{id}", volume.APIVersion), fn: func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Equal(t, "{}", string(body))
+		}},
+		{verb: "POST", path: volPath("", volume.APIVersion), fn: func(w http.ResponseWriter, r *http.Request) {
+			auditResource(w, "vol3")
+		}},
+	}
+	routes = append(routes, newClusterAPI().Routes()...)
+	ts := httptest.NewServer(newRouter("audit_test", auditRoutes("audit_test", routes), false))
+	defer ts.Close()
+
+	do := func(verb string, path string, body string) {
+		req, err := http.NewRequest(verb, ts.URL+path, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	vol := volPath("/vol1", volume.APIVersion)
+	do("GET", vol, "")
+	do("DELETE", vol, "")
+	do("PUT", vol, "{}")
+	do("POST", volPath("", volume.APIVersion), "{}")
+
+	c, err := client.NewClient(ts.URL, cluster.APIVersion, "")
+	require.NoError(t, err)
+	records, err := clusterclient.EnumerateAudit(c, &audit.Filter{Resource: "vol1"})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "DELETE "+volPath("/{id}", volume.APIVersion), records[0].Verb)
+	assert.Equal(t, "500 volume is mounted", records[0].Result)
+	assert.Contains(t, records[0].Caller, "127.0.0.1")
+	assert.Equal(t, "audit_test", records[0].Service)
+
+	assert.Equal(t, "PUT "+volPath("/{id}", volume.APIVersion), records[1].Verb)
+	assert.Equal(t, audit.ResultOK, records[1].Result)
+	assert.Equal(t, audit.Hash([]byte("{}")), records[1].RequestHash)
+
+	// The resource of a create is set by its handler.
+	records, err = clusterclient.EnumerateAudit(c, &audit.Filter{Resource: "vol3"})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "POST "+volPath("", volume.APIVersion), records[0].Verb)
+
+	records, err = clusterclient.EnumerateAudit(c, &audit.Filter{Resource: "vol2"})
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestAuditDenied(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	l, err := audit.NewFileLogger(filepath.Join(dir, "audit.log"), 0, 0)
+	require.NoError(t, err)
+	audit.SetInstance(l)
+	defer audit.SetInstance(nil)
+
+	key := []byte("audit test secret")
+	a, err := auth.NewJwtAuthenticator("openstorage", key)
+	require.NoError(t, err)
+	EnableAuth(a, false)
+	defer EnableAuth(nil, false)
+
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+	routes := []*Route{
+		{verb: "GET", path: volPath("/{id}", volume.APIVersion), fn: ok},
+		{verb: "DELETE", path: volPath("/{id}", volume.APIVersion), fn: ok},
+	}
+	ts := httptest.NewServer(newRouter("audit_test", auditRoutes("audit_test", routes), true))
+	defer ts.Close()
+
+	tok, err := auth.Token(&auth.Claims{
+		Issuer:    "openstorage",
+		Subject:   "reader",
+		Roles:     []string{RoleReadOnly},
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}, key)
+	require.NoError(t, err)
+	do := func(verb string, tok string) {
+		req, err := http.NewRequest(verb, ts.URL+volPath("/vol1", volume.APIVersion), nil)
+		require.NoError(t, err)
+		if tok != "" {
+			req.Header.Set("Authorization", "Bearer "+tok)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+	do("GET", "")
+	do("DELETE", "")
+	do("DELETE", tok)
+
+	records, err := l.Query(&audit.Filter{Resource: "vol1"})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "DELETE "+volPath("/{id}", volume.APIVersion), records[0].Verb)
+	assert.Equal(t, "401 Missing access token", records[0].Result)
+	assert.Contains(t, records[0].Caller, "127.0.0.1")
+
+	assert.Equal(t, "403 Access denied", records[1].Result)
+	assert.Equal(t, "reader", records[1].Caller)
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/auth"
+)
+
+const (
+	// RoleAdmin may make any request
+	RoleAdmin = auth.RoleAdmin
+	// RoleVolumeOperator may manage volumes and read the cluster state and
+	// the quotas
+	RoleVolumeOperator = auth.RoleVolumeOperator
+	// RoleReadOnly may only make GET requests
+	RoleReadOnly = auth.RoleReadOnly
+)
+
+type contextKey string
+
+// claimsKey is the request context key of the claims of the caller.
+const claimsKey = contextKey("claims")
+
+var (
+	// authenticator verifies the tokens of callers, requests are not
+	// authenticated if it is nil.
+	authenticator auth.Authenticator
+	// authTrustLocal lets requests on unix sockets skip authentication.
+	authTrustLocal bool
+)
+
+// roles maps each role to the requests it may make.
+var roles = map[string]func(verb string, path string) bool{
+	RoleAdmin: func(verb string, path string) bool {
+		return true
+	},
+	RoleVolumeOperator: func(verb string, path string) bool {
+		return verb == "GET" || !isAdminPath(path)
+	},
+	RoleReadOnly: func(verb string, path string) bool {
+		return verb == "GET"
+	},
+}
+
+// EnableAuth requires requests to the REST servers started after it is
+// called to carry a token verified by a. Requests on the unix sockets are
+// not authenticated if trustLocal is set, which is needed for callers that
+// cannot pass tokens such as the docker daemon.
+func EnableAuth(a auth.Authenticator, trustLocal bool) {
+	authenticator = a
+	authTrustLocal = trustLocal
+}
+
+// authenticate rejects requests to route without a valid token, or whose
+// caller has no role allowed to make them.
+func authenticate(
+	server string,
+	route *Route,
+	next func(http.ResponseWriter, *http.Request),
+) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		token := requestToken(r)
+		if token == "" {
+			auditDenied(server, route, r, requestCaller(r), start,
+				http.StatusUnauthorized, "Missing access token")
+			http.Error(w, "Missing access token", http.StatusUnauthorized)
+			return
+		}
+		claims, err := authenticator.AuthenticateToken(token)
+		if err != nil {
+			dlog.Warnf("Rejected %v %v: %v", r.Method, r.URL.Path, err)
+			auditDenied(server, route, r, requestCaller(r), start,
+				http.StatusUnauthorized, err.Error())
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		logger := dlog.WithFields(map[string]interface{}{
+			"Server": server,
+			"User":   claims.Subject,
+			"Roles":  claims.Roles,
+			"Method": r.Method,
+			"Path":   r.URL.Path,
+		})
+		if !authorized(claims, route.verb, route.path) {
+			logger.Warnln("Access denied")
+			auditDenied(server, route, r, claims.Subject, start,
+				http.StatusForbidden, "Access denied")
+			http.Error(w, "Access denied", http.StatusForbidden)
+			return
+		}
+		if r.Method != "GET" {
+			logger.Infoln("Access granted")
+		}
+		next(w, r.WithContext(context.WithValue(r.Context(), claimsKey, claims)))
+	}
+}
+
+// requestClaims returns the claims of the caller of an authenticated
+// request.
+func requestClaims(r *http.Request) (*auth.Claims, bool) {
+	claims, ok := r.Context().Value(claimsKey).(*auth.Claims)
+	return claims, ok
+}
+
+// authorized returns true if one of the roles of claims may make a verb
+// request to path.
+func authorized(claims *auth.Claims, verb string, path string) bool {
+	for _, role := range claims.Roles {
+		if allowed, ok := roles[role]; ok && allowed(verb, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestToken returns the bearer token or access token of a request.
+func requestToken(r *http.Request) string {
+	if h := r.Header.Get("Authorization"); strings.HasPrefix(h, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(h, "Bearer "))
+	}
+	return r.Header.Get("Access-Token")
+}
+
+// isAdminPath returns true if only admins may change the resources of path,
+// those of the cluster and the quotas limiting the volumes of the others.
+func isAdminPath(path string) bool {
+	path += "/"
+	return strings.Contains(path, "/cluster/") || strings.Contains(path, "/"+api.OsdQuotaPath+"/")
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/cluster"
+	"github.com/libopenstorage/openstorage/pkg/auth"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+func TestAuthenticate(t *testing.T) {
+	key := []byte("auth test secret")
+	a, err := auth.NewJwtAuthenticator("openstorage", key)
+	require.NoError(t, err)
+	EnableAuth(a, false)
+	defer EnableAuth(nil, false)
+
+	var caller string
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		claims, _ := requestClaims(r)
+		caller = claims.Subject
+	}
+	routes := []*Route{
+		{verb: "GET", path: volPath("", volume.APIVersion), fn: ok},
+		{verb: "DELETE", path: volPath("/{id}", volume.APIVersion), fn: ok},
+		{verb: "PUT", path: clusterPath("/shutdown", cluster.APIVersion), fn: ok},
+		{verb: "GET", path: quotaPath("", volume.APIVersion), fn: ok},
+		{verb: "POST", path: quotaPath("", volume.APIVersion), fn: ok},
+		{verb: "DELETE", path: quotaPath("/{id}", volume.APIVersion), fn: ok},
+	}
+	ts := httptest.NewServer(newRouter("auth_test", routes, true))
+	defer ts.Close()
+
+	token := func(roles ...string) string {
+		tok, err := auth.Token(&auth.Claims{
+			Issuer:    "openstorage",
+			Subject:   "user-" + roles[0],
+			Roles:     roles,
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		}, key)
+		require.NoError(t, err)
+		return tok
+	}
+	do := func(verb string, path string, tok string) int {
+		req, err := http.NewRequest(verb, ts.URL+path, nil)
+		require.NoError(t, err)
+		if tok != "" {
+			req.Header.Set("Authorization", "Bearer "+tok)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	volumes := volPath("", volume.APIVersion)
+	vol := volPath("/vol1", volume.APIVersion)
+	shutdown := clusterPath("/shutdown", cluster.APIVersion)
+	quotas := quotaPath("", volume.APIVersion)
+	quota := quotaPath("/quota1", volume.APIVersion)
+
+	assert.Equal(t, http.StatusUnauthorized, do("GET", volumes, ""))
+	assert.Equal(t, http.StatusUnauthorized, do("GET", volumes, "garbage"))
+
+	assert.Equal(t, http.StatusOK, do("GET", volumes, token(RoleReadOnly)))
+	assert.Equal(t, "user-"+RoleReadOnly, caller)
+	assert.Equal(t, http.StatusForbidden, do("DELETE", vol, token(RoleReadOnly)))
+
+	assert.Equal(t, http.StatusOK, do("DELETE", vol, token(RoleVolumeOperator)))
+	assert.Equal(t, http.StatusForbidden, do("PUT", shutdown, token(RoleVolumeOperator)))
+
+	// Volume operators may read the quotas but not change them.
+	assert.Equal(t, http.StatusOK, do("GET", quotas, token(RoleVolumeOperator)))
+	assert.Equal(t, http.StatusForbidden, do("POST", quotas, token(RoleVolumeOperator)))
+	assert.Equal(t, http.StatusForbidden, do("DELETE", quota, token(RoleVolumeOperator)))
+	assert.Equal(t, http.StatusOK, do("POST", quotas, token(RoleAdmin)))
+	assert.Equal(t, http.StatusOK, do("DELETE", quota, token(RoleAdmin)))
+
+	assert.Equal(t, http.StatusOK, do("PUT", shutdown, token(RoleAdmin)))
+	assert.Equal(t, http.StatusForbidden, do("PUT", shutdown, token("unknown")))
+	assert.Equal(t, http.StatusOK, do("PUT", shutdown, token("unknown", RoleAdmin)))
+}
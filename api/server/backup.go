@@ -21,6 +21,7 @@ func (vd *volAPI) backup(w http.ResponseWriter, r *http.Request) {
 		notFound(w, r)
 		return
 	}
+	auditResource(w, backupReq.VolumeID)
 	volumeResponse := &api.VolumeResponse{}
 	err = d.Backup(backupReq)
 	if err != nil {
@@ -44,6 +45,9 @@ func (vd *volAPI) backuprestore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	restoreResp = d.BackupRestore(restoreReq)
+	if restoreResp != nil {
+		auditResource(w, restoreResp.RestoreVolumeID)
+	}
 	json.NewEncoder(w).Encode(restoreResp)
 }
 
@@ -187,6 +191,9 @@ func (vd *volAPI) backupschedcreate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	backupSchedResp = d.BackupSchedCreate(backupSchedReq)
+	if backupSchedResp != nil {
+		auditResource(w, backupSchedResp.SchedUUID)
+	}
 	json.NewEncoder(w).Encode(backupSchedResp)
 }
 
@@ -205,6 +212,7 @@ func (vd *volAPI) backupscheddelete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	auditResource(w, deleteReq.SchedUUID)
 	err = d.BackupSchedDelete(deleteReq)
 	if err != nil {
 		volumeResponse.Error = err.Error()
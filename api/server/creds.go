@@ -48,6 +48,7 @@ func (vd *volAPI) credsCreate(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		response.CredErr = err.Error()
 	}
+	auditResource(w, response.UUID)
 	json.NewEncoder(w).Encode(response)
 }
 
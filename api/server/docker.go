@@ -7,6 +7,9 @@ import (
 	"net/http"
 	"os"
 	"path"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/api/spec"
@@ -20,6 +23,9 @@ import (
 const (
 	// VolumeDriver is the string returned in the handshake protocol.
 	VolumeDriver = "VolumeDriver"
+	// ManagedPluginSocket is the socket a Docker managed plugin serves the
+	// volume plugin API on, as set in the interface of its config.json.
+	ManagedPluginSocket = "osd.sock"
 )
 
 // Implementation of the Docker volumes plugin specification.
@@ -186,6 +192,10 @@ func (d *driver) create(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if !specParsed {
+			if err := d.validateOpts(request.Opts); err != nil {
+				d.errorResponse(method, w, err)
+				return
+			}
 			spec, locator, source, err = d.SpecFromOpts(request.Opts)
 			if err != nil {
 				d.errorResponse(method, w, err)
@@ -221,6 +231,99 @@ func (d *driver) create(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(&volumeResponse{})
 }
 
+// dockerOpts are the --opt options of a volume. Their values are checked
+// by SpecFromOpts, and by their validator for those SpecFromOpts ignores
+// invalid values of.
+var dockerOpts = map[string]func(v string) error{
+	api.SpecNodes:            nil,
+	api.SpecParent:           nil,
+	api.SpecEphemeral:        validateBool,
+	api.SpecSize:             nil,
+	api.SpecScale:            validateUint32,
+	api.SpecFilesystem:       nil,
+	api.SpecBlockSize:        nil,
+	api.SpecHaLevel:          validateInt,
+	api.SpecPriority:         nil,
+	api.SpecPriorityAlias:    nil,
+	api.SpecDedupe:           validateBool,
+	api.SpecSnapshotInterval: validateUint32,
+	api.SpecSnapshotSchedule: nil,
+	api.SpecAggregationLevel: validateAggregationLevel,
+	api.SpecShared:           nil,
+	api.SpecJournal:          nil,
+	api.SpecNfs:              nil,
+	api.SpecCascaded:         nil,
+	api.SpecSticky:           nil,
+	api.SpecSecure:           nil,
+	api.SpecPassphrase:       nil,
+	api.SpecSecretName:       nil,
+	api.SpecGroup:            nil,
+	api.SpecGroupEnforce:     nil,
+	api.SpecZones:            nil,
+	api.SpecRacks:            nil,
+	api.SpecRack:             nil,
+	api.SpecCompressed:       nil,
+	api.SpecLabels:           nil,
+	api.SpecIoProfile:        nil,
+}
+
+// validateOpts checks each of the --opt options of a volume is known and
+// valid, so that all the invalid ones are reported at once. Unknown
+// options are rejected rather than set as labels, as they are most likely
+// typos.
+func (d *driver) validateOpts(opts map[string]string) error {
+	keys := make([]string, 0, len(opts))
+	for k := range opts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var invalid []string
+	for _, k := range keys {
+		validate, ok := dockerOpts[k]
+		if !ok {
+			invalid = append(invalid, fmt.Sprintf("option %s: unknown option, "+
+				"set labels with %s=<key>=<value>", k, api.SpecLabels))
+			continue
+		}
+		if validate != nil {
+			if err := validate(opts[k]); err != nil {
+				invalid = append(invalid, fmt.Sprintf("option %s=%q: %v", k, opts[k], err))
+				continue
+			}
+		}
+		if _, _, _, err := d.SpecFromOpts(map[string]string{k: opts[k]}); err != nil {
+			invalid = append(invalid, fmt.Sprintf("option %s=%q: %v", k, opts[k], err))
+		}
+	}
+	if len(invalid) != 0 {
+		return fmt.Errorf("Invalid volume options: %s", strings.Join(invalid, "; "))
+	}
+	return nil
+}
+
+func validateBool(v string) error {
+	_, err := strconv.ParseBool(v)
+	return err
+}
+
+func validateInt(v string) error {
+	_, err := strconv.ParseInt(v, 10, 64)
+	return err
+}
+
+func validateUint32(v string) error {
+	_, err := strconv.ParseUint(v, 10, 32)
+	return err
+}
+
+func validateAggregationLevel(v string) error {
+	if v == api.SpecAutoAggregationValue {
+		return nil
+	}
+	return validateUint32(v)
+}
+
 func (d *driver) remove(w http.ResponseWriter, r *http.Request) {
 	method := "remove"
 	request, err := d.decode(method, w, r)
@@ -625,7 +728,7 @@ func (d *driver) capabilities(w http.ResponseWriter, r *http.Request) {
 	method := "capabilities"
 	var response capabilitiesResponse
 
-	response.Capabilities.Scope = "global"
+	response.Capabilities.Scope = volumedrivers.Scope(d.name)
 	d.logRequest(method, "").Infof("response %v", response.Capabilities.Scope)
 	json.NewEncoder(w).Encode(&response)
 }
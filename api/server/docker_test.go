@@ -0,0 +1,124 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+	volumedrivers "github.com/libopenstorage/openstorage/volume/drivers"
+)
+
+func testDockerServer(t *testing.T, name string) (*httptest.Server, *testServer) {
+	router := mux.NewRouter()
+	for _, route := range newVolumePlugin(name).Routes() {
+		router.Methods(route.verb).
+			Path(route.path).
+			Handler(http.HandlerFunc(route.fn))
+	}
+
+	ts := httptest.NewServer(router)
+	testVolDriver := newTestServer(t)
+	return ts, testVolDriver
+}
+
+func dockerRequest(t *testing.T, ts *httptest.Server, method string, req interface{}, resp interface{}) {
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+	res, err := http.Post(ts.URL+volDriverPath(method), "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.NoError(t, json.NewDecoder(res.Body).Decode(resp))
+}
+
+func TestDockerCapabilities(t *testing.T) {
+	for name, scope := range map[string]string{
+		"vfs":          volumedrivers.ScopeLocal,
+		"btrfs":        volumedrivers.ScopeLocal,
+		"buse":         volumedrivers.ScopeLocal,
+		"nfs":          volumedrivers.ScopeGlobal,
+		mockDriverName: volumedrivers.ScopeGlobal,
+	} {
+		ts, testVolDriver := testDockerServer(t, name)
+
+		var resp capabilitiesResponse
+		dockerRequest(t, ts, "Capabilities", &volumeRequest{}, &resp)
+		assert.Equal(t, scope, resp.Capabilities.Scope, "Unexpected scope of %s", name)
+
+		ts.Close()
+		testVolDriver.Stop()
+	}
+}
+
+func TestDockerCreateInvalidOpts(t *testing.T) {
+	ts, testVolDriver := testDockerServer(t, mockDriverName)
+	defer ts.Close()
+	defer testVolDriver.Stop()
+
+	name := "myvol"
+	testVolDriver.MockDriver().
+		EXPECT().
+		Inspect([]string{name}).
+		Return(nil, nil)
+	testVolDriver.MockDriver().
+		EXPECT().
+		Enumerate(&api.VolumeLocator{Name: name}, nil).
+		Return(nil, nil)
+
+	var resp volumeResponse
+	dockerRequest(t, ts, "Create", &volumeRequest{
+		Name: name,
+		Opts: map[string]string{
+			api.SpecSize:    "1G",
+			api.SpecHaLevel: "two",
+			api.SpecShared:  "maybe",
+			api.SpecScale:   "4294967296",
+			"replicas":      "2",
+		},
+	}, &resp)
+	assert.Contains(t, resp.Err, "option "+api.SpecHaLevel+`="two"`)
+	assert.Contains(t, resp.Err, "option "+api.SpecShared+`="maybe"`)
+	assert.Contains(t, resp.Err, "option "+api.SpecScale+`="4294967296"`)
+	assert.Contains(t, resp.Err, "option replicas: unknown option")
+	assert.NotContains(t, resp.Err, api.SpecSize)
+}
+
+func TestDockerCreate(t *testing.T) {
+	ts, testVolDriver := testDockerServer(t, mockDriverName)
+	defer ts.Close()
+	defer testVolDriver.Stop()
+
+	name := "myvol"
+	gomock.InOrder(
+		testVolDriver.MockDriver().
+			EXPECT().
+			Inspect([]string{name}).
+			Return(nil, nil),
+		testVolDriver.MockDriver().
+			EXPECT().
+			Enumerate(&api.VolumeLocator{Name: name}, nil).
+			Return(nil, nil),
+		testVolDriver.MockDriver().
+			EXPECT().
+			Create(gomock.Any(), nil, gomock.Any()).
+			Do(func(locator *api.VolumeLocator, source *api.Source, spec *api.VolumeSpec) {
+				assert.Equal(t, name, locator.GetName())
+				assert.Equal(t, int64(2), spec.GetHaLevel())
+			}).
+			Return("myid", nil),
+	)
+
+	var resp volumeResponse
+	dockerRequest(t, ts, "Create", &volumeRequest{
+		Name: name,
+		Opts: map[string]string{api.SpecHaLevel: "2"},
+	}, &resp)
+	assert.Empty(t, resp.Err)
+}
@@ -0,0 +1,292 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/jobs"
+	"github.com/libopenstorage/openstorage/volume"
+	"github.com/libopenstorage/openstorage/volume/drivers"
+)
+
+// isAsync returns true if r asks for its operation to run as a job.
+func isAsync(r *http.Request) bool {
+	async, _ := strconv.ParseBool(r.URL.Query().Get(api.OptAsync))
+	return async
+}
+
+// submitJob runs fn as a job made by request and responds with the pending
+// job. Jobs of the methods of resumers are resumed from request if they are
+// interrupted.
+func (vd *volAPI) submitJob(
+	w http.ResponseWriter,
+	method string,
+	resource string,
+	request interface{},
+	fn jobs.Func,
+) {
+	m := jobs.Instance()
+	if m == nil {
+		vd.sendError(vd.name, method, w, "Jobs are not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	j, err := m.SubmitRequest(method, vd.name, resource, request, fn)
+	if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	vd.logRequest(method, resource).Infof("submitted job %v", j.Id)
+	if resource == "" {
+		auditResource(w, j.Id)
+	}
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(j)
+}
+
+// resumers resume the jobs which can be run again after a restart: those
+// restoring or deleting a volume, or updating its spec. Jobs creating a
+// volume or a snapshot are not resumed as it may already exist, nor are
+// those attaching or mounting a volume.
+var resumers = map[string]jobs.ResumeFunc{
+	"restore": func(j *jobs.Job) (jobs.Func, error) {
+		d, err := jobDriver(j)
+		if err != nil {
+			return nil, err
+		}
+		var snapID string
+		if err := json.Unmarshal(j.Request, &snapID); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, progress func(int)) (string, error) {
+			return snapID, restoreContext(ctx, progress, d, j.Resource, snapID)
+		}, nil
+	},
+	"delete": func(j *jobs.Job) (jobs.Func, error) {
+		d, err := jobDriver(j)
+		if err != nil {
+			return nil, err
+		}
+		return func(context.Context, func(int)) (string, error) {
+			if vols, err := d.Inspect([]string{j.Resource}); err == nil && len(vols) == 0 {
+				// Deleted before the restart.
+				return "", nil
+			}
+			return "", d.Delete(j.Resource)
+		}, nil
+	},
+	"volumeSet": func(j *jobs.Job) (jobs.Func, error) {
+		d, err := jobDriver(j)
+		if err != nil {
+			return nil, err
+		}
+		var req api.VolumeSetRequest
+		if err := json.Unmarshal(j.Request, &req); err != nil {
+			return nil, err
+		}
+		if req.Action != nil {
+			return nil, fmt.Errorf("Volume actions are not resumed")
+		}
+		return func(context.Context, func(int)) (string, error) {
+			return "", volumeSet(j.Driver, d, j.Resource, &req)
+		}, nil
+	},
+}
+
+// ResumeJobs resumes with m the jobs of the REST API interrupted by a
+// restart of the node, once the volume drivers are started.
+func ResumeJobs(m *jobs.Manager) error {
+	return m.Resume(resumers)
+}
+
+// jobDriver returns the volume driver j was submitted to, preferring its
+// scheduler-based driver as getVolDriver does.
+func jobDriver(j *jobs.Job) (volume.VolumeDriver, error) {
+	if d, err := volumedrivers.Get(j.Driver + schedDriverPostFix); err == nil {
+		return d, nil
+	}
+	return volumedrivers.Get(j.Driver)
+}
+
+// createContext creates a volume with d, with the copy of the data of its
+// parent stopped by ctx and reporting its progress if d supports it.
+func createContext(
+	ctx context.Context,
+	progress func(int),
+	d volume.VolumeDriver,
+	locator *api.VolumeLocator,
+	source *api.Source,
+	spec *api.VolumeSpec,
+) (string, error) {
+	if cd, ok := d.(volume.ContextDriver); ok {
+		return cd.CreateContext(ctx, progress, locator, source, spec)
+	}
+	return d.Create(locator, source, spec)
+}
+
+// snapshotContext snapshots a volume with d, with the copy of its data
+// stopped by ctx and reporting its progress if d supports it.
+func snapshotContext(
+	ctx context.Context,
+	progress func(int),
+	d volume.VolumeDriver,
+	volumeID string,
+	readonly bool,
+	locator *api.VolumeLocator,
+) (string, error) {
+	if cd, ok := d.(volume.ContextDriver); ok {
+		return cd.SnapshotContext(ctx, progress, volumeID, readonly, locator)
+	}
+	return d.Snapshot(volumeID, readonly, locator)
+}
+
+// restoreContext restores a volume with d, with the copy of the data of the
+// snapshot stopped by ctx and reporting its progress if d supports it.
+func restoreContext(
+	ctx context.Context,
+	progress func(int),
+	d volume.VolumeDriver,
+	volumeID string,
+	snapshotID string,
+) error {
+	if cd, ok := d.(volume.ContextDriver); ok {
+		return cd.RestoreContext(ctx, progress, volumeID, snapshotID)
+	}
+	return d.Restore(volumeID, snapshotID)
+}
+
+// swagger:operation GET /osd-jobs jobs enumerate enumerateJobs
+//
+// Enumerate the jobs of all nodes.
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - name: state
+//   in: query
+//   description: state of the jobs to return
+//   required: false
+//   type: string
+// responses:
+//   '200':
+//     description: jobs, oldest first
+func (vd *volAPI) jobEnumerate(w http.ResponseWriter, r *http.Request) {
+	method := "jobEnumerate"
+	m := jobs.Instance()
+	if m == nil {
+		vd.sendError(vd.name, method, w, "Jobs are not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	all, err := m.Enumerate()
+	if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	state := r.URL.Query().Get("state")
+	selected := make([]*jobs.Job, 0, len(all))
+	for _, j := range all {
+		if state == "" || j.State == state {
+			selected = append(selected, j)
+		}
+	}
+	json.NewEncoder(w).Encode(selected)
+}
+
+// swagger:operation GET /osd-jobs/{id} jobs inspect inspectJob
+//
+// Inspect job with specified id.
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - name: id
+//   in: path
+//   description: id of the job
+//   required: true
+//   type: string
+// responses:
+//   '200':
+//     description: the job
+func (vd *volAPI) jobInspect(w http.ResponseWriter, r *http.Request) {
+	method := "jobInspect"
+	id, err := vd.parseID(r)
+	if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	m := jobs.Instance()
+	if m == nil {
+		vd.sendError(vd.name, method, w, "Jobs are not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	j, err := m.Get(id)
+	if err == jobs.ErrNotFound {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(j)
+}
+
+// swagger:operation POST /osd-jobs/cancel/{id} jobs cancel cancelJob
+//
+// Cancel job with specified id. Pending jobs never run. Running jobs copying
+// the data of a volume stop early on drivers supporting it, others are
+// cancelled once their operation returns.
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - name: id
+//   in: path
+//   description: id of the job
+//   required: true
+//   type: string
+// responses:
+//   '200':
+//     description: job cancelled
+//     schema:
+//         "$ref": "#/definitions/VolumeResponse"
+func (vd *volAPI) jobCancel(w http.ResponseWriter, r *http.Request) {
+	method := "jobCancel"
+	id, err := vd.parseID(r)
+	if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	vd.logRequest(method, id).Infoln("")
+
+	m := jobs.Instance()
+	if m == nil {
+		vd.sendError(vd.name, method, w, "Jobs are not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	volumeResponse := &api.VolumeResponse{}
+	if err := m.Cancel(id); err == jobs.ErrNotFound {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		volumeResponse.Error = err.Error()
+	}
+	json.NewEncoder(w).Encode(volumeResponse)
+}
+
+func jobPath(route, version string) string {
+	return volVersion(api.OsdJobPath+route, version)
+}
+
+func (vd *volAPI) jobRoutes() []*Route {
+	return []*Route{
+		{verb: "GET", path: jobPath("", volume.APIVersion), fn: vd.jobEnumerate},
+		{verb: "GET", path: jobPath("/{id}", volume.APIVersion), fn: vd.jobInspect},
+		{verb: "POST", path: jobPath("/cancel/{id}", volume.APIVersion), fn: vd.jobCancel},
+	}
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/portworx/kvdb"
+	"github.com/portworx/kvdb/mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+	volumeclient "github.com/libopenstorage/openstorage/api/client/volume"
+	"github.com/libopenstorage/openstorage/pkg/jobs"
+)
+
+func TestVolumeJobs(t *testing.T) {
+	ts, testVolDriver := testRestServer(t)
+	defer ts.Close()
+	defer testVolDriver.Stop()
+
+	cl, err := volumeclient.NewDriverClient(ts.URL, mockDriverName, version, mockDriverName)
+	require.NoError(t, err)
+
+	// Jobs are refused until enabled.
+	_, err = volumeclient.DeleteAsync(cl, "vol1")
+	assert.Error(t, err)
+
+	kv, err := kvdb.New(mem.Name, "jobs_test", []string{}, nil, nil)
+	require.NoError(t, err)
+	m, err := jobs.NewManager(kv, "node1", 1, 0)
+	require.NoError(t, err)
+	jobs.SetInstance(m)
+	defer jobs.SetInstance(nil)
+
+	wait := func(id string) *jobs.Job {
+		for i := 0; i < 500; i++ {
+			j, err := volumeclient.JobInspect(cl, id)
+			require.NoError(t, err)
+			if j.Completed() {
+				return j
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("Job %v did not complete", id)
+		return nil
+	}
+
+	testVolDriver.MockDriver().
+		EXPECT().
+		Snapshot("vol1", true, &api.VolumeLocator{Name: "snap"}).
+		Return("snap1", nil)
+	j, err := volumeclient.SnapshotAsync(cl, "vol1", true, &api.VolumeLocator{Name: "snap"})
+	require.NoError(t, err)
+	assert.Equal(t, "snap", j.Type)
+	assert.Equal(t, "vol1", j.Resource)
+	j = wait(j.Id)
+	assert.Equal(t, jobs.StateDone, j.State)
+	assert.Equal(t, "snap1", j.Result)
+
+	testVolDriver.MockDriver().
+		EXPECT().
+		Delete("vol1").
+		Return(fmt.Errorf("volume is mounted"))
+	j, err = volumeclient.DeleteAsync(cl, "vol1")
+	require.NoError(t, err)
+	j = wait(j.Id)
+	assert.Equal(t, jobs.StateFailed, j.State)
+	assert.Equal(t, "volume is mounted", j.Error)
+
+	all, err := volumeclient.JobEnumerate(cl, jobs.StateFailed)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, j.Id, all[0].Id)
+	assert.Error(t, volumeclient.JobCancel(cl, j.Id))
+
+	_, err = volumeclient.JobInspect(cl, "missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,288 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/cluster"
+	volumedrivers "github.com/libopenstorage/openstorage/volume/drivers"
+	"github.com/libopenstorage/openstorage/volume/drivers/common"
+)
+
+const (
+	metricsNamespace = "openstorage"
+	// metricsPath is served by every REST server.
+	metricsPath = "/metrics"
+)
+
+var (
+	restRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "rest",
+			Name:      "requests_total",
+			Help:      "REST requests by server, method, route and status code.",
+		},
+		[]string{"server", "method", "route", "code"},
+	)
+	restLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "rest",
+			Name:      "request_duration_seconds",
+			Help:      "REST request latencies by server, method and route.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"server", "method", "route"},
+	)
+	volumeMetrics  = newVolumeCollector()
+	clusterMetrics = &clusterCollector{}
+)
+
+func init() {
+	prometheus.MustRegister(restRequests, restLatency, volumeMetrics, clusterMetrics)
+}
+
+// statusRecorder records the status code written to a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	s.code = code
+	s.ResponseWriter.WriteHeader(code)
+}
+
+// Flush lets streaming handlers flush their response through the recorder.
+func (s *statusRecorder) Flush() {
+	if f, ok := s.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// instrument counts the requests to a route and records their latency.
+func instrument(
+	server string,
+	verb string,
+	path string,
+	fn func(http.ResponseWriter, *http.Request),
+) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		fn(rec, r)
+		restLatency.WithLabelValues(server, verb, path).
+			Observe(time.Since(start).Seconds())
+		restRequests.WithLabelValues(server, verb, path, strconv.Itoa(rec.code)).Inc()
+	}
+}
+
+func volumeDesc(name string, help string, labels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "volume", name),
+		help,
+		append([]string{"driver", "volume", "name"}, labels...),
+		nil,
+	)
+}
+
+var (
+	volumeCapacityDesc = volumeDesc("capacity_bytes", "Provisioned size of the volume.")
+	volumeUsageDesc    = volumeDesc("usage_bytes", "Bytes allocated to the volume.")
+	volumeIODesc       = volumeDesc("ios_total", "Completed IOs by operation.", "op")
+	volumeIOBytesDesc  = volumeDesc("io_bytes_total", "Bytes transferred by operation.", "op")
+	volumeIOTimeDesc   = volumeDesc("io_time_seconds_total",
+		"Time spent doing IOs by operation.", "op")
+	volumeIOProgressDesc = volumeDesc("ios_in_progress", "IOs currently in progress.")
+)
+
+// diskStats returns the IO counters of a block device.
+var diskStats = common.DiskStats
+
+// volumeCollector reports the volumes of every driver a volume
+// management API is started for. The usage reported is the one last saved
+// by the driver, and the IO counters are those of the block device of
+// volumes attached to this node, so scrapes do not scan volumes.
+type volumeCollector struct {
+	drivers map[string]bool
+	lock    sync.Mutex
+}
+
+func newVolumeCollector() *volumeCollector {
+	return &volumeCollector{drivers: make(map[string]bool)}
+}
+
+// add reports the volumes of driver.
+func (c *volumeCollector) add(driver string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.drivers[driver] = true
+}
+
+func (c *volumeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- volumeCapacityDesc
+	ch <- volumeUsageDesc
+	ch <- volumeIODesc
+	ch <- volumeIOBytesDesc
+	ch <- volumeIOTimeDesc
+	ch <- volumeIOProgressDesc
+}
+
+func (c *volumeCollector) Collect(ch chan<- prometheus.Metric) {
+	c.lock.Lock()
+	drivers := make([]string, 0, len(c.drivers))
+	for name := range c.drivers {
+		drivers = append(drivers, name)
+	}
+	c.lock.Unlock()
+	sort.Strings(drivers)
+
+	for _, name := range drivers {
+		d, err := volumedrivers.Get(name)
+		if err != nil {
+			continue
+		}
+		vols, err := d.Enumerate(&api.VolumeLocator{}, nil)
+		if err != nil {
+			dlog.Warnf("Failed to enumerate %v volumes for metrics: %v", name, err)
+			continue
+		}
+		for _, v := range vols {
+			labels := []string{name, v.Id, v.GetLocator().GetName()}
+			gauge := func(desc *prometheus.Desc, value uint64, extra ...string) {
+				ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue,
+					float64(value), append(labels, extra...)...)
+			}
+			counter := func(desc *prometheus.Desc, value float64, extra ...string) {
+				ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue,
+					value, append(labels, extra...)...)
+			}
+
+			gauge(volumeCapacityDesc, v.GetSpec().GetSize())
+			gauge(volumeUsageDesc, v.Usage)
+			if len(v.DevicePath) == 0 {
+				continue
+			}
+			stats, err := diskStats(v.DevicePath)
+			if err != nil {
+				// The device is not attached to this node.
+				continue
+			}
+			counter(volumeIODesc, float64(stats.Reads), "read")
+			counter(volumeIODesc, float64(stats.Writes), "write")
+			counter(volumeIOBytesDesc, float64(stats.ReadBytes), "read")
+			counter(volumeIOBytesDesc, float64(stats.WriteBytes), "write")
+			counter(volumeIOTimeDesc, float64(stats.ReadMs)/1000, "read")
+			counter(volumeIOTimeDesc, float64(stats.WriteMs)/1000, "write")
+			gauge(volumeIOProgressDesc, stats.IoProgress)
+		}
+	}
+}
+
+var (
+	clusterStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "cluster", "status"),
+		"Status of the cluster, see api.Status.",
+		[]string{"cluster"}, nil,
+	)
+	clusterNodesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "cluster", "nodes"),
+		"Number of nodes in the cluster.",
+		[]string{"cluster"}, nil,
+	)
+	nodeStatusDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "node", "status"),
+		"Status of the node, see api.Status.",
+		[]string{"cluster", "node", "hostname"}, nil,
+	)
+	nodeCPUDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "node", "cpu_percent"),
+		"CPU usage of the node.",
+		[]string{"cluster", "node", "hostname"}, nil,
+	)
+	nodeMemTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "node", "memory_total_bytes"),
+		"Total memory of the node.",
+		[]string{"cluster", "node", "hostname"}, nil,
+	)
+	nodeMemUsedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "node", "memory_used_bytes"),
+		"Used memory of the node.",
+		[]string{"cluster", "node", "hostname"}, nil,
+	)
+	alertsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "alerts"),
+		"Uncleared alerts by severity.",
+		[]string{"severity"}, nil,
+	)
+)
+
+// clusterCollector reports the nodes and alerts of the cluster, if the
+// cluster is initialized.
+type clusterCollector struct{}
+
+func (c *clusterCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- clusterStatusDesc
+	ch <- clusterNodesDesc
+	ch <- nodeStatusDesc
+	ch <- nodeCPUDesc
+	ch <- nodeMemTotalDesc
+	ch <- nodeMemUsedDesc
+	ch <- alertsDesc
+}
+
+func (c *clusterCollector) Collect(ch chan<- prometheus.Metric) {
+	inst, err := cluster.Inst()
+	if err != nil {
+		return
+	}
+	cl, err := inst.Enumerate()
+	if err != nil {
+		dlog.Warnf("Failed to enumerate cluster for metrics: %v", err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(clusterStatusDesc, prometheus.GaugeValue,
+		float64(cl.Status), cl.Id)
+	ch <- prometheus.MustNewConstMetric(clusterNodesDesc, prometheus.GaugeValue,
+		float64(len(cl.Nodes)), cl.Id)
+	for _, n := range cl.Nodes {
+		labels := []string{cl.Id, n.Id, n.Hostname}
+		ch <- prometheus.MustNewConstMetric(nodeStatusDesc, prometheus.GaugeValue,
+			float64(n.Status), labels...)
+		ch <- prometheus.MustNewConstMetric(nodeCPUDesc, prometheus.GaugeValue,
+			n.Cpu, labels...)
+		ch <- prometheus.MustNewConstMetric(nodeMemTotalDesc, prometheus.GaugeValue,
+			float64(n.MemTotal), labels...)
+		ch <- prometheus.MustNewConstMetric(nodeMemUsedDesc, prometheus.GaugeValue,
+			float64(n.MemUsed), labels...)
+	}
+
+	alerts, err := inst.EnumerateAlerts(time.Time{}, time.Now(),
+		api.ResourceType_RESOURCE_TYPE_NONE)
+	if err != nil {
+		dlog.Warnf("Failed to enumerate alerts for metrics: %v", err)
+		return
+	}
+	counts := make(map[api.SeverityType]int)
+	for severity := range api.SeverityType_name {
+		counts[api.SeverityType(severity)] = 0
+	}
+	for _, a := range alerts.GetAlert() {
+		if !a.Cleared {
+			counts[a.Severity]++
+		}
+	}
+	for severity, count := range counts {
+		ch <- prometheus.MustNewConstMetric(alertsDesc, prometheus.GaugeValue,
+			float64(count), severity.String())
+	}
+}
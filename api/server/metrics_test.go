@@ -0,0 +1,157 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+)
+
+// gather returns the metrics of collector by name.
+func gather(t *testing.T, collector prometheus.Collector) map[string][]*dto.Metric {
+	reg := prometheus.NewRegistry()
+	require.NoError(t, reg.Register(collector))
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	metrics := make(map[string][]*dto.Metric)
+	for _, f := range families {
+		metrics[f.GetName()] = f.GetMetric()
+	}
+	return metrics
+}
+
+func labelValue(m *dto.Metric, name string) string {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == name {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
+
+func TestMetricsInstrument(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(instrument("metrics_test", "GET", "/test/{id}",
+		func(w http.ResponseWriter, r *http.Request) {
+			http.NotFound(w, r)
+		})))
+	defer ts.Close()
+
+	for i := 0; i < 2; i++ {
+		resp, err := http.Get(ts.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+
+	m := &dto.Metric{}
+	require.NoError(t, restRequests.
+		WithLabelValues("metrics_test", "GET", "/test/{id}", "404").(prometheus.Metric).
+		Write(m))
+	assert.Equal(t, float64(2), m.GetCounter().GetValue())
+}
+
+func TestMetricsVolumes(t *testing.T) {
+	ts := newTestServer(t)
+	defer ts.Stop()
+
+	ts.MockDriver().
+		EXPECT().
+		Enumerate(&api.VolumeLocator{}, nil).
+		Return([]*api.Volume{
+			{
+				Id:         "vol1",
+				Locator:    &api.VolumeLocator{Name: "one"},
+				Spec:       &api.VolumeSpec{Size: 1024},
+				Usage:      256,
+				DevicePath: "/dev/nbd0",
+			},
+			{
+				Id:      "vol2",
+				Locator: &api.VolumeLocator{Name: "two"},
+				Spec:    &api.VolumeSpec{Size: 2048},
+				Usage:   512,
+			},
+		}, nil)
+	defer func(f func(string) (*api.Stats, error)) { diskStats = f }(diskStats)
+	diskStats = func(devicePath string) (*api.Stats, error) {
+		require.Equal(t, "/dev/nbd0", devicePath)
+		return &api.Stats{Reads: 3, Writes: 4}, nil
+	}
+
+	c := newVolumeCollector()
+	c.add(mockDriverName)
+	metrics := gather(t, c)
+
+	capacity := metrics["openstorage_volume_capacity_bytes"]
+	require.Len(t, capacity, 2)
+	usage := make(map[string]float64)
+	for _, m := range metrics["openstorage_volume_usage_bytes"] {
+		usage[labelValue(m, "volume")] = m.GetGauge().GetValue()
+	}
+	assert.Equal(t, map[string]float64{"vol1": 256, "vol2": 512}, usage)
+
+	ios := metrics["openstorage_volume_ios_total"]
+	require.Len(t, ios, 2)
+	for _, m := range ios {
+		assert.Equal(t, "vol1", labelValue(m, "volume"))
+		if labelValue(m, "op") == "read" {
+			assert.Equal(t, float64(3), m.GetCounter().GetValue())
+		} else {
+			assert.Equal(t, float64(4), m.GetCounter().GetValue())
+		}
+	}
+}
+
+func TestMetricsCluster(t *testing.T) {
+	tc := newTestClutser(t)
+	defer tc.Finish()
+
+	tc.MockCluster().
+		EXPECT().
+		Enumerate().
+		Return(api.Cluster{
+			Id:     "cluster-id",
+			Status: api.Status_STATUS_OK,
+			Nodes: []api.Node{
+				{Id: "1", Hostname: "node1", Status: api.Status_STATUS_OK},
+				{Id: "2", Hostname: "node2", Status: api.Status_STATUS_OFFLINE},
+			},
+		}, nil)
+	tc.MockCluster().
+		EXPECT().
+		EnumerateAlerts(gomock.Any(), gomock.Any(), api.ResourceType_RESOURCE_TYPE_NONE).
+		Return(&api.Alerts{Alert: []*api.Alert{
+			{Severity: api.SeverityType_SEVERITY_TYPE_ALARM},
+			{Severity: api.SeverityType_SEVERITY_TYPE_ALARM},
+			{Severity: api.SeverityType_SEVERITY_TYPE_ALARM, Cleared: true},
+			{Severity: api.SeverityType_SEVERITY_TYPE_WARNING},
+		}}, nil)
+
+	metrics := gather(t, &clusterCollector{})
+	require.Len(t, metrics["openstorage_cluster_nodes"], 1)
+	assert.Equal(t, float64(2), metrics["openstorage_cluster_nodes"][0].GetGauge().GetValue())
+
+	status := make(map[string]float64)
+	for _, m := range metrics["openstorage_node_status"] {
+		status[labelValue(m, "hostname")] = m.GetGauge().GetValue()
+	}
+	assert.Equal(t, map[string]float64{
+		"node1": float64(api.Status_STATUS_OK),
+		"node2": float64(api.Status_STATUS_OFFLINE),
+	}, status)
+
+	alerts := make(map[string]float64)
+	for _, m := range metrics["openstorage_alerts"] {
+		alerts[labelValue(m, "severity")] = m.GetGauge().GetValue()
+	}
+	assert.Equal(t, float64(2), alerts[api.SeverityType_SEVERITY_TYPE_ALARM.String()])
+	assert.Equal(t, float64(1), alerts[api.SeverityType_SEVERITY_TYPE_WARNING.String()])
+	assert.Equal(t, float64(0), alerts[api.SeverityType_SEVERITY_TYPE_NOTIFY.String()])
+}
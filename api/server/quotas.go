@@ -0,0 +1,241 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/api/errors"
+	"github.com/libopenstorage/openstorage/pkg/quota"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+// enforceQuota runs fn, which changes a volume of driver d from prev to
+// next, unless the change would exceed a quota.
+func enforceQuota(
+	driver string,
+	d volume.VolumeDriver,
+	prev *api.Volume,
+	next *api.Volume,
+	fn func() error,
+) error {
+	m := quota.Instance()
+	if m == nil {
+		return fn()
+	}
+	return m.Enforce(driver, d, prev, next, fn)
+}
+
+// enforceQuotaCreate runs fn, which creates the volumes next of driver d
+// together, unless they would exceed a quota.
+func enforceQuotaCreate(
+	driver string,
+	d volume.VolumeDriver,
+	next []*api.Volume,
+	fn func() error,
+) error {
+	m := quota.Instance()
+	if m == nil {
+		return fn()
+	}
+	return m.EnforceCreate(driver, d, next, fn)
+}
+
+// setQuotaEnforced updates the locator and spec of volume volumeID of
+// driver d, unless resizing it or changing its labels would exceed a
+// quota.
+func setQuotaEnforced(
+	driver string,
+	d volume.VolumeDriver,
+	volumeID string,
+	locator *api.VolumeLocator,
+	spec *api.VolumeSpec,
+) error {
+	set := func() error {
+		return d.Set(volumeID, locator, spec)
+	}
+	if quota.Instance() == nil {
+		return set()
+	}
+	vols, err := d.Inspect([]string{volumeID})
+	if err != nil {
+		return err
+	} else if len(vols) != 1 {
+		return &errors.ErrNotFound{Type: "Volume", ID: volumeID}
+	}
+	return enforceQuota(driver, d, vols[0], quota.Updated(vols[0], locator, spec), set)
+}
+
+// swagger:operation GET /osd-quotas quotas enumerate enumerateQuotas
+//
+// Enumerate the quotas and the usage of the volumes of the driver they
+// select.
+//
+// ---
+// produces:
+// - application/json
+// responses:
+//   '200':
+//     description: quotas and their usage
+func (vd *volAPI) quotaEnumerate(w http.ResponseWriter, r *http.Request) {
+	method := "quotaEnumerate"
+	d, err := vd.getVolDriver(r)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+	m := quota.Instance()
+	if m == nil {
+		vd.sendError(vd.name, method, w, "Quotas are not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	status, err := m.Status(d)
+	if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(status)
+}
+
+// swagger:operation GET /osd-quotas/{id} quotas inspect inspectQuota
+//
+// Inspect quota with specified name and the usage of the volumes of the
+// driver it selects.
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - name: id
+//   in: path
+//   description: name of the quota
+//   required: true
+//   type: string
+// responses:
+//   '200':
+//     description: quota and its usage
+func (vd *volAPI) quotaInspect(w http.ResponseWriter, r *http.Request) {
+	method := "quotaInspect"
+	name, err := vd.parseID(r)
+	if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	d, err := vd.getVolDriver(r)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+	m := quota.Instance()
+	if m == nil {
+		vd.sendError(vd.name, method, w, "Quotas are not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	status, err := m.Inspect(name, d)
+	if err == quota.ErrNotFound {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(status)
+}
+
+// swagger:operation POST /osd-quotas quotas set setQuota
+//
+// Create or replace a quota.
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - name: quota
+//   in: body
+//   description: quota to set
+//   required: true
+// responses:
+//   '200':
+//     description: volume response
+//     schema:
+//       "$ref": "#/definitions/VolumeResponse"
+func (vd *volAPI) quotaSet(w http.ResponseWriter, r *http.Request) {
+	method := "quotaSet"
+	q := &quota.Quota{}
+	if err := json.NewDecoder(r.Body).Decode(q); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	vd.logRequest(method, q.Name).Infof("selector %v", q.Selector)
+	auditResource(w, q.Name)
+
+	m := quota.Instance()
+	if m == nil {
+		vd.sendError(vd.name, method, w, "Quotas are not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	if err := m.Set(q); err == quota.ErrInvalidName {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	} else if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(&api.VolumeResponse{})
+}
+
+// swagger:operation DELETE /osd-quotas/{id} quotas delete deleteQuota
+//
+// Delete quota with specified name.
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - name: id
+//   in: path
+//   description: name of the quota
+//   required: true
+//   type: string
+// responses:
+//   '200':
+//     description: volume response
+//     schema:
+//       "$ref": "#/definitions/VolumeResponse"
+func (vd *volAPI) quotaDelete(w http.ResponseWriter, r *http.Request) {
+	method := "quotaDelete"
+	name, err := vd.parseID(r)
+	if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	vd.logRequest(method, name).Infoln("")
+
+	m := quota.Instance()
+	if m == nil {
+		vd.sendError(vd.name, method, w, "Quotas are not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	if err := m.Delete(name); err == quota.ErrNotFound {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusNotFound)
+		return
+	} else if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(&api.VolumeResponse{})
+}
+
+func quotaPath(route, version string) string {
+	return volVersion(api.OsdQuotaPath+route, version)
+}
+
+func (vd *volAPI) quotaRoutes() []*Route {
+	return []*Route{
+		{verb: "GET", path: quotaPath("", volume.APIVersion), fn: vd.quotaEnumerate},
+		{verb: "GET", path: quotaPath("/{id}", volume.APIVersion), fn: vd.quotaInspect},
+		{verb: "POST", path: quotaPath("", volume.APIVersion), fn: vd.quotaSet},
+		{verb: "DELETE", path: quotaPath("/{id}", volume.APIVersion), fn: vd.quotaDelete},
+	}
+}
+
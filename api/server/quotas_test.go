@@ -0,0 +1,98 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/portworx/kvdb"
+	"github.com/portworx/kvdb/mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+	volumeclient "github.com/libopenstorage/openstorage/api/client/volume"
+	"github.com/libopenstorage/openstorage/pkg/quota"
+)
+
+func TestVolumeQuotas(t *testing.T) {
+	ts, testVolDriver := testRestServer(t)
+	defer ts.Close()
+	defer testVolDriver.Stop()
+
+	cl, err := volumeclient.NewDriverClient(ts.URL, mockDriverName, version, mockDriverName)
+	require.NoError(t, err)
+	driver := volumeclient.VolumeDriver(cl)
+
+	// Quotas cannot be set until enabled.
+	assert.Error(t, volumeclient.QuotaSet(cl, &quota.Quota{Name: "foo"}))
+
+	kv, err := kvdb.New(mem.Name, "quotas_test", []string{}, nil, nil)
+	require.NoError(t, err)
+	quota.SetInstance(quota.NewManager(kv))
+	defer quota.SetInstance(nil)
+
+	foo := map[string]string{"namespace": "foo"}
+	require.NoError(t, volumeclient.QuotaSet(cl, &quota.Quota{
+		Name:         "foo",
+		Selector:     foo,
+		MaxSize:      100,
+		MaxSnapshots: 1,
+	}))
+	assert.Error(t, volumeclient.QuotaSet(cl, &quota.Quota{}))
+
+	vols := []*api.Volume{
+		{Id: "vol1", Locator: &api.VolumeLocator{VolumeLabels: foo}, Spec: &api.VolumeSpec{Size: 60}},
+	}
+	testVolDriver.MockDriver().
+		EXPECT().
+		Enumerate(&api.VolumeLocator{}, nil).
+		Return(vols, nil).
+		Times(6)
+
+	status, err := volumeclient.QuotaEnumerate(cl)
+	require.NoError(t, err)
+	require.Len(t, status, 1)
+	assert.Equal(t, &quota.Usage{Size: 60, Volumes: 1}, status[0].Usage)
+
+	// Creating and growing volumes beyond the quota fails.
+	locator := &api.VolumeLocator{Name: "vol2", VolumeLabels: foo}
+	_, err = driver.Create(locator, nil, &api.VolumeSpec{Size: 50})
+	assert.Error(t, err)
+
+	testVolDriver.MockDriver().
+		EXPECT().
+		Inspect([]string{"vol1"}).
+		Return(vols, nil)
+	assert.Error(t, driver.Set("vol1", nil, &api.VolumeSpec{Size: 120}))
+
+	testVolDriver.MockDriver().
+		EXPECT().
+		Create(locator, nil, &api.VolumeSpec{Size: 40}).
+		Return("vol2", nil)
+	id, err := driver.Create(locator, nil, &api.VolumeSpec{Size: 40})
+	require.NoError(t, err)
+	assert.Equal(t, "vol2", id)
+
+	testVolDriver.MockDriver().
+		EXPECT().
+		Snapshot("vol1", true, &api.VolumeLocator{Name: "snap1"}).
+		Return("snap1", nil)
+	_, err = driver.Snapshot("vol1", true, &api.VolumeLocator{Name: "snap1"})
+	require.NoError(t, err)
+
+	// Volumes without a spec can be resized.
+	testVolDriver.MockDriver().
+		EXPECT().
+		Inspect([]string{"vol3"}).
+		Return([]*api.Volume{{Id: "vol3"}}, nil).
+		Times(2)
+	testVolDriver.MockDriver().
+		EXPECT().
+		Set("vol3", nil, &api.VolumeSpec{Size: 10}).
+		Return(nil)
+	require.NoError(t, driver.Set("vol3", nil, &api.VolumeSpec{Size: 10}))
+
+	require.NoError(t, volumeclient.QuotaDelete(cl, "foo"))
+	assert.Error(t, volumeclient.QuotaDelete(cl, "foo"))
+	_, err = volumeclient.QuotaInspect(cl, "foo")
+	assert.Error(t, err)
+}
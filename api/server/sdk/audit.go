@@ -0,0 +1,104 @@
+package sdk
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/audit"
+)
+
+// auditInterceptor logs an audit record of every RPC changing state.
+func (s *Server) auditInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	method := path.Base(info.FullMethod)
+	if !audit.Enabled() || readOnlyRPC(method) {
+		return handler(ctx, req)
+	}
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	r := &audit.Record{
+		Time:     start,
+		Caller:   rpcCaller(ctx),
+		Service:  "sdk/" + s.config.DriverName,
+		Verb:     method,
+		Resource: rpcResource(req),
+		Result:   audit.ResultOK,
+		Latency:  time.Since(start),
+	}
+	if msg, ok := req.(proto.Message); ok {
+		if data, merr := proto.Marshal(msg); merr == nil {
+			r.RequestHash = audit.Hash(data)
+		}
+	}
+	if err != nil {
+		r.Result = err.Error()
+	}
+	audit.Log(r)
+	return resp, err
+}
+
+// readOnlyRPC returns true if method does not change state.
+func readOnlyRPC(method string) bool {
+	return method == "Inspect" ||
+		method == "Stats" ||
+		method == "Watch" ||
+		strings.HasSuffix(method, "Enumerate") ||
+		strings.HasSuffix(method, "Validate") ||
+		strings.HasSuffix(method, "Status")
+}
+
+// rpcResource returns the ID of the volume, credential or backup a request
+// is for, or the name of the volume it creates.
+func rpcResource(req interface{}) string {
+	if r, ok := req.(interface {
+		GetVolumeId() string
+	}); ok {
+		return r.GetVolumeId()
+	}
+	if r, ok := req.(interface {
+		GetCredentialId() string
+	}); ok {
+		return r.GetCredentialId()
+	}
+	if r, ok := req.(interface {
+		GetBackupId() string
+	}); ok {
+		return r.GetBackupId()
+	}
+	if r, ok := req.(interface {
+		GetSrcVolumeId() string
+	}); ok {
+		return r.GetSrcVolumeId()
+	}
+	if r, ok := req.(interface {
+		GetLocator() *api.VolumeLocator
+	}); ok {
+		return r.GetLocator().GetName()
+	}
+	return ""
+}
+
+// rpcCaller returns the identity of the caller of a RPC.
+func rpcCaller(ctx context.Context) string {
+	if claims, ok := rpcClaims(ctx); ok {
+		return claims.Subject
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil || p.Addr.Network() == "unix" {
+		return audit.CallerLocal
+	}
+	return p.Addr.String()
+}
@@ -0,0 +1,154 @@
+package sdk
+
+import (
+	"path"
+	"strings"
+
+	"go.pedge.io/dlog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/libopenstorage/openstorage/pkg/auth"
+)
+
+type contextKey string
+
+// claimsKey is the RPC context key of the claims of the caller.
+const claimsKey = contextKey("claims")
+
+// gatewayKey is the metadata key the REST gateway marks the RPCs it
+// forwards with, so that they are never trusted as local.
+const gatewayKey = "osd-gateway"
+
+// roles maps each role to the RPCs it may make.
+var roles = map[string]func(method string, fullMethod string) bool{
+	auth.RoleAdmin: func(method string, fullMethod string) bool {
+		return true
+	},
+	auth.RoleVolumeOperator: func(method string, fullMethod string) bool {
+		return readOnlyRPC(method) || !isClusterRPC(fullMethod)
+	},
+	auth.RoleReadOnly: func(method string, fullMethod string) bool {
+		return readOnlyRPC(method)
+	},
+}
+
+// unaryInterceptor authenticates RPCs and logs an audit record of those
+// changing state.
+func (s *Server) unaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	ctx, err := s.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return s.auditInterceptor(ctx, req, info, handler)
+}
+
+// streamInterceptor authenticates streaming RPCs.
+func (s *Server) streamInterceptor(
+	srv interface{},
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, err := s.authenticate(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authStream{ServerStream: stream, ctx: ctx})
+}
+
+// authenticate rejects RPCs without a valid bearer token, or whose caller
+// has no role allowed to make them, if the server has an authenticator.
+// The returned context holds the claims of the caller.
+func (s *Server) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	if s.config.Authenticator == nil {
+		return ctx, nil
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if s.config.TrustLocal && isLocal(ctx) && len(md[gatewayKey]) == 0 {
+		return ctx, nil
+	}
+	token := rpcToken(md)
+	if token == "" {
+		return nil, status.Errorf(codes.Unauthenticated, "Missing access token")
+	}
+	claims, err := s.config.Authenticator.AuthenticateToken(token)
+	if err != nil {
+		dlog.Warnf("Rejected %v: %v", fullMethod, err)
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	method := path.Base(fullMethod)
+	logger := dlog.WithFields(map[string]interface{}{
+		"Server": "sdk/" + s.config.DriverName,
+		"User":   claims.Subject,
+		"Roles":  claims.Roles,
+		"Method": fullMethod,
+	})
+	if !authorized(claims, method, fullMethod) {
+		logger.Warnln("Access denied")
+		return nil, status.Errorf(codes.PermissionDenied, "Access denied")
+	}
+	if !readOnlyRPC(method) {
+		logger.Infoln("Access granted")
+	}
+	return context.WithValue(ctx, claimsKey, claims), nil
+}
+
+// authorized returns true if one of the roles of claims may make the RPC.
+func authorized(claims *auth.Claims, method string, fullMethod string) bool {
+	for _, role := range claims.Roles {
+		if allowed, ok := roles[role]; ok && allowed(method, fullMethod) {
+			return true
+		}
+	}
+	return false
+}
+
+// rpcClaims returns the claims of the caller of an authenticated RPC.
+func rpcClaims(ctx context.Context) (*auth.Claims, bool) {
+	claims, ok := ctx.Value(claimsKey).(*auth.Claims)
+	return claims, ok
+}
+
+// rpcToken returns the bearer token of the authorization metadata, which
+// the REST gateway forwards from the Authorization header.
+func rpcToken(md metadata.MD) string {
+	for _, h := range md["authorization"] {
+		if strings.HasPrefix(h, "Bearer ") {
+			return strings.TrimSpace(strings.TrimPrefix(h, "Bearer "))
+		}
+	}
+	return ""
+}
+
+// isLocal returns true if the caller of a RPC is connected on a unix
+// socket.
+func isLocal(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	return ok && p.Addr != nil && p.Addr.Network() == "unix"
+}
+
+func isClusterRPC(fullMethod string) bool {
+	return strings.Contains(fullMethod, "OpenStorageCluster/")
+}
+
+// authStream is a server stream whose context holds the claims of the
+// caller.
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
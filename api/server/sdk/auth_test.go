@@ -0,0 +1,118 @@
+package sdk
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/auth"
+)
+
+var testKey = []byte("secret")
+
+func testToken(t *testing.T, roles ...string) string {
+	token, err := auth.Token(&auth.Claims{Subject: "user", Roles: roles}, testKey)
+	require.NoError(t, err)
+	return token
+}
+
+func newAuthTestServer(t *testing.T, trustLocal bool) (*testServer, func()) {
+	dir, err := ioutil.TempDir("", "sdk_auth")
+	require.NoError(t, err)
+	a, err := auth.NewJwtAuthenticator("", testKey)
+	require.NoError(t, err)
+	s := newTestServerWithConfig(t, &ServerConfig{
+		Net:           "unix",
+		Address:       filepath.Join(dir, "sdk.sock"),
+		RestAddress:   "127.0.0.1:0",
+		Authenticator: a,
+		TrustLocal:    trustLocal,
+	})
+	return s, func() {
+		s.Stop()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestAuthRPC(t *testing.T) {
+	s, stop := newAuthTestServer(t, false)
+	defer stop()
+
+	id := "myvol"
+	s.m.EXPECT().
+		Inspect([]string{id}).
+		Return([]*api.Volume{{Id: id}}, nil).
+		Times(1)
+
+	c := api.NewOpenStorageVolumeClient(s.conn)
+	req := &api.SdkVolumeInspectRequest{VolumeId: id}
+	_, err := c.Inspect(context.Background(), req)
+	serverError, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.Unauthenticated, serverError.Code())
+
+	ctx := metadata.NewOutgoingContext(context.Background(),
+		metadata.Pairs("authorization", "Bearer "+testToken(t, auth.RoleReadOnly)))
+	_, err = c.Inspect(ctx, req)
+	assert.NoError(t, err)
+	_, err = c.Delete(ctx, &api.SdkVolumeDeleteRequest{VolumeId: id})
+	serverError, ok = status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.PermissionDenied, serverError.Code())
+
+	// Streaming RPCs are authenticated too.
+	stream, err := api.NewOpenStorageWatchClient(s.conn).Watch(context.Background(), &api.SdkWatchRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	serverError, ok = status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.Unauthenticated, serverError.Code())
+}
+
+func TestAuthGateway(t *testing.T) {
+	s, stop := newAuthTestServer(t, true)
+	defer stop()
+
+	id := "myvol"
+	s.m.EXPECT().
+		Inspect([]string{id}).
+		Return([]*api.Volume{{Id: id}}, nil).
+		Times(2)
+	s.m.EXPECT().
+		Delete(id).
+		Return(nil).
+		Times(1)
+
+	// Local RPCs are trusted, but not those forwarded by the gateway.
+	_, err := api.NewOpenStorageVolumeClient(s.conn).Inspect(context.Background(),
+		&api.SdkVolumeInspectRequest{VolumeId: id})
+	assert.NoError(t, err)
+
+	url := "http://" + s.server.RestAddress() + "/v1/osd-volumes/" + id
+	do := func(method string, token string) int {
+		req, err := http.NewRequest(method, url, nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	assert.Equal(t, http.StatusUnauthorized, do("GET", ""))
+	assert.Equal(t, http.StatusUnauthorized, do("GET", "garbage"))
+	assert.Equal(t, http.StatusOK, do("GET", testToken(t, auth.RoleReadOnly)))
+	assert.Equal(t, http.StatusForbidden, do("DELETE", testToken(t, auth.RoleReadOnly)))
+	assert.Equal(t, http.StatusOK, do("DELETE", testToken(t, auth.RoleVolumeOperator)))
+}
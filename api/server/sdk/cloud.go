@@ -0,0 +1,203 @@
+package sdk
+
+import (
+	"encoding/json"
+	"time"
+
+	google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/proto/time"
+)
+
+// CredentialCreate creates a cloud credential.
+func (s *volumeServer) CredentialCreate(
+	ctx context.Context,
+	req *api.SdkCredentialCreateRequest,
+) (*api.SdkCredentialCreateResponse, error) {
+	if len(req.GetInputParams()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "Credential parameters must be provided")
+	}
+	id, err := s.driver.CredsCreate(req.GetInputParams())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &api.SdkCredentialCreateResponse{CredentialId: id}, nil
+}
+
+// CredentialEnumerate returns the cloud credentials with their parameters
+// JSON encoded.
+func (s *volumeServer) CredentialEnumerate(
+	ctx context.Context,
+	req *api.SdkCredentialEnumerateRequest,
+) (*api.SdkCredentialEnumerateResponse, error) {
+	creds, err := s.driver.CredsEnumerate()
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	resp := &api.SdkCredentialEnumerateResponse{
+		Credentials: make(map[string]string, len(creds)),
+	}
+	for id, params := range creds {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Unable to encode credential %s: %v", id, err)
+		}
+		resp.Credentials[id] = string(data)
+	}
+	return resp, nil
+}
+
+// CredentialDelete deletes a cloud credential.
+func (s *volumeServer) CredentialDelete(
+	ctx context.Context,
+	req *api.SdkCredentialDeleteRequest,
+) (*api.SdkCredentialDeleteResponse, error) {
+	if req.GetCredentialId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Credential id must be provided")
+	}
+	if err := s.driver.CredsDelete(req.GetCredentialId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &api.SdkCredentialDeleteResponse{}, nil
+}
+
+// CredentialValidate checks the cloud can be accessed with a credential.
+func (s *volumeServer) CredentialValidate(
+	ctx context.Context,
+	req *api.SdkCredentialValidateRequest,
+) (*api.SdkCredentialValidateResponse, error) {
+	if req.GetCredentialId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Credential id must be provided")
+	}
+	if err := s.driver.CredsValidate(req.GetCredentialId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &api.SdkCredentialValidateResponse{}, nil
+}
+
+// CloudBackupCreate backs a volume up to the cloud.
+func (s *volumeServer) CloudBackupCreate(
+	ctx context.Context,
+	req *api.SdkCloudBackupCreateRequest,
+) (*api.SdkCloudBackupCreateResponse, error) {
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume id must be provided")
+	}
+	err := s.driver.Backup(&api.BackupRequest{
+		VolumeID:       req.GetVolumeId(),
+		CredentialUUID: req.GetCredentialId(),
+		Full:           req.GetFull(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &api.SdkCloudBackupCreateResponse{}, nil
+}
+
+// CloudBackupRestore restores a cloud backup to a new volume.
+func (s *volumeServer) CloudBackupRestore(
+	ctx context.Context,
+	req *api.SdkCloudBackupRestoreRequest,
+) (*api.SdkCloudBackupRestoreResponse, error) {
+	if req.GetBackupId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Backup id must be provided")
+	}
+	resp := s.driver.BackupRestore(&api.BackupRestoreRequest{
+		CloudBackupID:     req.GetBackupId(),
+		RestoreVolumeName: req.GetRestoreVolumeName(),
+		CredentialUUID:    req.GetCredentialId(),
+		NodeID:            req.GetNodeId(),
+	})
+	if resp.RestoreErr != "" {
+		return nil, status.Error(codes.Internal, resp.RestoreErr)
+	}
+	return &api.SdkCloudBackupRestoreResponse{RestoreVolumeId: resp.RestoreVolumeID}, nil
+}
+
+// CloudBackupEnumerate returns the cloud backups of a volume or a cluster.
+func (s *volumeServer) CloudBackupEnumerate(
+	ctx context.Context,
+	req *api.SdkCloudBackupEnumerateRequest,
+) (*api.SdkCloudBackupEnumerateResponse, error) {
+	resp := s.driver.BackupEnumerate(&api.BackupEnumerateRequest{
+		BackupGenericRequest: api.BackupGenericRequest{
+			SrcVolumeID:    req.GetSrcVolumeId(),
+			ClusterID:      req.GetClusterId(),
+			All:            req.GetAll(),
+			CredentialUUID: req.GetCredentialId(),
+		},
+	})
+	if resp.EnumerateErr != "" {
+		return nil, status.Error(codes.Internal, resp.EnumerateErr)
+	}
+	backups := make([]*api.SdkCloudBackupInfo, 0, len(resp.Backups))
+	for _, b := range resp.Backups {
+		backups = append(backups, &api.SdkCloudBackupInfo{
+			SrcVolumeId:   b.SrcVolumeID,
+			SrcVolumeName: b.SrcVolumeName,
+			BackupId:      b.BackupID,
+			Timestamp:     timestampProto(b.Timestamp),
+			Status:        b.Status,
+		})
+	}
+	return &api.SdkCloudBackupEnumerateResponse{Backups: backups}, nil
+}
+
+// CloudBackupDelete deletes the cloud backups of a volume or a cluster.
+func (s *volumeServer) CloudBackupDelete(
+	ctx context.Context,
+	req *api.SdkCloudBackupDeleteRequest,
+) (*api.SdkCloudBackupDeleteResponse, error) {
+	err := s.driver.BackupDelete(&api.BackupDeleteRequest{
+		BackupGenericRequest: api.BackupGenericRequest{
+			SrcVolumeID:    req.GetSrcVolumeId(),
+			ClusterID:      req.GetClusterId(),
+			All:            req.GetAll(),
+			CredentialUUID: req.GetCredentialId(),
+		},
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &api.SdkCloudBackupDeleteResponse{}, nil
+}
+
+// CloudBackupStatus returns the status of the last backup or restore of
+// the volumes.
+func (s *volumeServer) CloudBackupStatus(
+	ctx context.Context,
+	req *api.SdkCloudBackupStatusRequest,
+) (*api.SdkCloudBackupStatusResponse, error) {
+	resp := s.driver.BackupStatus(&api.BackupStsRequest{
+		SrcVolumeID: req.GetSrcVolumeId(),
+		Local:       req.GetLocal(),
+	})
+	if resp.StsErr != "" {
+		return nil, status.Error(codes.Internal, resp.StsErr)
+	}
+	statuses := make(map[string]*api.SdkCloudBackupStatus, len(resp.Statuses))
+	for id, st := range resp.Statuses {
+		statuses[id] = &api.SdkCloudBackupStatus{
+			OpType:        st.OpType,
+			Status:        st.Status,
+			BytesDone:     st.BytesDone,
+			StartTime:     timestampProto(st.StartTime),
+			CompletedTime: timestampProto(st.CompletedTime),
+			BackupId:      st.BackupID,
+			NodeId:        st.NodeID,
+		}
+	}
+	return &api.SdkCloudBackupStatusResponse{Statuses: statuses}, nil
+}
+
+// timestampProto returns t as a protobuf timestamp, nil if t is not set.
+func timestampProto(t time.Time) *google_protobuf.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return prototime.TimeToTimestamp(t)
+}
@@ -0,0 +1,137 @@
+package sdk
+
+import (
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/proto/time"
+)
+
+// Enumerate returns the cluster and its nodes.
+func (s *clusterServer) Enumerate(
+	ctx context.Context,
+	req *api.SdkClusterEnumerateRequest,
+) (*api.SdkClusterEnumerateResponse, error) {
+	if s.cluster == nil {
+		return nil, status.Error(codes.Unavailable, "Cluster is not initialized")
+	}
+	c, err := s.cluster.Enumerate()
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &api.SdkClusterEnumerateResponse{Cluster: toStorageCluster(&c)}, nil
+}
+
+// Inspect returns a node of the cluster.
+func (s *clusterServer) Inspect(
+	ctx context.Context,
+	req *api.SdkClusterInspectRequest,
+) (*api.SdkClusterInspectResponse, error) {
+	if s.cluster == nil {
+		return nil, status.Error(codes.Unavailable, "Cluster is not initialized")
+	}
+	if req.GetNodeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Node id must be provided")
+	}
+	n, err := s.cluster.Inspect(req.GetNodeId())
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &api.SdkClusterInspectResponse{Node: toStorageNode(&n)}, nil
+}
+
+// AlertEnumerate returns the alerts of a resource type raised in the time
+// range of the request.
+func (s *clusterServer) AlertEnumerate(
+	ctx context.Context,
+	req *api.SdkClusterAlertEnumerateRequest,
+) (*api.SdkClusterAlertEnumerateResponse, error) {
+	if s.cluster == nil {
+		return nil, status.Error(codes.Unavailable, "Cluster is not initialized")
+	}
+	var start, end time.Time
+	if req.GetTimeStart() != nil {
+		start = prototime.TimestampToTime(req.GetTimeStart())
+	}
+	if req.GetTimeEnd() != nil {
+		end = prototime.TimestampToTime(req.GetTimeEnd())
+	}
+	alerts, err := s.cluster.EnumerateAlerts(start, end, req.GetResource())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &api.SdkClusterAlertEnumerateResponse{Alerts: alerts.GetAlert()}, nil
+}
+
+// AlertClear clears an alert.
+func (s *clusterServer) AlertClear(
+	ctx context.Context,
+	req *api.SdkClusterAlertClearRequest,
+) (*api.SdkClusterAlertClearResponse, error) {
+	if s.cluster == nil {
+		return nil, status.Error(codes.Unavailable, "Cluster is not initialized")
+	}
+	if err := s.cluster.ClearAlert(req.GetResource(), req.GetAlertId()); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &api.SdkClusterAlertClearResponse{}, nil
+}
+
+// AlertErase erases an alert.
+func (s *clusterServer) AlertErase(
+	ctx context.Context,
+	req *api.SdkClusterAlertEraseRequest,
+) (*api.SdkClusterAlertEraseResponse, error) {
+	if s.cluster == nil {
+		return nil, status.Error(codes.Unavailable, "Cluster is not initialized")
+	}
+	if err := s.cluster.EraseAlert(req.GetResource(), req.GetAlertId()); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &api.SdkClusterAlertEraseResponse{}, nil
+}
+
+func toStorageCluster(c *api.Cluster) *api.StorageCluster {
+	nodes := make([]*api.StorageNode, 0, len(c.Nodes))
+	for i := range c.Nodes {
+		nodes = append(nodes, toStorageNode(&c.Nodes[i]))
+	}
+	return &api.StorageCluster{
+		Status: c.Status,
+		Id:     c.Id,
+		NodeId: c.NodeId,
+		Nodes:  nodes,
+	}
+}
+
+func toStorageNode(n *api.Node) *api.StorageNode {
+	disks := make(map[string]*api.StorageResource, len(n.Disks))
+	for name, disk := range n.Disks {
+		d := disk
+		disks[name] = &d
+	}
+	pools := make([]*api.StoragePool, 0, len(n.Pools))
+	for i := range n.Pools {
+		pools = append(pools, &n.Pools[i])
+	}
+	return &api.StorageNode{
+		Id:         n.Id,
+		Cpu:        n.Cpu,
+		MemTotal:   n.MemTotal,
+		MemUsed:    n.MemUsed,
+		MemFree:    n.MemFree,
+		AvgLoad:    int64(n.Avgload),
+		Status:     n.Status,
+		Disks:      disks,
+		Pools:      pools,
+		MgmtIp:     n.MgmtIp,
+		DataIp:     n.DataIp,
+		Hostname:   n.Hostname,
+		NodeLabels: n.NodeLabels,
+		StartTime:  timestampProto(n.StartTime),
+	}
+}
@@ -0,0 +1,58 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+
+	"github.com/libopenstorage/openstorage/api"
+)
+
+func TestClusterEnumerate(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Stop()
+
+	s.c.EXPECT().
+		Enumerate().
+		Return(api.Cluster{
+			Id:     "cluster",
+			NodeId: "node1",
+			Status: api.Status_STATUS_OK,
+			Nodes: []api.Node{
+				{
+					Id:       "node1",
+					Hostname: "host1",
+					Disks: map[string]api.StorageResource{
+						"/dev/sda": {Path: "/dev/sda", Size: 1024},
+					},
+				},
+			},
+		}, nil).
+		Times(1)
+
+	c := api.NewOpenStorageClusterClient(s.conn)
+	r, err := c.Enumerate(context.Background(), &api.SdkClusterEnumerateRequest{})
+	assert.Nil(t, err)
+	cluster := r.GetCluster()
+	assert.Equal(t, "cluster", cluster.GetId())
+	assert.Equal(t, api.Status_STATUS_OK, cluster.GetStatus())
+	assert.Len(t, cluster.GetNodes(), 1)
+	assert.Equal(t, "host1", cluster.GetNodes()[0].GetHostname())
+	assert.Equal(t, uint64(1024), cluster.GetNodes()[0].GetDisks()["/dev/sda"].GetSize())
+}
+
+func TestClusterInspect(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Stop()
+
+	s.c.EXPECT().
+		Inspect("node1").
+		Return(api.Node{Id: "node1", MgmtIp: "10.0.0.1"}, nil).
+		Times(1)
+
+	c := api.NewOpenStorageClusterClient(s.conn)
+	r, err := c.Inspect(context.Background(), &api.SdkClusterInspectRequest{NodeId: "node1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.0.1", r.GetNode().GetMgmtIp())
+}
@@ -0,0 +1,254 @@
+// Package sdk serves the OpenStorageVolume, OpenStorageCluster and
+// OpenStorageWatch gRPC services of api/api.proto for a volume driver, and
+// the REST gateway translating JSON requests to them.
+package sdk
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"go.pedge.io/dlog"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/cluster"
+	"github.com/libopenstorage/openstorage/pkg/auth"
+	"github.com/libopenstorage/openstorage/volume"
+	volumedrivers "github.com/libopenstorage/openstorage/volume/drivers"
+)
+
+// ServerConfig provides the configuration of the server created by New.
+type ServerConfig struct {
+	// Net and Address the gRPC services are served on, usually "unix"
+	// and the path of a socket.
+	Net     string
+	Address string
+	// RestAddress is the TCP address of the REST gateway, which is not
+	// started if empty.
+	RestAddress string
+	DriverName  string
+	Cluster     cluster.Cluster
+	// TLSConfig secures the REST gateway if set
+	TLSConfig *tls.Config
+	// Authenticator verifies the bearer tokens of callers, RPCs are not
+	// authenticated if it is nil. The REST gateway forwards the
+	// Authorization header of requests as the token.
+	Authenticator auth.Authenticator
+	// TrustLocal lets RPCs on unix sockets skip authentication, except
+	// those forwarded by the REST gateway.
+	TrustLocal bool
+}
+
+// Server serves the gRPC services for a single driver.
+type Server struct {
+	config   ServerConfig
+	listener net.Listener
+	server   *grpc.Server
+	gateway  *http.Server
+	restAddr string
+	cancel   context.CancelFunc
+	volume   *volumeServer
+	cluster  *clusterServer
+	watch    *watchServer
+	wg       sync.WaitGroup
+	running  bool
+	lock     sync.Mutex
+}
+
+// New creates a server of the gRPC services listening on config.Address.
+func New(config *ServerConfig) (*Server, error) {
+	if config == nil {
+		return nil, fmt.Errorf("Configuration must be provided")
+	}
+	if len(config.Address) == 0 {
+		return nil, fmt.Errorf("Address must be provided")
+	}
+	if len(config.Net) == 0 {
+		return nil, fmt.Errorf("Net must be provided")
+	}
+	if len(config.DriverName) == 0 {
+		return nil, fmt.Errorf("OSD Driver name must be provided")
+	}
+
+	d, err := volumedrivers.Get(config.DriverName)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get driver %s info: %s", config.DriverName, err.Error())
+	}
+
+	l, err := net.Listen(config.Net, config.Address)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to setup server: %s", err.Error())
+	}
+
+	return &Server{
+		config:   *config,
+		listener: l,
+		volume:   &volumeServer{driver: d, driverName: config.DriverName, cluster: config.Cluster},
+		cluster:  &clusterServer{cluster: config.Cluster},
+		watch:    &watchServer{driverName: config.DriverName},
+	}, nil
+}
+
+// Start serves the gRPC services and the REST gateway.
+// It will return an error if the server is already running.
+func (s *Server) Start() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.running {
+		return fmt.Errorf("Server already running")
+	}
+
+	s.server = grpc.NewServer(
+		grpc.UnaryInterceptor(s.unaryInterceptor),
+		grpc.StreamInterceptor(s.streamInterceptor),
+	)
+	api.RegisterOpenStorageVolumeServer(s.server, s.volume)
+	api.RegisterOpenStorageClusterServer(s.server, s.cluster)
+	api.RegisterOpenStorageWatchServer(s.server, s.watch)
+	reflection.Register(s.server)
+
+	dlog.Infof("SDK gRPC Server ready on %s", s.Address())
+	server := s.server
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := server.Serve(s.listener); err != nil && err != grpc.ErrServerStopped {
+			dlog.Errorf("Unable to serve gRPC on %s: %v", s.Address(), err)
+		}
+	}()
+
+	if len(s.config.RestAddress) != 0 {
+		if err := s.startGateway(); err != nil {
+			s.server.Stop()
+			s.wg.Wait()
+			return err
+		}
+	}
+
+	s.running = true
+	return nil
+}
+
+// startGateway serves the REST gateway, which forwards the requests to the
+// gRPC server.
+func (s *Server) startGateway() error {
+	l, err := net.Listen("tcp", s.config.RestAddress)
+	if err != nil {
+		return fmt.Errorf("Unable to setup REST gateway: %s", err.Error())
+	}
+	if s.config.TLSConfig != nil {
+		l = tls.NewListener(l, s.config.TLSConfig)
+	}
+
+	addr := s.Address()
+	network := s.listener.Addr().Network()
+	opts := []grpc.DialOption{
+		grpc.WithInsecure(),
+		grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+			return net.Dial(network, addr)
+		}),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	mux := runtime.NewServeMux(runtime.WithMetadata(
+		func(context.Context, *http.Request) metadata.MD {
+			return metadata.Pairs(gatewayKey, "true")
+		},
+	))
+	if err := api.RegisterOpenStorageVolumeHandlerFromEndpoint(ctx, mux, addr, opts); err != nil {
+		cancel()
+		l.Close()
+		return err
+	}
+	if err := api.RegisterOpenStorageClusterHandlerFromEndpoint(ctx, mux, addr, opts); err != nil {
+		cancel()
+		l.Close()
+		return err
+	}
+	if err := api.RegisterOpenStorageWatchHandlerFromEndpoint(ctx, mux, addr, opts); err != nil {
+		cancel()
+		l.Close()
+		return err
+	}
+
+	s.cancel = cancel
+	s.restAddr = l.Addr().String()
+	gateway := &http.Server{Handler: mux}
+	s.gateway = gateway
+	dlog.Infof("SDK REST gateway ready on %s", l.Addr())
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := gateway.Serve(l); err != nil && err != http.ErrServerClosed {
+			dlog.Errorf("Unable to serve REST gateway on %s: %v", l.Addr(), err)
+		}
+	}()
+	return nil
+}
+
+// Stop stops the server. It does nothing if the server has already been
+// stopped.
+func (s *Server) Stop() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.running {
+		return
+	}
+
+	if s.gateway != nil {
+		s.gateway.Close()
+		s.cancel()
+		s.gateway = nil
+	}
+	s.server.Stop()
+	s.wg.Wait()
+	s.running = false
+}
+
+// Address returns the address of the gRPC server which can be used by
+// clients to connect.
+func (s *Server) Address() string {
+	return s.listener.Addr().String()
+}
+
+// RestAddress returns the address of the REST gateway, empty if it is not
+// running.
+func (s *Server) RestAddress() string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.gateway == nil {
+		return ""
+	}
+	return s.restAddr
+}
+
+// IsRunning returns true if the server is currently running
+func (s *Server) IsRunning() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.running
+}
+
+// volumeServer implements api.OpenStorageVolumeServer with a driver.
+type volumeServer struct {
+	driver     volume.VolumeDriver
+	driverName string
+	// cluster new volumes are placed in
+	cluster cluster.Cluster
+}
+
+// clusterServer implements api.OpenStorageClusterServer with a cluster.
+type clusterServer struct {
+	cluster cluster.Cluster
+}
@@ -0,0 +1,150 @@
+package sdk
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/kubernetes-csi/csi-test/utils"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/libopenstorage/openstorage/api"
+	mockcluster "github.com/libopenstorage/openstorage/cluster/mock"
+	"github.com/libopenstorage/openstorage/volume"
+	volumedrivers "github.com/libopenstorage/openstorage/volume/drivers"
+	mockdriver "github.com/libopenstorage/openstorage/volume/drivers/mock"
+)
+
+const (
+	mockDriverName = "mock"
+)
+
+// testServer starts the gRPC services and the REST gateway with a mock
+// driver and a mock cluster.
+type testServer struct {
+	conn   *grpc.ClientConn
+	server *Server
+	m      *mockdriver.MockVolumeDriver
+	c      *mockcluster.MockCluster
+	mc     *gomock.Controller
+}
+
+func newTestServer(t *testing.T) *testServer {
+	return newTestServerWithConfig(t, &ServerConfig{
+		Net:         "tcp",
+		Address:     "127.0.0.1:0",
+		RestAddress: "127.0.0.1:0",
+	})
+}
+
+// newTestServerWithConfig starts a server with config, whose driver and
+// cluster are the mocks.
+func newTestServerWithConfig(t *testing.T, config *ServerConfig) *testServer {
+	tester := &testServer{}
+
+	tester.mc = gomock.NewController(&utils.SafeGoroutineTester{})
+	tester.m = mockdriver.NewMockVolumeDriver(tester.mc)
+	tester.c = mockcluster.NewMockCluster(tester.mc)
+
+	volumedrivers.Add(mockDriverName, func(map[string]string) (volume.VolumeDriver, error) {
+		return tester.m, nil
+	})
+	err := volumedrivers.Register(mockDriverName, nil)
+	assert.Nil(t, err)
+
+	config.DriverName = mockDriverName
+	config.Cluster = tester.c
+	tester.server, err = New(config)
+	assert.Nil(t, err)
+	err = tester.server.Start()
+	assert.Nil(t, err)
+
+	tester.conn, err = grpc.Dial(
+		tester.server.Address(),
+		grpc.WithInsecure(),
+		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			return net.DialTimeout(config.Net, addr, timeout)
+		}),
+	)
+	assert.Nil(t, err)
+
+	return tester
+}
+
+func (s *testServer) Stop() {
+	volumedrivers.Remove(mockDriverName)
+
+	s.conn.Close()
+	s.server.Stop()
+
+	s.mc.Finish()
+}
+
+func TestServerStart(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Stop()
+
+	assert.True(t, s.server.IsRunning())
+	assert.NotEmpty(t, s.server.RestAddress())
+	assert.NotNil(t, s.server.Start())
+
+	s.server.Stop()
+	assert.False(t, s.server.IsRunning())
+	assert.Empty(t, s.server.RestAddress())
+}
+
+func TestServerConfig(t *testing.T) {
+	_, err := New(nil)
+	assert.NotNil(t, err)
+	_, err = New(&ServerConfig{Net: "tcp", DriverName: mockDriverName})
+	assert.NotNil(t, err)
+	_, err = New(&ServerConfig{Net: "tcp", Address: "127.0.0.1:0", DriverName: "nope"})
+	assert.NotNil(t, err)
+}
+
+func TestGatewayVolumeInspect(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Stop()
+
+	id := "myvol"
+	s.m.EXPECT().
+		Inspect([]string{id}).
+		Return([]*api.Volume{{Id: id, Locator: &api.VolumeLocator{Name: "name"}}}, nil).
+		Times(1)
+
+	resp, err := http.Get("http://" + s.server.RestAddress() + "/v1/osd-volumes/" + id)
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var r struct {
+		Volume struct {
+			Id      string `json:"id"`
+			Locator struct {
+				Name string `json:"name"`
+			} `json:"locator"`
+		} `json:"volume"`
+	}
+	assert.Nil(t, json.NewDecoder(resp.Body).Decode(&r))
+	assert.Equal(t, id, r.Volume.Id)
+	assert.Equal(t, "name", r.Volume.Locator.Name)
+}
+
+func TestGatewayVolumeNotFound(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Stop()
+
+	s.m.EXPECT().
+		Inspect([]string{"nope"}).
+		Return([]*api.Volume{}, nil).
+		Times(1)
+
+	resp, err := http.Get("http://" + s.server.RestAddress() + "/v1/osd-volumes/nope")
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
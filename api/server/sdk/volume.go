@@ -0,0 +1,224 @@
+package sdk
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/api/errors"
+	"github.com/libopenstorage/openstorage/pkg/placement"
+	"github.com/libopenstorage/openstorage/pkg/quota"
+	"github.com/libopenstorage/openstorage/volume"
+	volumedrivers "github.com/libopenstorage/openstorage/volume/drivers"
+)
+
+// Create creates a volume unless it would exceed a quota. Volumes of drivers
+// of global scope without a replica set are placed on the nodes of the
+// cluster.
+func (s *volumeServer) Create(
+	ctx context.Context,
+	req *api.SdkVolumeCreateRequest,
+) (*api.SdkVolumeCreateResponse, error) {
+	if req.GetLocator().GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume name must be provided")
+	}
+
+	err := volumedrivers.Place(s.driverName, s.driver, s.cluster, req.GetLocator(), req.GetSpec())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	var id string
+	next := &api.Volume{Locator: req.GetLocator(), Source: req.GetSource(), Spec: req.GetSpec()}
+	err = s.enforceQuota(nil, next, func() (err error) {
+		id, err = s.driver.Create(req.GetLocator(), req.GetSource(), req.GetSpec())
+		return err
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &api.SdkVolumeCreateResponse{VolumeId: id}, nil
+}
+
+// Inspect returns a volume.
+func (s *volumeServer) Inspect(
+	ctx context.Context,
+	req *api.SdkVolumeInspectRequest,
+) (*api.SdkVolumeInspectResponse, error) {
+	v, err := s.inspect(req.GetVolumeId())
+	if err != nil {
+		return nil, err
+	}
+	return &api.SdkVolumeInspectResponse{Volume: v}, nil
+}
+
+// Enumerate returns the volumes matching the locator and the labels of the
+// request.
+func (s *volumeServer) Enumerate(
+	ctx context.Context,
+	req *api.SdkVolumeEnumerateRequest,
+) (*api.SdkVolumeEnumerateResponse, error) {
+	locator := req.GetLocator()
+	if locator == nil {
+		locator = &api.VolumeLocator{}
+	}
+	vols, err := s.driver.Enumerate(locator, req.GetLabels())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &api.SdkVolumeEnumerateResponse{Volumes: vols}, nil
+}
+
+// Update changes the locator and the spec of a volume, unless resizing it
+// or changing its labels would exceed a quota.
+func (s *volumeServer) Update(
+	ctx context.Context,
+	req *api.SdkVolumeUpdateRequest,
+) (*api.SdkVolumeUpdateResponse, error) {
+	prev, err := s.inspect(req.GetVolumeId())
+	if err != nil {
+		return nil, err
+	}
+
+	next := quota.Updated(prev, req.GetLocator(), req.GetSpec())
+	err = s.enforceQuota(prev, next, func() error {
+		return s.driver.Set(req.GetVolumeId(), req.GetLocator(), req.GetSpec())
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &api.SdkVolumeUpdateResponse{}, nil
+}
+
+// Delete deletes a volume.
+func (s *volumeServer) Delete(
+	ctx context.Context,
+	req *api.SdkVolumeDeleteRequest,
+) (*api.SdkVolumeDeleteResponse, error) {
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume id must be provided")
+	}
+	if err := s.driver.Delete(req.GetVolumeId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &api.SdkVolumeDeleteResponse{}, nil
+}
+
+// Stats returns the stats of a volume.
+func (s *volumeServer) Stats(
+	ctx context.Context,
+	req *api.SdkVolumeStatsRequest,
+) (*api.SdkVolumeStatsResponse, error) {
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume id must be provided")
+	}
+	stats, err := s.driver.Stats(req.GetVolumeId(), !req.GetNotCumulative())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &api.SdkVolumeStatsResponse{Stats: stats}, nil
+}
+
+// SnapshotCreate snapshots a volume unless it would exceed a quota.
+func (s *volumeServer) SnapshotCreate(
+	ctx context.Context,
+	req *api.SdkSnapshotCreateRequest,
+) (*api.SdkSnapshotCreateResponse, error) {
+	if req.GetVolumeId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume id must be provided")
+	}
+
+	var id string
+	next := &api.Volume{
+		Locator:  req.GetLocator(),
+		Source:   &api.Source{Parent: req.GetVolumeId()},
+		Readonly: req.GetReadonly(),
+	}
+	err := s.enforceQuota(nil, next, func() (err error) {
+		id, err = s.driver.Snapshot(req.GetVolumeId(), req.GetReadonly(), req.GetLocator())
+		return err
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &api.SdkSnapshotCreateResponse{SnapshotId: id}, nil
+}
+
+// SnapshotEnumerate returns the snapshots of a volume, or of all volumes if
+// none is requested, matching the labels of the request.
+func (s *volumeServer) SnapshotEnumerate(
+	ctx context.Context,
+	req *api.SdkSnapshotEnumerateRequest,
+) (*api.SdkSnapshotEnumerateResponse, error) {
+	var ids []string
+	if req.GetVolumeId() != "" {
+		ids = []string{req.GetVolumeId()}
+	}
+	snaps, err := s.driver.SnapEnumerate(ids, req.GetLabels())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &api.SdkSnapshotEnumerateResponse{Snapshots: snaps}, nil
+}
+
+// SnapshotRestore restores a volume to one of its snapshots.
+func (s *volumeServer) SnapshotRestore(
+	ctx context.Context,
+	req *api.SdkSnapshotRestoreRequest,
+) (*api.SdkSnapshotRestoreResponse, error) {
+	if req.GetVolumeId() == "" || req.GetSnapshotId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume and snapshot ids must be provided")
+	}
+	if err := s.driver.Restore(req.GetVolumeId(), req.GetSnapshotId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &api.SdkSnapshotRestoreResponse{}, nil
+}
+
+// inspect returns volume id, or a NotFound status if it does not exist.
+func (s *volumeServer) inspect(id string) (*api.Volume, error) {
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "Volume id must be provided")
+	}
+	vols, err := s.driver.Inspect([]string{id})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if len(vols) != 1 {
+		return nil, toStatus(&errors.ErrNotFound{Type: "Volume", ID: id})
+	}
+	return vols[0], nil
+}
+
+// enforceQuota runs fn, which changes a volume from prev to next, unless
+// the change would exceed a quota.
+func (s *volumeServer) enforceQuota(prev, next *api.Volume, fn func() error) error {
+	m := quota.Instance()
+	if m == nil {
+		return fn()
+	}
+	return m.Enforce(s.driverName, s.driver, prev, next, fn)
+}
+
+// toStatus returns err as a gRPC status error.
+func toStatus(err error) error {
+	switch err.(type) {
+	case *quota.ErrExceeded:
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case *errors.ErrNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	}
+	switch err {
+	case placement.ErrNoCapacity:
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case volume.ErrEnoEnt:
+		return status.Error(codes.NotFound, err.Error())
+	case volume.ErrExist:
+		return status.Error(codes.AlreadyExists, err.Error())
+	case volume.ErrEinval:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case volume.ErrNotSupported:
+		return status.Error(codes.Unimplemented, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
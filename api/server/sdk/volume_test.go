@@ -0,0 +1,144 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+func TestVolumeCreate(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Stop()
+
+	// Volumes of drivers of global scope are placed.
+	cl := api.Cluster{
+		NodeId: "node1",
+		Nodes: []api.Node{
+			{Id: "node1", Status: api.Status_STATUS_OK},
+			{Id: "node2", Status: api.Status_STATUS_OK},
+		},
+	}
+	s.c.EXPECT().Enumerate().Return(cl, nil).Times(2)
+	locator := &api.VolumeLocator{Name: "myvol"}
+	spec := &api.VolumeSpec{Size: 1024, HaLevel: 2}
+	placed := &api.VolumeSpec{
+		Size:       1024,
+		HaLevel:    2,
+		ReplicaSet: &api.ReplicaSet{Nodes: []string{"node1", "node2"}},
+	}
+	s.m.EXPECT().
+		Create(locator, nil, placed).
+		Return("myid", nil).
+		Times(1)
+
+	c := api.NewOpenStorageVolumeClient(s.conn)
+	r, err := c.Create(context.Background(), &api.SdkVolumeCreateRequest{
+		Locator: locator,
+		Spec:    spec,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "myid", r.GetVolumeId())
+
+	_, err = c.Create(context.Background(), &api.SdkVolumeCreateRequest{
+		Locator: locator,
+		Spec:    &api.VolumeSpec{Size: 1024, HaLevel: 3},
+	})
+	assert.Equal(t, codes.ResourceExhausted, statusCode(t, err))
+
+	// Name is required
+	_, err = c.Create(context.Background(), &api.SdkVolumeCreateRequest{})
+	assert.Equal(t, codes.InvalidArgument, statusCode(t, err))
+}
+
+func TestVolumeCreateExists(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Stop()
+
+	s.m.EXPECT().
+		Create(gomock.Any(), nil, nil).
+		Return("", volume.ErrExist).
+		Times(1)
+
+	c := api.NewOpenStorageVolumeClient(s.conn)
+	_, err := c.Create(context.Background(), &api.SdkVolumeCreateRequest{
+		Locator: &api.VolumeLocator{Name: "myvol"},
+	})
+	assert.Equal(t, codes.AlreadyExists, statusCode(t, err))
+}
+
+func TestVolumeInspect(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Stop()
+
+	id := "myid"
+	s.m.EXPECT().
+		Inspect([]string{id}).
+		Return([]*api.Volume{{Id: id}}, nil).
+		Times(1)
+	s.m.EXPECT().
+		Inspect([]string{"nope"}).
+		Return([]*api.Volume{}, nil).
+		Times(1)
+
+	c := api.NewOpenStorageVolumeClient(s.conn)
+	r, err := c.Inspect(context.Background(), &api.SdkVolumeInspectRequest{VolumeId: id})
+	assert.Nil(t, err)
+	assert.Equal(t, id, r.GetVolume().GetId())
+
+	_, err = c.Inspect(context.Background(), &api.SdkVolumeInspectRequest{VolumeId: "nope"})
+	assert.Equal(t, codes.NotFound, statusCode(t, err))
+}
+
+func TestVolumeUpdate(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Stop()
+
+	// Volumes without a spec can be resized.
+	id := "myid"
+	spec := &api.VolumeSpec{Size: 1024}
+	s.m.EXPECT().
+		Inspect([]string{id}).
+		Return([]*api.Volume{{Id: id}}, nil).
+		Times(1)
+	s.m.EXPECT().
+		Set(id, nil, spec).
+		Return(nil).
+		Times(1)
+
+	c := api.NewOpenStorageVolumeClient(s.conn)
+	_, err := c.Update(context.Background(), &api.SdkVolumeUpdateRequest{
+		VolumeId: id,
+		Spec:     spec,
+	})
+	assert.Nil(t, err)
+}
+
+func TestVolumeStats(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Stop()
+
+	id := "myid"
+	s.m.EXPECT().
+		Stats(id, true).
+		Return(&api.Stats{Reads: 10}, nil).
+		Times(1)
+
+	c := api.NewOpenStorageVolumeClient(s.conn)
+	r, err := c.Stats(context.Background(), &api.SdkVolumeStatsRequest{VolumeId: id})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(10), r.GetStats().GetReads())
+}
+
+// statusCode returns the gRPC status code of err.
+func statusCode(t *testing.T, err error) codes.Code {
+	serverError, ok := status.FromError(err)
+	assert.True(t, ok)
+	return serverError.Code()
+}
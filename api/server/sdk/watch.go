@@ -0,0 +1,69 @@
+package sdk
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/watch"
+)
+
+// watchServer implements api.OpenStorageWatchServer with the events of
+// the broker of the watch package.
+type watchServer struct {
+	driverName string
+}
+
+// Watch streams the events of the volumes of the driver and of the nodes
+// selected by the request, until the client goes away.
+func (s *watchServer) Watch(
+	req *api.SdkWatchRequest,
+	stream api.OpenStorageWatch_WatchServer,
+) error {
+	b := watch.Instance()
+	if b == nil {
+		return status.Error(codes.Unavailable, "Watch is not enabled")
+	}
+	w, err := b.Watch(req.GetRevision(), &watch.Filter{
+		Types:  req.GetTypes(),
+		Driver: s.driverName,
+		Id:     req.GetId(),
+	})
+	if err == watch.ErrCompacted {
+		return status.Error(codes.OutOfRange, err.Error())
+	} else if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer w.Stop()
+
+	for {
+		select {
+		case e, ok := <-w.Events():
+			if !ok {
+				if err := w.Err(); err != nil {
+					return status.Error(codes.Aborted, err.Error())
+				}
+				return nil
+			}
+			if err := stream.Send(toSdkWatchEvent(e)); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		}
+	}
+}
+
+func toSdkWatchEvent(e *watch.Event) *api.SdkWatchEvent {
+	event := &api.SdkWatchEvent{
+		Revision: e.Revision,
+		Type:     e.Type,
+		Time:     timestampProto(e.Time),
+		Driver:   e.Driver,
+		Volume:   e.Volume,
+	}
+	if e.Node != nil {
+		event.Node = toStorageNode(e.Node)
+	}
+	return event
+}
@@ -0,0 +1,83 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/watch"
+)
+
+func TestWatch(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Stop()
+
+	b := watch.NewBroker(0)
+	watch.SetInstance(b)
+	defer watch.SetInstance(nil)
+
+	revision := b.Revision()
+	b.Publish(&watch.Event{
+		Type:   watch.EventVolumeCreate,
+		Driver: "other",
+		Volume: &api.Volume{Id: "vol0"},
+	})
+	b.Publish(&watch.Event{
+		Type:   watch.EventVolumeCreate,
+		Driver: mockDriverName,
+		Volume: &api.Volume{Id: "vol1"},
+	})
+	b.Publish(&watch.Event{
+		Type: watch.EventNodeDown,
+		Node: &api.Node{Id: "node1", Hostname: "host1"},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := api.NewOpenStorageWatchClient(s.conn)
+	stream, err := c.Watch(ctx, &api.SdkWatchRequest{Revision: revision})
+	require.NoError(t, err)
+
+	e, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, revision+2, e.GetRevision())
+	assert.Equal(t, watch.EventVolumeCreate, e.GetType())
+	assert.Equal(t, mockDriverName, e.GetDriver())
+	assert.Equal(t, "vol1", e.GetVolume().GetId())
+	assert.NotNil(t, e.GetTime())
+
+	e, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, watch.EventNodeDown, e.GetType())
+	assert.Equal(t, "host1", e.GetNode().GetHostname())
+}
+
+func TestWatchErrors(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Stop()
+
+	c := api.NewOpenStorageWatchClient(s.conn)
+	recvCode := func(req *api.SdkWatchRequest) codes.Code {
+		stream, err := c.Watch(context.Background(), req)
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		serr, ok := status.FromError(err)
+		require.True(t, ok, "unexpected error %v", err)
+		return serr.Code()
+	}
+
+	assert.Equal(t, codes.Unavailable, recvCode(&api.SdkWatchRequest{}))
+
+	b := watch.NewBroker(1)
+	watch.SetInstance(b)
+	defer watch.SetInstance(nil)
+	revision := b.Revision()
+	b.Publish(&watch.Event{Type: watch.EventNodeUp, Node: &api.Node{Id: "node1"}})
+	b.Publish(&watch.Event{Type: watch.EventNodeUp, Node: &api.Node{Id: "node2"}})
+	assert.Equal(t, codes.OutOfRange, recvCode(&api.SdkWatchRequest{Revision: revision}))
+}
@@ -10,6 +10,7 @@ import (
 	"go.pedge.io/dlog"
 
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // Route is a specification and  handler for a REST endpoint.
@@ -75,11 +76,12 @@ func StartVolumeMgmtAPI(
 	mgmtPort uint16,
 ) error {
 	volMgmtApi := newVolumeAPI(name)
+	volumeMetrics.add(name)
 	if err := startServer(
 		name,
 		mgmtBase,
 		mgmtPort,
-		volMgmtApi.Routes(),
+		auditRoutes(name, volMgmtApi.Routes()),
 	); err != nil {
 		return err
 	}
@@ -111,11 +113,32 @@ func StartVolumePluginAPI(
 	return nil
 }
 
+// StartManagedVolumePluginAPI starts a REST server to receive volume API
+// commands from the linux container engine on the socket of a Docker
+// managed plugin.
+func StartManagedVolumePluginAPI(
+	name string,
+	pluginBase string,
+	pluginPort uint16,
+) error {
+	volPluginApi := newVolumePlugin(name)
+	if err := startServerOnSocket(
+		name,
+		path.Join(pluginBase, ManagedPluginSocket),
+		pluginPort,
+		volPluginApi.Routes(),
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
 // StartClusterAPI starts a REST server to receive driver configuration commands
 // from the CLI/UX to control the OSD cluster.
 func StartClusterAPI(clusterApiBase string, clusterPort uint16) error {
 	clusterApi := newClusterAPI()
-	if err := startServer("osd", clusterApiBase, clusterPort, clusterApi.Routes()); err != nil {
+	routes := auditRoutes("cluster", clusterApi.Routes())
+	if err := startServer("osd", clusterApiBase, clusterPort, routes); err != nil {
 		return err
 	}
 
@@ -128,16 +151,20 @@ func GetClusterAPIRoutes() []*Route {
 }
 
 func startServer(name string, sockBase string, port uint16, routes []*Route) error {
+	return startServerOnSocket(name, path.Join(sockBase, name+".sock"), port, routes)
+}
+
+func startServerOnSocket(name string, socket string, port uint16, routes []*Route) error {
 	var (
 		listener net.Listener
 		err      error
 	)
-	router := mux.NewRouter()
-	router.NotFoundHandler = http.HandlerFunc(notFound)
-	for _, v := range routes {
-		router.Methods(v.verb).Path(v.path).HandlerFunc(v.fn)
-	}
-	socket := path.Join(sockBase, name+".sock")
+	routes = append(routes, &Route{
+		verb: "GET",
+		path: metricsPath,
+		fn:   prometheus.UninstrumentedHandler().ServeHTTP,
+	})
+	router := newRouter(name, routes, authenticator != nil && !authTrustLocal)
 	os.Remove(socket)
 	os.MkdirAll(path.Dir(socket), 0755)
 
@@ -150,11 +177,35 @@ func startServer(name string, sockBase string, port uint16, routes []*Route) err
 	go http.Serve(listener, router)
 	if port != 0 {
 		dlog.Printf("Starting REST service on port : %v", port)
-		go http.ListenAndServe(fmt.Sprintf(":%d", port), router)
+		srv := &http.Server{
+			Addr:    fmt.Sprintf(":%d", port),
+			Handler: newRouter(name, routes, authenticator != nil),
+		}
+		if tlsConfig != nil {
+			srv.TLSConfig = tlsConfig
+			go srv.ListenAndServeTLS("", "")
+		} else {
+			go srv.ListenAndServe()
+		}
 	}
 	return nil
 }
 
+// newRouter returns a router serving routes, which authenticates requests
+// if requireAuth is set.
+func newRouter(name string, routes []*Route, requireAuth bool) *mux.Router {
+	router := mux.NewRouter()
+	router.NotFoundHandler = http.HandlerFunc(notFound)
+	for _, v := range routes {
+		fn := v.fn
+		if requireAuth {
+			fn = authenticate(name, v, fn)
+		}
+		router.Methods(v.verb).Path(v.path).HandlerFunc(instrument(name, v.verb, v.path, fn))
+	}
+	return router
+}
+
 type restServer interface {
 	Routes() []*Route
 	String() string
@@ -0,0 +1,15 @@
+package server
+
+import (
+	"crypto/tls"
+)
+
+// tlsConfig secures the TCP ports of the REST servers, which serve plain
+// HTTP if it is nil. The unix sockets are never secured.
+var tlsConfig *tls.Config
+
+// EnableTLS serves the TCP ports of the REST servers started after it is
+// called over TLS with config.
+func EnableTLS(config *tls.Config) {
+	tlsConfig = config
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -10,8 +11,11 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/api/errors"
+	"github.com/libopenstorage/openstorage/cluster"
+	"github.com/libopenstorage/openstorage/pkg/placement"
 	"github.com/libopenstorage/openstorage/volume"
 	"github.com/libopenstorage/openstorage/volume/drivers"
+	"github.com/libopenstorage/openstorage/volume/drivers/common"
 )
 
 const schedDriverPostFix = "-sched"
@@ -68,7 +72,9 @@ func (vd *volAPI) parseID(r *http.Request) (string, error) {
 
 // swagger:operation POST /osd-volumes volume create createVolume
 //
-// Creates a single volume with given spec.
+// Creates a single volume with given spec. Volumes of drivers of global
+// scope without a replica set are placed on the nodes picked by the
+// placement of the cluster.
 //
 // ---
 // produces:
@@ -105,15 +111,101 @@ func (vd *volAPI) create(w http.ResponseWriter, r *http.Request) {
 		notFound(w, r)
 		return
 	}
-	id, err := d.Create(dcReq.Locator, dcReq.Source, dcReq.Spec)
+	create := func(ctx context.Context, progress func(int)) (id string, err error) {
+		if err := vd.place(d, dcReq.Locator, dcReq.Spec); err != nil {
+			return "", err
+		}
+		next := &api.Volume{Locator: dcReq.Locator, Source: dcReq.Source, Spec: dcReq.Spec}
+		err = enforceQuota(vd.name, d, nil, next, func() error {
+			id, err = createContext(ctx, progress, d, dcReq.Locator, dcReq.Source, dcReq.Spec)
+			return err
+		})
+		return id, err
+	}
+	if isAsync(r) {
+		vd.submitJob(w, method, "", nil, create)
+		return
+	}
+	id, err := create(context.Background(), nil)
 	dcRes.VolumeResponse = &api.VolumeResponse{Error: responseStatus(err)}
 	dcRes.Id = id
 
 	vd.logRequest(method, id).Infoln("")
+	auditResource(w, id)
 
 	json.NewEncoder(w).Encode(&dcRes)
 }
 
+// swagger:operation POST /osd-volumes/placement volume placement placeVolume
+//
+// Returns the nodes a volume with given spec would be placed on, without
+// creating it.
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - name: spec
+//   in: body
+//   description: spec to place volume with
+//   required: true
+//   schema:
+//         "$ref": "#/definitions/VolumeCreateRequest"
+// responses:
+//   '200':
+//     description: placement decision with the score of every node
+func (vd *volAPI) placement(w http.ResponseWriter, r *http.Request) {
+	var req api.VolumeCreateRequest
+	method := "placement"
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	d, err := vd.getVolDriver(r)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+	inst, err := cluster.Inst()
+	if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	cl, err := inst.Enumerate()
+	if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	vols, err := d.Enumerate(&api.VolumeLocator{}, nil)
+	if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// The decision explains why the volume cannot be placed, so it is
+	// returned on failure too.
+	decision, _ := placement.Place(&placement.Request{
+		Spec:      req.Spec,
+		Locator:   req.Locator,
+		LocalNode: cl.NodeId,
+		Nodes:     cl.Nodes,
+		Volumes:   vols,
+	})
+	json.NewEncoder(w).Encode(decision)
+}
+
+// place sets the replica set of a new volume of the driver of vd, if it is
+// of global scope, to the nodes of the cluster picked by placement.Place.
+func (vd *volAPI) place(d volume.VolumeDriver, locator *api.VolumeLocator, spec *api.VolumeSpec) error {
+	inst, err := cluster.Inst()
+	if err != nil {
+		return nil
+	}
+	return volumedrivers.Place(vd.name, d, inst, locator, spec)
+}
+
 func processErrorForVolSetResponse(action *api.VolumeStateAction, err error, resp *api.VolumeSetResponse) {
 	if err == nil || resp == nil {
 		return
@@ -198,8 +290,37 @@ func (vd *volAPI) volumeSet(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if isAsync(r) {
+		vd.submitJob(w, method, volumeID, &req, func(context.Context, func(int)) (string, error) {
+			return "", volumeSet(vd.name, d, volumeID, &req)
+		})
+		return
+	}
+	err = volumeSet(vd.name, d, volumeID, &req)
+	if err != nil {
+		processErrorForVolSetResponse(req.Action, err, &resp)
+	} else {
+		v, err := d.Inspect([]string{volumeID})
+		if err != nil {
+			processErrorForVolSetResponse(req.Action, err, &resp)
+		} else if v == nil || len(v) != 1 {
+			processErrorForVolSetResponse(req.Action, &errors.ErrNotFound{Type: "Volume", ID: volumeID}, &resp)
+		} else {
+			v0 := v[0]
+			resp.Volume = v0
+		}
+	}
+
+	json.NewEncoder(w).Encode(resp)
+
+}
+
+// volumeSet applies the changes and actions of req to volume volumeID of
+// driver.
+func volumeSet(driver string, d volume.VolumeDriver, volumeID string, req *api.VolumeSetRequest) error {
+	var err error
 	if req.Locator != nil || req.Spec != nil {
-		err = d.Set(volumeID, req.Locator, req.Spec)
+		err = setQuotaEnforced(driver, d, volumeID, req.Locator, req.Spec)
 	}
 
 	for err == nil && req.Action != nil {
@@ -230,23 +351,7 @@ func (vd *volAPI) volumeSet(w http.ResponseWriter, r *http.Request) {
 		}
 		break
 	}
-
-	if err != nil {
-		processErrorForVolSetResponse(req.Action, err, &resp)
-	} else {
-		v, err := d.Inspect([]string{volumeID})
-		if err != nil {
-			processErrorForVolSetResponse(req.Action, err, &resp)
-		} else if v == nil || len(v) != 1 {
-			processErrorForVolSetResponse(req.Action, &errors.ErrNotFound{Type: "Volume", ID: volumeID}, &resp)
-		} else {
-			v0 := v[0]
-			resp.Volume = v0
-		}
-	}
-
-	json.NewEncoder(w).Encode(resp)
-
+	return err
 }
 
 // swagger:operation GET /osd-volumes/{id} volume inspect inspectVolume
@@ -334,6 +439,13 @@ func (vd *volAPI) delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if isAsync(r) {
+		vd.submitJob(w, method, volumeID, nil, func(context.Context, func(int)) (string, error) {
+			return "", d.Delete(volumeID)
+		})
+		return
+	}
+
 	volumeResponse := &api.VolumeResponse{}
 
 	if err := d.Delete(volumeID); err != nil {
@@ -482,8 +594,28 @@ func (vd *volAPI) snap(w http.ResponseWriter, r *http.Request) {
 	}
 
 	vd.logRequest(method, string(snapReq.Id)).Infoln("")
+	auditResource(w, snapReq.Id)
 
-	id, err := d.Snapshot(snapReq.Id, snapReq.Readonly, snapReq.Locator)
+	snapshot := func(ctx context.Context, progress func(int)) (id string, err error) {
+		next := &api.Volume{
+			Locator:  snapReq.Locator,
+			Source:   &api.Source{Parent: snapReq.Id},
+			Readonly: snapReq.Readonly,
+		}
+		err = enforceQuota(vd.name, d, nil, next, func() error {
+			id, err = snapshotContext(ctx, progress, d, snapReq.Id, snapReq.Readonly, snapReq.Locator)
+			return err
+		})
+		return id, err
+	}
+	if isAsync(r) {
+		vd.submitJob(w, method, snapReq.Id, nil, snapshot)
+		return
+	}
+	id, err := snapshot(context.Background(), nil)
+	if id != "" {
+		auditResource(w, id)
+	}
 	snapRes.VolumeCreateResponse = &api.VolumeCreateResponse{
 		Id: id,
 		VolumeResponse: &api.VolumeResponse{
@@ -493,6 +625,52 @@ func (vd *volAPI) snap(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(&snapRes)
 }
 
+// swagger:operation POST /osd-snapshots/group snapshot create createGroupSnap
+//
+// Take snapshots of the volumes in GroupSnapCreateRequest at the same point
+// in time.
+//
+// ---
+// produces:
+// - application/json
+// parameters:
+// - name: spec
+//   in: body
+//   description: group or volumes to snapshot
+//   required: true
+//   schema:
+//    "$ref": "#/definitions/GroupSnapCreateRequest"
+// responses:
+//    '200':
+//      description: the snapshot of every volume
+//      schema:
+//       "$ref": '#/definitions/GroupSnapCreateResponse'
+func (vd *volAPI) groupSnap(w http.ResponseWriter, r *http.Request) {
+	var snapReq api.GroupSnapCreateRequest
+	method := "groupSnap"
+
+	if err := json.NewDecoder(r.Body).Decode(&snapReq); err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	d, err := vd.getVolDriver(r)
+	if err != nil {
+		notFound(w, r)
+		return
+	}
+
+	vd.logRequest(method, snapReq.Group).Infoln("")
+
+	snapRes, err := common.GroupSnapshot(d, &snapReq,
+		func(next []*api.Volume, fn func() error) error {
+			return enforceQuotaCreate(vd.name, d, next, fn)
+		})
+	if err != nil {
+		snapRes = &api.GroupSnapCreateResponse{GroupSnapCreateErr: err.Error()}
+	}
+	json.NewEncoder(w).Encode(snapRes)
+}
+
 // swagger:operation POST /osd-snapshots/restore/{id} snapshot restore restoreSnap
 //
 // Restore snapshot with specified id.
@@ -542,6 +720,13 @@ func (vd *volAPI) restore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if isAsync(r) {
+		vd.submitJob(w, method, volumeID, snapID, func(ctx context.Context, progress func(int)) (string, error) {
+			return snapID, restoreContext(ctx, progress, d, volumeID, snapID)
+		})
+		return
+	}
+
 	volumeResponse := &api.VolumeResponse{}
 	if err := d.Restore(volumeID, snapID); err != nil {
 		volumeResponse.Error = responseStatus(err)
@@ -925,9 +1110,10 @@ func backupPath(route, version string) string {
 }
 
 func (vd *volAPI) Routes() []*Route {
-	return []*Route{
+	routes := []*Route{
 		{verb: "GET", path: "/" + api.OsdVolumePath + "/versions", fn: vd.versions},
 		{verb: "POST", path: volPath("", volume.APIVersion), fn: vd.create},
+		{verb: "POST", path: volPath("/placement", volume.APIVersion), fn: vd.placement},
 		{verb: "PUT", path: volPath("/{id}", volume.APIVersion), fn: vd.volumeSet},
 		{verb: "GET", path: volPath("", volume.APIVersion), fn: vd.enumerate},
 		{verb: "GET", path: volPath("/{id}", volume.APIVersion), fn: vd.inspect},
@@ -942,6 +1128,7 @@ func (vd *volAPI) Routes() []*Route {
 		{verb: "POST", path: volPath("/unquiesce/{id}", volume.APIVersion), fn: vd.unquiesce},
 		{verb: "POST", path: snapPath("", volume.APIVersion), fn: vd.snap},
 		{verb: "GET", path: snapPath("", volume.APIVersion), fn: vd.snapEnumerate},
+		{verb: "POST", path: snapPath("/group", volume.APIVersion), fn: vd.groupSnap},
 		{verb: "POST", path: snapPath("/restore/{id}", volume.APIVersion), fn: vd.restore},
 		{verb: "GET", path: credsPath("", volume.APIVersion), fn: vd.credsEnumerate},
 		{verb: "POST", path: credsPath("", volume.APIVersion), fn: vd.credsCreate},
@@ -959,4 +1146,7 @@ func (vd *volAPI) Routes() []*Route {
 		{verb: "POST", path: backupPath("/scheddelete", volume.APIVersion), fn: vd.backupscheddelete},
 		{verb: "GET", path: backupPath("/schedenumerate", volume.APIVersion), fn: vd.backupschedenumerate},
 	}
+	routes = append(routes, vd.jobRoutes()...)
+	routes = append(routes, vd.quotaRoutes()...)
+	return append(routes, vd.watchRoutes()...)
 }
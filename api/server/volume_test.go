@@ -50,6 +50,59 @@ func TestVolumeCreateSuccess(t *testing.T) {
 	assert.Equal(t, id, res)
 }
 
+func TestVolumeCreatePlacement(t *testing.T) {
+	ts, testVolDriver := testRestServer(t)
+	defer ts.Close()
+	defer testVolDriver.Stop()
+	tc := newTestClutser(t)
+	defer tc.Finish()
+
+	cl, err := volumeclient.NewDriverClient(ts.URL, mockDriverName, version, mockDriverName)
+	require.NoError(t, err)
+	driverclient := volumeclient.VolumeDriver(cl)
+
+	tc.MockCluster().
+		EXPECT().
+		Enumerate().
+		Return(api.Cluster{
+			NodeId: "node1",
+			Nodes: []api.Node{
+				{Id: "node1", Status: api.Status_STATUS_OK},
+				{Id: "node2", Status: api.Status_STATUS_OK},
+				{Id: "node3", Status: api.Status_STATUS_OFFLINE},
+			},
+		}, nil).
+		Times(2)
+
+	// Volumes without a replica set are placed on online nodes.
+	locator := &api.VolumeLocator{Name: "myvol"}
+	source := &api.Source{}
+	placed := &api.VolumeSpec{
+		Size:       1234,
+		HaLevel:    2,
+		ReplicaSet: &api.ReplicaSet{Nodes: []string{"node1", "node2"}},
+	}
+	testVolDriver.MockDriver().
+		EXPECT().
+		Create(locator, source, placed).
+		Return("myid", nil)
+	id, err := driverclient.Create(locator, source, &api.VolumeSpec{Size: 1234, HaLevel: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "myid", id)
+
+	// Volumes which cannot be placed are not created.
+	_, err = driverclient.Create(locator, source, &api.VolumeSpec{Size: 1234, HaLevel: 3})
+	assert.Error(t, err)
+
+	// Replica sets of the request are kept.
+	testVolDriver.MockDriver().
+		EXPECT().
+		Create(locator, source, placed).
+		Return("myid", nil)
+	_, err = driverclient.Create(locator, source, placed)
+	require.NoError(t, err)
+}
+
 func TestVolumeCreateFailed(t *testing.T) {
 
 	var err error
@@ -1447,3 +1500,93 @@ func TestCredsValidateFailed(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "error in creds validate")
 }
+
+func TestVolumePlacement(t *testing.T) {
+	ts, testVolDriver := testRestServer(t)
+	defer ts.Close()
+	defer testVolDriver.Stop()
+	tc := newTestClutser(t)
+	defer tc.Finish()
+
+	cl, err := volumeclient.NewDriverClient(ts.URL, mockDriverName, version, mockDriverName)
+	require.NoError(t, err)
+
+	tc.MockCluster().
+		EXPECT().
+		Enumerate().
+		Return(api.Cluster{
+			NodeId: "node1",
+			Nodes: []api.Node{
+				{Id: "node1", Status: api.Status_STATUS_OK},
+				{Id: "node2", Status: api.Status_STATUS_OK},
+				{Id: "node3", Status: api.Status_STATUS_OFFLINE},
+			},
+		}, nil).
+		Times(2)
+	testVolDriver.MockDriver().
+		EXPECT().
+		Enumerate(&api.VolumeLocator{}, nil).
+		Return(nil, nil).
+		Times(2)
+
+	decision, err := volumeclient.Placement(cl, &api.VolumeCreateRequest{
+		Locator: &api.VolumeLocator{Name: "myvol"},
+		Spec:    &api.VolumeSpec{HaLevel: 2},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"node1", "node2"}, decision.ReplicaSet.Nodes)
+	assert.Empty(t, decision.Error)
+
+	decision, err = volumeclient.Placement(cl, &api.VolumeCreateRequest{
+		Locator: &api.VolumeLocator{Name: "myvol"},
+		Spec:    &api.VolumeSpec{HaLevel: 3},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, decision.Error)
+	require.Len(t, decision.Candidates, 3)
+	assert.Equal(t, "node is offline", decision.Candidates[2].Reason)
+}
+
+func TestVolumeGroupSnapshot(t *testing.T) {
+	ts, testVolDriver := testRestServer(t)
+	defer ts.Close()
+	defer testVolDriver.Stop()
+
+	cl, err := volumeclient.NewDriverClient(ts.URL, mockDriverName, version, mockDriverName)
+	require.NoError(t, err)
+
+	ids := []string{"vol1"}
+	testVolDriver.MockDriver().
+		EXPECT().
+		Inspect(ids).
+		Return([]*api.Volume{{Id: "vol1", Locator: &api.VolumeLocator{Name: "one"}}}, nil).
+		Times(2)
+	testVolDriver.MockDriver().
+		EXPECT().
+		Quiesce("vol1", gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(2)
+	testVolDriver.MockDriver().
+		EXPECT().
+		Unquiesce("vol1").
+		Return(nil).
+		Times(2)
+	gomock.InOrder(
+		testVolDriver.MockDriver().
+			EXPECT().
+			Snapshot("vol1", false, gomock.Any()).
+			Return("snap1", nil),
+		testVolDriver.MockDriver().
+			EXPECT().
+			Snapshot("vol1", false, gomock.Any()).
+			Return("", fmt.Errorf("no space")),
+	)
+
+	res, err := volumeclient.GroupSnapshot(cl, &api.GroupSnapCreateRequest{Ids: ids})
+	require.NoError(t, err)
+	assert.NotEmpty(t, res.Id)
+	assert.Equal(t, map[string]string{"vol1": "snap1"}, res.Snapshots)
+
+	_, err = volumeclient.GroupSnapshot(cl, &api.GroupSnapCreateRequest{Ids: ids})
+	assert.Contains(t, err.Error(), "no space")
+}
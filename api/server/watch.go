@@ -0,0 +1,131 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/watch"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+// watchKeepalive is the interval comments are sent at on idle watches so
+// that proxies do not close them.
+var watchKeepalive = 15 * time.Second
+
+// swagger:operation GET /osd-watch watch events watchEvents
+//
+// Stream the changes of the volumes of the driver and of the nodes as
+// server-sent events. The id of every event is its revision, which
+// clients pass in the revision parameter or the Last-Event-ID header to
+// resume watching after it.
+//
+// ---
+// produces:
+// - text/event-stream
+// parameters:
+// - name: revision
+//   in: query
+//   description: revision to resume after, new events only if not set
+//   required: false
+//   type: integer
+// - name: type
+//   in: query
+//   description: comma separated event type prefixes, e.g. volume,node.down
+//   required: false
+//   type: string
+// - name: id
+//   in: query
+//   description: ID of the volume or node to watch
+//   required: false
+//   type: string
+// responses:
+//   '200':
+//     description: stream of events
+//   '410':
+//     description: events after revision are no longer available
+func (vd *volAPI) watch(w http.ResponseWriter, r *http.Request) {
+	method := "watch"
+	b := watch.Instance()
+	if b == nil {
+		vd.sendError(vd.name, method, w, "Watch is not enabled", http.StatusServiceUnavailable)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		vd.sendError(vd.name, method, w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	params := r.URL.Query()
+	rev := params.Get("revision")
+	if rev == "" {
+		rev = r.Header.Get("Last-Event-ID")
+	}
+	revision := uint64(0)
+	if rev != "" {
+		var err error
+		if revision, err = strconv.ParseUint(rev, 10, 64); err != nil {
+			vd.sendError(vd.name, method, w, "Invalid revision "+rev, http.StatusBadRequest)
+			return
+		}
+	}
+	filter := &watch.Filter{Driver: vd.name, Id: params.Get("id")}
+	if t := params.Get("type"); t != "" {
+		filter.Types = strings.Split(t, ",")
+	}
+
+	watcher, err := b.Watch(revision, filter)
+	if err == watch.ErrCompacted {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusGone)
+		return
+	} else if err != nil {
+		vd.sendError(vd.name, method, w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer watcher.Stop()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepalive := time.NewTicker(watchKeepalive)
+	defer keepalive.Stop()
+	for {
+		select {
+		case e, ok := <-watcher.Events():
+			if !ok {
+				if err := watcher.Err(); err != nil {
+					fmt.Fprintf(w, "event: error\ndata: %s\n\n", err)
+				}
+				return
+			}
+			data, err := json.Marshal(e)
+			if err != nil {
+				vd.logRequest(method, "").Warnf("Failed to encode event %v: %v", e.Revision, err)
+				continue
+			}
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.Revision, e.Type, data)
+		case <-keepalive.C:
+			fmt.Fprint(w, ": keepalive\n\n")
+		case <-r.Context().Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+func watchPath(route, version string) string {
+	return volVersion(api.OsdWatchPath+route, version)
+}
+
+func (vd *volAPI) watchRoutes() []*Route {
+	return []*Route{
+		{verb: "GET", path: watchPath("", volume.APIVersion), fn: vd.watch},
+	}
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+	volumeclient "github.com/libopenstorage/openstorage/api/client/volume"
+	"github.com/libopenstorage/openstorage/pkg/watch"
+)
+
+func TestVolumeWatch(t *testing.T) {
+	ts, testVolDriver := testRestServer(t)
+	defer ts.Close()
+	defer testVolDriver.Stop()
+
+	cl, err := volumeclient.NewDriverClient(ts.URL, mockDriverName, version, mockDriverName)
+	require.NoError(t, err)
+
+	// Watching is refused until enabled.
+	err = volumeclient.Watch(cl, 0, nil, func(*watch.Event) error { return nil })
+	assert.Error(t, err)
+
+	b := watch.NewBroker(2)
+	watch.SetInstance(b)
+	defer watch.SetInstance(nil)
+
+	start := b.Revision()
+	b.Publish(&watch.Event{Type: watch.EventVolumeCreate, Driver: "other", Volume: &api.Volume{Id: "vol0"}})
+	b.Publish(&watch.Event{Type: watch.EventVolumeCreate, Driver: mockDriverName, Volume: &api.Volume{Id: "vol1"}})
+	b.Publish(&watch.Event{Type: watch.EventNodeDown, Node: &api.Node{Id: "node1"}})
+
+	// Events of other drivers and types are filtered out of the replay.
+	done := errors.New("done")
+	var events []*watch.Event
+	err = volumeclient.Watch(cl, start+1, []string{"volume"}, func(e *watch.Event) error {
+		events = append(events, e)
+		return done
+	})
+	assert.Equal(t, done, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, start+2, events[0].Revision)
+	assert.Equal(t, "vol1", events[0].Volume.Id)
+
+	events = nil
+	err = volumeclient.Watch(cl, start+2, []string{"node"}, func(e *watch.Event) error {
+		events = append(events, e)
+		return done
+	})
+	assert.Equal(t, done, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, watch.EventNodeDown, events[0].Type)
+
+	err = volumeclient.Watch(cl, start, nil, func(*watch.Event) error { return nil })
+	assert.Equal(t, watch.ErrCompacted, err)
+}
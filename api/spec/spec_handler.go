@@ -90,6 +90,7 @@ var (
 	nfsRegex        = regexp.MustCompile(api.SpecNfs + "=([A-Za-z]+),?")
 	cascadedRegex   = regexp.MustCompile(api.SpecCascaded + "=([A-Za-z]+),?")
 	passphraseRegex = regexp.MustCompile(api.SpecPassphrase + "=([0-9A-Za-z_@./#&+-]+),?")
+	secretNameRegex = regexp.MustCompile(api.SpecSecretName + "=([0-9A-Za-z_./-]+),?")
 	stickyRegex     = regexp.MustCompile(api.SpecSticky + "=([A-Za-z]+),?")
 	secureRegex     = regexp.MustCompile(api.SpecSecure + "=([A-Za-z]+),?")
 	zonesRegex      = regexp.MustCompile(api.SpecZones + "=([A-Za-z]+),?")
@@ -272,6 +273,9 @@ func (d *specHandler) UpdateSpecFromOpts(opts map[string]string, spec *api.Volum
 		case api.SpecPassphrase:
 			spec.Encrypted = true
 			spec.Passphrase = v
+		case api.SpecSecretName:
+			spec.Encrypted = true
+			spec.SecretName = v
 		case api.SpecGroup:
 			spec.Group = &api.Group{Id: v}
 		case api.SpecGroupEnforce:
@@ -379,6 +383,9 @@ func (d *specHandler) SpecOptsFromString(
 	if ok, passphrase := d.getVal(passphraseRegex, str); ok {
 		opts[api.SpecPassphrase] = passphrase
 	}
+	if ok, secretName := d.getVal(secretNameRegex, str); ok {
+		opts[api.SpecSecretName] = secretName
+	}
 	if ok, zones := d.getVal(zonesRegex, str); ok {
 		opts[api.SpecZones] = zones
 	}
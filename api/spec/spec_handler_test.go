@@ -54,3 +54,23 @@ func TestOptIoProfile(t *testing.T) {
 
 	testSpecFromStringErr(t, api.SpecIoProfile, "2")
 }
+
+func TestOptHaLevel(t *testing.T) {
+	s := NewSpecHandler()
+	spec, _, _, err := s.SpecFromOpts(map[string]string{api.SpecHaLevel: "2"})
+	require.NoError(t, err, "Failed to parse ha_level option")
+	require.Equal(t, int64(2), spec.HaLevel, "Unexpected ha_level value")
+
+	// Invalid values are ignored, the docker plugin rejects them.
+	spec, _, _, err = s.SpecFromOpts(map[string]string{api.SpecHaLevel: "two"})
+	require.NoError(t, err, "Failed to parse ha_level option")
+	require.Equal(t, int64(0), spec.HaLevel, "Unexpected ha_level value")
+}
+
+func TestOptSecretName(t *testing.T) {
+	testSpecOptString(t, api.SpecSecretName, "osd/vol1-key")
+
+	spec := testSpecFromString(t, api.SpecSecretName, "osd/vol1-key")
+	require.True(t, spec.Encrypted, "Failed to parse secret_name option into spec")
+	require.Equal(t, "osd/vol1-key", spec.SecretName, "Unexpected secret_name value")
+}
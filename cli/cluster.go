@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"text/tabwriter"
+	"time"
 
 	humanize "github.com/dustin/go-humanize"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/libopenstorage/openstorage/api"
 	clusterclient "github.com/libopenstorage/openstorage/api/client/cluster"
 	"github.com/libopenstorage/openstorage/cluster"
+	"github.com/libopenstorage/openstorage/pkg/audit"
 )
 
 type clusterClient struct {
@@ -143,6 +145,59 @@ func (c *clusterClient) gossipStatus(context *cli.Context) {
 	}
 }
 
+func (c *clusterClient) audit(context *cli.Context) {
+	fn := "audit"
+	clnt, err := clusterclient.NewClusterClient("", cluster.APIVersion)
+	if err != nil {
+		cmdError(context, fn, err)
+		return
+	}
+
+	filter := &audit.Filter{Resource: context.String("resource")}
+	if filter.Start, err = parseAuditTime(context.String("start")); err != nil {
+		cmdError(context, fn, err)
+		return
+	}
+	if filter.End, err = parseAuditTime(context.String("end")); err != nil {
+		cmdError(context, fn, err)
+		return
+	}
+	records, err := clusterclient.EnumerateAudit(clnt, filter)
+	if err != nil {
+		cmdError(context, fn, err)
+		return
+	}
+
+	if context.GlobalBool("json") {
+		fmtOutput(context, &Format{Result: records})
+		return
+	}
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 12, 12, 1, ' ', 0)
+	fmt.Fprintln(w, "TIME	 CALLER	 SERVICE	 VERB	 RESOURCE	 RESULT	 LATENCY")
+	for _, r := range records {
+		fmt.Fprintln(w, r.Time.Format(time.RFC3339), "\t", r.Caller, "\t", r.Service, "\t",
+			r.Verb, "\t", r.Resource, "\t", r.Result, "\t", r.Latency)
+	}
+	fmt.Fprintln(w)
+	w.Flush()
+}
+
+// parseAuditTime parses a RFC3339 time, or a duration before now.
+func parseAuditTime(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(v); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid time %q, expected RFC3339 or a duration", v)
+	}
+	return t, nil
+}
+
 // ClusterCommands exports CLI comamnds for File VolumeDriver
 func ClusterCommands() []cli.Command {
 	c := &clusterClient{}
@@ -217,6 +272,25 @@ func ClusterCommands() []cli.Command {
 				},
 			},
 		},
+		{
+			Name:   "audit",
+			Usage:  "List the audit records of control plane requests",
+			Action: c.audit,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "start",
+					Usage: "Only records after this RFC3339 time or duration ago, e.g 24h",
+				},
+				cli.StringFlag{
+					Name:  "end",
+					Usage: "Only records before this RFC3339 time or duration ago",
+				},
+				cli.StringFlag{
+					Name:  "resource,r",
+					Usage: "Only records of this volume, node or other resource ID",
+				},
+			},
+		},
 	}
 	return commands
 }
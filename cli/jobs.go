@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/codegangsta/cli"
+	"github.com/libopenstorage/openstorage/api/client"
+	volumeclient "github.com/libopenstorage/openstorage/api/client/volume"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+type jobClient struct {
+	client *client.Client
+}
+
+func (j *jobClient) jobOptions(context *cli.Context) {
+	driver := context.String("driver")
+	if driver == "" {
+		missingParameter(context, "jobs", "driver", "Driver whose API serves the jobs")
+		os.Exit(1)
+	}
+	clnt, err := volumeclient.NewDriverClient("", driver, volume.APIVersion, "")
+	if err != nil {
+		fmt.Printf("Failed to initialize client library: %v\n", err)
+		os.Exit(1)
+	}
+	j.client = clnt
+}
+
+func (j *jobClient) list(context *cli.Context) {
+	fn := "list"
+	j.jobOptions(context)
+	all, err := volumeclient.JobEnumerate(j.client, context.String("state"))
+	if err != nil {
+		cmdError(context, fn, err)
+		return
+	}
+
+	if context.GlobalBool("json") {
+		fmtOutput(context, &Format{Result: all})
+		return
+	}
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 12, 12, 1, ' ', 0)
+	fmt.Fprintln(w, "ID	 TYPE	 DRIVER	 RESOURCE	 NODE	 STATE	 PROGRESS	 CREATED	 RESULT")
+	for _, job := range all {
+		result := job.Result
+		if job.Error != "" {
+			result = job.Error
+		}
+		fmt.Fprintln(w, job.Id, "\t", job.Type, "\t", job.Driver, "\t", job.Resource, "\t",
+			job.Node, "\t", job.State, "\t", fmt.Sprintf("%d%%", job.Progress), "\t",
+			job.Created.Format(time.RFC3339), "\t", result)
+	}
+	fmt.Fprintln(w)
+	w.Flush()
+}
+
+func (j *jobClient) inspect(context *cli.Context) {
+	fn := "inspect"
+	if len(context.Args()) != 1 {
+		missingParameter(context, fn, "jobID", "Invalid number of arguments")
+		return
+	}
+	j.jobOptions(context)
+	job, err := volumeclient.JobInspect(j.client, context.Args()[0])
+	if err != nil {
+		cmdError(context, fn, err)
+		return
+	}
+	fmtOutput(context, &Format{Result: job})
+}
+
+func (j *jobClient) cancel(context *cli.Context) {
+	fn := "cancel"
+	if len(context.Args()) != 1 {
+		missingParameter(context, fn, "jobID", "Invalid number of arguments")
+		return
+	}
+	j.jobOptions(context)
+	if err := volumeclient.JobCancel(j.client, context.Args()[0]); err != nil {
+		cmdError(context, fn, err)
+		return
+	}
+	fmtOutput(context, &Format{UUID: []string{context.Args()[0]}})
+}
+
+// JobCommands exports CLI comamnds for the jobs running volume operations
+// in the background.
+func JobCommands() []cli.Command {
+	j := &jobClient{}
+	driverFlag := cli.StringFlag{
+		Name:  "driver,d",
+		Usage: "volume driver whose API is queried, any driver lists the jobs of all drivers",
+	}
+
+	commands := []cli.Command{
+		{
+			Name:    "list",
+			Aliases: []string{"l"},
+			Usage:   "List jobs",
+			Action:  j.list,
+			Flags: []cli.Flag{
+				driverFlag,
+				cli.StringFlag{
+					Name:  "state",
+					Usage: "only list jobs in state: pending|running|done|failed|cancelled",
+				},
+			},
+		},
+		{
+			Name:    "inspect",
+			Aliases: []string{"i"},
+			Usage:   "Inspect a job",
+			Action:  j.inspect,
+			Flags:   []cli.Flag{driverFlag},
+		},
+		{
+			Name:    "cancel",
+			Aliases: []string{"c"},
+			Usage:   "Cancel a pending or running job",
+			Action:  j.cancel,
+			Flags:   []cli.Flag{driverFlag},
+		},
+	}
+	return commands
+}
@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/codegangsta/cli"
+	"github.com/libopenstorage/openstorage/api/client"
+	volumeclient "github.com/libopenstorage/openstorage/api/client/volume"
+	"github.com/libopenstorage/openstorage/pkg/quota"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+type quotaClient struct {
+	client *client.Client
+}
+
+func (q *quotaClient) quotaOptions(context *cli.Context) {
+	driver := context.String("driver")
+	if driver == "" {
+		missingParameter(context, "quotas", "driver", "Driver whose volumes the usage is of")
+		os.Exit(1)
+	}
+	clnt, err := volumeclient.NewDriverClient("", driver, volume.APIVersion, "")
+	if err != nil {
+		fmt.Printf("Failed to initialize client library: %v\n", err)
+		os.Exit(1)
+	}
+	q.client = clnt
+}
+
+func (q *quotaClient) list(context *cli.Context) {
+	fn := "list"
+	q.quotaOptions(context)
+	all, err := volumeclient.QuotaEnumerate(q.client)
+	if err != nil {
+		cmdError(context, fn, err)
+		return
+	}
+
+	if context.GlobalBool("json") {
+		fmtOutput(context, &Format{Result: all})
+		return
+	}
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 12, 12, 1, ' ', 0)
+	fmt.Fprintln(w, "NAME	 SELECTOR	 SIZE (MB)	 VOLUMES	 SNAPSHOTS")
+	for _, s := range all {
+		fmt.Fprintln(w, s.Quota.Name, "\t", formatSelector(s.Quota.Selector), "\t",
+			formatUsage(s.Usage.Size/uint64(MiB), s.Quota.MaxSize/uint64(MiB)), "\t",
+			formatUsage(s.Usage.Volumes, s.Quota.MaxVolumes), "\t",
+			formatUsage(s.Usage.Snapshots, s.Quota.MaxSnapshots))
+	}
+	fmt.Fprintln(w)
+	w.Flush()
+}
+
+func (q *quotaClient) inspect(context *cli.Context) {
+	fn := "inspect"
+	if len(context.Args()) != 1 {
+		missingParameter(context, fn, "name", "Invalid number of arguments")
+		return
+	}
+	q.quotaOptions(context)
+	status, err := volumeclient.QuotaInspect(q.client, context.Args()[0])
+	if err != nil {
+		cmdError(context, fn, err)
+		return
+	}
+	fmtOutput(context, &Format{Result: status})
+}
+
+func (q *quotaClient) set(context *cli.Context) {
+	fn := "set"
+	if len(context.Args()) != 1 {
+		missingParameter(context, fn, "name", "Invalid number of arguments")
+		return
+	}
+	q.quotaOptions(context)
+	selector := make(map[string]string)
+	if l := context.String("selector"); l != "" {
+		var err error
+		if selector, err = processLabels(l); err != nil {
+			cmdError(context, fn, err)
+			return
+		}
+	}
+	qt := &quota.Quota{
+		Name:         context.Args()[0],
+		Selector:     selector,
+		MaxSize:      uint64(VolumeSzUnits(context.Int("size")) * MiB),
+		MaxVolumes:   uint64(context.Int("volumes")),
+		MaxSnapshots: uint64(context.Int("snapshots")),
+	}
+	if err := volumeclient.QuotaSet(q.client, qt); err != nil {
+		cmdError(context, fn, err)
+		return
+	}
+	fmtOutput(context, &Format{UUID: []string{qt.Name}})
+}
+
+func (q *quotaClient) delete(context *cli.Context) {
+	fn := "delete"
+	if len(context.Args()) != 1 {
+		missingParameter(context, fn, "name", "Invalid number of arguments")
+		return
+	}
+	q.quotaOptions(context)
+	if err := volumeclient.QuotaDelete(q.client, context.Args()[0]); err != nil {
+		cmdError(context, fn, err)
+		return
+	}
+	fmtOutput(context, &Format{UUID: []string{context.Args()[0]}})
+}
+
+func formatSelector(selector map[string]string) string {
+	if len(selector) == 0 {
+		return "*"
+	}
+	labels := make([]string, 0, len(selector))
+	for k, v := range selector {
+		labels = append(labels, k+"="+v)
+	}
+	sort.Strings(labels)
+	return strings.Join(labels, ",")
+}
+
+func formatUsage(used, limit uint64) string {
+	if limit == 0 {
+		return fmt.Sprintf("%d", used)
+	}
+	return fmt.Sprintf("%d/%d", used, limit)
+}
+
+// QuotaCommands exports CLI comamnds for the quotas limiting the volumes
+// selected by their labels.
+func QuotaCommands() []cli.Command {
+	q := &quotaClient{}
+	driverFlag := cli.StringFlag{
+		Name:  "driver,d",
+		Usage: "volume driver whose volumes the usage is of",
+	}
+
+	commands := []cli.Command{
+		{
+			Name:    "list",
+			Aliases: []string{"l"},
+			Usage:   "List quotas and their usage",
+			Action:  q.list,
+			Flags:   []cli.Flag{driverFlag},
+		},
+		{
+			Name:    "inspect",
+			Aliases: []string{"i"},
+			Usage:   "Inspect a quota and its usage",
+			Action:  q.inspect,
+			Flags:   []cli.Flag{driverFlag},
+		},
+		{
+			Name:    "set",
+			Aliases: []string{"s"},
+			Usage:   "Create or replace a quota",
+			Action:  q.set,
+			Flags: []cli.Flag{
+				driverFlag,
+				cli.StringFlag{
+					Name:  "selector,l",
+					Usage: "Comma separated name=value labels of the volumes, e.g namespace=foo, all volumes if not set",
+				},
+				cli.IntFlag{
+					Name:  "size,s",
+					Usage: "total size of the volumes in MB, unlimited if 0",
+				},
+				cli.IntFlag{
+					Name:  "volumes",
+					Usage: "number of volumes, unlimited if 0",
+				},
+				cli.IntFlag{
+					Name:  "snapshots",
+					Usage: "number of snapshots, unlimited if 0",
+				},
+			},
+		},
+		{
+			Name:    "delete",
+			Aliases: []string{"d"},
+			Usage:   "Delete a quota",
+			Action:  q.delete,
+			Flags:   []cli.Flag{driverFlag},
+		},
+	}
+	return commands
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/codegangsta/cli"
 	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/api/client"
 	clusterclient "github.com/libopenstorage/openstorage/api/client/cluster"
 	volumeclient "github.com/libopenstorage/openstorage/api/client/volume"
 	"github.com/libopenstorage/openstorage/cluster"
@@ -34,6 +35,7 @@ const (
 
 type volDriver struct {
 	volDriver volume.VolumeDriver
+	client    *client.Client
 	name      string
 }
 
@@ -60,6 +62,7 @@ func (v *volDriver) volumeOptions(context *cli.Context) {
 		fmt.Printf("Failed to initialize client library: %v\n", err)
 		os.Exit(1)
 	}
+	v.client = clnt
 	v.volDriver = volumeclient.VolumeDriver(clnt)
 }
 
@@ -107,6 +110,15 @@ func (v *volDriver) volumeCreate(context *cli.Context) {
 	source := &api.Source{
 		Seed: context.String("seed"),
 	}
+	if context.Bool("async") {
+		job, err := volumeclient.CreateAsync(v.client, locator, source, spec)
+		if err != nil {
+			cmdError(context, fn, err)
+			return
+		}
+		fmtOutput(context, &Format{Result: job})
+		return
+	}
 	if id, err = v.volDriver.Create(locator, source, spec); err != nil {
 		cmdError(context, fn, err)
 		return
@@ -199,6 +211,41 @@ func (v *volDriver) volumeDetach(context *cli.Context) {
 	fmtOutput(context, &Format{UUID: []string{context.Args()[0]}})
 }
 
+func (v *volDriver) volumeResize(context *cli.Context) {
+	fn := "resize"
+	if len(context.Args()) != 1 {
+		missingParameter(context, fn, "volumeID", "Invalid number of arguments")
+		return
+	}
+	volumeID := context.Args()[0]
+
+	size := context.Int("size")
+	if size <= 0 {
+		missingParameter(context, fn, "size", "New size in MB")
+		return
+	}
+
+	v.volumeOptions(context)
+	vols, err := v.volDriver.Inspect([]string{volumeID})
+	if err != nil {
+		cmdError(context, fn, err)
+		return
+	}
+	if len(vols) != 1 || vols[0].Spec == nil {
+		cmdError(context, fn, fmt.Errorf("Volume %v not found", volumeID))
+		return
+	}
+	// Drivers only accept the spec of the volume with a new size.
+	spec := vols[0].Spec
+	spec.Size = uint64(VolumeSzUnits(size) * MiB)
+	if err := v.volDriver.Set(volumeID, nil, spec); err != nil {
+		cmdError(context, fn, err)
+		return
+	}
+
+	fmtOutput(context, &Format{UUID: []string{volumeID}})
+}
+
 func (v *volDriver) volumeInspect(context *cli.Context) {
 	v.volumeOptions(context)
 	fn := "inspect"
@@ -269,6 +316,15 @@ func (v *volDriver) volumeDelete(context *cli.Context) {
 	}
 	volumeID := context.Args()[0]
 	v.volumeOptions(context)
+	if context.Bool("async") {
+		job, err := volumeclient.DeleteAsync(v.client, volumeID)
+		if err != nil {
+			cmdError(context, fn, err)
+			return
+		}
+		fmtOutput(context, &Format{Result: job})
+		return
+	}
 	err := v.volDriver.Delete(volumeID)
 	if err != nil {
 		cmdError(context, fn, err)
@@ -301,6 +357,15 @@ func (v *volDriver) snapCreate(context *cli.Context) {
 		VolumeLabels: labels,
 	}
 	readonly := context.Bool("readonly")
+	if context.Bool("async") {
+		job, err := volumeclient.SnapshotAsync(v.client, volumeID, readonly, locator)
+		if err != nil {
+			cmdError(context, fn, err)
+			return
+		}
+		fmtOutput(context, &Format{Result: job})
+		return
+	}
 	id, err := v.volDriver.Snapshot(volumeID, readonly, locator)
 	if err != nil {
 		cmdError(context, fn, err)
@@ -310,6 +375,37 @@ func (v *volDriver) snapCreate(context *cli.Context) {
 	fmtOutput(context, &Format{UUID: []string{string(id)}})
 }
 
+func (v *volDriver) snapGroup(context *cli.Context) {
+	var err error
+	fn := "snapGroup"
+
+	req := &api.GroupSnapCreateRequest{
+		Group:             context.String("group"),
+		Ids:               context.Args(),
+		Readonly:          context.Bool("readonly"),
+		QuiesceTimeoutSec: uint64(context.Int("timeout")),
+	}
+	if req.Group == "" && len(req.Ids) == 0 {
+		missingParameter(context, fn, "group", "Group or volume IDs required")
+		return
+	}
+
+	v.volumeOptions(context)
+	if l := context.String("label"); l != "" {
+		if req.Labels, err = processLabels(l); err != nil {
+			cmdError(context, fn, err)
+			return
+		}
+	}
+	res, err := volumeclient.GroupSnapshot(v.client, req)
+	if err != nil {
+		cmdError(context, fn, err)
+		return
+	}
+
+	fmtOutput(context, &Format{Result: res})
+}
+
 func (v *volDriver) snapEnumerate(context *cli.Context) {
 	locator := &api.VolumeLocator{}
 	var err error
@@ -404,6 +500,10 @@ func baseVolumeCommand(v *volDriver) []cli.Command {
 					Usage: "snapshot interval in minutes, 0 disables snaps",
 					Value: 0,
 				},
+				cli.BoolFlag{
+					Name:  "async",
+					Usage: "return a job ID instead of waiting for the operation",
+				},
 			},
 		},
 		{
@@ -435,6 +535,12 @@ func baseVolumeCommand(v *volDriver) []cli.Command {
 			Aliases: []string{"rm"},
 			Usage:   "Detach specified volume",
 			Action:  v.volumeDelete,
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "async",
+					Usage: "return a job ID instead of waiting for the operation",
+				},
+			},
 		},
 		{
 			Name:    "enumerate",
@@ -458,6 +564,17 @@ func baseVolumeCommand(v *volDriver) []cli.Command {
 			Usage:   "Inspect volume",
 			Action:  v.volumeInspect,
 		},
+		{
+			Name:   "resize",
+			Usage:  "Grow volume to the specified size",
+			Action: v.volumeResize,
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  "size,s",
+					Usage: "new size in MB",
+				},
+			},
+		},
 		{
 			Name:   "alerts",
 			Usage:  "Enumerate volume alerts",
@@ -486,6 +603,35 @@ func baseVolumeCommand(v *volDriver) []cli.Command {
 					Name:  "readonly",
 					Usage: "true if snapshot is readonly",
 				},
+				cli.BoolFlag{
+					Name:  "async",
+					Usage: "return a job ID instead of waiting for the operation",
+				},
+			},
+		},
+		{
+			Name:    "snapGroup",
+			Aliases: []string{"sg"},
+			Usage:   "create consistent snaps of a group or of the given volume IDs",
+			Action:  v.snapGroup,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "group,g",
+					Usage: "group whose volumes are snapped",
+				},
+				cli.StringFlag{
+					Name:  "label,l",
+					Usage: "Comma separated name=value pairs, e.g name=sqlvolume,type=production",
+				},
+				cli.BoolFlag{
+					Name:  "readonly",
+					Usage: "true if snapshots are readonly",
+				},
+				cli.IntFlag{
+					Name:  "timeout,t",
+					Usage: "seconds the volumes may stay quiesced",
+					Value: 0,
+				},
 			},
 		},
 		{
@@ -27,11 +27,14 @@
 package main
 
 import (
+	"crypto/tls"
 	"fmt"
+	"io/ioutil"
 	"net/url"
 	"os"
 	"runtime"
 	"strconv"
+	"time"
 
 	"go.pedge.io/dlog"
 
@@ -40,13 +43,22 @@ import (
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/api/flexvolume"
 	"github.com/libopenstorage/openstorage/api/server"
+	"github.com/libopenstorage/openstorage/api/server/sdk"
 	osdcli "github.com/libopenstorage/openstorage/cli"
 	"github.com/libopenstorage/openstorage/cluster"
 	"github.com/libopenstorage/openstorage/config"
 	"github.com/libopenstorage/openstorage/csi"
 	"github.com/libopenstorage/openstorage/graph/drivers"
+	"github.com/libopenstorage/openstorage/pkg/audit"
+	osdauth "github.com/libopenstorage/openstorage/pkg/auth"
+	"github.com/libopenstorage/openstorage/pkg/jobs"
+	"github.com/libopenstorage/openstorage/pkg/quota"
+	"github.com/libopenstorage/openstorage/pkg/sched"
+	"github.com/libopenstorage/openstorage/pkg/tlsutil"
+	"github.com/libopenstorage/openstorage/pkg/watch"
 	"github.com/libopenstorage/openstorage/volume"
 	"github.com/libopenstorage/openstorage/volume/drivers"
+	"github.com/libopenstorage/openstorage/volume/snapsched"
 	"github.com/portworx/kvdb"
 	"github.com/portworx/kvdb/consul"
 	etcd "github.com/portworx/kvdb/etcd/v2"
@@ -89,6 +101,30 @@ func main() {
 			Usage: "file to read the OSD configuration from.",
 			Value: "",
 		},
+		cli.StringFlag{
+			Name:  "tls-cert",
+			Usage: "PEM encoded certificate the REST API ports are served with over TLS",
+		},
+		cli.StringFlag{
+			Name:  "tls-key",
+			Usage: "PEM encoded private key of the TLS certificate",
+		},
+		cli.StringFlag{
+			Name:  "tls-ca",
+			Usage: "PEM encoded CA certificates client certificates are verified with",
+		},
+		cli.BoolFlag{
+			Name:  "tls-client-auth",
+			Usage: "reject clients without a certificate signed by the TLS CA",
+		},
+		cli.BoolFlag{
+			Name:  "tls-csi",
+			Usage: "also serve the CSI endpoints over TLS",
+		},
+		cli.BoolFlag{
+			Name:  "managed-plugin",
+			Usage: "serve the Docker volume plugin API of the default driver as a managed plugin",
+		},
 	}
 	app.Action = wrapAction(start)
 	app.Commands = []cli.Command{
@@ -104,6 +140,18 @@ func main() {
 			Usage:       "Manage cluster",
 			Subcommands: osdcli.ClusterCommands(),
 		},
+		{
+			Name:        "jobs",
+			Aliases:     []string{"j"},
+			Usage:       "Manage volume operations running in the background",
+			Subcommands: osdcli.JobCommands(),
+		},
+		{
+			Name:        "quotas",
+			Aliases:     []string{"q"},
+			Usage:       "Manage the quotas of volumes selected by their labels",
+			Subcommands: osdcli.QuotaCommands(),
+		},
 		{
 			Name:    "version",
 			Aliases: []string{"v"},
@@ -184,6 +232,47 @@ func start(c *cli.Context) error {
 		return fmt.Errorf("Failed to initialize KVDB: %v", err)
 	}
 
+	// Authenticate API requests before any server is started.
+	var authenticator osdauth.Authenticator
+	var authTrustLocal bool
+	if auth := cfg.Osd.Auth; auth != nil {
+		key, err := ioutil.ReadFile(auth.KeyFile)
+		if err != nil {
+			return fmt.Errorf("Unable to read token issuer key: %v", err)
+		}
+		authenticator, err = osdauth.NewJwtAuthenticator(auth.Issuer, key)
+		if err != nil {
+			return fmt.Errorf("Unable to init token authentication: %v", err)
+		}
+		authTrustLocal = auth.TrustLocal
+		server.EnableAuth(authenticator, authTrustLocal)
+	}
+
+	// Secure the API servers before any is started.
+	var tlsConfig, restTLSConfig *tls.Config
+	if tlsCfg := tlsFlags(c, cfg.Osd.TLS); tlsCfg != nil {
+		reloader, err := tlsutil.NewReloader(&tlsutil.Config{
+			CertFile:   tlsCfg.CertFile,
+			KeyFile:    tlsCfg.KeyFile,
+			CAFile:     tlsCfg.CAFile,
+			ClientAuth: tlsCfg.ClientAuth,
+		})
+		if err != nil {
+			return fmt.Errorf("Unable to init TLS: %v", err)
+		}
+		server.EnableTLS(reloader.TLSConfig())
+		restTLSConfig = reloader.TLSConfig()
+		if tlsCfg.CSI {
+			tlsConfig = reloader.TLSConfig()
+		}
+	}
+
+	if cfg.Osd.Audit != nil {
+		if err := initAudit(cfg.Osd.Audit, kv); err != nil {
+			return fmt.Errorf("Unable to init audit log: %v", err)
+		}
+	}
+
 	// Start the cluster state machine, if enabled.
 	clusterInit := false
 	if cfg.Osd.ClusterConfig.NodeId != "" && cfg.Osd.ClusterConfig.ClusterId != "" {
@@ -197,6 +286,37 @@ func start(c *cli.Context) error {
 		clusterInit = true
 	}
 
+	// Snapshot schedules are owned by one node at a time, identified by
+	// the cluster node ID or the hostname when not in cluster mode.
+	nodeID := cfg.Osd.ClusterConfig.NodeId
+	if nodeID == "" {
+		if nodeID, err = os.Hostname(); err != nil {
+			return fmt.Errorf("Unable to get hostname: %v", err)
+		}
+	}
+	sched.Init(time.Second)
+
+	// Run long volume operations in the background when requested.
+	if err := initJobs(cfg.Osd.Jobs, kv, nodeID); err != nil {
+		return fmt.Errorf("Unable to init jobs: %v", err)
+	}
+
+	// Limit the volumes of label selectors to their quotas, if any.
+	quota.SetInstance(quota.NewManager(kv))
+
+	// Publish the changes of the volumes and of the nodes to watchers.
+	broker := watch.NewBroker(0)
+	watch.SetInstance(broker)
+
+	// A Docker managed plugin serves a single driver on the socket set in
+	// its config.json.
+	var pluginDriver string
+	if c.Bool("managed-plugin") {
+		if pluginDriver, err = managedPluginDriver(cfg); err != nil {
+			return err
+		}
+	}
+
 	isDefaultSet := false
 	// Start the volume drivers.
 	for d, v := range cfg.Osd.Drivers {
@@ -204,6 +324,9 @@ func start(c *cli.Context) error {
 		if err := volumedrivers.Register(d, v); err != nil {
 			return fmt.Errorf("Unable to start volume driver: %v, %v", d, err)
 		}
+		if err := watch.WatchVolumes(broker, kv, d); err != nil {
+			dlog.Warnf("Unable to watch the volumes of %v: %v", d, err)
+		}
 
 		var mgmtPort, pluginPort uint64
 		if port, ok := v[config.MgmtPortKey]; ok {
@@ -224,7 +347,22 @@ func start(c *cli.Context) error {
 			pluginPort = 0
 		}
 
-		if err := server.StartPluginAPI(
+		if d == pluginDriver {
+			if err := server.StartVolumeMgmtAPI(
+				d,
+				volume.DriverAPIBase,
+				uint16(mgmtPort),
+			); err != nil {
+				return fmt.Errorf("Unable to start volume plugin: %v", err)
+			}
+			if err := server.StartManagedVolumePluginAPI(
+				d,
+				volume.PluginAPIBase,
+				uint16(pluginPort),
+			); err != nil {
+				return fmt.Errorf("Unable to start managed volume plugin: %v", err)
+			}
+		} else if err := server.StartPluginAPI(
 			d,
 			volume.DriverAPIBase,
 			volume.PluginAPIBase,
@@ -247,11 +385,46 @@ func start(c *cli.Context) error {
 			Address:    fmt.Sprintf("/var/lib/osd/driver/%s-csi.sock", d),
 			DriverName: d,
 			Cluster:    cm,
+			TLSConfig:  tlsConfig,
 		})
 		if err != nil {
 			return fmt.Errorf("Failed to start CSI server for driver %s: %v", d, err)
 		}
 		csiServer.Start()
+
+		// Start the SDK gRPC server and its REST gateway for this driver
+		var sdkRestAddress string
+		if port, ok := v[config.SdkRestPortKey]; ok {
+			if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+				return fmt.Errorf("Invalid OSD Config File. Invalid SDK REST Port number for Driver : %s", d)
+			}
+			sdkRestAddress = ":" + port
+		}
+		sdkServer, err := sdk.New(&sdk.ServerConfig{
+			Net:           "unix",
+			Address:       fmt.Sprintf("/var/lib/osd/driver/%s-sdk.sock", d),
+			RestAddress:   sdkRestAddress,
+			DriverName:    d,
+			Cluster:       cm,
+			TLSConfig:     restTLSConfig,
+			Authenticator: authenticator,
+			TrustLocal:    authTrustLocal,
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to start SDK server for driver %s: %v", d, err)
+		}
+		if err := sdkServer.Start(); err != nil {
+			return fmt.Errorf("Failed to start SDK server for driver %s: %v", d, err)
+		}
+
+		// Start taking scheduled snapshots for this driver
+		vd, err := volumedrivers.Get(d)
+		if err != nil {
+			return fmt.Errorf("Unable to find volume driver %s: %v", d, err)
+		}
+		if err := snapsched.New(d, vd, kv, sched.Instance(), nodeID).Start(); err != nil {
+			return fmt.Errorf("Unable to start snapshot scheduler for driver %s: %v", d, err)
+		}
 	}
 
 	if cfg.Osd.ClusterConfig.DefaultDriver != "" && !isDefaultSet {
@@ -275,15 +448,101 @@ func start(c *cli.Context) error {
 		if err != nil {
 			return fmt.Errorf("Unable to find cluster instance: %v", err)
 		}
+		if err := cm.AddEventListener(watch.NewNodeListener(broker)); err != nil {
+			return fmt.Errorf("Unable to watch the nodes: %v", err)
+		}
 		if err := cm.Start(0, false); err != nil {
 			return fmt.Errorf("Unable to start cluster manager: %v", err)
 		}
 	}
 
+	// Resume the jobs interrupted by the previous process, now that the
+	// volume drivers are started.
+	if err := server.ResumeJobs(jobs.Instance()); err != nil {
+		return fmt.Errorf("Unable to resume jobs: %v", err)
+	}
+
 	// Daemon does not exit.
 	select {}
 }
 
+// initJobs runs the jobs of nodeID as tuned by cfg.
+func initJobs(cfg *config.JobsConfig, kv kvdb.Kvdb, nodeID string) error {
+	workers, retention := 4, 24*time.Hour
+	if cfg != nil && cfg.Workers > 0 {
+		workers = cfg.Workers
+	}
+	if cfg != nil && cfg.RetentionHours > 0 {
+		retention = time.Duration(cfg.RetentionHours) * time.Hour
+	}
+	m, err := jobs.NewManager(kv, nodeID, workers, retention)
+	if err != nil {
+		return err
+	}
+	jobs.SetInstance(m)
+	return nil
+}
+
+// initAudit logs audit records to the destinations of cfg.
+func initAudit(cfg *config.AuditConfig, kv kvdb.Kvdb) error {
+	var loggers []audit.Logger
+	if cfg.File != "" {
+		l, err := audit.NewFileLogger(cfg.File, cfg.MaxSizeMB*1024*1024, cfg.MaxBackups)
+		if err != nil {
+			return err
+		}
+		loggers = append(loggers, l)
+	}
+	if cfg.Kvdb {
+		ttl := time.Duration(cfg.KvdbTTLHours) * time.Hour
+		loggers = append(loggers, audit.NewKvdbLogger(kv, ttl))
+	}
+	if len(loggers) == 0 {
+		return fmt.Errorf("No audit file or kvdb configured")
+	}
+	audit.SetInstance(audit.NewMultiLogger(loggers...))
+	return nil
+}
+
+// managedPluginDriver returns the driver served by a managed plugin, the
+// default driver or the only one configured.
+func managedPluginDriver(cfg *config.Config) (string, error) {
+	if d := cfg.Osd.ClusterConfig.DefaultDriver; d != "" {
+		return d, nil
+	}
+	if len(cfg.Osd.Drivers) != 1 {
+		return "", fmt.Errorf("A default driver must be set to run as a managed plugin with %d drivers", len(cfg.Osd.Drivers))
+	}
+	for d := range cfg.Osd.Drivers {
+		return d, nil
+	}
+	return "", nil
+}
+
+// tlsFlags returns cfg overridden by the TLS flags, or nil if TLS is not
+// enabled.
+func tlsFlags(c *cli.Context, cfg *config.TLSConfig) *config.TLSConfig {
+	tlsCfg := &config.TLSConfig{}
+	if cfg != nil {
+		*tlsCfg = *cfg
+	}
+	if v := c.String("tls-cert"); v != "" {
+		tlsCfg.CertFile = v
+	}
+	if v := c.String("tls-key"); v != "" {
+		tlsCfg.KeyFile = v
+	}
+	if v := c.String("tls-ca"); v != "" {
+		tlsCfg.CAFile = v
+	}
+	tlsCfg.ClientAuth = tlsCfg.ClientAuth || c.Bool("tls-client-auth")
+	tlsCfg.CSI = tlsCfg.CSI || c.Bool("tls-csi")
+	if cfg == nil && tlsCfg.CertFile == "" && tlsCfg.KeyFile == "" {
+		return nil
+	}
+	return tlsCfg
+}
+
 func showVersion(c *cli.Context) error {
 	fmt.Println("OSD Version:", config.Version)
 	fmt.Println("Go Version:", runtime.Version())
@@ -17,6 +17,7 @@ const (
 	UrlKey                    = "url"
 	MgmtPortKey               = "mgmtPort"
 	PluginPortKey             = "pluginPort"
+	SdkRestPortKey            = "sdkRestPort"
 	VersionKey                = "version"
 	DataDir                   = ".data"
 	FlexVolumePort     uint16 = 2345
@@ -42,9 +43,67 @@ type ClusterConfig struct {
 	FluentDHost   string
 }
 
+// AuthConfig enables token authentication of the REST API servers and of the
+// SDK gRPC servers and their REST gateways.
+type AuthConfig struct {
+	// Issuer expected in tokens, any issuer is accepted if empty
+	Issuer string
+	// KeyFile holds the shared secret or PEM encoded RSA public key
+	// tokens are verified with
+	KeyFile string `yaml:"keyfile"`
+	// TrustLocal skips authentication of requests on unix sockets
+	TrustLocal bool `yaml:"trust_local"`
+}
+
+// TLSConfig secures the TCP ports of the REST API servers.
+type TLSConfig struct {
+	// CertFile holds the PEM encoded certificate chain of the servers
+	CertFile string `yaml:"certfile"`
+	// KeyFile holds the PEM encoded private key of the servers
+	KeyFile string `yaml:"keyfile"`
+	// CAFile holds the certificates client certificates are verified with
+	CAFile string `yaml:"cafile"`
+	// ClientAuth rejects clients without a certificate signed by CAFile
+	ClientAuth bool `yaml:"client_auth"`
+	// CSI also secures the CSI endpoints
+	CSI bool `yaml:"csi"`
+}
+
+// AuditConfig enables the audit log of control plane requests.
+type AuditConfig struct {
+	// File records are appended to
+	File string `yaml:"file"`
+	// MaxSizeMB is the size in MB the file is rotated at
+	MaxSizeMB int64 `yaml:"max_size_mb"`
+	// MaxBackups is the number of rotated files kept
+	MaxBackups int `yaml:"max_backups"`
+	// Kvdb also stores records in kvdb, shared by the cluster
+	Kvdb bool `yaml:"kvdb"`
+	// KvdbTTLHours is the number of hours records are kept in kvdb,
+	// forever if zero
+	KvdbTTLHours int `yaml:"kvdb_ttl_hours"`
+}
+
+// JobsConfig tunes the jobs running volume operations in the background.
+type JobsConfig struct {
+	// Workers is the number of jobs run at once, 4 if zero
+	Workers int `yaml:"workers"`
+	// RetentionHours is the number of hours completed jobs are kept, 24
+	// if zero
+	RetentionHours int `yaml:"retention_hours"`
+}
+
 type Config struct {
 	Osd struct {
 		ClusterConfig ClusterConfig `yaml:"cluster"`
+		// Auth is nil if the API servers are not authenticated
+		Auth *AuthConfig
+		// TLS is nil if the servers are not secured
+		TLS *TLSConfig `yaml:"tls"`
+		// Audit is nil if requests are not audited
+		Audit *AuditConfig `yaml:"audit"`
+		// Jobs is nil to run jobs with the defaults
+		Jobs *JobsConfig `yaml:"jobs"`
 		// map[string]string is volume.VolumeParams equivalent
 		Drivers map[string]map[string]string
 		// map[string]string is volume.VolumeParams equivalent
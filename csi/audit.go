@@ -0,0 +1,107 @@
+/*
+Package csi is CSI driver interface for OSD
+Copyright 2017 Portworx
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package csi
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/libopenstorage/openstorage/pkg/audit"
+)
+
+// auditInterceptor logs an audit record of every RPC changing state.
+func (s *OsdCsiServer) auditInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	method := path.Base(info.FullMethod)
+	if !audit.Enabled() || readOnlyRPC(method) {
+		return handler(ctx, req)
+	}
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+
+	r := &audit.Record{
+		Time:     start,
+		Caller:   rpcCaller(ctx),
+		Service:  "csi/" + s.driverName,
+		Verb:     method,
+		Resource: rpcResource(req),
+		Result:   audit.ResultOK,
+		Latency:  time.Since(start),
+	}
+	if msg, ok := req.(proto.Message); ok {
+		if data, merr := proto.Marshal(msg); merr == nil {
+			r.RequestHash = audit.Hash(data)
+		}
+	}
+	if err != nil {
+		r.Result = err.Error()
+	}
+	audit.Log(r)
+	return resp, err
+}
+
+// readOnlyRPC returns true if method does not change state.
+func readOnlyRPC(method string) bool {
+	return strings.Contains(method, "Get") ||
+		strings.HasPrefix(method, "List") ||
+		strings.HasPrefix(method, "Validate") ||
+		strings.HasSuffix(method, "Probe")
+}
+
+// rpcResource returns the ID of the volume a request is for, or the name
+// of the volume it creates.
+func rpcResource(req interface{}) string {
+	if r, ok := req.(interface {
+		GetVolumeId() string
+	}); ok {
+		return r.GetVolumeId()
+	}
+	if r, ok := req.(interface {
+		GetName() string
+	}); ok {
+		return r.GetName()
+	}
+	return ""
+}
+
+// rpcCaller returns the identity of the caller of a RPC.
+func rpcCaller(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return audit.CallerLocal
+	}
+	if info, ok := p.AuthInfo.(credentials.TLSInfo); ok &&
+		len(info.State.PeerCertificates) > 0 {
+		return info.State.PeerCertificates[0].Subject.CommonName
+	}
+	if p.Addr == nil || p.Addr.Network() == "unix" {
+		return audit.CallerLocal
+	}
+	return p.Addr.String()
+}
@@ -22,6 +22,8 @@ import (
 	"github.com/portworx/kvdb"
 
 	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/placement"
+	"github.com/libopenstorage/openstorage/pkg/quota"
 	"github.com/libopenstorage/openstorage/pkg/util"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
@@ -36,6 +38,7 @@ const (
 	volumeCapabilityMessageNotMultinodeVolume = "Volume is not a multinode volume"
 	volumeCapabilityMessageReadOnlyVolume     = "Volume is read only"
 	volumeCapabilityMessageNotReadOnlyVolume  = "Volume is not read only"
+	volumeCapabilityMessageBlockNotSupported  = "Driver does not support raw block volumes"
 	defaultCSIVolumeSize                      = uint64(1024 * 1024 * 1024)
 )
 
@@ -69,10 +72,20 @@ func (s *OsdCsiServer) ControllerGetCapabilities(
 		},
 	}
 
+	// GetCapacity supported
+	capGetCapacity := &csi.ControllerServiceCapability{
+		Type: &csi.ControllerServiceCapability_Rpc{
+			Rpc: &csi.ControllerServiceCapability_RPC{
+				Type: csi.ControllerServiceCapability_RPC_GET_CAPACITY,
+			},
+		},
+	}
+
 	return &csi.ControllerGetCapabilitiesResponse{
 		Capabilities: []*csi.ControllerServiceCapability{
 			capCreateDeleteVolume,
 			capListVolumes,
+			capGetCapacity,
 		},
 	}, nil
 
@@ -162,14 +175,19 @@ func (s *OsdCsiServer) ValidateVolumeCapabilities(
 
 	// Check capability
 	for _, capability := range capabilities {
-		// Currently the CSI spec defines all storage as "file systems."
-		// So we do not need to check this with the volume. All we will check
-		// here is the validity of the capability access type.
+		// All volumes can be mounted, but only the volumes of block drivers
+		// can be published as raw block devices.
 		if capability.GetMount() == nil && capability.GetBlock() == nil {
 			return nil, status.Error(
 				codes.InvalidArgument,
 				"Cannot have both mount and block be undefined")
 		}
+		if capability.GetBlock() != nil &&
+			s.driver.Type() != api.DriverType_DRIVER_TYPE_BLOCK {
+			result.Supported = false
+			result.Message = volumeCapabilityMessageBlockNotSupported
+			return result, nil
+		}
 
 		// Check access mode is setup correctly
 		mode := capability.GetAccessMode()
@@ -236,6 +254,67 @@ func (s *OsdCsiServer) ValidateVolumeCapabilities(
 	return result, nil
 }
 
+// GetCapacity is a CSI API which returns the free space of the storage pools
+// of the nodes a volume created with the parameters of the request can be
+// placed on. The zones and racks in the parameters restrict the nodes to the
+// ones whose labels match, and the free space is divided by the number of
+// replicas of the volume.
+func (s *OsdCsiServer) GetCapacity(
+	ctx context.Context,
+	req *csi.GetCapacityRequest,
+) (*csi.GetCapacityResponse, error) {
+
+	dlog.Debugf("GetCapacity req[%#v]", req)
+
+	// Check arguments
+	if req.GetVersion() == nil {
+		return nil, status.Error(codes.InvalidArgument, "Version must be provided")
+	}
+
+	// Only block drivers provide raw block volumes
+	for _, capability := range req.GetVolumeCapabilities() {
+		if capability.GetBlock() != nil &&
+			s.driver.Type() != api.DriverType_DRIVER_TYPE_BLOCK {
+			return &csi.GetCapacityResponse{}, nil
+		}
+	}
+
+	spec, locator, _, err := s.specHandler.SpecFromOpts(req.GetParameters())
+	if err != nil {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Unable to get parameters: %s",
+			err.Error())
+	}
+
+	clus, err := s.cluster.Enumerate()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Unable to Enumerate cluster: %s", err)
+	}
+
+	// Any size fits, only the topology constraints exclude nodes
+	spec.Size = 0
+	decision, _ := placement.Place(&placement.Request{
+		Spec:      spec,
+		Locator:   locator,
+		LocalNode: clus.NodeId,
+		Nodes:     clus.Nodes,
+	})
+	free := uint64(0)
+	for _, c := range decision.Candidates {
+		if len(c.Reason) == 0 {
+			free += c.Free
+		}
+	}
+	if spec.GetHaLevel() > 1 {
+		free /= uint64(spec.GetHaLevel())
+	}
+
+	return &csi.GetCapacityResponse{
+		AvailableCapacity: free,
+	}, nil
+}
+
 // ListVolumes is a CSI API which returns to the caller all volume ids
 // on this cluster. This includes ids created by CSI and ids created
 // using other interfaces. This is important because the user could
@@ -393,10 +472,17 @@ func (s *OsdCsiServer) CreateVolume(
 		}
 
 		// Create a snapshot from the parent
-		id, err = s.driver.Snapshot(parent.GetId(), false, &api.VolumeLocator{
+		snapLocator := &api.VolumeLocator{
 			Name: req.GetName(),
+		}
+		next := &api.Volume{Locator: snapLocator, Source: &api.Source{Parent: parent.GetId()}}
+		err = s.enforceQuota(next, func() error {
+			id, err = s.driver.Snapshot(parent.GetId(), false, snapLocator)
+			return err
 		})
-		if err != nil {
+		if _, ok := err.(*quota.ErrExceeded); ok {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		} else if err != nil {
 			e := fmt.Sprintf("unable to create snapshot: %s\n", err.Error())
 			dlog.Errorln(e)
 			return nil, status.Error(codes.Internal, e)
@@ -407,8 +493,14 @@ func (s *OsdCsiServer) CreateVolume(
 
 		// Create the volume
 		locator.Name = req.GetName()
-		id, err = s.driver.Create(locator, source, spec)
-		if err != nil {
+		next := &api.Volume{Locator: locator, Source: source, Spec: spec}
+		err = s.enforceQuota(next, func() error {
+			id, err = s.driver.Create(locator, source, spec)
+			return err
+		})
+		if _, ok := err.(*quota.ErrExceeded); ok {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		} else if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
 	}
@@ -424,6 +516,16 @@ func (s *OsdCsiServer) CreateVolume(
 	return resp, nil
 }
 
+// enforceQuota runs fn, which creates volume next, unless next would
+// exceed a quota.
+func (s *OsdCsiServer) enforceQuota(next *api.Volume, fn func() error) error {
+	m := quota.Instance()
+	if m == nil {
+		return fn()
+	}
+	return m.Enforce(s.driver.Name(), s.driver, nil, next, fn)
+}
+
 // DeleteVolume is a CSI API which deletes a volume
 func (s *OsdCsiServer) DeleteVolume(
 	ctx context.Context,
@@ -484,6 +586,20 @@ func csiRequestsSharedVolume(req *csi.CreateVolumeRequest) bool {
 /*
 For next patches what still needs to be worked on in the Conroller server:
 
-	GetCapacity(context.Context, *GetCapacityRequest) (*GetCapacityResponse, error)
-	ControllerGetCapabilities(context.Context, *ControllerGetCapabilitiesRequest) (*ControllerGetCapabilitiesResponse, error)
+Topology (NodeGetInfo, the ACCESSIBILITY_CONSTRAINTS capability and the
+accessibility requirements of CreateVolume) was introduced in CSI
+0.3.0. Once the spec is updated:
+
+	NodeGetInfo -> AccessibleTopology segments from the placement.NodeLabelZone,
+	               placement.NodeLabelRack and placement.NodeLabelRegion node
+	               labels of the local node
+	CreateVolume -> the zones, racks and regions of the requisite segments, or
+	               of the preferred ones if none is required, set as the
+	               api.SpecZones, api.SpecRacks and api.SpecRegions volume
+	               labels placement constrains the replicas to
+	GetCapacity  -> AccessibleTopology of the request restricting the nodes
+	               counted like the zones and racks parameters do today
+
+Until then the topology of a volume is requested with the zones and racks
+parameters, which the spec handler maps to the same volume labels.
 */
@@ -50,6 +50,7 @@ func TestControllerGetCapabilities(t *testing.T) {
 	expectedValues := []csi.ControllerServiceCapability_RPC_Type{
 		csi.ControllerServiceCapability_RPC_LIST_VOLUMES,
 		csi.ControllerServiceCapability_RPC_CREATE_DELETE_VOLUME,
+		csi.ControllerServiceCapability_RPC_GET_CAPACITY,
 	}
 	caps := r.GetCapabilities()
 	assert.Len(t, caps, len(expectedValues))
@@ -772,6 +773,160 @@ func TestControllerValidateVolumeAccessModeUnknown(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestControllerValidateVolumeBlock(t *testing.T) {
+	// Create server and client connection
+	s := newTestServer(t)
+	defer s.Stop()
+
+	// Setup mock
+	id := "testvolumeid"
+	s.MockDriver().
+		EXPECT().
+		Inspect([]string{id}).
+		Return([]*api.Volume{
+			&api.Volume{
+				Id: id,
+				Spec: &api.VolumeSpec{
+					Shared: false,
+				},
+			},
+		}, nil).
+		Times(2)
+	gomock.InOrder(
+		s.MockDriver().
+			EXPECT().
+			Type().
+			Return(api.DriverType_DRIVER_TYPE_BLOCK).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Type().
+			Return(api.DriverType_DRIVER_TYPE_FILE).
+			Times(1),
+	)
+
+	// Setup request
+	req := &csi.ValidateVolumeCapabilitiesRequest{
+		Version: &csi.Version{},
+		VolumeCapabilities: []*csi.VolumeCapability{
+			&csi.VolumeCapability{
+				AccessType: &csi.VolumeCapability_Block{
+					Block: &csi.VolumeCapability_BlockVolume{},
+				},
+				AccessMode: &csi.VolumeCapability_AccessMode{
+					Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER,
+				},
+			},
+		},
+		VolumeId: id,
+	}
+
+	// Expect supported by a block driver
+	c := csi.NewControllerClient(s.Conn())
+	r, err := c.ValidateVolumeCapabilities(context.Background(), req)
+	assert.Nil(t, err)
+	assert.True(t, r.GetSupported())
+
+	// Expect not supported by a file driver
+	r, err = c.ValidateVolumeCapabilities(context.Background(), req)
+	assert.Nil(t, err)
+	assert.False(t, r.GetSupported())
+	assert.Equal(t, volumeCapabilityMessageBlockNotSupported, r.GetMessage())
+}
+
+func TestControllerGetCapacity(t *testing.T) {
+	// Create server and client connection
+	s := newTestServer(t)
+	defer s.Stop()
+
+	// Setup mock
+	pool := func(total, used uint64) []api.StoragePool {
+		return []api.StoragePool{{TotalSize: total, Used: used}}
+	}
+	s.MockCluster().
+		EXPECT().
+		Enumerate().
+		Return(api.Cluster{
+			NodeId: "node1",
+			Nodes: []api.Node{
+				{
+					Id:         "node1",
+					Status:     api.Status_STATUS_OK,
+					NodeLabels: map[string]string{"zone": "east"},
+					Pools:      pool(100, 40),
+				},
+				{
+					Id:         "node2",
+					Status:     api.Status_STATUS_OK,
+					NodeLabels: map[string]string{"zone": "west"},
+					Pools:      pool(100, 20),
+				},
+				{
+					Id:         "node3",
+					Status:     api.Status_STATUS_OFFLINE,
+					NodeLabels: map[string]string{"zone": "east"},
+					Pools:      pool(100, 0),
+				},
+			},
+		}, nil).
+		Times(3)
+
+	c := csi.NewControllerClient(s.Conn())
+	tests := []struct {
+		params   map[string]string
+		expected uint64
+	}{
+		// Online nodes only
+		{params: nil, expected: 140},
+		// Nodes of the zone only
+		{params: map[string]string{api.SpecZones: "east"}, expected: 60},
+		// Shared by the replicas
+		{params: map[string]string{api.SpecHaLevel: "2"}, expected: 70},
+	}
+	for _, test := range tests {
+		r, err := c.GetCapacity(context.Background(), &csi.GetCapacityRequest{
+			Version:    &csi.Version{},
+			Parameters: test.params,
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, r.GetAvailableCapacity())
+	}
+}
+
+func TestControllerGetCapacityBadArguments(t *testing.T) {
+	// Create server and client connection
+	s := newTestServer(t)
+	defer s.Stop()
+	c := csi.NewControllerClient(s.Conn())
+
+	// Expect error without version
+	_, err := c.GetCapacity(context.Background(), &csi.GetCapacityRequest{})
+	assert.NotNil(t, err)
+	serverError, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, serverError.Code(), codes.InvalidArgument)
+	assert.Contains(t, serverError.Message(), "Version")
+
+	// Expect no capacity for raw block volumes of a file driver
+	s.MockDriver().
+		EXPECT().
+		Type().
+		Return(api.DriverType_DRIVER_TYPE_FILE).
+		Times(1)
+	r, err := c.GetCapacity(context.Background(), &csi.GetCapacityRequest{
+		Version: &csi.Version{},
+		VolumeCapabilities: []*csi.VolumeCapability{
+			&csi.VolumeCapability{
+				AccessType: &csi.VolumeCapability_Block{
+					Block: &csi.VolumeCapability_BlockVolume{},
+				},
+			},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), r.GetAvailableCapacity())
+}
+
 func TestControllerListVolumesInvalidArguments(t *testing.T) {
 	// Create server and client connection
 	s := newTestServer(t)
@@ -17,17 +17,22 @@ limitations under the License.
 package csi
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
+	"path/filepath"
 	"sync"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
 	"go.pedge.io/dlog"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/libopenstorage/openstorage/api/spec"
 	"github.com/libopenstorage/openstorage/cluster"
+	"github.com/libopenstorage/openstorage/pkg/keylock"
+	"github.com/libopenstorage/openstorage/pkg/mount"
 	"github.com/libopenstorage/openstorage/volume"
 	volumedrivers "github.com/libopenstorage/openstorage/volume/drivers"
 )
@@ -39,6 +44,11 @@ type OsdCsiServerConfig struct {
 	Address    string
 	DriverName string
 	Cluster    cluster.Cluster
+	// TLSConfig secures the server if set
+	TLSConfig *tls.Config
+	// StagingPath is the directory the volumes are staged under,
+	// defaultStagingPath/DriverName if empty
+	StagingPath string
 }
 
 // OsdCsiServer is a OSD CSI compliant server which
@@ -48,11 +58,19 @@ type OsdCsiServer struct {
 	listener    net.Listener
 	server      *grpc.Server
 	driver      volume.VolumeDriver
+	driverName  string
 	cluster     cluster.Cluster
+	tlsConfig   *tls.Config
 	wg          sync.WaitGroup
 	running     bool
 	lock        sync.Mutex
 	specHandler spec.SpecHandler
+	// mounter and mountManager bind mount the staging paths and devices
+	// of the volumes onto the target paths
+	mounter      mount.MountImpl
+	mountManager mount.Manager
+	stagingBase  string
+	volumeLocks  keylock.KeyLock
 }
 
 // NewOsdCsiServer creates a gRPC CSI complient server on the
@@ -77,16 +95,32 @@ func NewOsdCsiServer(config *OsdCsiServerConfig) (Server, error) {
 		return nil, fmt.Errorf("Unable to get driver %s info: %s", config.DriverName, err.Error())
 	}
 
+	stagingBase := config.StagingPath
+	if len(stagingBase) == 0 {
+		stagingBase = filepath.Join(defaultStagingPath, config.DriverName)
+	}
+	mounter := &mount.DefaultMounter{}
+	mountManager, err := newMountManager(mounter, stagingBase)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load mount table: %s", err.Error())
+	}
+
 	l, err := net.Listen(config.Net, config.Address)
 	if err != nil {
 		return nil, fmt.Errorf("Unable to setup server: %s", err.Error())
 	}
 
 	return &OsdCsiServer{
-		listener:    l,
-		driver:      d,
-		cluster:     config.Cluster,
-		specHandler: spec.NewSpecHandler(),
+		listener:     l,
+		driver:       d,
+		driverName:   config.DriverName,
+		cluster:      config.Cluster,
+		tlsConfig:    config.TLSConfig,
+		specHandler:  spec.NewSpecHandler(),
+		mounter:      mounter,
+		mountManager: mountManager,
+		stagingBase:  stagingBase,
+		volumeLocks:  keylock.New(),
 	}, nil
 }
 
@@ -100,7 +134,11 @@ func (s *OsdCsiServer) Start() error {
 		return fmt.Errorf("Server already running")
 	}
 
-	s.server = grpc.NewServer()
+	opts := []grpc.ServerOption{grpc.UnaryInterceptor(s.auditInterceptor)}
+	if s.tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(s.tlsConfig)))
+	}
+	s.server = grpc.NewServer(opts...)
 
 	csi.RegisterIdentityServer(s.server, s)
 	csi.RegisterControllerServer(s.server, s)
@@ -19,6 +19,8 @@ package csi
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"syscall"
 
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/pkg/options"
@@ -55,7 +57,8 @@ func (s *OsdCsiServer) GetNodeID(ctx context.Context, req *csi.GetNodeIDRequest)
 }
 
 // NodePublishVolume is a CSI API call which mounts the volume on the specified
-// target path on the node.
+// target path on the node. Volumes requested with the block access type are
+// attached and their device bind mounted on the target path instead.
 //
 // TODO: Support READ ONLY Mounts
 //
@@ -103,6 +106,11 @@ func (s *OsdCsiServer) NodePublishVolume(
 		opts[options.OptionsSecret] = spec.GetPassphrase()
 	}
 
+	// Raw block volumes are bind mounted onto a file instead
+	if req.GetVolumeCapability().GetBlock() != nil {
+		return s.nodePublishBlockVolume(req, v, opts)
+	}
+
 	// Verify target location is an existing directory
 	// See: https://github.com/container-storage-interface/spec/issues/60
 	if err := verifyTargetLocation(req.GetTargetPath()); err != nil {
@@ -113,25 +121,23 @@ func (s *OsdCsiServer) NodePublishVolume(
 			err.Error())
 	}
 
-	// If this is for a block driver, first attach the volume
-	if s.driver.Type() == api.DriverType_DRIVER_TYPE_BLOCK {
-		if _, err := s.driver.Attach(req.GetVolumeId(), opts); err != nil {
-			return nil, status.Errorf(
-				codes.Internal,
-				"Unable to attach volume: %s",
-				err.Error())
-		}
+	h := s.volumeLocks.Acquire(v.GetId())
+	defer s.volumeLocks.Release(&h)
+
+	// Attach and mount the volume once on the node
+	stagingPath, err := s.nodeStageVolume(v, opts)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"Unable to stage volume %s: %s",
+			req.GetVolumeId(),
+			err.Error())
 	}
 
-	// Mount volume onto the path
-	if err := s.driver.Mount(req.GetVolumeId(), req.GetTargetPath(), nil); err != nil {
-		// Detach on error
-		detachErr := s.driver.Detach(v.GetId(), opts)
-		if detachErr != nil {
-			dlog.Errorf("Unable to detach volume %s: %s",
-				v.GetId(),
-				detachErr.Error())
-		}
+	// Bind mount the staging path onto the target path
+	err = s.mountManager.Mount(0, stagingPath, req.GetTargetPath(), "", syscall.MS_BIND, "", 0, nil)
+	if err != nil {
+		s.maybeUnstageVolume(v.GetId(), stagingPath)
 		return nil, status.Errorf(
 			codes.Internal,
 			"Unable to mount volume %s onto %s: %s",
@@ -147,7 +153,10 @@ func (s *OsdCsiServer) NodePublishVolume(
 	return &csi.NodePublishVolumeResponse{}, nil
 }
 
-// NodeUnpublishVolume is a CSI API call which unmounts the volume.
+// NodeUnpublishVolume is a CSI API call which unmounts the volume, or the
+// device of a volume published with the block access type. The volume is
+// unmounted from its staging path and detached once it is not published on
+// any other target path.
 func (s *OsdCsiServer) NodeUnpublishVolume(
 	ctx context.Context,
 	req *csi.NodeUnpublishVolumeRequest,
@@ -167,13 +176,23 @@ func (s *OsdCsiServer) NodeUnpublishVolume(
 	}
 
 	// Get volume information
-	_, err := util.VolumeFromName(s.driver, req.GetVolumeId())
+	v, err := util.VolumeFromName(s.driver, req.GetVolumeId())
 	if err != nil {
 		return nil, status.Errorf(codes.NotFound, "Volume id %s not found: %s",
 			req.GetVolumeId(),
 			err.Error())
 	}
 
+	h := s.volumeLocks.Acquire(v.GetId())
+	defer s.volumeLocks.Release(&h)
+
+	// Targets are bind mounts of a staging path or of a device
+	if source, err := s.mountManager.GetSourcePath(req.GetTargetPath()); err == nil {
+		return s.nodeUnpublishBindMount(req, v, source)
+	}
+
+	// Volumes published before staging was supported are mounted directly
+	// on the target path.
 	// Verify target location is an existing directory
 	// See: https://github.com/container-storage-interface/spec/issues/60
 	if err = verifyTargetLocation(req.GetTargetPath()); err != nil {
@@ -204,6 +223,118 @@ func (s *OsdCsiServer) NodeUnpublishVolume(
 	return &csi.NodeUnpublishVolumeResponse{}, nil
 }
 
+// nodeUnpublishBindMount unmounts source from the target path, and unstages
+// the volume if source is not mounted anywhere else.
+func (s *OsdCsiServer) nodeUnpublishBindMount(
+	req *csi.NodeUnpublishVolumeRequest,
+	v *api.Volume,
+	source string,
+) (*csi.NodeUnpublishVolumeResponse, error) {
+	err := s.mountManager.Unmount(source, req.GetTargetPath(), 0, 0, nil)
+	if err != nil && err != syscall.EINVAL {
+		return nil, status.Errorf(
+			codes.Internal,
+			"Unable to unmount volume %s from %s: %s",
+			req.GetVolumeId(),
+			req.GetTargetPath(),
+			err.Error())
+	}
+	if err := s.mountManager.RemoveMountPath(req.GetTargetPath(), nil); err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"Unable to remove target %s: %s",
+			req.GetTargetPath(),
+			err.Error())
+	}
+
+	if s.mountManager.HasMounts(source) == 0 {
+		if err := s.nodeUnstageVolume(v.GetId(), source); err != nil {
+			return nil, status.Errorf(
+				codes.Internal,
+				"Unable to unstage volume %s: %s",
+				req.GetVolumeId(),
+				err.Error())
+		}
+	}
+
+	dlog.Infof("Volume %s unpublished from %s",
+		req.GetVolumeId(),
+		req.GetTargetPath())
+
+	return &csi.NodeUnpublishVolumeResponse{}, nil
+}
+
+// nodePublishBlockVolume attaches a volume of a block driver and bind mounts
+// its device onto the target path, which is created as a file if missing.
+func (s *OsdCsiServer) nodePublishBlockVolume(
+	req *csi.NodePublishVolumeRequest,
+	v *api.Volume,
+	opts map[string]string,
+) (*csi.NodePublishVolumeResponse, error) {
+	if s.driver.Type() != api.DriverType_DRIVER_TYPE_BLOCK {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"Driver %s does not support raw block volumes",
+			s.driverName)
+	}
+
+	if err := verifyBlockTargetLocation(req.GetTargetPath()); err != nil {
+		return nil, status.Errorf(
+			codes.Aborted,
+			"Failed to use target location %s: %s",
+			req.GetTargetPath(),
+			err.Error())
+	}
+
+	h := s.volumeLocks.Acquire(v.GetId())
+	defer s.volumeLocks.Release(&h)
+
+	// Attach the volume once on the node
+	devicePath, err := s.nodeStageBlockVolume(v, opts)
+	if err != nil {
+		return nil, status.Errorf(
+			codes.Internal,
+			"Unable to attach volume: %s",
+			err.Error())
+	}
+
+	// Bind mount the device onto the path
+	err = s.mountManager.Mount(0, devicePath, req.GetTargetPath(), "", syscall.MS_BIND, "", 0, nil)
+	if err == nil && req.GetReadonly() {
+		err = s.mounter.Mount(
+			devicePath,
+			req.GetTargetPath(),
+			"",
+			syscall.MS_BIND|syscall.MS_REMOUNT|syscall.MS_RDONLY,
+			"",
+			0)
+		if err != nil {
+			unmountErr := s.mountManager.Unmount(devicePath, req.GetTargetPath(), 0, 0, nil)
+			if unmountErr != nil {
+				dlog.Errorf("Unable to unmount %s: %s",
+					req.GetTargetPath(),
+					unmountErr.Error())
+			}
+		}
+	}
+	if err != nil {
+		s.maybeUnstageVolume(v.GetId(), devicePath)
+		return nil, status.Errorf(
+			codes.Internal,
+			"Unable to bind mount device %s onto %s: %s",
+			devicePath,
+			req.GetTargetPath(),
+			err.Error())
+	}
+
+	dlog.Infof("Volume %s device %s published on %s",
+		req.GetVolumeId(),
+		devicePath,
+		req.GetTargetPath())
+
+	return &csi.NodePublishVolumeResponse{}, nil
+}
+
 // NodeProbe is a CSI API function which asks the driver to check if the
 // node has all the necessary components to run successfully.
 func (s *OsdCsiServer) NodeProbe(
@@ -268,3 +399,29 @@ func verifyTargetLocation(targetPath string) error {
 
 	return nil
 }
+
+// verifyBlockTargetLocation creates targetPath as a file for a device to be
+// bind mounted on, unless it already exists and is not a directory.
+func verifyBlockTargetLocation(targetPath string) error {
+	if err := verifyTargetLocation(filepath.Dir(targetPath)); err != nil {
+		return err
+	}
+	fileInfo, err := os.Stat(targetPath)
+	if err == nil {
+		if fileInfo.IsDir() {
+			return fmt.Errorf("Target location %s is a directory", targetPath)
+		}
+		return nil
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf(
+			"Unknown error while verifying target location %s: %s",
+			targetPath,
+			err.Error())
+	}
+
+	f, err := os.OpenFile(targetPath, os.O_CREATE, 0640)
+	if err != nil {
+		return fmt.Errorf("Unable to create target location %s: %s", targetPath, err.Error())
+	}
+	return f.Close()
+}
@@ -18,11 +18,17 @@ package csi
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
 	"testing"
 
 	"github.com/container-storage-interface/spec/lib/go/csi"
 	"github.com/golang/mock/gomock"
 	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/chattr"
+	"github.com/libopenstorage/openstorage/pkg/mount"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/codes"
@@ -315,6 +321,8 @@ func TestNodePublishVolumeFailedToAttach(t *testing.T) {
 	// Create server and client connection
 	s := newTestServer(t)
 	defer s.Stop()
+	_, dir := setupFakeMounter(t, s)
+	defer cleanupDir(dir)
 
 	// Make a call
 	c := csi.NewNodeClient(s.Conn())
@@ -352,7 +360,7 @@ func TestNodePublishVolumeFailedToAttach(t *testing.T) {
 	req := &csi.NodePublishVolumeRequest{
 		Version:    &csi.Version{},
 		VolumeId:   name,
-		TargetPath: "/mnt",
+		TargetPath: dir,
 		VolumeCapability: &csi.VolumeCapability{
 			AccessMode: &csi.VolumeCapability_AccessMode{},
 		},
@@ -371,13 +379,15 @@ func TestNodePublishVolumeFailedMount(t *testing.T) {
 	// Create server and client connection
 	s := newTestServer(t)
 	defer s.Stop()
+	_, dir := setupFakeMounter(t, s)
+	defer cleanupDir(dir)
 
 	// Make a call
 	c := csi.NewNodeClient(s.Conn())
 
 	name := "myvol"
 	size := uint64(10)
-	targetPath := "/mnt"
+	stagingPath := s.Server().(*OsdCsiServer).stagingPath(name)
 	gomock.InOrder(
 		s.MockDriver().
 			EXPECT().
@@ -406,7 +416,7 @@ func TestNodePublishVolumeFailedMount(t *testing.T) {
 			Times(1),
 		s.MockDriver().
 			EXPECT().
-			Mount(name, targetPath, nil).
+			Mount(name, stagingPath, nil).
 			Return(fmt.Errorf("MOUNT ERROR")).
 			Times(1),
 		s.MockDriver().
@@ -419,7 +429,7 @@ func TestNodePublishVolumeFailedMount(t *testing.T) {
 	req := &csi.NodePublishVolumeRequest{
 		Version:    &csi.Version{},
 		VolumeId:   name,
-		TargetPath: targetPath,
+		TargetPath: dir,
 		VolumeCapability: &csi.VolumeCapability{
 			AccessMode: &csi.VolumeCapability_AccessMode{},
 		},
@@ -438,13 +448,17 @@ func TestNodePublishVolumeMount(t *testing.T) {
 	// Create server and client connection
 	s := newTestServer(t)
 	defer s.Stop()
+	m, dir := setupFakeMounter(t, s)
+	defer cleanupDir(dir)
 
 	// Make a call
 	c := csi.NewNodeClient(s.Conn())
 
 	name := "myvol"
 	size := uint64(10)
-	targetPath := "/mnt"
+	targetPath := filepath.Join(dir, "target")
+	assert.Nil(t, os.Mkdir(targetPath, 0750))
+	stagingPath := s.Server().(*OsdCsiServer).stagingPath(name)
 	gomock.InOrder(
 		s.MockDriver().
 			EXPECT().
@@ -473,7 +487,7 @@ func TestNodePublishVolumeMount(t *testing.T) {
 			Times(1),
 		s.MockDriver().
 			EXPECT().
-			Mount(name, targetPath, nil).
+			Mount(name, stagingPath, nil).
 			Return(nil).
 			Times(1),
 	)
@@ -490,6 +504,171 @@ func TestNodePublishVolumeMount(t *testing.T) {
 	r, err := c.NodePublishVolume(context.Background(), req)
 	assert.Nil(t, err)
 	assert.NotNil(t, r)
+
+	// Expect the staging path bind mounted on the target
+	assert.Equal(t, stagingPath, m.mounts[targetPath])
+}
+
+func TestNodePublishVolumeStaged(t *testing.T) {
+	// Create server and client connection
+	s := newTestServer(t)
+	defer s.Stop()
+	m, dir := setupFakeMounter(t, s)
+	defer cleanupDir(dir)
+
+	// Make a call
+	c := csi.NewNodeClient(s.Conn())
+
+	name := "myvol"
+	targets := []string{filepath.Join(dir, "pod1"), filepath.Join(dir, "pod2")}
+	for _, target := range targets {
+		assert.Nil(t, os.Mkdir(target, 0750))
+	}
+	stagingPath := s.Server().(*OsdCsiServer).stagingPath(name)
+	s.MockDriver().
+		EXPECT().
+		Inspect([]string{name}).
+		Return([]*api.Volume{
+			&api.Volume{
+				Id: name,
+			},
+		}, nil).
+		Times(4)
+	gomock.InOrder(
+		s.MockDriver().
+			EXPECT().
+			Type().
+			Return(api.DriverType_DRIVER_TYPE_BLOCK).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Attach(name, gomock.Any()).
+			Return("", nil).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Mount(name, stagingPath, nil).
+			Return(nil).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Unmount(name, stagingPath, nil).
+			Return(nil).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Type().
+			Return(api.DriverType_DRIVER_TYPE_BLOCK).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Detach(name, gomock.Any()).
+			Return(nil).
+			Times(1),
+	)
+
+	// Expect the volume attached and mounted once
+	for _, target := range targets {
+		_, err := c.NodePublishVolume(context.Background(), &csi.NodePublishVolumeRequest{
+			Version:    &csi.Version{},
+			VolumeId:   name,
+			TargetPath: target,
+			VolumeCapability: &csi.VolumeCapability{
+				AccessMode: &csi.VolumeCapability_AccessMode{},
+			},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, stagingPath, m.mounts[target])
+	}
+
+	// Expect the volume detached only once not published anymore
+	for _, target := range targets {
+		_, err := c.NodeUnpublishVolume(context.Background(), &csi.NodeUnpublishVolumeRequest{
+			Version:    &csi.Version{},
+			VolumeId:   name,
+			TargetPath: target,
+		})
+		assert.Nil(t, err)
+		_, err = os.Stat(target)
+		assert.True(t, os.IsNotExist(err))
+	}
+	assert.Empty(t, m.mounts)
+	_, err := os.Stat(stagingPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestNodeUnpublishVolumeStillPublished(t *testing.T) {
+	// Create server and client connection
+	s := newTestServer(t)
+	defer s.Stop()
+	m, dir := setupFakeMounter(t, s)
+	defer cleanupDir(dir)
+
+	// Make a call
+	c := csi.NewNodeClient(s.Conn())
+
+	name := "myvol"
+	targets := []string{filepath.Join(dir, "pod1"), filepath.Join(dir, "pod2")}
+	for _, target := range targets {
+		assert.Nil(t, os.Mkdir(target, 0750))
+	}
+	server := s.Server().(*OsdCsiServer)
+	stagingPath := server.stagingPath(name)
+	s.MockDriver().
+		EXPECT().
+		Inspect([]string{name}).
+		Return([]*api.Volume{
+			&api.Volume{
+				Id: name,
+			},
+		}, nil).
+		Times(3)
+	// The volume must not be unmounted nor detached
+	gomock.InOrder(
+		s.MockDriver().
+			EXPECT().
+			Type().
+			Return(api.DriverType_DRIVER_TYPE_BLOCK).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Attach(name, gomock.Any()).
+			Return("", nil).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Mount(name, stagingPath, nil).
+			Return(nil).
+			Times(1),
+	)
+
+	for _, target := range targets {
+		_, err := c.NodePublishVolume(context.Background(), &csi.NodePublishVolumeRequest{
+			Version:    &csi.Version{},
+			VolumeId:   name,
+			TargetPath: target,
+			VolumeCapability: &csi.VolumeCapability{
+				AccessMode: &csi.VolumeCapability_AccessMode{},
+			},
+		})
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, 2, server.mountManager.HasMounts(stagingPath))
+
+	_, err := c.NodeUnpublishVolume(context.Background(), &csi.NodeUnpublishVolumeRequest{
+		Version:    &csi.Version{},
+		VolumeId:   name,
+		TargetPath: targets[0],
+	})
+	assert.Nil(t, err)
+
+	// Expect the volume still staged and published on the other target
+	_, err = os.Stat(targets[0])
+	assert.True(t, os.IsNotExist(err))
+	assert.Equal(t, map[string]string{targets[1]: stagingPath}, m.mounts)
+	assert.Equal(t, 1, server.mountManager.HasMounts(stagingPath))
+	_, err = os.Stat(stagingPath)
+	assert.Nil(t, err)
 }
 
 func TestNodeUnpublishVolumeVolumeNotFound(t *testing.T) {
@@ -740,6 +919,307 @@ func TestNodeUnpublishVolumeUnmount(t *testing.T) {
 	assert.NotNil(t, r)
 }
 
+// fakeMounter records the bind mounts of the staging paths and devices.
+type fakeMounter struct {
+	mounts map[string]string
+	err    error
+}
+
+func (m *fakeMounter) Mount(
+	source string,
+	target string,
+	fstype string,
+	flags uintptr,
+	data string,
+	timeout int,
+) error {
+	if m.err != nil {
+		return m.err
+	}
+	if flags&syscall.MS_BIND == 0 {
+		return fmt.Errorf("Unexpected flags %v", flags)
+	}
+	m.mounts[target] = source
+	return nil
+}
+
+func (m *fakeMounter) Unmount(target string, flags int, timeout int) error {
+	if _, ok := m.mounts[target]; !ok {
+		return syscall.EINVAL
+	}
+	delete(m.mounts, target)
+	return nil
+}
+
+// setupFakeMounter makes the server of s stage the volumes under a
+// temporary directory, which is returned, and bind mount them with
+// a fakeMounter.
+func setupFakeMounter(t *testing.T, s *testServer) (*fakeMounter, string) {
+	dir, err := ioutil.TempDir("", "csi-node")
+	assert.Nil(t, err)
+
+	m := &fakeMounter{mounts: make(map[string]string)}
+	server := s.Server().(*OsdCsiServer)
+	server.mounter = m
+	server.mountManager, err = mount.New(
+		mount.CustomMount,
+		m,
+		nil,
+		func() (mount.CustomLoad, mount.CustomReload) {
+			return func([]string, mount.DeviceMap, mount.PathMap) error { return nil },
+				func(string, mount.DeviceMap, mount.PathMap) error { return nil }
+		},
+		nil,
+		"")
+	assert.Nil(t, err)
+	server.stagingBase = filepath.Join(dir, "staging")
+	return m, dir
+}
+
+// cleanupDir removes dir, whose mount paths may have been made immutable.
+func cleanupDir(dir string) {
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		chattr.RemoveImmutable(path)
+		return nil
+	})
+	os.RemoveAll(dir)
+}
+
+func blockVolumeCapability() *csi.VolumeCapability {
+	return &csi.VolumeCapability{
+		AccessType: &csi.VolumeCapability_Block{
+			Block: &csi.VolumeCapability_BlockVolume{},
+		},
+		AccessMode: &csi.VolumeCapability_AccessMode{
+			Mode: csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER,
+		},
+	}
+}
+
+func TestNodePublishVolumeBlock(t *testing.T) {
+	// Create server and client connection
+	s := newTestServer(t)
+	defer s.Stop()
+	m, dir := setupFakeMounter(t, s)
+	defer cleanupDir(dir)
+
+	// Make a call
+	c := csi.NewNodeClient(s.Conn())
+
+	name := "myvol"
+	devicePath := "/dev/fake0"
+	targetPath := filepath.Join(dir, "dev")
+	gomock.InOrder(
+		s.MockDriver().
+			EXPECT().
+			Inspect([]string{name}).
+			Return([]*api.Volume{
+				&api.Volume{
+					Id: name,
+					Locator: &api.VolumeLocator{
+						Name: name,
+					},
+				},
+			}, nil).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Type().
+			Return(api.DriverType_DRIVER_TYPE_BLOCK).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Attach(name, gomock.Any()).
+			Return(devicePath, nil).
+			Times(1),
+	)
+
+	req := &csi.NodePublishVolumeRequest{
+		Version:          &csi.Version{},
+		VolumeId:         name,
+		TargetPath:       targetPath,
+		VolumeCapability: blockVolumeCapability(),
+	}
+
+	r, err := c.NodePublishVolume(context.Background(), req)
+	assert.Nil(t, err)
+	assert.NotNil(t, r)
+
+	// Expect the device bind mounted on a new file
+	fileInfo, err := os.Stat(targetPath)
+	assert.Nil(t, err)
+	assert.False(t, fileInfo.IsDir())
+	assert.Equal(t, devicePath, m.mounts[targetPath])
+}
+
+func TestNodePublishVolumeBlockFailedMount(t *testing.T) {
+	// Create server and client connection
+	s := newTestServer(t)
+	defer s.Stop()
+	m, dir := setupFakeMounter(t, s)
+	defer cleanupDir(dir)
+	m.err = fmt.Errorf("TEST")
+
+	// Make a call
+	c := csi.NewNodeClient(s.Conn())
+
+	name := "myvol"
+	gomock.InOrder(
+		s.MockDriver().
+			EXPECT().
+			Inspect([]string{name}).
+			Return([]*api.Volume{
+				&api.Volume{
+					Id: name,
+				},
+			}, nil).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Type().
+			Return(api.DriverType_DRIVER_TYPE_BLOCK).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Attach(name, gomock.Any()).
+			Return("/dev/fake0", nil).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Type().
+			Return(api.DriverType_DRIVER_TYPE_BLOCK).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Detach(name, gomock.Any()).
+			Return(nil).
+			Times(1),
+	)
+
+	req := &csi.NodePublishVolumeRequest{
+		Version:          &csi.Version{},
+		VolumeId:         name,
+		TargetPath:       filepath.Join(dir, "dev"),
+		VolumeCapability: blockVolumeCapability(),
+	}
+
+	_, err := c.NodePublishVolume(context.Background(), req)
+	assert.NotNil(t, err)
+	serverError, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, serverError.Code(), codes.Internal)
+	assert.Contains(t, serverError.Message(), "TEST")
+}
+
+func TestNodePublishVolumeBlockNotSupported(t *testing.T) {
+	// Create server and client connection
+	s := newTestServer(t)
+	defer s.Stop()
+
+	// Make a call
+	c := csi.NewNodeClient(s.Conn())
+
+	name := "myvol"
+	gomock.InOrder(
+		s.MockDriver().
+			EXPECT().
+			Inspect([]string{name}).
+			Return([]*api.Volume{
+				&api.Volume{
+					Id: name,
+				},
+			}, nil).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Type().
+			Return(api.DriverType_DRIVER_TYPE_FILE).
+			Times(1),
+	)
+
+	req := &csi.NodePublishVolumeRequest{
+		Version:          &csi.Version{},
+		VolumeId:         name,
+		TargetPath:       "/mnt/dev",
+		VolumeCapability: blockVolumeCapability(),
+	}
+
+	_, err := c.NodePublishVolume(context.Background(), req)
+	assert.NotNil(t, err)
+	serverError, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, serverError.Code(), codes.InvalidArgument)
+	assert.Contains(t, serverError.Message(), "block")
+}
+
+func TestNodeUnpublishVolumeBlock(t *testing.T) {
+	// Create server and client connection
+	s := newTestServer(t)
+	defer s.Stop()
+	m, dir := setupFakeMounter(t, s)
+	defer cleanupDir(dir)
+
+	// Make a call
+	c := csi.NewNodeClient(s.Conn())
+
+	name := "myvol"
+	devicePath := "/dev/fake0"
+	targets := []string{filepath.Join(dir, "dev1"), filepath.Join(dir, "dev2")}
+	s.MockDriver().
+		EXPECT().
+		Inspect([]string{name}).
+		Return([]*api.Volume{
+			&api.Volume{
+				Id:         name,
+				DevicePath: devicePath,
+			},
+		}, nil).
+		Times(4)
+	s.MockDriver().
+		EXPECT().
+		Type().
+		Return(api.DriverType_DRIVER_TYPE_BLOCK).
+		Times(3)
+	gomock.InOrder(
+		s.MockDriver().
+			EXPECT().
+			Attach(name, gomock.Any()).
+			Return(devicePath, nil).
+			Times(1),
+		s.MockDriver().
+			EXPECT().
+			Detach(name, gomock.Any()).
+			Return(nil).
+			Times(1),
+	)
+
+	// Expect the volume attached once
+	for _, target := range targets {
+		_, err := c.NodePublishVolume(context.Background(), &csi.NodePublishVolumeRequest{
+			Version:          &csi.Version{},
+			VolumeId:         name,
+			TargetPath:       target,
+			VolumeCapability: blockVolumeCapability(),
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, devicePath, m.mounts[target])
+	}
+
+	// Expect the device unmounted, the files removed and the volume
+	// detached once not published anymore
+	for _, target := range targets {
+		_, err := c.NodeUnpublishVolume(context.Background(), &csi.NodeUnpublishVolumeRequest{
+			Version:    &csi.Version{},
+			VolumeId:   name,
+			TargetPath: target,
+		})
+		assert.Nil(t, err)
+		_, err = os.Stat(target)
+		assert.True(t, os.IsNotExist(err))
+	}
+	assert.Empty(t, m.mounts)
+}
+
 func TestNodeProbe(t *testing.T) {
 	// Create server and client connection
 	s := newTestServer(t)
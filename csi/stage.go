@@ -0,0 +1,213 @@
+/*
+Package csi is CSI driver interface for OSD
+Copyright 2017 Portworx
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package csi
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	dockermount "github.com/docker/docker/pkg/mount"
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/mount"
+)
+
+// The spec version vendored does not have the NodeStageVolume and
+// NodeUnstageVolume RPCs, so volumes are staged by NodePublishVolume and
+// unstaged by NodeUnpublishVolume. A volume is attached and mounted once on
+// the node on its staging path, which is bind mounted onto every target
+// path. The raw block volumes are attached once and their device bind
+// mounted onto every target path. The bind mounts are tracked by
+// mountManager, whose count of mounts of a staging path or device is the
+// number of times the volume is published on the node.
+
+const (
+	// defaultStagingPath is the directory under which the volumes of
+	// a driver are staged.
+	defaultStagingPath = "/var/lib/osd/csi/staging"
+)
+
+// stagingPath returns the path volume id is staged on.
+func (s *OsdCsiServer) stagingPath(id string) string {
+	return filepath.Join(s.stagingBase, id)
+}
+
+// nodeStageVolume attaches v if its driver is a block driver and mounts it
+// on its staging path, unless it is already staged. It returns the staging
+// path.
+func (s *OsdCsiServer) nodeStageVolume(v *api.Volume, opts map[string]string) (string, error) {
+	stagingPath := s.stagingPath(v.GetId())
+	if s.mountManager.HasMounts(stagingPath) > 0 {
+		return stagingPath, nil
+	}
+	for _, p := range v.GetAttachPath() {
+		if p == stagingPath {
+			return stagingPath, nil
+		}
+	}
+
+	if err := os.MkdirAll(stagingPath, 0750); err != nil {
+		return "", err
+	}
+
+	isBlock := s.driver.Type() == api.DriverType_DRIVER_TYPE_BLOCK
+	if isBlock {
+		if _, err := s.driver.Attach(v.GetId(), opts); err != nil {
+			return "", fmt.Errorf("Unable to attach volume: %s", err.Error())
+		}
+	}
+	if err := s.driver.Mount(v.GetId(), stagingPath, nil); err != nil {
+		if isBlock {
+			if detachErr := s.driver.Detach(v.GetId(), opts); detachErr != nil {
+				dlog.Errorf("Unable to detach volume %s: %s",
+					v.GetId(),
+					detachErr.Error())
+			}
+		}
+		return "", fmt.Errorf("Unable to mount volume on %s: %s", stagingPath, err.Error())
+	}
+
+	dlog.Infof("Volume %s staged on %s", v.GetId(), stagingPath)
+	return stagingPath, nil
+}
+
+// nodeStageBlockVolume attaches v, unless its device is already bind
+// mounted on a target path. It returns the path of the device.
+func (s *OsdCsiServer) nodeStageBlockVolume(v *api.Volume, opts map[string]string) (string, error) {
+	for _, devicePath := range []string{v.GetSecureDevicePath(), v.GetDevicePath()} {
+		if len(devicePath) != 0 && s.mountManager.HasMounts(devicePath) > 0 {
+			return devicePath, nil
+		}
+	}
+
+	devicePath, err := s.driver.Attach(v.GetId(), opts)
+	if err == nil && len(devicePath) == 0 {
+		err = fmt.Errorf("Driver did not return a device path")
+	}
+	return devicePath, err
+}
+
+// nodeUnstageVolume unmounts volume id from its staging path, if source is
+// its staging path, and detaches it if its driver is a block driver.
+func (s *OsdCsiServer) nodeUnstageVolume(id, source string) error {
+	if source == s.stagingPath(id) {
+		if err := s.driver.Unmount(id, source, nil); err != nil {
+			return fmt.Errorf("Unable to unmount volume from %s: %s", source, err.Error())
+		}
+		if err := os.Remove(source); err != nil && !os.IsNotExist(err) {
+			dlog.Warnf("Unable to remove staging path %s: %s", source, err.Error())
+		}
+	}
+
+	if s.driver.Type() == api.DriverType_DRIVER_TYPE_BLOCK {
+		if err := s.driver.Detach(id, nil); err != nil {
+			return fmt.Errorf("Unable to detach volume: %s", err.Error())
+		}
+	}
+
+	dlog.Infof("Volume %s unstaged", id)
+	return nil
+}
+
+// maybeUnstageVolume unstages volume id if source is not bind mounted on
+// any target path, after a failure to publish it.
+func (s *OsdCsiServer) maybeUnstageVolume(id, source string) {
+	if s.mountManager.HasMounts(source) != 0 {
+		return
+	}
+	if err := s.nodeUnstageVolume(id, source); err != nil {
+		dlog.Errorf("Unable to unstage volume %s: %s", id, err.Error())
+	}
+}
+
+// newMountManager returns a mount manager tracking the bind mounts of the
+// staging paths under stagingBase and of the devices of the volumes, which
+// are reloaded from the mount table of the node.
+func newMountManager(mountImpl mount.MountImpl, stagingBase string) (mount.Manager, error) {
+	load := func(_ []string, mounts mount.DeviceMap, paths mount.PathMap) error {
+		return loadBindMounts(stagingBase, mounts, paths)
+	}
+	reload := func(_ string, mounts mount.DeviceMap, paths mount.PathMap) error {
+		return loadBindMounts(stagingBase, mounts, paths)
+	}
+	return mount.New(
+		mount.CustomMount,
+		mountImpl,
+		nil,
+		func() (mount.CustomLoad, mount.CustomReload) { return load, reload },
+		nil,
+		"")
+}
+
+// loadBindMounts adds the bind mounts of the staging paths under
+// stagingBase and of the block devices to mounts and paths.
+func loadBindMounts(stagingBase string, mounts mount.DeviceMap, paths mount.PathMap) error {
+	infos, err := dockermount.GetMounts()
+	if err != nil {
+		return err
+	}
+
+	// A bind mount has the device and root of the mount it is made from
+	key := func(info *dockermount.Info) string {
+		return fmt.Sprintf("%d:%d:%s", info.Major, info.Minor, info.Root)
+	}
+	staged := make(map[string]string)
+	for _, info := range infos {
+		if strings.HasPrefix(info.Mountpoint, stagingBase+"/") {
+			staged[key(info)] = info.Mountpoint
+		}
+	}
+
+	for _, info := range infos {
+		if strings.HasPrefix(info.Mountpoint, stagingBase+"/") {
+			continue
+		}
+		source, ok := staged[key(info)]
+		if !ok {
+			if info.Fstype != "devtmpfs" || info.Root == "/" {
+				continue
+			}
+			source = filepath.Join("/dev", info.Root)
+			if fileInfo, err := os.Stat(source); err != nil ||
+				fileInfo.Mode()&os.ModeDevice == 0 ||
+				fileInfo.Mode()&os.ModeCharDevice != 0 {
+				continue
+			}
+		}
+		addBindMount(mounts, paths, source, info.Mountpoint)
+	}
+	return nil
+}
+
+// addBindMount adds the mount of source on path, unless already present.
+func addBindMount(mounts mount.DeviceMap, paths mount.PathMap, source, path string) {
+	info, ok := mounts[source]
+	if !ok {
+		info = &mount.Info{Device: source, Mountpoint: make([]*mount.PathInfo, 0)}
+		mounts[source] = info
+	}
+	for _, p := range info.Mountpoint {
+		if p.Path == path {
+			return
+		}
+	}
+	info.Mountpoint = append(info.Mountpoint, &mount.PathInfo{Path: path})
+	paths[path] = source
+}
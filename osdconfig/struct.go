@@ -62,6 +62,7 @@ type SecretsConfig struct {
 	ClusterSecretKey string       `json:"cluster_secret_key,omitempty"`
 	Vault            *VaultConfig `json:"vault,omitempty"`
 	Aws              *AWSConfig   `json:"aws,omitempty"`
+	Local            *LocalConfig `json:"local,omitempty"`
 }
 
 // VaultConfig is a vault configuration parameters struct
@@ -86,6 +87,12 @@ type AWSConfig struct {
 	AwsRegion          string `json:"aws_region,omitempty"`
 }
 
+// LocalConfig is a configuration parameters struct for secrets kept in
+// files on the local node
+type LocalConfig struct {
+	SecretsDir string `json:"secrets_dir,omitempty"`
+}
+
 // StorageConfig is a storage configuration parameters struct
 type StorageConfig struct {
 	DevicesMd        []string `json:"devices_md,omitempty"`
@@ -0,0 +1,145 @@
+// Package audit records who made which control plane requests.
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+
+	"go.pedge.io/dlog"
+)
+
+const (
+	// ResultOK is the result of successful requests
+	ResultOK = "OK"
+	// CallerLocal identifies unauthenticated callers on unix sockets
+	CallerLocal = "local"
+)
+
+// Record of a request. Records are never modified once logged.
+type Record struct {
+	// Time the request was received at
+	Time time.Time `json:"time"`
+	// Caller that made the request
+	Caller string `json:"caller"`
+	// Service the request was made to, such as a volume driver
+	Service string `json:"service"`
+	// Verb of the request, the HTTP method and route or the RPC
+	Verb string `json:"verb"`
+	// Resource is the ID of the volume, node or other object the request
+	// is for, if any
+	Resource string `json:"resource,omitempty"`
+	// RequestHash is the SHA256 of the request body
+	RequestHash string `json:"request_hash,omitempty"`
+	// Result is ResultOK or the error of the request
+	Result string `json:"result"`
+	// Latency of the request
+	Latency time.Duration `json:"latency"`
+}
+
+// Filter selects records.
+type Filter struct {
+	// Start selects records at or after Start if set
+	Start time.Time
+	// End selects records before End if set
+	End time.Time
+	// Resource selects the records of a resource if set
+	Resource string
+}
+
+// Match returns true if the filter selects r.
+func (f *Filter) Match(r *Record) bool {
+	if !f.Start.IsZero() && r.Time.Before(f.Start) {
+		return false
+	}
+	if !f.End.IsZero() && !r.Time.Before(f.End) {
+		return false
+	}
+	return f.Resource == "" || f.Resource == r.Resource
+}
+
+// Logger stores records.
+type Logger interface {
+	// Log appends r to the log.
+	Log(r *Record) error
+	// Query returns the records selected by f, oldest first.
+	Query(f *Filter) ([]*Record, error)
+}
+
+var (
+	instance Logger
+	lock     sync.RWMutex
+)
+
+// SetInstance sets the Logger records are logged to. Records are dropped
+// if it is nil.
+func SetInstance(l Logger) {
+	lock.Lock()
+	defer lock.Unlock()
+	instance = l
+}
+
+// Instance returns the Logger records are logged to, nil if auditing is
+// disabled.
+func Instance() Logger {
+	lock.RLock()
+	defer lock.RUnlock()
+	return instance
+}
+
+// Enabled returns true if records are logged.
+func Enabled() bool {
+	return Instance() != nil
+}
+
+// Log logs r to the instance. Failures are logged as warnings rather than
+// failing the request that was audited.
+func Log(r *Record) {
+	l := Instance()
+	if l == nil {
+		return
+	}
+	if err := l.Log(r); err != nil {
+		dlog.Warnf("Failed to log audit record of %v %v by %v: %v",
+			r.Verb, r.Resource, r.Caller, err)
+	}
+}
+
+// Hash returns the hash of a request body.
+func Hash(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// NewMultiLogger returns a Logger logging to all of loggers, and querying
+// the first of them.
+func NewMultiLogger(loggers ...Logger) Logger {
+	return multiLogger(loggers)
+}
+
+type multiLogger []Logger
+
+func (m multiLogger) Log(r *Record) error {
+	var err error
+	for _, l := range m {
+		if lerr := l.Log(r); lerr != nil {
+			err = lerr
+		}
+	}
+	return err
+}
+
+func (m multiLogger) Query(f *Filter) ([]*Record, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+	return m[0].Query(f)
+}
+
+func sortRecords(records []*Record) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time.Before(records[j].Time)
+	})
+}
@@ -0,0 +1,91 @@
+package audit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/portworx/kvdb"
+	"github.com/portworx/kvdb/mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRecords(start time.Time) []*Record {
+	records := make([]*Record, 0)
+	for i, resource := range []string{"vol1", "vol2", "vol1", "node1"} {
+		records = append(records, &Record{
+			Time:     start.Add(time.Duration(i) * time.Minute),
+			Caller:   "user",
+			Service:  "test",
+			Verb:     "DELETE /v1/osd-volumes/{id}",
+			Resource: resource,
+			Result:   ResultOK,
+		})
+	}
+	return records
+}
+
+func testQuery(t *testing.T, l Logger) {
+	start := time.Now().Add(-time.Hour).Round(time.Second).UTC()
+	for _, r := range testRecords(start) {
+		require.NoError(t, l.Log(r))
+	}
+
+	all, err := l.Query(&Filter{})
+	require.NoError(t, err)
+	require.Len(t, all, 4)
+	assert.True(t, all[0].Time.Equal(start))
+
+	vol1, err := l.Query(&Filter{Resource: "vol1"})
+	require.NoError(t, err)
+	require.Len(t, vol1, 2)
+
+	window, err := l.Query(&Filter{
+		Start: start.Add(time.Minute),
+		End:   start.Add(3 * time.Minute),
+	})
+	require.NoError(t, err)
+	require.Len(t, window, 2)
+	assert.Equal(t, "vol2", window[0].Resource)
+	assert.Equal(t, "vol1", window[1].Resource)
+}
+
+func TestFileLogger(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	l, err := NewFileLogger(filepath.Join(dir, "audit.log"), 0, 2)
+	require.NoError(t, err)
+	testQuery(t, l)
+}
+
+func TestFileLoggerRotate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// Every record rotates the file, so only the last three are kept.
+	path := filepath.Join(dir, "audit.log")
+	l, err := NewFileLogger(path, 1, 2)
+	require.NoError(t, err)
+	for _, r := range testRecords(time.Now()) {
+		require.NoError(t, l.Log(r))
+	}
+	records, err := l.Query(&Filter{})
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, "vol2", records[0].Resource)
+	assert.Equal(t, "node1", records[2].Resource)
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestKvdbLogger(t *testing.T) {
+	kv, err := kvdb.New(mem.Name, "audit_test", []string{}, nil, nil)
+	require.NoError(t, err)
+	testQuery(t, NewKvdbLogger(kv, 0))
+}
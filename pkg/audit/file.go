@@ -0,0 +1,146 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// DefaultMaxSize of an audit log file before it is rotated
+	DefaultMaxSize = 100 * 1024 * 1024
+	// DefaultMaxBackups is the number of rotated audit log files kept
+	DefaultMaxBackups = 5
+)
+
+type fileLogger struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+	lock       sync.Mutex
+}
+
+// NewFileLogger returns a Logger appending records as JSON lines to path.
+// The file is rotated to path.1 once it grows past maxSize, keeping at
+// most maxBackups rotated files. Defaults are used for sizes of zero.
+func NewFileLogger(path string, maxSize int64, maxBackups int) (Logger, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+	if maxBackups <= 0 {
+		maxBackups = DefaultMaxBackups
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	l := &fileLogger{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *fileLogger) open() error {
+	f, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	l.file = f
+	l.size = st.Size()
+	return nil
+}
+
+func (l *fileLogger) Log(r *Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.size > 0 && l.size+int64(len(data)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := l.file.Write(data)
+	l.size += int64(n)
+	return err
+}
+
+// rotate shifts path.N to path.N+1, dropping the oldest file, and starts a
+// new file at path.
+func (l *fileLogger) rotate() error {
+	if err := l.file.Close(); err != nil {
+		return err
+	}
+	os.Remove(l.backup(l.maxBackups))
+	for i := l.maxBackups - 1; i > 0; i-- {
+		if err := os.Rename(l.backup(i), l.backup(i+1)); err != nil &&
+			!os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(l.path, l.backup(1)); err != nil {
+		return err
+	}
+	return l.open()
+}
+
+func (l *fileLogger) backup(n int) string {
+	return fmt.Sprintf("%s.%d", l.path, n)
+}
+
+func (l *fileLogger) Query(f *Filter) ([]*Record, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	records := make([]*Record, 0)
+	files := []string{l.path}
+	for i := 1; i <= l.maxBackups; i++ {
+		files = append([]string{l.backup(i)}, files...)
+	}
+	for _, path := range files {
+		if err := readRecords(path, f, &records); err != nil {
+			return nil, err
+		}
+	}
+	sortRecords(records)
+	return records, nil
+}
+
+func readRecords(path string, f *Filter, records *[]*Record) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		r := &Record{}
+		if err := json.Unmarshal(scanner.Bytes(), r); err != nil {
+			// Skip a line torn by a crash.
+			continue
+		}
+		if f.Match(r) {
+			*records = append(*records, r)
+		}
+	}
+	return scanner.Err()
+}
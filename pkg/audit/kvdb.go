@@ -0,0 +1,52 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/portworx/kvdb"
+)
+
+const kvdbPrefix = "openstorage/audit/"
+
+type kvdbLogger struct {
+	kv  kvdb.Kvdb
+	ttl uint64
+	seq uint64
+}
+
+// NewKvdbLogger returns a Logger storing records in kv, shared by the
+// nodes of a cluster. Records expire after ttl, or never if it is zero.
+func NewKvdbLogger(kv kvdb.Kvdb, ttl time.Duration) Logger {
+	return &kvdbLogger{kv: kv, ttl: uint64(ttl.Seconds())}
+}
+
+func (l *kvdbLogger) Log(r *Record) error {
+	// Keys sort by time and are created only once, so records are never
+	// overwritten.
+	key := fmt.Sprintf("%s%020d-%d", kvdbPrefix, r.Time.UnixNano(),
+		atomic.AddUint64(&l.seq, 1))
+	_, err := l.kv.Create(key, r, l.ttl)
+	return err
+}
+
+func (l *kvdbLogger) Query(f *Filter) ([]*Record, error) {
+	kvp, err := l.kv.Enumerate(kvdbPrefix)
+	if err != nil {
+		return nil, err
+	}
+	records := make([]*Record, 0)
+	for _, kv := range kvp {
+		r := &Record{}
+		if err := json.Unmarshal(kv.Value, r); err != nil {
+			return nil, err
+		}
+		if f.Match(r) {
+			records = append(records, r)
+		}
+	}
+	sortRecords(records)
+	return records, nil
+}
@@ -0,0 +1,249 @@
+// Package auth verifies the signed tokens callers of the openstorage APIs
+// identify themselves with.
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	algHS256 = "HS256"
+	algRS256 = "RS256"
+)
+
+// Roles the APIs grant access by.
+const (
+	// RoleAdmin may make any request
+	RoleAdmin = "admin"
+	// RoleVolumeOperator may manage volumes and read the cluster state and
+	// the quotas
+	RoleVolumeOperator = "volume-operator"
+	// RoleReadOnly may only make requests reading state
+	RoleReadOnly = "read-only"
+)
+
+var (
+	// ErrInvalidToken returned when a token is malformed or its signature
+	// does not verify
+	ErrInvalidToken = errors.New("Invalid token")
+	// ErrTokenExpired returned when a token is expired or not valid yet
+	ErrTokenExpired = errors.New("Token expired")
+)
+
+// Claims are the claims of a token.
+type Claims struct {
+	// Issuer of the token
+	Issuer string `json:"iss,omitempty"`
+	// Subject is the unique identity of the caller
+	Subject string `json:"sub,omitempty"`
+	// Name of the caller
+	Name string `json:"name,omitempty"`
+	// Email of the caller
+	Email string `json:"email,omitempty"`
+	// Roles granted to the caller
+	Roles []string `json:"roles,omitempty"`
+	// ExpiresAt is the unix time after which the token is rejected
+	ExpiresAt int64 `json:"exp,omitempty"`
+	// NotBefore is the unix time before which the token is rejected
+	NotBefore int64 `json:"nbf,omitempty"`
+	// IssuedAt is the unix time the token was issued at
+	IssuedAt int64 `json:"iat,omitempty"`
+}
+
+// Authenticator verifies tokens.
+type Authenticator interface {
+	// AuthenticateToken returns the claims of token if it is valid.
+	AuthenticateToken(token string) (*Claims, error)
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+}
+
+type jwtAuthenticator struct {
+	issuer  string
+	hmacKey []byte
+	rsaKey  *rsa.PublicKey
+}
+
+// NewJwtAuthenticator returns an Authenticator for JWTs issued by issuer.
+// key is either a PEM encoded RSA public key or certificate, verifying
+// RS256 tokens, or a shared secret verifying HS256 tokens. If issuer is
+// empty the issuer of tokens is not checked.
+func NewJwtAuthenticator(issuer string, key []byte) (Authenticator, error) {
+	a := &jwtAuthenticator{issuer: issuer}
+	if block, _ := pem.Decode(key); block != nil {
+		pub, err := parsePublicKey(block)
+		if err != nil {
+			return nil, err
+		}
+		a.rsaKey = pub
+		return a, nil
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("Missing token issuer key")
+	}
+	a.hmacKey = key
+	return a, nil
+}
+
+func (a *jwtAuthenticator) AuthenticateToken(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+	header := &jwtHeader{}
+	if err := decodeSegment(parts[0], header); err != nil {
+		return nil, ErrInvalidToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	signed := []byte(parts[0] + "." + parts[1])
+
+	// Only the algorithm of the configured key is accepted.
+	switch {
+	case a.rsaKey != nil && header.Alg == algRS256:
+		hash := sha256.Sum256(signed)
+		if rsa.VerifyPKCS1v15(a.rsaKey, crypto.SHA256, hash[:], sig) != nil {
+			return nil, ErrInvalidToken
+		}
+	case a.hmacKey != nil && header.Alg == algHS256:
+		if !hmac.Equal(sig, hmacSign(a.hmacKey, signed)) {
+			return nil, ErrInvalidToken
+		}
+	default:
+		return nil, ErrInvalidToken
+	}
+
+	claims := &Claims{}
+	if err := decodeSegment(parts[1], claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	now := time.Now().Unix()
+	if claims.ExpiresAt != 0 && now >= claims.ExpiresAt {
+		return nil, ErrTokenExpired
+	}
+	if claims.NotBefore != 0 && now < claims.NotBefore {
+		return nil, ErrTokenExpired
+	}
+	if a.issuer != "" && claims.Issuer != a.issuer {
+		return nil, fmt.Errorf("Token issued by %q, expected %q", claims.Issuer, a.issuer)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("Token has no subject")
+	}
+	return claims, nil
+}
+
+// Token returns a JWT with claims signed by key. key is either a PEM
+// encoded RSA private key, signing a RS256 token, or a shared secret,
+// signing a HS256 token.
+func Token(claims *Claims, key []byte) (string, error) {
+	var rsaKey *rsa.PrivateKey
+	header := &jwtHeader{Alg: algHS256, Typ: "JWT"}
+	if block, _ := pem.Decode(key); block != nil {
+		var err error
+		if rsaKey, err = parsePrivateKey(block); err != nil {
+			return "", err
+		}
+		header.Alg = algRS256
+	} else if len(key) == 0 {
+		return "", fmt.Errorf("Missing token issuer key")
+	}
+
+	h, err := encodeSegment(header)
+	if err != nil {
+		return "", err
+	}
+	c, err := encodeSegment(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := h + "." + c
+	var sig []byte
+	if rsaKey != nil {
+		hash := sha256.Sum256([]byte(signed))
+		if sig, err = rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, hash[:]); err != nil {
+			return "", err
+		}
+	} else {
+		sig = hmacSign(key, []byte(signed))
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+func hmacSign(key []byte, data []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func encodeSegment(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func parsePublicKey(block *pem.Block) (*rsa.PublicKey, error) {
+	var key interface{}
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		key = cert.PublicKey
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		var err error
+		if key, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+			return nil, err
+		}
+	}
+	pub, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("Token issuer key is not a RSA public key")
+	}
+	return pub, nil
+}
+
+func parsePrivateKey(block *pem.Block) (*rsa.PrivateKey, error) {
+	if block.Type == "RSA PRIVATE KEY" {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	priv, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("Token signing key is not a RSA private key")
+	}
+	return priv, nil
+}
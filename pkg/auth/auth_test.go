@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testClaims() *Claims {
+	return &Claims{
+		Issuer:    "openstorage",
+		Subject:   "user@example.com",
+		Roles:     []string{"admin"},
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func TestHS256(t *testing.T) {
+	key := []byte("issuer secret")
+	a, err := NewJwtAuthenticator("openstorage", key)
+	require.NoError(t, err)
+
+	token, err := Token(testClaims(), key)
+	require.NoError(t, err)
+	claims, err := a.AuthenticateToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", claims.Subject)
+	assert.Equal(t, []string{"admin"}, claims.Roles)
+
+	// Tokens signed with another key or tampered with are rejected.
+	other, err := Token(testClaims(), []byte("other secret"))
+	require.NoError(t, err)
+	_, err = a.AuthenticateToken(other)
+	assert.Equal(t, ErrInvalidToken, err)
+	parts := strings.Split(token, ".")
+	forged := &Claims{Issuer: "openstorage", Subject: "user@example.com",
+		Roles: []string{"admin", "volume-operator"}}
+	payload, err := encodeSegment(forged)
+	require.NoError(t, err)
+	_, err = a.AuthenticateToken(parts[0] + "." + payload + "." + parts[2])
+	assert.Equal(t, ErrInvalidToken, err)
+	_, err = a.AuthenticateToken("garbage")
+	assert.Equal(t, ErrInvalidToken, err)
+
+	expired := testClaims()
+	expired.ExpiresAt = time.Now().Add(-time.Minute).Unix()
+	token, err = Token(expired, key)
+	require.NoError(t, err)
+	_, err = a.AuthenticateToken(token)
+	assert.Equal(t, ErrTokenExpired, err)
+
+	wrongIssuer := testClaims()
+	wrongIssuer.Issuer = "someone"
+	token, err = Token(wrongIssuer, key)
+	require.NoError(t, err)
+	_, err = a.AuthenticateToken(token)
+	assert.Error(t, err)
+}
+
+func TestRS256(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	privPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(priv),
+	})
+	pubDER, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+
+	a, err := NewJwtAuthenticator("", pubPEM)
+	require.NoError(t, err)
+	token, err := Token(testClaims(), privPEM)
+	require.NoError(t, err)
+	claims, err := a.AuthenticateToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", claims.Subject)
+
+	// A HS256 token keyed with the public key must not verify.
+	hsToken, err := Token(testClaims(), pubDER)
+	require.NoError(t, err)
+	_, err = a.AuthenticateToken(hsToken)
+	assert.Equal(t, ErrInvalidToken, err)
+}
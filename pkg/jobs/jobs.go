@@ -0,0 +1,341 @@
+// Package jobs runs long volume operations in the background and tracks
+// their progress. Jobs interrupted by a restart of their node are resumed
+// by Resume if their operation can safely be run again, and failed
+// otherwise.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/portworx/kvdb"
+	"go.pedge.io/dlog"
+)
+
+// States of a job.
+const (
+	// StatePending jobs wait for a worker
+	StatePending = "pending"
+	// StateRunning jobs are being run
+	StateRunning = "running"
+	// StateDone jobs completed successfully
+	StateDone = "done"
+	// StateFailed jobs returned an error or were interrupted by a restart
+	// and could not be resumed
+	StateFailed = "failed"
+	// StateCancelled jobs were cancelled before they completed
+	StateCancelled = "cancelled"
+)
+
+const kvdbPrefix = "openstorage/jobs/"
+
+var (
+	// ErrNotFound returned when a job does not exist
+	ErrNotFound = errors.New("Job not found")
+	// ErrCompleted returned when cancelling a completed job
+	ErrCompleted = errors.New("Job already completed")
+	// ErrInterrupted is the error of jobs whose node restarted while they
+	// were pending or running, and which could not be resumed
+	ErrInterrupted = errors.New("Job interrupted by a restart of its node")
+)
+
+// Job is a volume operation run in the background.
+type Job struct {
+	// Id of the job
+	Id string `json:"id"`
+	// Type of operation, such as create or snapshot
+	Type string `json:"type"`
+	// Driver the operation is made to
+	Driver string `json:"driver"`
+	// Resource is the ID of the volume the operation is for, if any
+	Resource string `json:"resource,omitempty"`
+	// Node running the job
+	Node string `json:"node"`
+	// State of the job
+	State string `json:"state"`
+	// Progress in percent
+	Progress int `json:"progress"`
+	// Result of the operation once done, such as the ID of a new volume
+	Result string `json:"result,omitempty"`
+	// Error of the operation if it failed
+	Error string `json:"error,omitempty"`
+	// Request the job was submitted with, from which it may be resumed
+	Request json.RawMessage `json:"request,omitempty"`
+	// Created is the time the job was submitted at
+	Created time.Time `json:"created"`
+	// Updated is the time the job last changed at
+	Updated time.Time `json:"updated"`
+}
+
+// Completed returns true if the job will not change anymore.
+func (j *Job) Completed() bool {
+	return j.State == StateDone || j.State == StateFailed || j.State == StateCancelled
+}
+
+// Func runs the operation of a job. It returns the result of the job, and
+// may report the progress of the job in percent. Operations should stop
+// early once ctx is cancelled, if they can.
+type Func func(ctx context.Context, progress func(percent int)) (string, error)
+
+// ResumeFunc returns the operation of a job interrupted by a restart, from
+// the request of the job. It returns an error if the operation cannot be run
+// again, such as one creating a volume which may already have been created.
+type ResumeFunc func(j *Job) (Func, error)
+
+// Manager runs jobs and stores them in kvdb, so that they outlive the
+// process and can be inspected from any node.
+type Manager struct {
+	kv        kvdb.Kvdb
+	node      string
+	retention uint64
+	workers   chan struct{}
+	lock      sync.Mutex
+	cancels   map[string]context.CancelFunc
+}
+
+// NewManager returns a Manager running up to workers jobs at once on node.
+// Completed jobs are kept for retention, or forever if it is zero. Jobs of
+// node left pending or running by a previous process stay so until Resume
+// is called.
+func NewManager(kv kvdb.Kvdb, node string, workers int, retention time.Duration) (*Manager, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	m := &Manager{
+		kv:        kv,
+		node:      node,
+		retention: uint64(retention.Seconds()),
+		workers:   make(chan struct{}, workers),
+		cancels:   make(map[string]context.CancelFunc),
+	}
+	return m, nil
+}
+
+// Resume runs again the jobs of the node left pending or running by a
+// previous process, with the operation returned by the ResumeFunc of their
+// type. Jobs without a ResumeFunc, or whose ResumeFunc fails, are marked
+// failed with ErrInterrupted.
+func (m *Manager) Resume(resumers map[string]ResumeFunc) error {
+	jobs, err := m.Enumerate()
+	if err != nil {
+		return err
+	}
+	for _, j := range jobs {
+		if j.Node != m.node || j.Completed() {
+			continue
+		}
+		var fn Func
+		if resume, ok := resumers[j.Type]; ok {
+			if fn, err = resume(j); err != nil {
+				dlog.Infof("Not resuming %v job %v: %v", j.Type, j.Id, err)
+			}
+		}
+		if fn == nil {
+			j.State = StateFailed
+			j.Error = ErrInterrupted.Error()
+			if err := m.put(j); err != nil {
+				return err
+			}
+			continue
+		}
+		dlog.Infof("Resuming %v job %v", j.Type, j.Id)
+		j.State = StatePending
+		j.Progress = 0
+		if err := m.put(j); err != nil {
+			return err
+		}
+		m.start(j, fn)
+	}
+	return nil
+}
+
+// Submit runs fn in the background as a job of type jobType for resource
+// of driver, and returns the pending job. The job is failed if its node
+// restarts before it completes.
+func (m *Manager) Submit(jobType string, driver string, resource string, fn Func) (*Job, error) {
+	return m.SubmitRequest(jobType, driver, resource, nil, fn)
+}
+
+// SubmitRequest is Submit for a job whose operation is made by request,
+// which is stored with the job for Resume.
+func (m *Manager) SubmitRequest(
+	jobType string,
+	driver string,
+	resource string,
+	request interface{},
+	fn Func,
+) (*Job, error) {
+	now := time.Now()
+	j := &Job{
+		Id:       uuid.New(),
+		Type:     jobType,
+		Driver:   driver,
+		Resource: resource,
+		Node:     m.node,
+		State:    StatePending,
+		Created:  now,
+		Updated:  now,
+	}
+	if request != nil {
+		b, err := json.Marshal(request)
+		if err != nil {
+			return nil, err
+		}
+		j.Request = b
+	}
+	if _, err := m.kv.Create(kvdbPrefix+j.Id, j, 0); err != nil {
+		return nil, err
+	}
+	submitted := *j
+	m.start(j, fn)
+	return &submitted, nil
+}
+
+// start runs fn in the background as the operation of pending job j.
+func (m *Manager) start(j *Job, fn Func) {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.lock.Lock()
+	m.cancels[j.Id] = cancel
+	m.lock.Unlock()
+	go m.run(ctx, j, fn)
+}
+
+func (m *Manager) run(ctx context.Context, j *Job, fn Func) {
+	defer func() {
+		m.lock.Lock()
+		delete(m.cancels, j.Id)
+		m.lock.Unlock()
+	}()
+
+	select {
+	case m.workers <- struct{}{}:
+		defer func() { <-m.workers }()
+	case <-ctx.Done():
+		j.State = StateCancelled
+		m.update(j)
+		return
+	}
+
+	j.State = StateRunning
+	m.update(j)
+	result, err := fn(ctx, func(percent int) {
+		if percent != j.Progress {
+			j.Progress = percent
+			m.update(j)
+		}
+	})
+	j.Result = result
+	switch {
+	case err == nil:
+		j.State = StateDone
+		j.Progress = 100
+	case ctx.Err() != nil:
+		j.State = StateCancelled
+		j.Error = err.Error()
+	default:
+		j.State = StateFailed
+		j.Error = err.Error()
+	}
+	m.update(j)
+}
+
+// update stores j, logging failures as the job keeps running regardless.
+func (m *Manager) update(j *Job) {
+	if err := m.put(j); err != nil {
+		dlog.Warnf("Failed to update %v job %v: %v", j.Type, j.Id, err)
+	}
+}
+
+func (m *Manager) put(j *Job) error {
+	j.Updated = time.Now()
+	ttl := uint64(0)
+	if j.Completed() {
+		ttl = m.retention
+	}
+	_, err := m.kv.Put(kvdbPrefix+j.Id, j, ttl)
+	return err
+}
+
+// Get returns the job with ID id.
+func (m *Manager) Get(id string) (*Job, error) {
+	kvp, err := m.kv.Get(kvdbPrefix + id)
+	if err == kvdb.ErrNotFound {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	j := &Job{}
+	if err := json.Unmarshal(kvp.Value, j); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// Enumerate returns the jobs of all nodes, oldest first.
+func (m *Manager) Enumerate() ([]*Job, error) {
+	kvp, err := m.kv.Enumerate(kvdbPrefix)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]*Job, 0, len(kvp))
+	for _, kv := range kvp {
+		j := &Job{}
+		if err := json.Unmarshal(kv.Value, j); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+	}
+	sort.Slice(jobs, func(i, k int) bool { return jobs[i].Created.Before(jobs[k].Created) })
+	return jobs, nil
+}
+
+// Cancel cancels the job with ID id. Pending jobs never run, running jobs
+// have their context cancelled and are cancelled once their operation
+// returns.
+func (m *Manager) Cancel(id string) error {
+	j, err := m.Get(id)
+	if err != nil {
+		return err
+	}
+	if j.Completed() {
+		return ErrCompleted
+	}
+	m.lock.Lock()
+	cancel, ok := m.cancels[id]
+	m.lock.Unlock()
+	if !ok && j.Node == m.node {
+		// It completed since it was read.
+		return ErrCompleted
+	} else if !ok {
+		return fmt.Errorf("Job %v runs on node %v", id, j.Node)
+	}
+	cancel()
+	return nil
+}
+
+var (
+	instance *Manager
+	lock     sync.RWMutex
+)
+
+// SetInstance sets the Manager jobs are submitted to. Operations cannot
+// be run in the background if it is nil.
+func SetInstance(m *Manager) {
+	lock.Lock()
+	defer lock.Unlock()
+	instance = m
+}
+
+// Instance returns the Manager jobs are submitted to, nil if jobs are
+// disabled.
+func Instance() *Manager {
+	lock.RLock()
+	defer lock.RUnlock()
+	return instance
+}
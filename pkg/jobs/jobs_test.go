@@ -0,0 +1,151 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/portworx/kvdb"
+	"github.com/portworx/kvdb/mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKvdb(t *testing.T) kvdb.Kvdb {
+	kv, err := kvdb.New(mem.Name, "jobs_test", []string{}, nil, nil)
+	require.NoError(t, err)
+	return kv
+}
+
+func waitJob(t *testing.T, m *Manager, id string) *Job {
+	for i := 0; i < 500; i++ {
+		j, err := m.Get(id)
+		require.NoError(t, err)
+		if j.Completed() {
+			return j
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Job %v did not complete", id)
+	return nil
+}
+
+func TestSubmit(t *testing.T) {
+	m, err := NewManager(newTestKvdb(t), "node1", 1, 0)
+	require.NoError(t, err)
+
+	j, err := m.Submit("create", "vfs", "", func(ctx context.Context, progress func(int)) (string, error) {
+		progress(50)
+		return "vol1", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, StatePending, j.State)
+	j = waitJob(t, m, j.Id)
+	assert.Equal(t, StateDone, j.State)
+	assert.Equal(t, "vol1", j.Result)
+	assert.Equal(t, 100, j.Progress)
+	assert.Equal(t, "node1", j.Node)
+
+	j, err = m.Submit("delete", "vfs", "vol1", func(ctx context.Context, progress func(int)) (string, error) {
+		return "", errors.New("busy")
+	})
+	require.NoError(t, err)
+	j = waitJob(t, m, j.Id)
+	assert.Equal(t, StateFailed, j.State)
+	assert.Equal(t, "busy", j.Error)
+
+	jobs, err := m.Enumerate()
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "create", jobs[0].Type)
+	assert.Equal(t, ErrCompleted, m.Cancel(jobs[0].Id))
+
+	_, err = m.Get("missing")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestCancel(t *testing.T) {
+	m, err := NewManager(newTestKvdb(t), "node1", 1, 0)
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	running, err := m.Submit("restore", "vfs", "vol1", func(ctx context.Context, progress func(int)) (string, error) {
+		close(started)
+		<-ctx.Done()
+		return "", ctx.Err()
+	})
+	require.NoError(t, err)
+	<-started
+
+	// The only worker is busy, so this job stays pending.
+	ran := false
+	pending, err := m.Submit("restore", "vfs", "vol2", func(ctx context.Context, progress func(int)) (string, error) {
+		ran = true
+		return "", nil
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, m.Cancel(pending.Id))
+	assert.Equal(t, StateCancelled, waitJob(t, m, pending.Id).State)
+	require.NoError(t, m.Cancel(running.Id))
+	assert.Equal(t, StateCancelled, waitJob(t, m, running.Id).State)
+	assert.False(t, ran)
+}
+
+func TestRestart(t *testing.T) {
+	kv := newTestKvdb(t)
+	m, err := NewManager(kv, "node1", 1, 0)
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	block := make(chan struct{})
+	defer close(block)
+	local, err := m.Submit("snapshot", "vfs", "vol1", func(ctx context.Context, progress func(int)) (string, error) {
+		close(started)
+		<-block
+		return "", nil
+	})
+	require.NoError(t, err)
+	<-started
+	pending, err := m.SubmitRequest("restore", "vfs", "vol1", "snap1", func(ctx context.Context, progress func(int)) (string, error) {
+		return "", nil
+	})
+	require.NoError(t, err)
+	other := &Job{Id: "other", Node: "node2", State: StateRunning}
+	_, err = kv.Put(kvdbPrefix+other.Id, other, 0)
+	require.NoError(t, err)
+
+	// A new process of node1 resumes the jobs of the previous one it can
+	// run again, and fails the others.
+	m, err = NewManager(kv, "node1", 1, 0)
+	require.NoError(t, err)
+	var resumed string
+	require.NoError(t, m.Resume(map[string]ResumeFunc{
+		"restore": func(j *Job) (Func, error) {
+			var snap string
+			if err := json.Unmarshal(j.Request, &snap); err != nil {
+				return nil, err
+			}
+			return func(ctx context.Context, progress func(int)) (string, error) {
+				resumed = snap
+				return snap, nil
+			}, nil
+		},
+	}))
+	j, err := m.Get(local.Id)
+	require.NoError(t, err)
+	assert.Equal(t, StateFailed, j.State)
+	assert.Equal(t, ErrInterrupted.Error(), j.Error)
+
+	j = waitJob(t, m, pending.Id)
+	assert.Equal(t, StateDone, j.State)
+	assert.Equal(t, "snap1", j.Result)
+	assert.Equal(t, "snap1", resumed)
+
+	j, err = m.Get(other.Id)
+	require.NoError(t, err)
+	assert.Equal(t, StateRunning, j.State)
+	assert.Error(t, m.Cancel(other.Id))
+}
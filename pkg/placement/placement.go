@@ -0,0 +1,269 @@
+// Package placement decides which cluster nodes hold the replicas of a
+// volume.
+package placement
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+const (
+	// NodeLabelZone is the node label holding the zone of a node
+	NodeLabelZone = "zone"
+	// NodeLabelRack is the node label holding the rack of a node
+	NodeLabelRack = "rack"
+	// NodeLabelRegion is the node label holding the region of a node
+	NodeLabelRegion = "region"
+)
+
+// Scores added to the free capacity fraction of a node, which is at most 1,
+// so that spreading replicas across failure domains always wins over
+// capacity.
+const (
+	scoreNewZone   = 100
+	scoreNewRack   = 10
+	scoreGroupFree = 1
+)
+
+var (
+	// ErrNoCapacity returned when too few nodes satisfy the constraints of
+	// a volume to hold all its replicas
+	ErrNoCapacity = errors.New("Not enough nodes satisfy the placement constraints")
+)
+
+// Candidate is a node considered for a replica.
+type Candidate struct {
+	// Node ID
+	Node string `json:"node"`
+	// Zone of the node
+	Zone string `json:"zone,omitempty"`
+	// Rack of the node
+	Rack string `json:"rack,omitempty"`
+	// Free capacity of the node in bytes
+	Free uint64 `json:"free"`
+	// Score of the node when it was selected, higher is better
+	Score float64 `json:"score"`
+	// Selected is true if the node holds a replica
+	Selected bool `json:"selected"`
+	// Reason the node was excluded, if it was
+	Reason string `json:"reason,omitempty"`
+}
+
+// Decision of where to place a volume.
+type Decision struct {
+	// ReplicaSet is the nodes holding the replicas
+	ReplicaSet *api.ReplicaSet `json:"replica_set"`
+	// Candidates are all nodes of the cluster, in order of selection
+	Candidates []*Candidate `json:"candidates"`
+	// Error is set if the volume cannot be placed
+	Error string `json:"error,omitempty"`
+}
+
+// Request to place a volume.
+type Request struct {
+	// Spec of the volume
+	Spec *api.VolumeSpec
+	// Locator of the volume, whose labels hold the topology constraints
+	Locator *api.VolumeLocator
+	// LocalNode is the ID of the node the request is made on
+	LocalNode string
+	// Nodes of the cluster
+	Nodes []api.Node
+	// Volumes already placed, used to spread the volumes of a group
+	Volumes []*api.Volume
+}
+
+// Place returns the nodes that should hold the replicas of the volume of
+// req. Nodes must be online, be in the zones, racks and regions the volume
+// is constrained to and have room for the volume, if their pools are
+// known. Replicas are spread
+// across zones, then racks, then nodes without volumes of the same group,
+// then nodes with the most free capacity.
+func Place(req *Request) (*Decision, error) {
+	spec := req.Spec
+	if spec == nil {
+		spec = &api.VolumeSpec{}
+	}
+	labels := req.Locator.GetVolumeLabels()
+	replicas := int(spec.HaLevel)
+	if replicas < 1 {
+		replicas = 1
+	}
+
+	groupNodes := make(map[string]bool)
+	if group := spec.GetGroup().GetId(); group != "" {
+		for _, v := range req.Volumes {
+			if v.GetSpec().GetGroup().GetId() != group {
+				continue
+			}
+			for _, node := range volumeNodes(v) {
+				groupNodes[node] = true
+			}
+		}
+	}
+
+	var (
+		required = requiredNodes(spec, labels, req.LocalNode)
+		zones    = labelSet(labels[api.SpecZones])
+		racks    = labelSet(labels[api.SpecRacks])
+		regions  = labelSet(labels[api.SpecRegions])
+	)
+	decision := &Decision{ReplicaSet: &api.ReplicaSet{}}
+	eligible := make([]*Candidate, 0)
+	for _, n := range req.Nodes {
+		c := &Candidate{
+			Node: n.Id,
+			Zone: n.NodeLabels[NodeLabelZone],
+			Rack: n.NodeLabels[NodeLabelRack],
+			Free: freeCapacity(&n),
+		}
+		decision.Candidates = append(decision.Candidates, c)
+		switch {
+		case n.Status != api.Status_STATUS_OK:
+			c.Reason = "node is " + n.Status.SimpleString()
+		case required != nil && !required[n.Id]:
+			c.Reason = "node not in the requested nodes"
+		case !zones.has(c.Zone):
+			c.Reason = "zone not in " + labels[api.SpecZones]
+		case !racks.has(c.Rack):
+			c.Reason = "rack not in " + labels[api.SpecRacks]
+		case !regions.has(n.NodeLabels[NodeLabelRegion]):
+			c.Reason = "region not in " + labels[api.SpecRegions]
+		case spec.Size > 0 && len(n.Pools) > 0 && c.Free < spec.Size:
+			c.Reason = fmt.Sprintf("only %d bytes free", c.Free)
+		case spec.GroupEnforced && groupNodes[n.Id]:
+			c.Reason = "node holds a volume of group " + spec.Group.Id
+		default:
+			eligible = append(eligible, c)
+		}
+	}
+
+	maxFree := uint64(0)
+	for _, c := range eligible {
+		if c.Free > maxFree {
+			maxFree = c.Free
+		}
+	}
+	usedZones := make(map[string]bool)
+	usedRacks := make(map[string]bool)
+	for len(decision.ReplicaSet.Nodes) < replicas {
+		var best *Candidate
+		for _, c := range eligible {
+			if c.Selected {
+				continue
+			}
+			c.Score = float64(0)
+			if c.Zone == "" || !usedZones[c.Zone] {
+				c.Score += scoreNewZone
+			}
+			if c.Rack == "" || !usedRacks[c.Rack] {
+				c.Score += scoreNewRack
+			}
+			if !groupNodes[c.Node] {
+				c.Score += scoreGroupFree
+			}
+			if maxFree > 0 {
+				c.Score += float64(c.Free) / float64(maxFree)
+			}
+			if best == nil || c.Score > best.Score ||
+				(c.Score == best.Score && c.Node < best.Node) {
+				best = c
+			}
+		}
+		if best == nil {
+			break
+		}
+		best.Selected = true
+		usedZones[best.Zone] = true
+		usedRacks[best.Rack] = true
+		decision.ReplicaSet.Nodes = append(decision.ReplicaSet.Nodes, best.Node)
+	}
+
+	// Selected nodes first in order of selection, then the others.
+	order := make(map[string]int)
+	for i, node := range decision.ReplicaSet.Nodes {
+		order[node] = i
+	}
+	sort.SliceStable(decision.Candidates, func(i, j int) bool {
+		ci, cj := decision.Candidates[i], decision.Candidates[j]
+		if ci.Selected != cj.Selected {
+			return ci.Selected
+		}
+		if ci.Selected {
+			return order[ci.Node] < order[cj.Node]
+		}
+		return ci.Reason == "" && cj.Reason != ""
+	})
+
+	if len(decision.ReplicaSet.Nodes) < replicas {
+		decision.Error = ErrNoCapacity.Error()
+		return decision, ErrNoCapacity
+	}
+	return decision, nil
+}
+
+// requiredNodes returns the nodes a volume must be placed on, nil if it
+// may be placed on any node.
+func requiredNodes(spec *api.VolumeSpec, labels map[string]string, local string) map[string]bool {
+	var nodes []string
+	if spec.ReplicaSet != nil && len(spec.ReplicaSet.Nodes) > 0 {
+		nodes = spec.ReplicaSet.Nodes
+	} else if labels[volume.LocationConstraint] == volume.LocalNode && local != "" {
+		nodes = []string{local}
+	} else {
+		return nil
+	}
+	required := make(map[string]bool)
+	for _, node := range nodes {
+		required[node] = true
+	}
+	return required
+}
+
+// volumeNodes returns the nodes holding replicas of v.
+func volumeNodes(v *api.Volume) []string {
+	nodes := make([]string, 0)
+	for _, rs := range v.ReplicaSets {
+		nodes = append(nodes, rs.GetNodes()...)
+	}
+	if len(nodes) == 0 {
+		nodes = append(nodes, v.GetSpec().GetReplicaSet().GetNodes()...)
+	}
+	if len(nodes) == 0 && v.AttachedOn != "" {
+		nodes = append(nodes, v.AttachedOn)
+	}
+	return nodes
+}
+
+// freeCapacity returns the free capacity of the pools of n.
+func freeCapacity(n *api.Node) uint64 {
+	free := uint64(0)
+	for _, p := range n.Pools {
+		if p.TotalSize > p.Used {
+			free += p.TotalSize - p.Used
+		}
+	}
+	return free
+}
+
+// set of allowed label values, empty to allow any value.
+type set map[string]bool
+
+func labelSet(value string) set {
+	s := make(set)
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			s[v] = true
+		}
+	}
+	return s
+}
+
+func (s set) has(v string) bool {
+	return len(s) == 0 || s[v]
+}
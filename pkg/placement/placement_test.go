@@ -0,0 +1,127 @@
+package placement
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+func node(id string, zone string, rack string, free uint64) api.Node {
+	return api.Node{
+		Id:         id,
+		Status:     api.Status_STATUS_OK,
+		NodeLabels: map[string]string{NodeLabelZone: zone, NodeLabelRack: rack},
+		Pools:      []api.StoragePool{{TotalSize: free * 2, Used: free}},
+	}
+}
+
+func testNodes() []api.Node {
+	nodes := []api.Node{
+		node("a1", "a", "r1", 100),
+		node("a2", "a", "r2", 300),
+		node("b1", "b", "r3", 200),
+		node("c1", "c", "r4", 50),
+		node("c2", "c", "r4", 1000),
+	}
+	nodes[4].Status = api.Status_STATUS_OFFLINE
+	return nodes
+}
+
+func reasons(d *Decision) map[string]string {
+	reasons := make(map[string]string)
+	for _, c := range d.Candidates {
+		if !c.Selected {
+			reasons[c.Node] = c.Reason
+		}
+	}
+	return reasons
+}
+
+func TestPlaceSpreadsZones(t *testing.T) {
+	d, err := Place(&Request{
+		Spec:  &api.VolumeSpec{HaLevel: 3, Size: 10},
+		Nodes: testNodes(),
+	})
+	require.NoError(t, err)
+	// One replica per zone, largest nodes first.
+	assert.Equal(t, []string{"a2", "b1", "c1"}, d.ReplicaSet.Nodes)
+	assert.Equal(t, "a2", d.Candidates[0].Node)
+	assert.Equal(t, "node is offline", reasons(d)["c2"])
+}
+
+func TestPlaceConstraints(t *testing.T) {
+	d, err := Place(&Request{
+		Spec:    &api.VolumeSpec{HaLevel: 2, Size: 150},
+		Locator: &api.VolumeLocator{VolumeLabels: map[string]string{api.SpecZones: "a,b"}},
+		Nodes:   testNodes(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a2", "b1"}, d.ReplicaSet.Nodes)
+	excluded := reasons(d)
+	assert.Equal(t, "only 100 bytes free", excluded["a1"])
+	assert.Equal(t, "zone not in a,b", excluded["c1"])
+
+	_, err = Place(&Request{
+		Spec:    &api.VolumeSpec{HaLevel: 2},
+		Locator: &api.VolumeLocator{VolumeLabels: map[string]string{api.SpecRacks: "r1"}},
+		Nodes:   testNodes(),
+	})
+	assert.Equal(t, ErrNoCapacity, err)
+
+	d, err = Place(&Request{
+		Spec:  &api.VolumeSpec{ReplicaSet: &api.ReplicaSet{Nodes: []string{"a1"}}},
+		Nodes: testNodes(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a1"}, d.ReplicaSet.Nodes)
+
+	d, err = Place(&Request{
+		Locator: &api.VolumeLocator{VolumeLabels: map[string]string{
+			volume.LocationConstraint: volume.LocalNode,
+		}},
+		LocalNode: "c1",
+		Nodes:     testNodes(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c1"}, d.ReplicaSet.Nodes)
+}
+
+func TestPlaceGroupAntiAffinity(t *testing.T) {
+	group := &api.Group{Id: "db"}
+	volumes := []*api.Volume{
+		{
+			Id:          "vol1",
+			Spec:        &api.VolumeSpec{Group: group},
+			ReplicaSets: []*api.ReplicaSet{{Nodes: []string{"a2"}}},
+		},
+		{
+			Id:          "vol2",
+			Spec:        &api.VolumeSpec{},
+			ReplicaSets: []*api.ReplicaSet{{Nodes: []string{"b1"}}},
+		},
+	}
+
+	// Nodes holding volumes of the group are avoided.
+	d, err := Place(&Request{
+		Spec:    &api.VolumeSpec{Group: group},
+		Locator: &api.VolumeLocator{VolumeLabels: map[string]string{api.SpecZones: "a"}},
+		Nodes:   testNodes(),
+		Volumes: volumes,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a1"}, d.ReplicaSet.Nodes)
+
+	// And excluded if the group is enforced.
+	d, err = Place(&Request{
+		Spec:    &api.VolumeSpec{Group: group, GroupEnforced: true, HaLevel: 2},
+		Locator: &api.VolumeLocator{VolumeLabels: map[string]string{api.SpecZones: "a"}},
+		Nodes:   testNodes(),
+		Volumes: volumes,
+	})
+	assert.Equal(t, ErrNoCapacity, err)
+	assert.Equal(t, "node holds a volume of group db", reasons(d)["a2"])
+}
@@ -0,0 +1,450 @@
+// Package quota limits the capacity, volumes and snapshots a group of
+// volumes, selected by their labels, may use.
+package quota
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pborman/uuid"
+	"github.com/portworx/kvdb"
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+)
+
+const (
+	kvdbPrefix            = "openstorage/quotas/"
+	kvdbLockPrefix        = "openstorage/quota-locks/"
+	kvdbReservationPrefix = "openstorage/quota-reservations/"
+	// reservationTTL is how long the usage of a change is reserved for at
+	// most, so that the reservations of a process which died making a
+	// change do not use quotas forever.
+	reservationTTL = uint64(time.Hour / time.Second)
+)
+
+var (
+	// ErrNotFound returned when a quota does not exist
+	ErrNotFound = errors.New("Quota not found")
+	// ErrInvalidName returned when setting a quota without a name
+	ErrInvalidName = errors.New("Quota name must be provided")
+)
+
+// Quota limits the volumes of a driver whose locator labels contain all
+// the labels of Selector. Read-only volumes created from a parent are
+// snapshots, which count as snapshots of the quotas of their parent.
+// Writable volumes created from a parent are clones, which count as
+// volumes. Zero limits are unlimited.
+type Quota struct {
+	// Name of the quota
+	Name string `json:"name"`
+	// Selector of the volumes, all volumes if empty
+	Selector map[string]string `json:"selector"`
+	// MaxSize is the total size of the volumes in bytes
+	MaxSize uint64 `json:"max_size,omitempty"`
+	// MaxVolumes is the number of volumes, snapshots excluded
+	MaxVolumes uint64 `json:"max_volumes,omitempty"`
+	// MaxSnapshots is the number of snapshots
+	MaxSnapshots uint64 `json:"max_snapshots,omitempty"`
+}
+
+// Usage is what the volumes selected by a quota use.
+type Usage struct {
+	// Size is the total size of the volumes in bytes, snapshots excluded
+	Size uint64 `json:"size"`
+	// Volumes is the number of volumes, snapshots excluded
+	Volumes uint64 `json:"volumes"`
+	// Snapshots is the number of snapshots
+	Snapshots uint64 `json:"snapshots"`
+}
+
+// Status is a quota and the current usage of its volumes.
+type Status struct {
+	Quota *Quota `json:"quota"`
+	Usage *Usage `json:"usage"`
+}
+
+// ErrExceeded is returned when an operation would exceed a quota.
+type ErrExceeded struct {
+	// Quota exceeded
+	Quota string
+	// Resource exceeded: size, volumes or snapshots
+	Resource string
+	// Limit of the resource
+	Limit uint64
+	// Requested is the usage the operation would lead to
+	Requested uint64
+}
+
+func (e *ErrExceeded) Error() string {
+	return fmt.Sprintf("Quota %v exceeded: %v would be %v, limit is %v",
+		e.Quota, e.Resource, e.Requested, e.Limit)
+}
+
+// Matches returns true if the quota selects a volume with labels.
+func (q *Quota) Matches(labels map[string]string) bool {
+	for k, v := range q.Selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// reservation is the usage of a change in progress, which counts until the
+// change is done and its volumes are listed by the driver.
+type reservation struct {
+	// Prev is the ID of the volume changed, empty for new volumes
+	Prev string `json:"prev,omitempty"`
+	// Next are the volumes after the change
+	Next []*api.Volume `json:"next"`
+}
+
+// Enumerator lists the volumes of a driver.
+type Enumerator interface {
+	Enumerate(locator *api.VolumeLocator, labels map[string]string) ([]*api.Volume, error)
+}
+
+// Manager stores quotas in kvdb and enforces them.
+type Manager struct {
+	kv kvdb.Kvdb
+}
+
+// NewManager returns a Manager storing quotas in kv.
+func NewManager(kv kvdb.Kvdb) *Manager {
+	return &Manager{kv: kv}
+}
+
+// Set creates or replaces quota q.
+func (m *Manager) Set(q *Quota) error {
+	if q.Name == "" {
+		return ErrInvalidName
+	}
+	_, err := m.kv.Put(kvdbPrefix+q.Name, q, 0)
+	return err
+}
+
+// Get returns the quota with name.
+func (m *Manager) Get(name string) (*Quota, error) {
+	q := &Quota{}
+	if _, err := m.kv.GetVal(kvdbPrefix+name, q); err == kvdb.ErrNotFound {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// Enumerate returns all quotas sorted by name.
+func (m *Manager) Enumerate() ([]*Quota, error) {
+	kvp, err := m.kv.Enumerate(kvdbPrefix)
+	if err != nil {
+		return nil, err
+	}
+	quotas := make([]*Quota, 0, len(kvp))
+	for _, p := range kvp {
+		q := &Quota{}
+		if err := json.Unmarshal(p.Value, q); err != nil {
+			return nil, err
+		}
+		quotas = append(quotas, q)
+	}
+	sort.Slice(quotas, func(i, j int) bool { return quotas[i].Name < quotas[j].Name })
+	return quotas, nil
+}
+
+// Delete deletes the quota with name.
+func (m *Manager) Delete(name string) error {
+	if _, err := m.kv.Delete(kvdbPrefix + name); err == kvdb.ErrNotFound {
+		return ErrNotFound
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Status returns the quotas and the usage of the volumes of d they
+// select.
+func (m *Manager) Status(d Enumerator) ([]*Status, error) {
+	quotas, err := m.Enumerate()
+	if err != nil {
+		return nil, err
+	}
+	vols, err := d.Enumerate(&api.VolumeLocator{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	status := make([]*Status, 0, len(quotas))
+	for _, q := range quotas {
+		status = append(status, &Status{Quota: q, Usage: usage(q, vols)})
+	}
+	return status, nil
+}
+
+// Inspect returns the quota with name and the usage of the volumes of d it
+// selects.
+func (m *Manager) Inspect(name string, d Enumerator) (*Status, error) {
+	q, err := m.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	vols, err := d.Enumerate(&api.VolumeLocator{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Status{Quota: q, Usage: usage(q, vols)}, nil
+}
+
+// Enforce runs fn, which changes a volume of driver d from prev to next,
+// unless the change would exceed a quota. prev is nil for new volumes,
+// clones and snapshots. Clones and snapshots must set Source.Parent, and
+// Readonly for snapshots, and have the size of their parent if next has
+// no spec. The usage of the change is reserved until fn returns, so that
+// concurrent changes cannot exceed the quotas together, but the quotas are
+// only locked while checking and reserving it. Changes that do not
+// increase the usage of a quota, such as shrinking a volume, are allowed
+// even if it is already exceeded.
+func (m *Manager) Enforce(
+	driver string,
+	d Enumerator,
+	prev *api.Volume,
+	next *api.Volume,
+	fn func() error,
+) error {
+	return m.enforce(driver, d, prev, []*api.Volume{next}, fn)
+}
+
+// EnforceCreate runs fn, which creates the volumes next of driver d
+// together, such as the snapshots of a group, unless they would exceed a
+// quota together.
+func (m *Manager) EnforceCreate(
+	driver string,
+	d Enumerator,
+	next []*api.Volume,
+	fn func() error,
+) error {
+	return m.enforce(driver, d, nil, next, fn)
+}
+
+func (m *Manager) enforce(
+	driver string,
+	d Enumerator,
+	prev *api.Volume,
+	next []*api.Volume,
+	fn func() error,
+) error {
+	quotas, err := m.Enumerate()
+	if err != nil {
+		return err
+	}
+	if len(quotas) == 0 {
+		return fn()
+	}
+	key, err := m.reserve(driver, d, quotas, prev, next)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if _, err := m.kv.Delete(key); err != nil {
+			dlog.Warnf("Failed to release the quota reservation %v: %v", key, err)
+		}
+	}()
+	return fn()
+}
+
+// reserve records the usage of changing prev to next in the quotas of
+// driver, unless it would exceed one of quotas, and returns the key of the
+// reservation.
+func (m *Manager) reserve(
+	driver string,
+	d Enumerator,
+	quotas []*Quota,
+	prev *api.Volume,
+	next []*api.Volume,
+) (string, error) {
+	kvlock, err := m.kv.Lock(kvdbLockPrefix + driver)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := m.kv.Unlock(kvlock); err != nil {
+			dlog.Warnf("Failed to unlock the quotas of %v: %v", driver, err)
+		}
+	}()
+
+	vols, err := d.Enumerate(&api.VolumeLocator{}, nil)
+	if err != nil {
+		return "", err
+	}
+	kvp, err := m.kv.Enumerate(kvdbReservationPrefix + driver + "/")
+	if err != nil {
+		return "", err
+	}
+	for _, p := range kvp {
+		res := &reservation{}
+		if err := json.Unmarshal(p.Value, res); err != nil {
+			return "", err
+		}
+		vols = apply(vols, res)
+	}
+
+	res := &reservation{Next: make([]*api.Volume, 0, len(next))}
+	if prev != nil {
+		res.Prev = prev.Id
+	}
+	for _, v := range next {
+		v = withParentSpec(v, vols)
+		res.Next = append(res.Next, &api.Volume{
+			Id:       v.Id,
+			Locator:  v.Locator,
+			Source:   v.Source,
+			Spec:     v.Spec,
+			Readonly: v.Readonly,
+		})
+	}
+	after := apply(vols, res)
+	for _, q := range quotas {
+		if err := check(q, usage(q, vols), usage(q, after)); err != nil {
+			return "", err
+		}
+	}
+	key := kvdbReservationPrefix + driver + "/" + uuid.New()
+	if _, err := m.kv.Create(key, res, reservationTTL); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// apply returns vols changed as res reserves.
+func apply(vols []*api.Volume, res *reservation) []*api.Volume {
+	after := make([]*api.Volume, 0, len(vols)+len(res.Next))
+	for _, v := range vols {
+		if res.Prev == "" || v.Id != res.Prev {
+			after = append(after, v)
+		}
+	}
+	return append(after, res.Next...)
+}
+
+// Updated returns prev as counted by the quotas once its locator and spec
+// are set to those given, of which only the labels and the size count.
+func Updated(prev *api.Volume, locator *api.VolumeLocator, spec *api.VolumeSpec) *api.Volume {
+	next := *prev
+	if locator != nil {
+		next.Locator = locator
+	}
+	if spec.GetSize() != 0 {
+		nextSpec := &api.VolumeSpec{}
+		if prev.GetSpec() != nil {
+			nextSpec = proto.Clone(prev.GetSpec()).(*api.VolumeSpec)
+		}
+		nextSpec.Size = spec.GetSize()
+		next.Spec = nextSpec
+	}
+	return &next
+}
+
+// check returns an error if changing the usage of q from before to after
+// increases a resource beyond its limit.
+func check(q *Quota, before *Usage, after *Usage) error {
+	limits := []struct {
+		resource      string
+		limit         uint64
+		before, after uint64
+	}{
+		{"size", q.MaxSize, before.Size, after.Size},
+		{"volumes", q.MaxVolumes, before.Volumes, after.Volumes},
+		{"snapshots", q.MaxSnapshots, before.Snapshots, after.Snapshots},
+	}
+	for _, l := range limits {
+		if l.limit != 0 && l.after > l.limit && l.after > l.before {
+			return &ErrExceeded{
+				Quota:     q.Name,
+				Resource:  l.resource,
+				Limit:     l.limit,
+				Requested: l.after,
+			}
+		}
+	}
+	return nil
+}
+
+// usage returns the usage of the volumes vols selected by q.
+func usage(q *Quota, vols []*api.Volume) *Usage {
+	byID := make(map[string]*api.Volume, len(vols))
+	for _, v := range vols {
+		if v.Id != "" {
+			byID[v.Id] = v
+		}
+	}
+	u := &Usage{}
+	for _, v := range vols {
+		if !q.Matches(labels(v, byID)) {
+			continue
+		}
+		if isSnapshot(v) {
+			u.Snapshots++
+			continue
+		}
+		u.Volumes++
+		u.Size += v.GetSpec().GetSize()
+	}
+	return u
+}
+
+// labels returns the labels a volume is selected by, which are those of
+// the parent of snapshots.
+func labels(v *api.Volume, byID map[string]*api.Volume) map[string]string {
+	if isSnapshot(v) {
+		if parent, ok := byID[v.Source.Parent]; ok {
+			return parent.GetLocator().GetVolumeLabels()
+		}
+	}
+	return v.GetLocator().GetVolumeLabels()
+}
+
+func isSnapshot(v *api.Volume) bool {
+	return v.GetSource().GetParent() != "" && v.GetReadonly()
+}
+
+// withParentSpec returns v with the spec of its parent in vols if v is
+// created from a parent without a spec.
+func withParentSpec(v *api.Volume, vols []*api.Volume) *api.Volume {
+	if v.GetSpec() != nil || v.GetSource().GetParent() == "" {
+		return v
+	}
+	for _, p := range vols {
+		if p.Id == v.Source.Parent {
+			c := *v
+			c.Spec = p.GetSpec()
+			return &c
+		}
+	}
+	return v
+}
+
+var (
+	instance *Manager
+	lock     sync.RWMutex
+)
+
+// SetInstance sets the Manager enforcing quotas, or disables quotas if m
+// is nil.
+func SetInstance(m *Manager) {
+	lock.Lock()
+	defer lock.Unlock()
+	instance = m
+}
+
+// Instance returns the Manager enforcing quotas, nil if quotas are
+// disabled.
+func Instance() *Manager {
+	lock.RLock()
+	defer lock.RUnlock()
+	return instance
+}
@@ -0,0 +1,173 @@
+package quota
+
+import (
+	"testing"
+
+	"github.com/portworx/kvdb"
+	"github.com/portworx/kvdb/mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+)
+
+type volumes []*api.Volume
+
+func (v volumes) Enumerate(locator *api.VolumeLocator, labels map[string]string) ([]*api.Volume, error) {
+	return v, nil
+}
+
+func newVolume(id string, size uint64, labels map[string]string) *api.Volume {
+	return &api.Volume{
+		Id:      id,
+		Locator: &api.VolumeLocator{VolumeLabels: labels},
+		Spec:    &api.VolumeSpec{Size: size},
+	}
+}
+
+func newSnapshot(id string, parent string) *api.Volume {
+	return &api.Volume{
+		Id:       id,
+		Locator:  &api.VolumeLocator{},
+		Source:   &api.Source{Parent: parent},
+		Spec:     &api.VolumeSpec{},
+		Readonly: true,
+	}
+}
+
+func newClone(id string, parent string, size uint64, labels map[string]string) *api.Volume {
+	v := newVolume(id, size, labels)
+	v.Source = &api.Source{Parent: parent}
+	return v
+}
+
+func newManager(t *testing.T) *Manager {
+	kv, err := kvdb.New(mem.Name, "quota_test", []string{}, nil, nil)
+	require.NoError(t, err)
+	return NewManager(kv)
+}
+
+func TestQuotaStore(t *testing.T) {
+	m := newManager(t)
+	assert.Equal(t, ErrInvalidName, m.Set(&Quota{}))
+	require.NoError(t, m.Set(&Quota{Name: "foo", Selector: map[string]string{"namespace": "foo"}, MaxVolumes: 1}))
+	require.NoError(t, m.Set(&Quota{Name: "bar", MaxVolumes: 2}))
+
+	q, err := m.Get("foo")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), q.MaxVolumes)
+	all, err := m.Enumerate()
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, "bar", all[0].Name)
+
+	vols := volumes{
+		newVolume("vol1", 10, map[string]string{"namespace": "foo"}),
+		newVolume("vol2", 20, map[string]string{"namespace": "bar"}),
+		newSnapshot("snap1", "vol1"),
+	}
+	s, err := m.Inspect("foo", vols)
+	require.NoError(t, err)
+	assert.Equal(t, &Usage{Size: 10, Volumes: 1, Snapshots: 1}, s.Usage)
+	status, err := m.Status(vols)
+	require.NoError(t, err)
+	assert.Equal(t, &Usage{Size: 30, Volumes: 2, Snapshots: 1}, status[0].Usage)
+
+	require.NoError(t, m.Delete("foo"))
+	assert.Equal(t, ErrNotFound, m.Delete("foo"))
+	_, err = m.Get("foo")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestEnforce(t *testing.T) {
+	m := newManager(t)
+	foo := map[string]string{"namespace": "foo"}
+	require.NoError(t, m.Set(&Quota{Name: "foo", Selector: foo, MaxSize: 100, MaxVolumes: 2, MaxSnapshots: 1}))
+	vols := volumes{newVolume("vol1", 60, foo), newVolume("vol2", 60, nil)}
+
+	ran := false
+	fn := func() error {
+		ran = true
+		return nil
+	}
+	exceeded := func(err error, resource string) {
+		require.IsType(t, &ErrExceeded{}, err)
+		assert.Equal(t, resource, err.(*ErrExceeded).Resource)
+		assert.False(t, ran)
+	}
+
+	// Volumes outside of the quota are not limited.
+	require.NoError(t, m.Enforce("test", vols, nil, newVolume("", 200, nil), fn))
+	assert.True(t, ran)
+
+	ran = false
+	exceeded(m.Enforce("test", vols, nil, newVolume("", 50, foo), fn), "size")
+	require.NoError(t, m.Enforce("test", vols, nil, newVolume("", 40, foo), fn))
+	assert.True(t, ran)
+
+	// Snapshots count against the quota of their parent.
+	ran = false
+	vols = append(vols, newSnapshot("snap1", "vol1"))
+	exceeded(m.Enforce("test", vols, nil, newSnapshot("", "vol1"), fn), "snapshots")
+	require.NoError(t, m.Enforce("test", vols, nil, newSnapshot("", "vol2"), fn))
+
+	// Clones count as volumes of their own quotas, sized as their parent
+	// if they have no spec.
+	ran = false
+	exceeded(m.Enforce("test", vols, nil, newClone("", "vol1", 50, foo), fn), "size")
+	writable := &api.Volume{Locator: &api.VolumeLocator{VolumeLabels: foo}, Source: &api.Source{Parent: "vol1"}}
+	exceeded(m.Enforce("test", vols, nil, writable, fn), "size")
+	require.NoError(t, m.Enforce("test", vols, nil, newClone("", "vol1", 40, foo), fn))
+	require.NoError(t, m.Enforce("test", vols, nil, newClone("", "vol2", 100, nil), fn))
+	status, err := m.Inspect("foo", append(vols, newClone("clone1", "vol1", 20, foo)))
+	require.NoError(t, err)
+	assert.Equal(t, &Usage{Size: 80, Volumes: 2, Snapshots: 1}, status.Usage)
+
+	// Volumes created together count together.
+	ran = false
+	exceeded(m.EnforceCreate("test", vols, []*api.Volume{newVolume("", 30, foo), newVolume("", 30, foo)}, fn), "size")
+	require.NoError(t, m.EnforceCreate("test", vols, []*api.Volume{newVolume("", 20, foo), newSnapshot("", "vol2")}, fn))
+	assert.True(t, ran)
+
+	// Resizing and relabelling count the change only.
+	ran = false
+	exceeded(m.Enforce("test", vols, vols[0], newVolume("vol1", 120, foo), fn), "size")
+	exceeded(m.Enforce("test", vols, vols[1], newVolume("vol2", 60, foo), fn), "size")
+	require.NoError(t, m.Enforce("test", vols, vols[0], newVolume("vol1", 100, foo), fn))
+	require.NoError(t, m.Enforce("test", vols, vols[1], newVolume("vol2", 80, nil), fn))
+
+	// Changes that do not grow an exceeded quota are allowed.
+	require.NoError(t, m.Set(&Quota{Name: "foo", Selector: foo, MaxSize: 10}))
+	require.NoError(t, m.Enforce("test", vols, vols[0], newVolume("vol1", 60, map[string]string{"namespace": "foo", "tier": "gold"}), fn))
+}
+
+func TestEnforceReserves(t *testing.T) {
+	m := newManager(t)
+	require.NoError(t, m.Set(&Quota{Name: "all", MaxSize: 100}))
+	vols := volumes{newVolume("vol1", 40, nil)}
+
+	// The quotas are not locked while a change is made, but its usage is
+	// reserved until it is done.
+	err := m.Enforce("test", vols, nil, newVolume("", 40, nil), func() error {
+		exceeded := m.Enforce("test", vols, nil, newVolume("", 40, nil), func() error { return nil })
+		require.IsType(t, &ErrExceeded{}, exceeded)
+		return m.Enforce("test", vols, vols[0], newVolume("vol1", 60, nil), func() error { return nil })
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.Enforce("test", vols, nil, newVolume("", 60, nil), func() error { return nil }))
+}
+
+func TestUpdated(t *testing.T) {
+	prev := newVolume("vol1", 10, map[string]string{"a": "b"})
+	prev.Spec.HaLevel = 2
+
+	next := Updated(prev, nil, &api.VolumeSpec{Size: 20})
+	assert.Equal(t, uint64(20), next.Spec.Size)
+	assert.Equal(t, int64(2), next.Spec.HaLevel)
+	assert.Equal(t, uint64(10), prev.Spec.Size)
+
+	locator := &api.VolumeLocator{VolumeLabels: map[string]string{"c": "d"}}
+	next = Updated(prev, locator, &api.VolumeSpec{HaLevel: 3})
+	assert.Equal(t, locator, next.Locator)
+	assert.Equal(t, prev.Spec, next.Spec)
+}
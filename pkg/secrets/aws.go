@@ -0,0 +1,113 @@
+package secrets
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/private/protocol/jsonrpc"
+	"github.com/aws/aws-sdk-go/private/signer/v4"
+
+	"github.com/libopenstorage/openstorage/osdconfig"
+)
+
+const (
+	kmsServiceName  = "kms"
+	kmsAPIVersion   = "2014-11-01"
+	kmsTargetPrefix = "TrentService"
+	kmsDecrypt      = "Decrypt"
+)
+
+type awsKMS struct {
+	ciphertexts Secrets
+	client      *client.Client
+}
+
+// kmsDecryptInput and kmsDecryptOutput are the parameters of the KMS
+// Decrypt API we use.
+type kmsDecryptInput struct {
+	_              struct{} `type:"structure"`
+	CiphertextBlob []byte   `min:"1" type:"blob" required:"true"`
+}
+
+type kmsDecryptOutput struct {
+	_         struct{} `type:"structure"`
+	KeyId     *string  `min:"1" type:"string"`
+	Plaintext []byte   `min:"1" type:"blob"`
+}
+
+// NewAWS returns a Secrets store that keeps each secret encrypted with an
+// AWS KMS key, base64 encoded in a file named after the secret under dir,
+// and decrypts it with KMS when read. The key is not needed to decrypt, as
+// KMS finds it from the ciphertext. The AWS credentials default to those
+// of the environment and the instance if cfg has none.
+func NewAWS(cfg *osdconfig.AWSConfig, dir string) (Secrets, error) {
+	return newAWS(cfg, dir, "")
+}
+
+func newAWS(cfg *osdconfig.AWSConfig, dir string, endpoint string) (Secrets, error) {
+	if cfg.AwsRegion == "" {
+		return nil, fmt.Errorf("Missing AWS region")
+	}
+	awsConfig := aws.NewConfig().WithRegion(cfg.AwsRegion)
+	if cfg.AwsAccessKeyId != "" {
+		awsConfig = awsConfig.WithCredentials(credentials.NewStaticCredentials(
+			cfg.AwsAccessKeyId, cfg.AwsSecretAccessKey, cfg.AwsSecretTokenKey))
+	}
+	if endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(endpoint)
+	}
+	c := session.New(awsConfig).ClientConfig(kmsServiceName)
+	kms := client.New(
+		*c.Config,
+		metadata.ClientInfo{
+			ServiceName:   kmsServiceName,
+			SigningRegion: c.SigningRegion,
+			Endpoint:      c.Endpoint,
+			APIVersion:    kmsAPIVersion,
+			JSONVersion:   "1.1",
+			TargetPrefix:  kmsTargetPrefix,
+		},
+		c.Handlers,
+	)
+	kms.Handlers.Sign.PushBack(v4.Sign)
+	kms.Handlers.Build.PushBackNamed(jsonrpc.BuildHandler)
+	kms.Handlers.Unmarshal.PushBackNamed(jsonrpc.UnmarshalHandler)
+	kms.Handlers.UnmarshalMeta.PushBackNamed(jsonrpc.UnmarshalMetaHandler)
+	kms.Handlers.UnmarshalError.PushBackNamed(jsonrpc.UnmarshalErrorHandler)
+
+	return &awsKMS{
+		ciphertexts: NewLocal(dir),
+		client:      kms,
+	}, nil
+}
+
+func (a *awsKMS) String() string {
+	return TypeAWS
+}
+
+func (a *awsKMS) GetSecret(name string) (string, error) {
+	encoded, err := a.ciphertexts.GetSecret(name)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("Invalid ciphertext of secret %q: %v", name, err)
+	}
+	output := &kmsDecryptOutput{}
+	req := a.client.NewRequest(
+		&request.Operation{Name: kmsDecrypt, HTTPMethod: "POST", HTTPPath: "/"},
+		&kmsDecryptInput{CiphertextBlob: ciphertext},
+		output,
+	)
+	if err := req.Send(); err != nil {
+		return "", fmt.Errorf("Failed to decrypt secret %q with AWS KMS: %v", name, err)
+	}
+	return string(output.Plaintext), nil
+}
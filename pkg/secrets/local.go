@@ -0,0 +1,37 @@
+package secrets
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type local struct {
+	dir string
+}
+
+// NewLocal returns a Secrets store that keeps each secret in a file named
+// after the secret under dir. It is meant for testing and single node
+// setups.
+func NewLocal(dir string) Secrets {
+	return &local{dir: dir}
+}
+
+func (l *local) String() string {
+	return TypeLocal
+}
+
+func (l *local) GetSecret(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return "", ErrInvalidSecretName
+	}
+	data, err := ioutil.ReadFile(filepath.Join(l.dir, name))
+	if os.IsNotExist(err) {
+		return "", ErrSecretNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
@@ -0,0 +1,63 @@
+// Package secrets provides access to named secrets kept in an external
+// secrets store, such as encryption keys for volumes.
+package secrets
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/libopenstorage/openstorage/osdconfig"
+)
+
+const (
+	// TypeLocal keeps secrets in files on the local node
+	TypeLocal = "local"
+	// TypeVault reads secrets from a Hashicorp Vault server
+	TypeVault = "vault"
+	// TypeAWS decrypts secrets kept in files on the local node with AWS KMS
+	TypeAWS = "aws"
+	// DefaultLocalSecretsDir is used by the local and aws providers if no
+	// directory is configured
+	DefaultLocalSecretsDir = "/etc/osd/secrets"
+)
+
+var (
+	// ErrSecretNotFound returned when a secret does not exist
+	ErrSecretNotFound = errors.New("Secret not found")
+	// ErrInvalidSecretName returned when a secret name is empty or unsafe
+	ErrInvalidSecretName = errors.New("Invalid secret name")
+)
+
+// Secrets is a store of named secrets.
+type Secrets interface {
+	// String returns the type of the secrets store.
+	String() string
+	// GetSecret returns the secret stored under name.
+	GetSecret(name string) (string, error)
+}
+
+// New returns the secrets store configured in cfg.
+func New(cfg *osdconfig.SecretsConfig) (Secrets, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("No secrets configuration")
+	}
+	dir := DefaultLocalSecretsDir
+	if cfg.Local != nil && cfg.Local.SecretsDir != "" {
+		dir = cfg.Local.SecretsDir
+	}
+	switch cfg.SecretType {
+	case TypeLocal:
+		return NewLocal(dir), nil
+	case TypeVault:
+		if cfg.Vault == nil {
+			return nil, fmt.Errorf("Missing vault configuration")
+		}
+		return NewVault(cfg.Vault)
+	case TypeAWS:
+		if cfg.Aws == nil {
+			return nil, fmt.Errorf("Missing aws configuration")
+		}
+		return NewAWS(cfg.Aws, dir)
+	}
+	return nil, fmt.Errorf("Unknown secret type %q", cfg.SecretType)
+}
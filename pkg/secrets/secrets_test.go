@@ -0,0 +1,133 @@
+package secrets
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/osdconfig"
+)
+
+func TestLocal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secrets")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "key"), []byte("passphrase\n"), 0600))
+
+	s, err := New(&osdconfig.SecretsConfig{
+		SecretType: TypeLocal,
+		Local:      &osdconfig.LocalConfig{SecretsDir: dir},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, TypeLocal, s.String())
+
+	secret, err := s.GetSecret("key")
+	require.NoError(t, err)
+	assert.Equal(t, "passphrase", secret)
+
+	_, err = s.GetSecret("missing")
+	assert.Equal(t, ErrSecretNotFound, err)
+	_, err = s.GetSecret("../key")
+	assert.Equal(t, ErrInvalidSecretName, err)
+}
+
+func TestVault(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors": ["permission denied"]}`))
+			return
+		}
+		if r.URL.Path != "/v1/osd/key" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors": []}`))
+			return
+		}
+		w.Write([]byte(`{"data": {"value": "passphrase"}}`))
+	}))
+	defer ts.Close()
+
+	cfg := &osdconfig.SecretsConfig{
+		SecretType: TypeVault,
+		Vault: &osdconfig.VaultConfig{
+			VaultAddr:     ts.URL,
+			VaultToken:    "token",
+			VaultBasePath: "/osd/",
+		},
+	}
+	s, err := New(cfg)
+	require.NoError(t, err)
+
+	secret, err := s.GetSecret("key")
+	require.NoError(t, err)
+	assert.Equal(t, "passphrase", secret)
+	_, err = s.GetSecret("missing")
+	assert.Equal(t, ErrSecretNotFound, err)
+
+	cfg.Vault.VaultToken = "bad"
+	s, err = New(cfg)
+	require.NoError(t, err)
+	_, err = s.GetSecret("key")
+	assert.Error(t, err)
+}
+
+func TestAWS(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Target") != "TrentService.Decrypt" ||
+			!strings.Contains(r.Header.Get("Authorization"), "Credential=id/") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type": "InvalidRequest", "message": "invalid request"}`))
+			return
+		}
+		input := &struct{ CiphertextBlob []byte }{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(input))
+		if string(input.CiphertextBlob) != "encrypted passphrase" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type": "InvalidCiphertextException", "message": "invalid"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"KeyId":     "arn:aws:kms:us-east-1:1:key/cmk",
+			"Plaintext": []byte("passphrase"),
+		})
+	}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "secrets")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "key"),
+		[]byte(base64.StdEncoding.EncodeToString([]byte("encrypted passphrase"))), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "bad"),
+		[]byte(base64.StdEncoding.EncodeToString([]byte("garbage"))), 0600))
+
+	s, err := newAWS(&osdconfig.AWSConfig{
+		AwsAccessKeyId:     "id",
+		AwsSecretAccessKey: "secret",
+		AwsRegion:          "us-east-1",
+	}, dir, ts.URL)
+	require.NoError(t, err)
+	assert.Equal(t, TypeAWS, s.String())
+
+	secret, err := s.GetSecret("key")
+	require.NoError(t, err)
+	assert.Equal(t, "passphrase", secret)
+	_, err = s.GetSecret("bad")
+	assert.Error(t, err)
+	_, err = s.GetSecret("missing")
+	assert.Equal(t, ErrSecretNotFound, err)
+
+	_, err = New(&osdconfig.SecretsConfig{SecretType: TypeAWS})
+	assert.Error(t, err)
+	_, err = New(&osdconfig.SecretsConfig{SecretType: TypeAWS, Aws: &osdconfig.AWSConfig{}})
+	assert.Error(t, err)
+}
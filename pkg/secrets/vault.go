@@ -0,0 +1,149 @@
+package secrets
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/libopenstorage/openstorage/osdconfig"
+)
+
+const (
+	// defaultVaultBasePath is the mount of the vault key/value backend
+	defaultVaultBasePath = "secret"
+	// vaultSecretField is the field of a vault secret holding its value
+	vaultSecretField = "value"
+	vaultTimeout     = 30 * time.Second
+)
+
+type vault struct {
+	addr     string
+	token    string
+	basePath string
+	client   *http.Client
+}
+
+// vaultResponse is the subset of a vault read response we use.
+type vaultResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []string               `json:"errors"`
+}
+
+// NewVault returns a Secrets store that reads secrets from the key/value
+// backend of a vault server. Each secret is expected to keep its value in
+// the "value" field.
+func NewVault(cfg *osdconfig.VaultConfig) (Secrets, error) {
+	if cfg.VaultAddr == "" {
+		return nil, fmt.Errorf("Missing vault address")
+	}
+	if cfg.VaultToken == "" {
+		return nil, fmt.Errorf("Missing vault token")
+	}
+	tlsConfig, err := vaultTLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	basePath := strings.Trim(cfg.VaultBasePath, "/")
+	if basePath == "" {
+		basePath = defaultVaultBasePath
+	}
+	return &vault{
+		addr:     strings.TrimRight(cfg.VaultAddr, "/"),
+		token:    cfg.VaultToken,
+		basePath: basePath,
+		client: &http.Client{
+			Timeout:   vaultTimeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}, nil
+}
+
+func (v *vault) String() string {
+	return TypeVault
+}
+
+func (v *vault) GetSecret(name string) (string, error) {
+	if name == "" || strings.Contains(name, "..") {
+		return "", ErrInvalidSecretName
+	}
+	url := fmt.Sprintf("%s/v1/%s/%s", v.addr, v.basePath, strings.TrimLeft(name, "/"))
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", v.token)
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", ErrSecretNotFound
+	}
+	vr := &vaultResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(vr); err != nil {
+		return "", fmt.Errorf("Invalid response from vault: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Failed to read secret %q from vault: %s %v",
+			name, resp.Status, vr.Errors)
+	}
+	value, ok := vr.Data[vaultSecretField].(string)
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return value, nil
+}
+
+func vaultTLSConfig(cfg *osdconfig.VaultConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{ServerName: cfg.VaultTlsServerName}
+	if cfg.VaultSkipVerify != "" {
+		skip, err := strconv.ParseBool(cfg.VaultSkipVerify)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid vault_skip_verify: %v", err)
+		}
+		tlsConfig.InsecureSkipVerify = skip
+	}
+
+	caFiles := make([]string, 0)
+	if cfg.VaultCacert != "" {
+		caFiles = append(caFiles, cfg.VaultCacert)
+	}
+	if cfg.VaultCapath != "" {
+		files, err := filepath.Glob(filepath.Join(cfg.VaultCapath, "*.pem"))
+		if err != nil {
+			return nil, err
+		}
+		caFiles = append(caFiles, files...)
+	}
+	if len(caFiles) > 0 {
+		pool := x509.NewCertPool()
+		for _, file := range caFiles {
+			pem, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("No certificates found in %v", file)
+			}
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.VaultClientCert != "" || cfg.VaultClientKey != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.VaultClientCert, cfg.VaultClientKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
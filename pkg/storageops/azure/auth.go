@@ -0,0 +1,156 @@
+package azure
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+	"golang.org/x/oauth2"
+)
+
+const (
+	metadataEndpoint = "http://169.254.169.254/metadata"
+	loginEndpoint    = "https://login.microsoftonline.com"
+	// managementResource is the resource tokens are requested for.
+	managementResource = "https://management.azure.com/"
+)
+
+// metadataClient queries the instance metadata service, which only answers
+// on Azure virtual machines.
+var metadataClient = &http.Client{Timeout: 5 * time.Second}
+
+// instanceMetadata is the compute metadata of the running virtual machine.
+type instanceMetadata struct {
+	Name              string `json:"name"`
+	Location          string `json:"location"`
+	ResourceGroupName string `json:"resourceGroupName"`
+	SubscriptionID    string `json:"subscriptionId"`
+}
+
+// azureInfo fetches the instance metadata from the metadata service.
+func azureInfo(cfg *Config) error {
+	req, err := http.NewRequest("GET", metadataEndpoint+"/instance/compute?api-version=2017-08-01", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Metadata", "true")
+	resp, err := metadataClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("instance metadata returned %s", resp.Status)
+	}
+
+	var m instanceMetadata
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return err
+	}
+	cfg.InstanceName = m.Name
+	cfg.Location = m.Location
+	cfg.ResourceGroup = m.ResourceGroupName
+	cfg.SubscriptionID = m.SubscriptionID
+	return nil
+}
+
+// newAuthClient returns a client authenticating requests with the service
+// principal set in the environment, or with the managed identity of the
+// virtual machine.
+func newAuthClient() *http.Client {
+	var src oauth2.TokenSource = &msiTokenSource{}
+	tenant := os.Getenv("AZURE_TENANT_ID")
+	clientID := os.Getenv("AZURE_CLIENT_ID")
+	secret := os.Getenv("AZURE_CLIENT_SECRET")
+	if len(tenant) != 0 && len(clientID) != 0 && len(secret) != 0 {
+		src = &servicePrincipalTokenSource{
+			tenantID:     tenant,
+			clientID:     clientID,
+			clientSecret: secret,
+		}
+	}
+	return oauth2.NewClient(context.Background(), oauth2.ReuseTokenSource(nil, src))
+}
+
+// servicePrincipalTokenSource requests tokens from Azure Active Directory
+// with the credentials of a service principal.
+type servicePrincipalTokenSource struct {
+	tenantID     string
+	clientID     string
+	clientSecret string
+}
+
+func (s *servicePrincipalTokenSource) Token() (*oauth2.Token, error) {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {s.clientID},
+		"client_secret": {s.clientSecret},
+		"resource":      {managementResource},
+	}
+	req, err := http.NewRequest("POST",
+		fmt.Sprintf("%s/%s/oauth2/token", loginEndpoint, url.PathEscape(s.tenantID)),
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return fetchToken(http.DefaultClient, req)
+}
+
+// msiTokenSource requests tokens for the managed identity of the virtual
+// machine from the metadata service.
+type msiTokenSource struct{}
+
+func (s *msiTokenSource) Token() (*oauth2.Token, error) {
+	req, err := http.NewRequest("GET",
+		metadataEndpoint+"/identity/oauth2/token?api-version=2018-02-01&resource="+
+			url.QueryEscape(managementResource),
+		nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata", "true")
+	return fetchToken(metadataClient, req)
+}
+
+// fetchToken sends a token request, whose response has the format of the
+// version 1 endpoints of Azure Active Directory.
+func fetchToken(client *http.Client, req *http.Request) (*oauth2.Token, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get azure token: %s: %s", resp.Status, data)
+	}
+
+	var t struct {
+		AccessToken string      `json:"access_token"`
+		TokenType   string      `json:"token_type"`
+		ExpiresIn   json.Number `json:"expires_in"`
+	}
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+	expiresIn, err := t.ExpiresIn.Int64()
+	if err != nil {
+		return nil, fmt.Errorf("invalid azure token expiry %q: %v", t.ExpiresIn, err)
+	}
+	return &oauth2.Token{
+		AccessToken: t.AccessToken,
+		TokenType:   t.TokenType,
+		Expiry:      time.Now().Add(time.Duration(expiresIn) * time.Second),
+	}, nil
+}
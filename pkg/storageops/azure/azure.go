@@ -0,0 +1,602 @@
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/libopenstorage/openstorage/pkg/storageops"
+	"github.com/portworx/sched-ops/task"
+)
+
+// azureDiskPrefix is the prefix of the links udev creates to the data disks
+// of a virtual machine, suffixed by their LUN.
+const azureDiskPrefix = "/dev/disk/azure/scsi1/lun"
+
+// maxLuns is the maximum number of data disks of a virtual machine.
+const maxLuns = 64
+
+// Config is the configuration of an Azure operations client.
+type Config struct {
+	// InstanceName is the name of the virtual machine disks are attached to.
+	InstanceName   string
+	SubscriptionID string
+	ResourceGroup  string
+	// Location disks are created in unless their template sets one.
+	Location string
+	// Endpoint of the Azure Resource Manager, DefaultEndpoint if empty.
+	Endpoint string
+}
+
+type azureOps struct {
+	cfg     Config
+	compute *computeClient
+	mutex   sync.Mutex
+}
+
+// IsDevMode checks if the pkg is invoked in developer mode where the Azure
+// instance details are set as env variables
+func IsDevMode() bool {
+	var cfg Config
+	err := azureInfoFromEnv(&cfg)
+	return err == nil
+}
+
+// NewClient creates a new Azure operations client for the virtual machine
+// described by the env variables in developer mode, or else by the
+// instance metadata. Requests are authenticated with the service principal
+// set in AZURE_TENANT_ID, AZURE_CLIENT_ID and AZURE_CLIENT_SECRET, or with
+// the managed identity of the virtual machine.
+func NewClient() (storageops.Ops, error) {
+	var cfg Config
+	var err error
+	if IsDevMode() {
+		err = azureInfoFromEnv(&cfg)
+	} else {
+		err = azureInfo(&cfg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error fetching instance info. Err: %v", err)
+	}
+
+	return New(&cfg, newAuthClient())
+}
+
+// New creates a new Azure operations client sending its requests with
+// client, which must authenticate them.
+func New(cfg *Config, client *http.Client) (storageops.Ops, error) {
+	if len(cfg.InstanceName) == 0 ||
+		len(cfg.SubscriptionID) == 0 ||
+		len(cfg.ResourceGroup) == 0 {
+		return nil, fmt.Errorf("instance name, subscription and resource group must be provided")
+	}
+
+	endpoint := cfg.Endpoint
+	if len(endpoint) == 0 {
+		endpoint = DefaultEndpoint
+	}
+	return &azureOps{
+		cfg: *cfg,
+		compute: &computeClient{
+			client:         client,
+			endpoint:       endpoint,
+			subscriptionID: cfg.SubscriptionID,
+			resourceGroup:  cfg.ResourceGroup,
+		},
+	}, nil
+}
+
+func (s *azureOps) Name() string { return "azure" }
+
+func (s *azureOps) ApplyTags(
+	diskName string,
+	labels map[string]string,
+) error {
+	d, err := s.compute.getDisk(diskName)
+	if err != nil {
+		return err
+	}
+
+	tags := make(map[string]string)
+	for k, v := range d.Tags {
+		tags[k] = v
+	}
+	for k, v := range labels {
+		tags[k] = v
+	}
+	return s.compute.setDiskTags(d.Name, tags)
+}
+
+func (s *azureOps) Attach(diskName string) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	d, err := s.compute.getDisk(diskName)
+	if err != nil {
+		return "", err
+	}
+
+	vm, err := s.describe()
+	if err != nil {
+		return "", err
+	}
+
+	if len(d.ManagedBy) != 0 {
+		if strings.EqualFold(d.ManagedBy, vm.ID) {
+			return s.DevicePath(diskName)
+		}
+		return "", storageops.NewStorageError(
+			storageops.ErrVolAttachedOnRemoteNode,
+			fmt.Sprintf("disk %s is already attached to %s", diskName, d.ManagedBy),
+			s.cfg.InstanceName)
+	}
+
+	dataDisks := vm.dataDisks()
+	lun, err := freeLun(dataDisks)
+	if err != nil {
+		return "", err
+	}
+	dataDisks = append(dataDisks, DataDisk{
+		Lun:          lun,
+		Name:         d.Name,
+		CreateOption: DiskCreateOptionAttach,
+		ManagedDisk:  &ManagedDiskRef{ID: d.ID},
+	})
+	if err := s.compute.setDataDisks(s.cfg.InstanceName, dataDisks); err != nil {
+		return "", err
+	}
+
+	return s.waitForAttach(d.Name)
+}
+
+func (s *azureOps) Create(
+	template interface{},
+	labels map[string]string,
+) (interface{}, error) {
+	v, ok := template.(*Disk)
+	if !ok {
+		return nil, storageops.NewStorageError(storageops.ErrVolInval,
+			"Invalid volume template given", "")
+	}
+
+	tags := make(map[string]string)
+	for k, val := range v.Tags {
+		tags[k] = val
+	}
+	for k, val := range labels {
+		tags[k] = val
+	}
+
+	newDisk := &Disk{
+		Name:     v.Name,
+		Location: v.Location,
+		Tags:     tags,
+		Sku:      v.Sku,
+		Zones:    v.Zones,
+		Properties: &DiskProperties{
+			CreationData: &CreationData{CreateOption: DiskCreateOptionEmpty},
+		},
+	}
+	if len(newDisk.Location) == 0 {
+		newDisk.Location = s.cfg.Location
+	}
+	if v.Properties != nil {
+		newDisk.Properties.DiskSizeGB = v.Properties.DiskSizeGB
+		if v.Properties.CreationData != nil {
+			newDisk.Properties.CreationData = v.Properties.CreationData
+		}
+	}
+
+	if _, err := s.compute.putDisk(newDisk); err != nil {
+		return nil, err
+	}
+
+	d, err := s.waitDiskProvisioned(newDisk.Name)
+	if err != nil {
+		return nil, s.rollbackCreate(newDisk.Name, err)
+	}
+
+	return d, nil
+}
+
+func (s *azureOps) Delete(diskName string) error {
+	return s.compute.deleteDisk(diskName)
+}
+
+func (s *azureOps) Detach(diskName string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	vm, err := s.describe()
+	if err != nil {
+		return err
+	}
+
+	found := false
+	var dataDisks []DataDisk
+	for _, dd := range vm.dataDisks() {
+		if strings.EqualFold(dd.Name, diskName) {
+			found = true
+			continue
+		}
+		dataDisks = append(dataDisks, dd)
+	}
+	if !found {
+		return storageops.NewStorageError(storageops.ErrVolDetached,
+			fmt.Sprintf("Disk: %s is not attached to %s", diskName, s.cfg.InstanceName),
+			s.cfg.InstanceName)
+	}
+
+	if err := s.compute.setDataDisks(s.cfg.InstanceName, dataDisks); err != nil {
+		return err
+	}
+
+	return s.waitForDetach(diskName)
+}
+
+func (s *azureOps) DeviceMappings() (map[string]string, error) {
+	vm, err := s.describe()
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]string)
+	for _, dd := range vm.dataDisks() {
+		m[fmt.Sprintf("%s%d", azureDiskPrefix, dd.Lun)] = dd.Name
+	}
+
+	return m, nil
+}
+
+func (s *azureOps) DevicePath(diskName string) (string, error) {
+	d, err := s.compute.getDisk(diskName)
+	if err != nil {
+		return "", err
+	}
+
+	if len(d.ManagedBy) == 0 {
+		return "", storageops.NewStorageError(storageops.ErrVolDetached,
+			fmt.Sprintf("Disk: %s is detached", d.Name), s.cfg.InstanceName)
+	}
+
+	vm, err := s.describe()
+	if err != nil {
+		return "", err
+	}
+
+	if strings.EqualFold(d.ManagedBy, vm.ID) {
+		for _, dd := range vm.dataDisks() {
+			if strings.EqualFold(dd.Name, d.Name) {
+				return fmt.Sprintf("%s%d", azureDiskPrefix, dd.Lun), nil
+			}
+		}
+	}
+
+	return "", storageops.NewStorageError(
+		storageops.ErrVolAttachedOnRemoteNode,
+		fmt.Sprintf("disk %s is not attached on: %s (Attached on: %v)",
+			d.Name, s.cfg.InstanceName, d.ManagedBy),
+		s.cfg.InstanceName)
+}
+
+func (s *azureOps) Enumerate(
+	volumeIds []*string,
+	labels map[string]string,
+	setIdentifier string,
+) (map[string][]interface{}, error) {
+	sets := make(map[string][]interface{})
+
+	disks, err := s.compute.listDisks()
+	if err != nil {
+		logrus.Errorf("failed to list disks: %v", err)
+		return nil, err
+	}
+
+	for _, disk := range disks {
+		if !matchName(disk, volumeIds) || !matchTags(disk, labels) {
+			continue
+		}
+
+		if len(setIdentifier) == 0 {
+			storageops.AddElementToMap(sets, disk, storageops.SetIdentifierNone)
+		} else if v, ok := disk.Tags[setIdentifier]; ok && len(v) != 0 {
+			storageops.AddElementToMap(sets, disk, v)
+		} else {
+			storageops.AddElementToMap(sets, disk, storageops.SetIdentifierNone)
+		}
+	}
+
+	return sets, nil
+}
+
+func (s *azureOps) FreeDevices(
+	blockDeviceMappings []interface{},
+	rootDeviceName string,
+) ([]string, error) {
+	return nil, fmt.Errorf("function not implemented")
+}
+
+func (s *azureOps) GetDeviceID(disk interface{}) (string, error) {
+	if d, ok := disk.(*Disk); ok {
+		return d.Name, nil
+	} else if d, ok := disk.(*Snapshot); ok {
+		return d.Name, nil
+	} else {
+		return "", fmt.Errorf("invalid type: %v given to GetDeviceID", disk)
+	}
+}
+
+func (s *azureOps) Inspect(diskNames []*string) ([]interface{}, error) {
+	var disks []interface{}
+
+	for _, id := range diskNames {
+		d, err := s.compute.getDisk(*id)
+		if err != nil {
+			return nil, err
+		}
+
+		disks = append(disks, d)
+	}
+
+	return disks, nil
+}
+
+func (s *azureOps) RemoveTags(
+	diskName string,
+	labels map[string]string,
+) error {
+	d, err := s.compute.getDisk(diskName)
+	if err != nil {
+		return err
+	}
+
+	if len(d.Tags) == 0 {
+		return nil
+	}
+	for k := range labels {
+		delete(d.Tags, k)
+	}
+	return s.compute.setDiskTags(d.Name, d.Tags)
+}
+
+func (s *azureOps) Snapshot(
+	diskName string,
+	readonly bool,
+) (interface{}, error) {
+	d, err := s.compute.getDisk(diskName)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := &Snapshot{
+		Name:     fmt.Sprintf("%s-snap-%s", d.Name, time.Now().UTC().Format("20060102150405")),
+		Location: d.Location,
+		Sku:      d.Sku,
+		Properties: &DiskProperties{
+			CreationData: &CreationData{
+				CreateOption:     DiskCreateOptionCopy,
+				SourceResourceID: d.ID,
+			},
+		},
+	}
+	if _, err := s.compute.putSnapshot(snap); err != nil {
+		return nil, err
+	}
+
+	return s.waitSnapshotProvisioned(snap.Name)
+}
+
+func (s *azureOps) SnapshotDelete(snapID string) error {
+	return s.compute.deleteSnapshot(snapID)
+}
+
+func (s *azureOps) Tags(diskName string) (map[string]string, error) {
+	d, err := s.compute.getDisk(diskName)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Tags, nil
+}
+
+// Describe current instance.
+func (s *azureOps) Describe() (interface{}, error) {
+	return s.describe()
+}
+
+func (s *azureOps) describe() (*VirtualMachine, error) {
+	return s.compute.getVM(s.cfg.InstanceName)
+}
+
+// dataDisks returns the data disks attached to the virtual machine.
+func (vm *VirtualMachine) dataDisks() []DataDisk {
+	if vm.Properties == nil || vm.Properties.StorageProfile == nil {
+		return nil
+	}
+	return vm.Properties.StorageProfile.DataDisks
+}
+
+// freeLun returns the lowest LUN no data disk is attached on.
+func freeLun(dataDisks []DataDisk) (int32, error) {
+	used := make(map[int32]bool, len(dataDisks))
+	for _, dd := range dataDisks {
+		used[dd.Lun] = true
+	}
+	for lun := int32(0); lun < maxLuns; lun++ {
+		if !used[lun] {
+			return lun, nil
+		}
+	}
+	return 0, fmt.Errorf("No more free LUNs")
+}
+
+func matchName(d *Disk, names []*string) bool {
+	if len(names) == 0 {
+		return true
+	}
+	for _, name := range names {
+		if name != nil && strings.EqualFold(*name, d.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchTags(d *Disk, labels map[string]string) bool {
+	for k, v := range labels {
+		if tag, ok := d.Tags[k]; !ok || tag != v {
+			return false
+		}
+	}
+	return true
+}
+
+func azureInfoFromEnv(cfg *Config) error {
+	var err error
+	cfg.InstanceName, err = getEnvValueStrict("AZURE_INSTANCE_NAME")
+	if err != nil {
+		return err
+	}
+
+	cfg.SubscriptionID, err = getEnvValueStrict("AZURE_SUBSCRIPTION_ID")
+	if err != nil {
+		return err
+	}
+
+	cfg.ResourceGroup, err = getEnvValueStrict("AZURE_RESOURCE_GROUP")
+	if err != nil {
+		return err
+	}
+
+	cfg.Location, err = getEnvValueStrict("AZURE_LOCATION")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func getEnvValueStrict(key string) (string, error) {
+	if val := os.Getenv(key); len(val) != 0 {
+		return val, nil
+	}
+
+	return "", fmt.Errorf("env variable %s is not set", key)
+}
+
+func (s *azureOps) rollbackCreate(id string, createErr error) error {
+	logrus.Warnf("Rollback create volume %v, Error %v", id, createErr)
+	err := s.Delete(id)
+	if err != nil {
+		logrus.Warnf("Rollback failed volume %v, Error %v", id, err)
+	}
+	return createErr
+}
+
+// waitDiskProvisioned waits for disk name to be created or updated.
+func (s *azureOps) waitDiskProvisioned(name string) (*Disk, error) {
+	d, err := task.DoRetryWithTimeout(
+		func() (interface{}, bool, error) {
+			d, err := s.compute.getDisk(name)
+			if err != nil {
+				return nil, true, err
+			}
+
+			if d.Properties == nil {
+				return nil, true, fmt.Errorf("nil disk properties for %v", name)
+			}
+			retry, err := checkProvisioningState("disk", name, d.Properties.ProvisioningState)
+			return d, retry, err
+		},
+		storageops.ProviderOpsTimeout,
+		storageops.ProviderOpsRetryInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.(*Disk), nil
+}
+
+// waitSnapshotProvisioned waits for snapshot name to be created.
+func (s *azureOps) waitSnapshotProvisioned(name string) (*Snapshot, error) {
+	snap, err := task.DoRetryWithTimeout(
+		func() (interface{}, bool, error) {
+			snap, err := s.compute.getSnapshot(name)
+			if err != nil {
+				return nil, true, err
+			}
+
+			if snap.Properties == nil {
+				return nil, true, fmt.Errorf("nil snapshot properties for %v", name)
+			}
+			retry, err := checkProvisioningState("snapshot", name, snap.Properties.ProvisioningState)
+			return snap, retry, err
+		},
+		storageops.ProviderOpsTimeout,
+		storageops.ProviderOpsRetryInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	return snap.(*Snapshot), nil
+}
+
+// checkProvisioningState returns whether to retry waiting for a resource
+// in provisioning state actual, and the error to retry on.
+func checkProvisioningState(kind, name, actual string) (bool, error) {
+	switch actual {
+	case ProvisioningStateSucceeded:
+		return false, nil
+	case ProvisioningStateFailed:
+		return false, fmt.Errorf("failed to provision %s: %s", kind, name)
+	}
+	return true, fmt.Errorf("invalid status: %s for %s: %s. expected: %s",
+		actual, kind, name, ProvisioningStateSucceeded)
+}
+
+// waitForDetach checks if given disk is detached from the local instance
+func (s *azureOps) waitForDetach(diskName string) error {
+	_, err := task.DoRetryWithTimeout(
+		func() (interface{}, bool, error) {
+			d, err := s.compute.getDisk(diskName)
+			if err != nil {
+				return nil, true, err
+			}
+
+			if len(d.ManagedBy) != 0 {
+				return nil, true,
+					fmt.Errorf("disk: %s is still attached to instance: %s",
+						diskName, d.ManagedBy)
+			}
+
+			return nil, false, nil
+		},
+		storageops.ProviderOpsTimeout,
+		storageops.ProviderOpsRetryInterval)
+
+	return err
+}
+
+// waitForAttach checks if given disk is attached to the local instance
+func (s *azureOps) waitForAttach(diskName string) (string, error) {
+	devicePath, err := task.DoRetryWithTimeout(
+		func() (interface{}, bool, error) {
+			devicePath, err := s.DevicePath(diskName)
+			if err != nil {
+				return "", true, err
+			}
+
+			return devicePath, false, nil
+		},
+		storageops.ProviderOpsTimeout,
+		storageops.ProviderOpsRetryInterval)
+	if err != nil {
+		return "", err
+	}
+
+	return devicePath.(string), nil
+}
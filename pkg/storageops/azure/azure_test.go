@@ -0,0 +1,203 @@
+package azure
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/libopenstorage/openstorage/pkg/storageops"
+	"github.com/libopenstorage/openstorage/pkg/storageops/test"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	newDiskSizeInGB = 10
+	newDiskPrefix   = "openstorage-test"
+
+	fakeSubscription  = "sub"
+	fakeResourceGroup = "rg"
+	fakeLocation      = "westus"
+)
+
+var diskName = fmt.Sprintf("%s-%s", newDiskPrefix, uuid.NewV4())
+
+func newDiskTemplate(name string, tags map[string]string) *Disk {
+	return &Disk{
+		Name: name,
+		Tags: tags,
+		Sku:  &DiskSku{Name: "Standard_LRS"},
+		Properties: &DiskProperties{
+			DiskSizeGB: newDiskSizeInGB,
+		},
+	}
+}
+
+// newFakeOps returns the operations of virtual machine vm served by a fake
+// of the compute API.
+func newFakeOps(t *testing.T, ts *httptest.Server, vm string) storageops.Ops {
+	d, err := New(&Config{
+		InstanceName:   vm,
+		SubscriptionID: fakeSubscription,
+		ResourceGroup:  fakeResourceGroup,
+		Location:       fakeLocation,
+		Endpoint:       ts.URL,
+	}, http.DefaultClient)
+	require.NoError(t, err, "failed to instantiate storage ops driver")
+	return d
+}
+
+func TestAll(t *testing.T) {
+	drivers := make(map[string]storageops.Ops)
+	diskTemplates := make(map[string]map[string]interface{})
+
+	if IsDevMode() {
+		d, err := NewClient()
+		require.NoError(t, err, "failed to instantiate storage ops driver")
+		drivers[d.Name()] = d
+	} else {
+		ts := httptest.NewServer(newFakeCompute(fakeSubscription, fakeResourceGroup, "vm"))
+		defer ts.Close()
+		d := newFakeOps(t, ts, "vm")
+		drivers[d.Name()] = d
+	}
+	for name := range drivers {
+		diskTemplates[name] = map[string]interface{}{
+			diskName: newDiskTemplate(diskName, nil),
+		}
+	}
+
+	test.RunTest(drivers, diskTemplates, t)
+}
+
+func TestAttachRemote(t *testing.T) {
+	ts := httptest.NewServer(newFakeCompute(fakeSubscription, fakeResourceGroup, "vm1", "vm2"))
+	defer ts.Close()
+	d1 := newFakeOps(t, ts, "vm1")
+	d2 := newFakeOps(t, ts, "vm2")
+
+	_, err := d1.Create(newDiskTemplate("disk", nil), nil)
+	require.NoError(t, err)
+
+	_, err = d1.DevicePath("disk")
+	requireStorageError(t, err, storageops.ErrVolDetached)
+
+	devPath, err := d1.Attach("disk")
+	require.NoError(t, err)
+	require.Equal(t, azureDiskPrefix+"0", devPath)
+
+	devPath, err = d1.Attach("disk")
+	require.NoError(t, err, "attaching an attached disk must succeed")
+	require.Equal(t, azureDiskPrefix+"0", devPath)
+
+	_, err = d2.Attach("disk")
+	requireStorageError(t, err, storageops.ErrVolAttachedOnRemoteNode)
+	_, err = d2.DevicePath("disk")
+	requireStorageError(t, err, storageops.ErrVolAttachedOnRemoteNode)
+	err = d2.Detach("disk")
+	requireStorageError(t, err, storageops.ErrVolDetached)
+
+	require.NoError(t, d1.Detach("disk"))
+	devPath, err = d2.Attach("disk")
+	require.NoError(t, err)
+	require.Equal(t, azureDiskPrefix+"0", devPath)
+}
+
+func TestDeviceMappings(t *testing.T) {
+	ts := httptest.NewServer(newFakeCompute(fakeSubscription, fakeResourceGroup, "vm"))
+	defer ts.Close()
+	d := newFakeOps(t, ts, "vm")
+
+	for _, name := range []string{"disk0", "disk1", "disk2"} {
+		_, err := d.Create(newDiskTemplate(name, nil), nil)
+		require.NoError(t, err)
+		_, err = d.Attach(name)
+		require.NoError(t, err)
+	}
+	require.NoError(t, d.Detach("disk1"))
+
+	devPath, err := d.Attach("disk1")
+	require.NoError(t, err)
+	require.Equal(t, azureDiskPrefix+"1", devPath, "detached LUN must be reused")
+
+	_, err = d.Create(newDiskTemplate("disk3", nil), nil)
+	require.NoError(t, err)
+	devPath, err = d.Attach("disk3")
+	require.NoError(t, err)
+	require.Equal(t, azureDiskPrefix+"3", devPath)
+
+	mappings, err := d.DeviceMappings()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		azureDiskPrefix + "0": "disk0",
+		azureDiskPrefix + "1": "disk1",
+		azureDiskPrefix + "2": "disk2",
+		azureDiskPrefix + "3": "disk3",
+	}, mappings)
+}
+
+func TestEnumerate(t *testing.T) {
+	ts := httptest.NewServer(newFakeCompute(fakeSubscription, fakeResourceGroup, "vm"))
+	defer ts.Close()
+	d := newFakeOps(t, ts, "vm")
+
+	for name, tags := range map[string]map[string]string{
+		"disk0": {"app": "db", "set": "a"},
+		"disk1": {"app": "db", "set": "b"},
+		"disk2": {"app": "db"},
+		"disk3": {"app": "web", "set": "a"},
+	} {
+		_, err := d.Create(newDiskTemplate(name, nil), tags)
+		require.NoError(t, err)
+	}
+
+	sets, err := d.Enumerate(nil, map[string]string{"app": "db"}, "set")
+	require.NoError(t, err)
+	require.Len(t, sets, 3)
+	require.Equal(t, []string{"disk0"}, diskNames(t, d, sets["a"]))
+	require.Equal(t, []string{"disk1"}, diskNames(t, d, sets["b"]))
+	require.Equal(t, []string{"disk2"}, diskNames(t, d, sets[storageops.SetIdentifierNone]))
+
+	name := "disk3"
+	sets, err = d.Enumerate([]*string{&name}, nil, "")
+	require.NoError(t, err)
+	require.Len(t, sets, 1)
+	require.Equal(t, []string{"disk3"}, diskNames(t, d, sets[storageops.SetIdentifierNone]))
+}
+
+func diskNames(t *testing.T, d storageops.Ops, disks []interface{}) []string {
+	names := make([]string, 0, len(disks))
+	for _, disk := range disks {
+		name, err := d.GetDeviceID(disk)
+		require.NoError(t, err)
+		names = append(names, name)
+	}
+	return names
+}
+
+func requireStorageError(t *testing.T, err error, code int) {
+	require.Error(t, err)
+	serr, ok := err.(*storageops.StorageError)
+	require.True(t, ok, "unexpected error %v", err)
+	require.Equal(t, code, serr.Code, "unexpected error %v", err)
+}
+
+func TestFetchToken(t *testing.T) {
+	for _, expiresIn := range []string{`"3599"`, `3599`} {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "true", r.Header.Get("Metadata"))
+			fmt.Fprintf(w, `{"access_token":"token","token_type":"Bearer","expires_in":%s}`, expiresIn)
+		}))
+
+		req, err := http.NewRequest("GET", ts.URL, nil)
+		require.NoError(t, err)
+		req.Header.Set("Metadata", "true")
+		token, err := fetchToken(http.DefaultClient, req)
+		require.NoError(t, err)
+		require.Equal(t, "token", token.AccessToken)
+		require.Equal(t, "Bearer", token.TokenType)
+		require.True(t, token.Valid(), "token must not have expired")
+		ts.Close()
+	}
+}
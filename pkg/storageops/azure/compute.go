@@ -0,0 +1,290 @@
+package azure
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// DefaultEndpoint is the URL of the Azure Resource Manager.
+	DefaultEndpoint = "https://management.azure.com"
+
+	diskAPIVersion = "2017-03-30"
+	vmAPIVersion   = "2017-12-01"
+
+	// ProvisioningStateSucceeded is the provisioning state of resources
+	// which have been created or updated.
+	ProvisioningStateSucceeded = "Succeeded"
+	// ProvisioningStateFailed is the provisioning state of resources which
+	// could not be created or updated.
+	ProvisioningStateFailed = "Failed"
+
+	// DiskCreateOptionEmpty creates an empty disk.
+	DiskCreateOptionEmpty = "Empty"
+	// DiskCreateOptionCopy creates a disk or a snapshot from another disk
+	// or snapshot.
+	DiskCreateOptionCopy = "Copy"
+	// DiskCreateOptionAttach attaches an existing disk to a virtual machine.
+	DiskCreateOptionAttach = "Attach"
+)
+
+// Disk is a managed disk.
+type Disk struct {
+	ID       string            `json:"id,omitempty"`
+	Name     string            `json:"name,omitempty"`
+	Location string            `json:"location,omitempty"`
+	Tags     map[string]string `json:"tags,omitempty"`
+	Sku      *DiskSku          `json:"sku,omitempty"`
+	Zones    []string          `json:"zones,omitempty"`
+	// ManagedBy is the ID of the virtual machine the disk is attached to.
+	ManagedBy  string          `json:"managedBy,omitempty"`
+	Properties *DiskProperties `json:"properties,omitempty"`
+}
+
+// Snapshot is a snapshot of a managed disk.
+type Snapshot struct {
+	ID         string            `json:"id,omitempty"`
+	Name       string            `json:"name,omitempty"`
+	Location   string            `json:"location,omitempty"`
+	Tags       map[string]string `json:"tags,omitempty"`
+	Sku        *DiskSku          `json:"sku,omitempty"`
+	Properties *DiskProperties   `json:"properties,omitempty"`
+}
+
+// DiskSku is the storage account type of a disk, such as Standard_LRS or
+// Premium_LRS.
+type DiskSku struct {
+	Name string `json:"name,omitempty"`
+}
+
+// DiskProperties are the properties of a disk or of a snapshot.
+type DiskProperties struct {
+	CreationData      *CreationData `json:"creationData,omitempty"`
+	DiskSizeGB        int32         `json:"diskSizeGB,omitempty"`
+	TimeCreated       string        `json:"timeCreated,omitempty"`
+	ProvisioningState string        `json:"provisioningState,omitempty"`
+}
+
+// CreationData describes the source of a disk or of a snapshot.
+type CreationData struct {
+	CreateOption     string `json:"createOption,omitempty"`
+	SourceResourceID string `json:"sourceResourceId,omitempty"`
+}
+
+// VirtualMachine is a virtual machine disks are attached to.
+type VirtualMachine struct {
+	ID         string                    `json:"id,omitempty"`
+	Name       string                    `json:"name,omitempty"`
+	Location   string                    `json:"location,omitempty"`
+	Properties *VirtualMachineProperties `json:"properties,omitempty"`
+}
+
+// VirtualMachineProperties are the properties of a virtual machine.
+type VirtualMachineProperties struct {
+	StorageProfile    *StorageProfile `json:"storageProfile,omitempty"`
+	ProvisioningState string          `json:"provisioningState,omitempty"`
+}
+
+// StorageProfile lists the data disks of a virtual machine.
+type StorageProfile struct {
+	DataDisks []DataDisk `json:"dataDisks"`
+}
+
+// DataDisk is a disk attached to a virtual machine on a LUN.
+type DataDisk struct {
+	Lun                     int32            `json:"lun"`
+	Name                    string           `json:"name,omitempty"`
+	Caching                 string           `json:"caching,omitempty"`
+	CreateOption            string           `json:"createOption,omitempty"`
+	DiskSizeGB              int32            `json:"diskSizeGB,omitempty"`
+	ManagedDisk             *ManagedDiskRef  `json:"managedDisk,omitempty"`
+	Vhd                     *VirtualHardDisk `json:"vhd,omitempty"`
+	WriteAcceleratorEnabled *bool            `json:"writeAcceleratorEnabled,omitempty"`
+}
+
+// ManagedDiskRef references the managed disk of a data disk.
+type ManagedDiskRef struct {
+	ID                 string `json:"id,omitempty"`
+	StorageAccountType string `json:"storageAccountType,omitempty"`
+}
+
+// VirtualHardDisk references the blob of an unmanaged data disk.
+type VirtualHardDisk struct {
+	URI string `json:"uri,omitempty"`
+}
+
+// apiError is an error returned by the Azure Resource Manager.
+type apiError struct {
+	StatusCode int
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("azure: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// computeClient calls the compute API of the Azure Resource Manager for
+// the resources of a resource group.
+type computeClient struct {
+	client         *http.Client
+	endpoint       string
+	subscriptionID string
+	resourceGroup  string
+}
+
+// resourceURL returns the URL of the resource name of type kind, or of the
+// collection of the resources of type kind if name is empty.
+func (c *computeClient) resourceURL(kind, name, apiVersion string) string {
+	u := fmt.Sprintf("%s/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/%s",
+		strings.TrimSuffix(c.endpoint, "/"),
+		url.PathEscape(c.subscriptionID),
+		url.PathEscape(c.resourceGroup),
+		kind)
+	if len(name) != 0 {
+		u += "/" + url.PathEscape(name)
+	}
+	return u + "?api-version=" + apiVersion
+}
+
+// do sends a request with in JSON encoded as body, and decodes the response
+// into out if it is not nil.
+func (c *computeClient) do(method, u string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		var e struct {
+			Error apiError `json:"error"`
+		}
+		if err := json.Unmarshal(data, &e); err != nil || len(e.Error.Code) == 0 {
+			e.Error.Code = http.StatusText(resp.StatusCode)
+			e.Error.Message = string(data)
+		}
+		e.Error.StatusCode = resp.StatusCode
+		return &e.Error
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+func (c *computeClient) getDisk(name string) (*Disk, error) {
+	var d Disk
+	if err := c.do("GET", c.resourceURL("disks", name, diskAPIVersion), nil, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (c *computeClient) putDisk(d *Disk) (*Disk, error) {
+	var out Disk
+	if err := c.do("PUT", c.resourceURL("disks", d.Name, diskAPIVersion), d, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// setDiskTags replaces the tags of disk name.
+func (c *computeClient) setDiskTags(name string, tags map[string]string) error {
+	if tags == nil {
+		tags = make(map[string]string)
+	}
+	update := struct {
+		Tags map[string]string `json:"tags"`
+	}{tags}
+	return c.do("PATCH", c.resourceURL("disks", name, diskAPIVersion), &update, nil)
+}
+
+func (c *computeClient) deleteDisk(name string) error {
+	return c.do("DELETE", c.resourceURL("disks", name, diskAPIVersion), nil, nil)
+}
+
+// listDisks returns all the disks of the resource group.
+func (c *computeClient) listDisks() ([]*Disk, error) {
+	var disks []*Disk
+	u := c.resourceURL("disks", "", diskAPIVersion)
+	for len(u) != 0 {
+		var page struct {
+			Value    []*Disk `json:"value"`
+			NextLink string  `json:"nextLink"`
+		}
+		if err := c.do("GET", u, nil, &page); err != nil {
+			return nil, err
+		}
+		disks = append(disks, page.Value...)
+		u = page.NextLink
+	}
+	return disks, nil
+}
+
+func (c *computeClient) getSnapshot(name string) (*Snapshot, error) {
+	var s Snapshot
+	if err := c.do("GET", c.resourceURL("snapshots", name, diskAPIVersion), nil, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (c *computeClient) putSnapshot(s *Snapshot) (*Snapshot, error) {
+	var out Snapshot
+	if err := c.do("PUT", c.resourceURL("snapshots", s.Name, diskAPIVersion), s, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *computeClient) deleteSnapshot(name string) error {
+	return c.do("DELETE", c.resourceURL("snapshots", name, diskAPIVersion), nil, nil)
+}
+
+func (c *computeClient) getVM(name string) (*VirtualMachine, error) {
+	var vm VirtualMachine
+	if err := c.do("GET", c.resourceURL("virtualMachines", name, vmAPIVersion), nil, &vm); err != nil {
+		return nil, err
+	}
+	return &vm, nil
+}
+
+// setDataDisks replaces the data disks of virtual machine name.
+func (c *computeClient) setDataDisks(name string, disks []DataDisk) error {
+	if disks == nil {
+		disks = []DataDisk{}
+	}
+	update := &VirtualMachine{
+		Properties: &VirtualMachineProperties{
+			StorageProfile: &StorageProfile{DataDisks: disks},
+		},
+	}
+	return c.do("PATCH", c.resourceURL("virtualMachines", name, vmAPIVersion), update, nil)
+}
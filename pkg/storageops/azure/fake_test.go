@@ -0,0 +1,212 @@
+package azure
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// fakeCompute is a fake of the compute API of the Azure Resource Manager
+// for the disks, the snapshots and the virtual machines of a resource
+// group. Disks are provisioned immediately, but the responses to their
+// creation report them as still updating.
+type fakeCompute struct {
+	mu        sync.Mutex
+	prefix    string
+	disks     map[string]*Disk
+	snapshots map[string]*Snapshot
+	vms       map[string]*VirtualMachine
+}
+
+func newFakeCompute(subscriptionID, resourceGroup string, vms ...string) *fakeCompute {
+	f := &fakeCompute{
+		prefix: fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Compute/",
+			subscriptionID, resourceGroup),
+		disks:     make(map[string]*Disk),
+		snapshots: make(map[string]*Snapshot),
+		vms:       make(map[string]*VirtualMachine),
+	}
+	for _, name := range vms {
+		f.vms[strings.ToLower(name)] = &VirtualMachine{
+			ID:   f.prefix + "virtualMachines/" + name,
+			Name: name,
+			Properties: &VirtualMachineProperties{
+				StorageProfile:    &StorageProfile{DataDisks: []DataDisk{}},
+				ProvisioningState: ProvisioningStateSucceeded,
+			},
+		}
+	}
+	return f
+}
+
+func (f *fakeCompute) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.URL.Path, f.prefix) {
+		fakeError(w, http.StatusNotFound, "ResourceGroupNotFound", r.URL.Path)
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, f.prefix), "/")
+	kind, name := parts[0], ""
+	if len(parts) > 1 {
+		name = parts[1]
+	}
+	key := strings.ToLower(name)
+
+	switch {
+	case kind == "disks" && len(name) == 0 && r.Method == "GET":
+		disks := make([]*Disk, 0, len(f.disks))
+		for _, d := range f.disks {
+			disks = append(disks, d)
+		}
+		fakeReply(w, http.StatusOK, map[string]interface{}{"value": disks})
+	case kind == "disks" && r.Method == "GET":
+		if d, ok := f.disks[key]; ok {
+			fakeReply(w, http.StatusOK, d)
+		} else {
+			fakeError(w, http.StatusNotFound, "ResourceNotFound", name)
+		}
+	case kind == "disks" && r.Method == "PUT":
+		var d Disk
+		if !fakeDecode(w, r, &d) {
+			return
+		}
+		d.ID = f.prefix + "disks/" + name
+		d.Name = name
+		if d.Properties == nil {
+			d.Properties = &DiskProperties{}
+		}
+		d.Properties.ProvisioningState = "Updating"
+		fakeReply(w, http.StatusAccepted, &d)
+		stored := d
+		props := *d.Properties
+		props.ProvisioningState = ProvisioningStateSucceeded
+		stored.Properties = &props
+		f.disks[key] = &stored
+	case kind == "disks" && r.Method == "PATCH":
+		d, ok := f.disks[key]
+		if !ok {
+			fakeError(w, http.StatusNotFound, "ResourceNotFound", name)
+			return
+		}
+		var update Disk
+		if !fakeDecode(w, r, &update) {
+			return
+		}
+		d.Tags = update.Tags
+		fakeReply(w, http.StatusOK, d)
+	case kind == "disks" && r.Method == "DELETE":
+		d, ok := f.disks[key]
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if len(d.ManagedBy) != 0 {
+			fakeError(w, http.StatusConflict, "OperationNotAllowed", "disk is attached")
+			return
+		}
+		delete(f.disks, key)
+		w.WriteHeader(http.StatusAccepted)
+	case kind == "snapshots" && r.Method == "GET":
+		if s, ok := f.snapshots[key]; ok {
+			fakeReply(w, http.StatusOK, s)
+		} else {
+			fakeError(w, http.StatusNotFound, "ResourceNotFound", name)
+		}
+	case kind == "snapshots" && r.Method == "PUT":
+		var s Snapshot
+		if !fakeDecode(w, r, &s) {
+			return
+		}
+		src := s.Properties.CreationData.SourceResourceID
+		d, ok := f.disks[strings.ToLower(src[strings.LastIndex(src, "/")+1:])]
+		if !ok {
+			fakeError(w, http.StatusNotFound, "ResourceNotFound", src)
+			return
+		}
+		s.ID = f.prefix + "snapshots/" + name
+		s.Name = name
+		s.Properties.DiskSizeGB = d.Properties.DiskSizeGB
+		s.Properties.ProvisioningState = ProvisioningStateSucceeded
+		f.snapshots[key] = &s
+		fakeReply(w, http.StatusAccepted, &s)
+	case kind == "snapshots" && r.Method == "DELETE":
+		delete(f.snapshots, key)
+		w.WriteHeader(http.StatusAccepted)
+	case kind == "virtualMachines" && r.Method == "GET":
+		if vm, ok := f.vms[key]; ok {
+			fakeReply(w, http.StatusOK, vm)
+		} else {
+			fakeError(w, http.StatusNotFound, "ResourceNotFound", name)
+		}
+	case kind == "virtualMachines" && r.Method == "PATCH":
+		vm, ok := f.vms[key]
+		if !ok {
+			fakeError(w, http.StatusNotFound, "ResourceNotFound", name)
+			return
+		}
+		var update VirtualMachine
+		if !fakeDecode(w, r, &update) {
+			return
+		}
+		f.setDataDisks(w, vm, update.Properties.StorageProfile.DataDisks)
+	default:
+		fakeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", r.Method+" "+r.URL.Path)
+	}
+}
+
+// setDataDisks attaches the disks of dataDisks to vm, and detaches the
+// disks missing from dataDisks.
+func (f *fakeCompute) setDataDisks(w http.ResponseWriter, vm *VirtualMachine, dataDisks []DataDisk) {
+	luns := make(map[int32]bool)
+	for _, dd := range dataDisks {
+		if luns[dd.Lun] {
+			fakeError(w, http.StatusConflict, "InvalidParameter", fmt.Sprintf("LUN %d is in use", dd.Lun))
+			return
+		}
+		luns[dd.Lun] = true
+		d, ok := f.disks[strings.ToLower(dd.Name)]
+		if !ok {
+			fakeError(w, http.StatusNotFound, "NotFound", dd.Name)
+			return
+		}
+		if len(d.ManagedBy) != 0 && d.ManagedBy != vm.ID {
+			fakeError(w, http.StatusConflict, "AttachDiskWhileBeingDetached", dd.Name)
+			return
+		}
+	}
+
+	for _, dd := range vm.Properties.StorageProfile.DataDisks {
+		if d, ok := f.disks[strings.ToLower(dd.Name)]; ok {
+			d.ManagedBy = ""
+		}
+	}
+	for _, dd := range dataDisks {
+		f.disks[strings.ToLower(dd.Name)].ManagedBy = vm.ID
+	}
+	vm.Properties.StorageProfile.DataDisks = dataDisks
+	fakeReply(w, http.StatusOK, vm)
+}
+
+func fakeDecode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		fakeError(w, http.StatusBadRequest, "InvalidRequestContent", err.Error())
+		return false
+	}
+	return true
+}
+
+func fakeReply(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+func fakeError(w http.ResponseWriter, code int, errCode, msg string) {
+	fakeReply(w, code, map[string]interface{}{
+		"error": map[string]string{"code": errCode, "message": msg},
+	})
+}
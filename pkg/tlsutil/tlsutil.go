@@ -0,0 +1,162 @@
+// Package tlsutil builds server TLS configurations whose certificates are
+// reloaded when the files on disk change.
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"go.pedge.io/dlog"
+)
+
+// checkInterval is the minimum interval between checks of the files for
+// changes.
+var checkInterval = time.Second
+
+// Config locates the certificates of a server.
+type Config struct {
+	// CertFile holds the PEM encoded certificate chain of the server
+	CertFile string
+	// KeyFile holds the PEM encoded private key of the server
+	KeyFile string
+	// CAFile holds the PEM encoded certificates client certificates are
+	// verified with. Clients are not asked for a certificate if it is
+	// empty.
+	CAFile string
+	// ClientAuth rejects clients without a certificate signed by CAFile,
+	// otherwise clients without a certificate are accepted.
+	ClientAuth bool
+}
+
+// Reloader serves the certificates of a Config, reloading them when their
+// files change.
+type Reloader struct {
+	config    Config
+	lock      sync.Mutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  []time.Time
+	checked   time.Time
+}
+
+// NewReloader returns a Reloader for the certificates of config.
+func NewReloader(config *Config) (*Reloader, error) {
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, fmt.Errorf("TLS certificate and key must be provided")
+	}
+	if config.ClientAuth && config.CAFile == "" {
+		return nil, fmt.Errorf("TLS client authentication requires a CA file")
+	}
+	r := &Reloader{config: *config}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// TLSConfig returns a server TLS configuration serving the current
+// certificates.
+func (r *Reloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.current(), nil
+		},
+	}
+}
+
+// current returns the configuration of a handshake, reloading the
+// certificates first if their files changed.
+func (r *Reloader) current() *tls.Config {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if time.Since(r.checked) >= checkInterval {
+		r.checked = time.Now()
+		if r.changed() {
+			// Keep serving the old certificates if the new ones are
+			// not usable, such as while they are being written.
+			if err := r.loadLocked(); err != nil {
+				dlog.Warnf("Failed to reload TLS certificates: %v", err)
+			} else {
+				dlog.Infof("Reloaded TLS certificate %v", r.config.CertFile)
+			}
+		}
+	}
+
+	c := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*r.cert},
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+	if r.clientCAs != nil {
+		c.ClientCAs = r.clientCAs
+		c.ClientAuth = tls.VerifyClientCertIfGiven
+		if r.config.ClientAuth {
+			c.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+	return c
+}
+
+func (r *Reloader) load() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.checked = time.Now()
+	return r.loadLocked()
+}
+
+func (r *Reloader) loadLocked() error {
+	modTimes := r.modTimesOf()
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return fmt.Errorf("Unable to load TLS certificate: %v", err)
+	}
+	var clientCAs *x509.CertPool
+	if r.config.CAFile != "" {
+		pem, err := ioutil.ReadFile(r.config.CAFile)
+		if err != nil {
+			return fmt.Errorf("Unable to read TLS CA file: %v", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("No certificates found in TLS CA file %v", r.config.CAFile)
+		}
+	}
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	return nil
+}
+
+// changed returns true if a file changed since the certificates were
+// loaded.
+func (r *Reloader) changed() bool {
+	modTimes := r.modTimesOf()
+	for i := range modTimes {
+		if !modTimes[i].Equal(r.modTimes[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reloader) modTimesOf() []time.Time {
+	files := []string{r.config.CertFile, r.config.KeyFile, r.config.CAFile}
+	modTimes := make([]time.Time, len(files))
+	for i, file := range files {
+		if file == "" {
+			continue
+		}
+		if st, err := os.Stat(file); err == nil {
+			modTimes[i] = st.ModTime()
+		}
+	}
+	return modTimes
+}
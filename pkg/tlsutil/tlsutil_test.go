@@ -0,0 +1,154 @@
+package tlsutil
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *rsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newCert returns a certificate for name signed by parent, or self signed
+// if parent is nil.
+func newCert(t *testing.T, name string, serial int64, parent *testCert) *testCert {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth,
+			x509.ExtKeyUsageClientAuth,
+		},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA = true
+		tmpl.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM: pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		}),
+	}
+}
+
+func (c *testCert) write(t *testing.T, certFile string, keyFile string) {
+	require.NoError(t, ioutil.WriteFile(certFile, c.certPEM, 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, c.keyPEM, 0600))
+}
+
+func TestReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tlsutil")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newCert(t, "ca", 1, nil)
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(t, ioutil.WriteFile(caFile, ca.certPEM, 0600))
+	certFile := filepath.Join(dir, "server.pem")
+	keyFile := filepath.Join(dir, "server.key")
+	newCert(t, "server1", 2, ca).write(t, certFile, keyFile)
+	client := newCert(t, "client", 3, ca)
+
+	_, err = NewReloader(&Config{CertFile: certFile, KeyFile: keyFile, ClientAuth: true})
+	assert.Error(t, err)
+	r, err := NewReloader(&Config{
+		CertFile:   certFile,
+		KeyFile:    keyFile,
+		CAFile:     caFile,
+		ClientAuth: true,
+	})
+	require.NoError(t, err)
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	ts.TLS = r.TLSConfig()
+	ts.StartTLS()
+	defer ts.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	get := func(withCert bool) (string, error) {
+		config := &tls.Config{RootCAs: roots}
+		if withCert {
+			cert, err := tls.X509KeyPair(client.certPEM, client.keyPEM)
+			require.NoError(t, err)
+			config.Certificates = []tls.Certificate{cert}
+		}
+		// Keep-alives are off so each request makes a new handshake.
+		hc := &http.Client{Transport: &http.Transport{
+			TLSClientConfig:   config,
+			DisableKeepAlives: true,
+		}}
+		resp, err := hc.Get(ts.URL)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return "", err
+		}
+		assert.Equal(t, "client", string(body))
+		return resp.TLS.PeerCertificates[0].Subject.CommonName, nil
+	}
+
+	name, err := get(true)
+	require.NoError(t, err)
+	assert.Equal(t, "server1", name)
+	_, err = get(false)
+	assert.Error(t, err)
+
+	// A rotated certificate is served without a restart.
+	checkInterval = 0
+	defer func() { checkInterval = time.Second }()
+	later := time.Now().Add(time.Minute)
+	newCert(t, "server2", 4, ca).write(t, certFile, keyFile)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+	name, err = get(true)
+	require.NoError(t, err)
+	assert.Equal(t, "server2", name)
+
+	// A broken certificate keeps the previous one in use.
+	require.NoError(t, ioutil.WriteFile(certFile, []byte("garbage"), 0600))
+	require.NoError(t, os.Chtimes(certFile, later.Add(time.Minute), later.Add(time.Minute)))
+	name, err = get(true)
+	require.NoError(t, err)
+	assert.Equal(t, "server2", name)
+}
@@ -0,0 +1,53 @@
+package watch
+
+import (
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/cluster"
+)
+
+type nodeListener struct {
+	cluster.NullClusterListener
+	broker *Broker
+}
+
+// NewNodeListener returns a cluster listener publishing to b the nodes
+// going up, down or being decommissioned.
+func NewNodeListener(b *Broker) cluster.ClusterListener {
+	return &nodeListener{broker: b}
+}
+
+func (l *nodeListener) String() string {
+	return "watch"
+}
+
+func (l *nodeListener) Add(node *api.Node) error {
+	l.publish(EventNodeUp, node)
+	return nil
+}
+
+func (l *nodeListener) Update(node *api.Node) error {
+	switch node.Status {
+	case api.Status_STATUS_OK:
+		l.publish(EventNodeUp, node)
+	case api.Status_STATUS_OFFLINE:
+		l.publish(EventNodeDown, node)
+	case api.Status_STATUS_DECOMMISSION:
+		l.publish(EventNodeDecommission, node)
+	}
+	return nil
+}
+
+func (l *nodeListener) MarkNodeDown(node *api.Node) error {
+	l.publish(EventNodeDown, node)
+	return nil
+}
+
+func (l *nodeListener) Remove(node *api.Node, forceRemove bool) error {
+	l.publish(EventNodeDecommission, node)
+	return nil
+}
+
+func (l *nodeListener) publish(eventType string, node *api.Node) {
+	n := *node
+	l.broker.Publish(&Event{Type: eventType, Node: &n})
+}
@@ -0,0 +1,151 @@
+package watch
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/portworx/kvdb"
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume/drivers/common"
+)
+
+// volumeState is the last known state of a volume, nil once deleted.
+type volumeState struct {
+	volume *api.Volume
+	index  uint64
+}
+
+type volumeWatcher struct {
+	broker  *Broker
+	driver  string
+	lock    sync.Mutex
+	volumes map[string]*volumeState
+}
+
+// WatchVolumes publishes to b the changes of the volumes of driver, which
+// must store them with the default store enumerator of kv.
+func WatchVolumes(b *Broker, kv kvdb.Kvdb, driver string) error {
+	w := &volumeWatcher{
+		broker:  b,
+		driver:  driver,
+		volumes: make(map[string]*volumeState),
+	}
+	prefix := common.VolumeKeyPrefix(driver)
+	kvp, err := kv.Enumerate(prefix)
+	if err != nil {
+		return err
+	}
+	index := uint64(0)
+	for _, p := range kvp {
+		if isLockKey(p.Key) {
+			continue
+		}
+		v := &api.Volume{}
+		if err := json.Unmarshal(p.Value, v); err != nil {
+			return err
+		}
+		w.volumes[v.Id] = &volumeState{volume: v, index: p.ModifiedIndex}
+		if p.ModifiedIndex > index {
+			index = p.ModifiedIndex
+		}
+	}
+	return kv.WatchTree(prefix, index, nil, w.watch)
+}
+
+func (w *volumeWatcher) watch(prefix string, opaque interface{}, kvp *kvdb.KVPair, err error) error {
+	if err != nil {
+		dlog.Warnf("Stopped watching the volumes of %v: %v", w.driver, err)
+		return err
+	}
+	if kvp == nil || isLockKey(kvp.Key) {
+		return nil
+	}
+	id := path.Base(kvp.Key)
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	// Updates may be delivered out of order.
+	last := w.volumes[id]
+	if last != nil && kvp.ModifiedIndex <= last.index {
+		return nil
+	}
+
+	if kvp.Action == kvdb.KVDelete || kvp.Action == kvdb.KVExpire {
+		w.volumes[id] = &volumeState{index: kvp.ModifiedIndex}
+		if last != nil && last.volume != nil {
+			w.publish(EventVolumeDelete, last.volume)
+		}
+		return nil
+	}
+	v := &api.Volume{}
+	if err := json.Unmarshal(kvp.Value, v); err != nil {
+		dlog.Warnf("Failed to decode volume %v of %v: %v", id, w.driver, err)
+		return nil
+	}
+	w.volumes[id] = &volumeState{volume: v, index: kvp.ModifiedIndex}
+	var prev *api.Volume
+	if last != nil {
+		prev = last.volume
+	}
+	for _, t := range volumeEvents(prev, v) {
+		w.publish(t, v)
+	}
+	return nil
+}
+
+func (w *volumeWatcher) publish(eventType string, v *api.Volume) {
+	w.broker.Publish(&Event{Type: eventType, Driver: w.driver, Volume: v})
+}
+
+// volumeEvents returns the types of the events of the change of a volume
+// from prev, nil if it was just created, to v.
+func volumeEvents(prev *api.Volume, v *api.Volume) []string {
+	if prev == nil {
+		return []string{EventVolumeCreate}
+	}
+	events := make([]string, 0)
+	if prev.AttachedOn == "" && v.AttachedOn != "" {
+		events = append(events, EventVolumeAttach)
+	}
+	if isMounted(prev) != isMounted(v) {
+		if isMounted(v) {
+			events = append(events, EventVolumeMount)
+		} else {
+			events = append(events, EventVolumeUnmount)
+		}
+	}
+	if prev.AttachedOn != "" && v.AttachedOn == "" {
+		events = append(events, EventVolumeDetach)
+	}
+	if len(events) == 0 && !onlyUsageChanged(prev, v) {
+		events = append(events, EventVolumeUpdate)
+	}
+	return events
+}
+
+// onlyUsageChanged returns true if v only differs from prev by the usage
+// saved by the periodic scans of its driver.
+func onlyUsageChanged(prev *api.Volume, v *api.Volume) bool {
+	p := *prev
+	p.Usage = v.Usage
+	p.LastScan = v.LastScan
+	return proto.Equal(&p, v)
+}
+
+func isMounted(v *api.Volume) bool {
+	for _, p := range v.AttachPath {
+		if p != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func isLockKey(key string) bool {
+	return strings.HasSuffix(key, ".lock")
+}
@@ -0,0 +1,257 @@
+// Package watch streams changes of volumes and nodes to clients, which can
+// resume from the last revision they saw after reconnecting.
+package watch
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libopenstorage/openstorage/api"
+)
+
+// Types of events.
+const (
+	// EventVolumeCreate is sent when a volume is created
+	EventVolumeCreate = "volume.create"
+	// EventVolumeUpdate is sent when a volume changes otherwise
+	EventVolumeUpdate = "volume.update"
+	// EventVolumeDelete is sent when a volume is deleted
+	EventVolumeDelete = "volume.delete"
+	// EventVolumeAttach is sent when a volume is attached to a node
+	EventVolumeAttach = "volume.attach"
+	// EventVolumeDetach is sent when a volume is detached
+	EventVolumeDetach = "volume.detach"
+	// EventVolumeMount is sent when a volume is mounted
+	EventVolumeMount = "volume.mount"
+	// EventVolumeUnmount is sent when a volume is unmounted
+	EventVolumeUnmount = "volume.unmount"
+	// EventNodeUp is sent when a node joins or comes back online
+	EventNodeUp = "node.up"
+	// EventNodeDown is sent when a node goes offline
+	EventNodeDown = "node.down"
+	// EventNodeDecommission is sent when a node is removed from the cluster
+	EventNodeDecommission = "node.decommission"
+)
+
+// DefaultHistory is the number of events kept for clients to resume from.
+const DefaultHistory = 4096
+
+// watcherBuffer is the number of events a watcher may lag behind by
+// before it is stopped.
+const watcherBuffer = 256
+
+var (
+	// ErrCompacted returned when resuming from a revision that is no
+	// longer kept. Clients must list the current state and watch again.
+	ErrCompacted = errors.New("Revision no longer available, list and watch again")
+	// ErrSlow ends watchers that do not keep up with the events.
+	ErrSlow = errors.New("Watcher too slow, resume from the last revision received")
+)
+
+// Event is a change of a volume or node.
+type Event struct {
+	// Revision orders events, and is used to resume watching after it
+	Revision uint64 `json:"revision"`
+	// Type of event
+	Type string `json:"type"`
+	// Time of the event
+	Time time.Time `json:"time"`
+	// Driver of the volume of volume events
+	Driver string `json:"driver,omitempty"`
+	// Volume of volume events, as it was after the event
+	Volume *api.Volume `json:"volume,omitempty"`
+	// Node of node events
+	Node *api.Node `json:"node,omitempty"`
+}
+
+// Filter selects events.
+type Filter struct {
+	// Types selects events whose type has one of these prefixes, such as
+	// "volume" or "node.down", if not empty
+	Types []string
+	// Driver selects the volume events of a driver, and all node events,
+	// if set
+	Driver string
+	// Id selects the events of a volume or node if set
+	Id string
+}
+
+// Match returns true if the filter selects e.
+func (f *Filter) Match(e *Event) bool {
+	if len(f.Types) > 0 {
+		found := false
+		for _, t := range f.Types {
+			if strings.HasPrefix(e.Type, t) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.Driver != "" && e.Volume != nil && e.Driver != f.Driver {
+		return false
+	}
+	if f.Id != "" {
+		return (e.Volume != nil && e.Volume.Id == f.Id) ||
+			(e.Node != nil && e.Node.Id == f.Id)
+	}
+	return true
+}
+
+// Broker publishes events to watchers and keeps the latest events for
+// watchers to resume from.
+type Broker struct {
+	lock     sync.Mutex
+	history  []*Event
+	size     int
+	revision uint64
+	watchers map[*Watcher]bool
+}
+
+// NewBroker returns a Broker keeping the last history events. Revisions
+// start at the current time in nanoseconds, so that they increase across
+// restarts and revisions of a previous process are reported compacted.
+func NewBroker(history int) *Broker {
+	if history < 1 {
+		history = DefaultHistory
+	}
+	return &Broker{
+		size:     history,
+		revision: uint64(time.Now().UnixNano()),
+		watchers: make(map[*Watcher]bool),
+	}
+}
+
+// Revision returns the revision of the last event.
+func (b *Broker) Revision() uint64 {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.revision
+}
+
+// Publish assigns the next revision to e and sends it to the watchers.
+func (b *Broker) Publish(e *Event) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.revision++
+	e.Revision = b.revision
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	b.history = append(b.history, e)
+	if len(b.history) > b.size {
+		b.history = b.history[len(b.history)-b.size:]
+	}
+	for w := range b.watchers {
+		w.send(e)
+	}
+}
+
+// Watch returns a watcher of the events selected by f after revision, or
+// of new events if revision is zero. ErrCompacted is returned if events
+// after revision are no longer kept.
+func (b *Broker) Watch(revision uint64, f *Filter) (*Watcher, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	var backlog []*Event
+	if revision != 0 {
+		oldest := b.revision + 1
+		if len(b.history) > 0 {
+			oldest = b.history[0].Revision
+		}
+		if revision < oldest-1 || revision > b.revision {
+			return nil, ErrCompacted
+		}
+		backlog = b.history[len(b.history)-int(b.revision-revision):]
+	}
+
+	w := &Watcher{
+		broker: b,
+		filter: f,
+		events: make(chan *Event, len(backlog)+watcherBuffer),
+	}
+	for _, e := range backlog {
+		w.send(e)
+	}
+	b.watchers[w] = true
+	return w, nil
+}
+
+// Watcher receives events from a Broker.
+type Watcher struct {
+	broker *Broker
+	filter *Filter
+	events chan *Event
+	done   bool
+	err    error
+}
+
+// Events returns the channel events are received on. It is closed when
+// the watcher stops.
+func (w *Watcher) Events() <-chan *Event {
+	return w.events
+}
+
+// Err returns why the watcher stopped once Events is closed, nil if it
+// was stopped by Stop.
+func (w *Watcher) Err() error {
+	w.broker.lock.Lock()
+	defer w.broker.lock.Unlock()
+	return w.err
+}
+
+// Stop stops the watcher.
+func (w *Watcher) Stop() {
+	w.broker.lock.Lock()
+	defer w.broker.lock.Unlock()
+	w.stop(nil)
+}
+
+// send queues e if selected, with the broker locked.
+func (w *Watcher) send(e *Event) {
+	if w.done || (w.filter != nil && !w.filter.Match(e)) {
+		return
+	}
+	select {
+	case w.events <- e:
+	default:
+		w.stop(ErrSlow)
+	}
+}
+
+// stop closes the watcher with the broker locked.
+func (w *Watcher) stop(err error) {
+	if w.done {
+		return
+	}
+	w.done = true
+	w.err = err
+	delete(w.broker.watchers, w)
+	close(w.events)
+}
+
+var (
+	instance *Broker
+	lock     sync.RWMutex
+)
+
+// SetInstance sets the Broker events are watched from. Watching is
+// disabled if it is nil.
+func SetInstance(b *Broker) {
+	lock.Lock()
+	defer lock.Unlock()
+	instance = b
+}
+
+// Instance returns the Broker events are watched from, nil if watching is
+// disabled.
+func Instance() *Broker {
+	lock.RLock()
+	defer lock.RUnlock()
+	return instance
+}
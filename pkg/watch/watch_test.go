@@ -0,0 +1,154 @@
+package watch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/portworx/kvdb"
+	"github.com/portworx/kvdb/mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/proto/time"
+	"github.com/libopenstorage/openstorage/volume/drivers/common"
+)
+
+func next(t *testing.T, w *Watcher) *Event {
+	select {
+	case e, ok := <-w.Events():
+		require.True(t, ok, "watcher stopped: %v", w.Err())
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("No event received")
+	}
+	return nil
+}
+
+func TestBrokerResume(t *testing.T) {
+	b := NewBroker(3)
+	start := b.Revision()
+
+	w, err := b.Watch(0, &Filter{Types: []string{"volume"}})
+	require.NoError(t, err)
+	defer w.Stop()
+	b.Publish(&Event{Type: EventNodeUp, Node: &api.Node{Id: "node1"}})
+	b.Publish(&Event{Type: EventVolumeCreate, Volume: &api.Volume{Id: "vol1"}})
+	e := next(t, w)
+	assert.Equal(t, EventVolumeCreate, e.Type)
+	assert.Equal(t, start+2, e.Revision)
+
+	// Resuming replays the events after the revision.
+	resumed, err := b.Watch(start+1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, e.Revision, next(t, resumed).Revision)
+	b.Publish(&Event{Type: EventVolumeDelete, Volume: &api.Volume{Id: "vol1"}})
+	assert.Equal(t, EventVolumeDelete, next(t, resumed).Type)
+	resumed.Stop()
+	_, ok := <-resumed.Events()
+	assert.False(t, ok)
+	assert.NoError(t, resumed.Err())
+
+	b.Publish(&Event{Type: EventNodeDown, Node: &api.Node{Id: "node1"}})
+	_, err = b.Watch(start, nil)
+	assert.Equal(t, ErrCompacted, err)
+	_, err = b.Watch(start+1, nil)
+	assert.NoError(t, err)
+	_, err = b.Watch(b.Revision()+1, nil)
+	assert.Equal(t, ErrCompacted, err)
+}
+
+func TestBrokerSlowWatcher(t *testing.T) {
+	b := NewBroker(0)
+	w, err := b.Watch(0, nil)
+	require.NoError(t, err)
+	for i := 0; i <= watcherBuffer; i++ {
+		b.Publish(&Event{Type: EventVolumeUpdate, Volume: &api.Volume{Id: "vol1"}})
+	}
+	for range w.Events() {
+	}
+	assert.Equal(t, ErrSlow, w.Err())
+}
+
+func TestFilter(t *testing.T) {
+	vol := &Event{Type: EventVolumeMount, Driver: "nfs", Volume: &api.Volume{Id: "vol1"}}
+	node := &Event{Type: EventNodeDown, Node: &api.Node{Id: "node1"}}
+
+	f := &Filter{Driver: "nfs"}
+	assert.True(t, f.Match(vol))
+	assert.True(t, f.Match(node))
+	f = &Filter{Driver: "vfs"}
+	assert.False(t, f.Match(vol))
+	f = &Filter{Types: []string{"volume.mount", "node.up"}}
+	assert.True(t, f.Match(vol))
+	assert.False(t, f.Match(node))
+	f = &Filter{Id: "node1"}
+	assert.False(t, f.Match(vol))
+	assert.True(t, f.Match(node))
+}
+
+func TestWatchVolumes(t *testing.T) {
+	kv, err := kvdb.New(mem.Name, "watch_test", []string{}, nil, nil)
+	require.NoError(t, err)
+	store := common.NewDefaultStoreEnumerator("watch_test", kv)
+	existing := &api.Volume{Id: "vol0", Locator: &api.VolumeLocator{}, Spec: &api.VolumeSpec{}}
+	require.NoError(t, store.CreateVol(existing))
+
+	b := NewBroker(0)
+	w, err := b.Watch(0, nil)
+	require.NoError(t, err)
+	defer w.Stop()
+	require.NoError(t, WatchVolumes(b, kv, "watch_test"))
+
+	v := &api.Volume{Id: "vol1", Locator: &api.VolumeLocator{}, Spec: &api.VolumeSpec{}}
+	require.NoError(t, store.CreateVol(v))
+	e := next(t, w)
+	assert.Equal(t, EventVolumeCreate, e.Type)
+	assert.Equal(t, "watch_test", e.Driver)
+	assert.Equal(t, "vol1", e.Volume.Id)
+
+	v.AttachedOn = "node1"
+	v.AttachPath = []string{"/mnt/vol1"}
+	require.NoError(t, store.UpdateVol(v))
+	assert.Equal(t, EventVolumeAttach, next(t, w).Type)
+	assert.Equal(t, EventVolumeMount, next(t, w).Type)
+
+	v.AttachPath = nil
+	require.NoError(t, store.UpdateVol(v))
+	assert.Equal(t, EventVolumeUnmount, next(t, w).Type)
+
+	token, err := store.Lock("vol1")
+	require.NoError(t, err)
+	require.NoError(t, store.Unlock(token))
+	v.Usage = 4096
+	v.LastScan = prototime.Now()
+	require.NoError(t, store.UpdateVol(v))
+	v.Locator.Name = "renamed"
+	require.NoError(t, store.UpdateVol(v))
+	e = next(t, w)
+	assert.Equal(t, EventVolumeUpdate, e.Type)
+	assert.Equal(t, "renamed", e.Volume.Locator.Name, "usage scans must not be published")
+
+	require.NoError(t, store.DeleteVol("vol0"))
+	e = next(t, w)
+	assert.Equal(t, EventVolumeDelete, e.Type)
+	assert.Equal(t, "vol0", e.Volume.Id)
+}
+
+func TestNodeListener(t *testing.T) {
+	b := NewBroker(0)
+	w, err := b.Watch(0, nil)
+	require.NoError(t, err)
+	defer w.Stop()
+
+	l := NewNodeListener(b)
+	node := &api.Node{Id: "node1", Status: api.Status_STATUS_OFFLINE}
+	require.NoError(t, l.Update(node))
+	require.NoError(t, l.Add(node))
+	require.NoError(t, l.Remove(node, false))
+	assert.Equal(t, EventNodeDown, next(t, w).Type)
+	assert.Equal(t, EventNodeUp, next(t, w).Type)
+	e := next(t, w)
+	assert.Equal(t, EventNodeDecommission, e.Type)
+	assert.Equal(t, "node1", e.Node.Id)
+}
@@ -494,6 +494,8 @@ func (d *Driver) Shutdown() {
 	dlog.Printf("%s Shutting down", Name)
 }
 
+// Set is not supported. Resizing EBS volumes needs the ModifyVolume call,
+// which the vendored aws-sdk-go predates.
 func (d *Driver) Set(volumeID string, locator *api.VolumeLocator, spec *api.VolumeSpec) error {
 	return volume.ErrNotSupported
 }
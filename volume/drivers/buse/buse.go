@@ -1,12 +1,13 @@
 package buse
 
 import (
+	"context"
 	"fmt"
-	"io"
 	"os"
 	"os/exec"
 	"path"
 	"strings"
+	"sync"
 	"syscall"
 
 	"go.pedge.io/dlog"
@@ -14,6 +15,7 @@ import (
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/cluster"
 	"github.com/libopenstorage/openstorage/volume"
+	"github.com/libopenstorage/openstorage/volume/drivers/cloudbackup"
 	"github.com/libopenstorage/openstorage/volume/drivers/common"
 	"github.com/pborman/uuid"
 	"github.com/portworx/kvdb"
@@ -28,6 +30,10 @@ const (
 	BuseDBKey = "OpenStorageBuseKey"
 	// BuseMountPath mount path for openstorage
 	BuseMountPath = "/var/lib/openstorage/buse/"
+	// attachKeyPrefix is the kvdb prefix of the passphrases encrypted
+	// volumes were attached with, kept like those of their spec so that
+	// they are reattached with them after a restart.
+	attachKeyPrefix = "openstorage/buse/attach-keys/"
 )
 
 // Implements the open storage volume interface.
@@ -38,8 +44,14 @@ type driver struct {
 	volume.QuiesceDriver
 	volume.CredsDriver
 	volume.CloudBackupDriver
+	// cow holds the layered contents of all volumes
+	cow *cowStore
+	// buseDevices are the connected devices indexed by volume ID
 	buseDevices map[string]*buseDev
+	devLock     sync.Mutex
 	cl          cluster.ClusterListener
+	// stopScan stops saving the usage of volumes
+	stopScan func()
 }
 
 type clusterListener struct {
@@ -48,44 +60,18 @@ type clusterListener struct {
 
 // Implements the Device interface.
 type buseDev struct {
-	file string
-	f    *os.File
-	nbd  *NBD
+	vol *cowVolume
+	nbd *NBD
+	// key the dm-crypt mapping of encrypted volumes was opened with
+	key string
 }
 
 func (d *buseDev) ReadAt(b []byte, off int64) (n int, err error) {
-	return d.f.ReadAt(b, off)
+	return d.vol.ReadAt(b, off)
 }
 
 func (d *buseDev) WriteAt(b []byte, off int64) (n int, err error) {
-	return d.f.WriteAt(b, off)
-}
-
-func copyFile(source string, dest string) (err error) {
-	sourcefile, err := os.Open(source)
-	if err != nil {
-		return err
-	}
-
-	defer sourcefile.Close()
-
-	destfile, err := os.Create(dest)
-	if err != nil {
-		return err
-	}
-
-	defer destfile.Close()
-
-	_, err = io.Copy(destfile, sourcefile)
-	if err == nil {
-		sourceinfo, err := os.Stat(source)
-		if err != nil {
-			err = os.Chmod(dest, sourceinfo.Mode())
-		}
-
-	}
-
-	return
+	return d.vol.WriteAt(b, off)
 }
 
 // Init intialized the buse driver
@@ -96,15 +82,32 @@ func Init(params map[string]string) (volume.VolumeDriver, error) {
 		IODriver: volume.IONotSupported,
 		StoreEnumerator: common.NewDefaultStoreEnumerator(Name,
 			kvdb.Instance()),
-		StatsDriver:       volume.StatsNotSupported,
-		QuiesceDriver:     volume.QuiesceNotSupported,
-		CredsDriver:       volume.CredsNotSupported,
-		CloudBackupDriver: volume.CloudBackupNotSupported,
+		QuiesceDriver: volume.QuiesceNotSupported,
+		CredsDriver:   common.NewDefaultCredsDriver(Name, kvdb.Instance(), common.ClusterSecret),
 	}
 	inst.buseDevices = make(map[string]*buseDev)
 	if err := os.MkdirAll(BuseMountPath, 0744); err != nil {
 		return nil, err
 	}
+	cow, err := newCowStore(BuseMountPath)
+	if err != nil {
+		return nil, err
+	}
+	inst.cow = cow
+	inst.StatsDriver = common.NewDefaultStatsDriver(inst.StoreEnumerator, cow.usage,
+		func(volumeID string) (string, error) {
+			v, err := inst.GetVol(volumeID)
+			if err != nil {
+				return "", err
+			}
+			return v.DevicePath, nil
+		})
+	inst.CloudBackupDriver = cloudbackup.New(&cloudbackup.Config{
+		Name:   Name,
+		Driver: inst,
+		Source: &cowSource{store: cow},
+		Store:  cloudbackup.NewLocalStoreProvider(params),
+	})
 	volumeInfo, err := inst.StoreEnumerator.Enumerate(
 		&api.VolumeLocator{},
 		nil,
@@ -115,6 +118,18 @@ func Init(params map[string]string) (volume.VolumeDriver, error) {
 				info.Status = api.VolumeStatus_VOLUME_STATUS_UP
 				inst.UpdateVol(info)
 			}
+			if !cow.exists(info.Id) {
+				// Volumes created before snapshot layers have a flat file.
+				if err := cow.importFile(info.Id, path.Join(BuseMountPath, info.Id)); err != nil {
+					dlog.Warnf("Failed to import BUSE volume %v: %v", info.Id, err)
+					continue
+				}
+			}
+			if info.State == api.VolumeState_VOLUME_STATE_ATTACHED {
+				if err := inst.reattach(info); err != nil {
+					dlog.Warnf("Failed to reattach BUSE volume %v: %v", info.Id, err)
+				}
+			}
 		}
 	} else {
 		dlog.Println("Could not enumerate Volumes, ", err)
@@ -129,6 +144,9 @@ func Init(params map[string]string) (volume.VolumeDriver, error) {
 		c.AddEventListener(inst.cl)
 	}
 
+	inst.stopScan = common.StartUsageScanner(inst.StoreEnumerator, cow.usage,
+		common.UsageScanInterval)
+
 	dlog.Println("BUSE initialized and driver mounted at: ", BuseMountPath)
 	return inst, nil
 }
@@ -159,6 +177,19 @@ func (d *driver) Create(
 	source *api.Source,
 	spec *api.VolumeSpec,
 ) (string, error) {
+	return d.CreateContext(context.Background(), nil, locator, source, spec)
+}
+
+func (d *driver) CreateContext(
+	ctx context.Context,
+	progress func(percent int),
+	locator *api.VolumeLocator,
+	source *api.Source,
+	spec *api.VolumeSpec,
+) (string, error) {
+	if source != nil && source.Parent != "" {
+		return d.clone(ctx, progress, locator, source, spec)
+	}
 	volumeID := uuid.New()
 	volumeID = strings.TrimSuffix(volumeID, "\n")
 	if spec.Size == 0 {
@@ -167,43 +198,27 @@ func (d *driver) Create(
 	if spec.Format == api.FSType_FS_TYPE_NONE {
 		return "", fmt.Errorf("Missing volume format: buse")
 	}
-	// Create a file on the local buse path with this UUID.
-	buseFile := path.Join(BuseMountPath, volumeID)
-	f, err := os.Create(buseFile)
-	if err != nil {
+	if err := d.cow.create(volumeID, int64(spec.Size)); err != nil {
 		dlog.Println(err)
 		return "", err
 	}
 
-	if err := f.Truncate(int64(spec.Size)); err != nil {
-		dlog.Println(err)
-		return "", err
-	}
-
-	bd := &buseDev{
-		file: buseFile,
-		f:    f,
-	}
-	nbd := Create(bd, volumeID, int64(spec.Size))
-	bd.nbd = nbd
-
-	dlog.Infof("Connecting to NBD...")
-	dev, err := bd.nbd.Connect()
+	// Connect the volume only for as long as it takes to format it, it is
+	// connected again on Attach.
+	bd, err := d.connect(volumeID, int64(spec.Size))
 	if err != nil {
 		dlog.Println(err)
+		d.cow.delete(volumeID)
 		return "", err
 	}
-
-	dlog.Infof("Formatting %s with %v", dev, spec.Format)
-	cmd := "/sbin/mkfs." + spec.Format.SimpleString()
-	o, err := exec.Command(cmd, dev).Output()
-	if err != nil {
-		dlog.Warnf("Failed to run command %v %v: %v", cmd, dev, o)
+	if err := d.format(volumeID, bd.nbd.devicePath, spec); err != nil {
+		d.disconnect(volumeID)
+		d.cow.delete(volumeID)
 		return "", err
 	}
+	d.disconnect(volumeID)
 
-	dlog.Infof("BUSE mapped NBD device %s (size=%v) to block file %s", dev,
-		spec.Size, buseFile)
+	dlog.Infof("BUSE created volume %v (size=%v)", volumeID, spec.Size)
 
 	v := common.NewVolume(
 		volumeID,
@@ -212,9 +227,7 @@ func (d *driver) Create(
 		source,
 		spec,
 	)
-	v.DevicePath = dev
-
-	d.buseDevices[dev] = bd
+	v.State = api.VolumeState_VOLUME_STATE_DETACHED
 
 	err = d.CreateVol(v)
 	if err != nil {
@@ -223,6 +236,86 @@ func (d *driver) Create(
 	return v.Id, err
 }
 
+// clone creates a writable copy of the volume source.Parent. The layers of
+// a detached parent are shared with the clone. The device of an attached
+// parent is copied through the device of the clone, as the layers of the
+// parent keep changing under its filesystem.
+func (d *driver) clone(
+	ctx context.Context,
+	progress func(percent int),
+	locator *api.VolumeLocator,
+	source *api.Source,
+	spec *api.VolumeSpec,
+) (string, error) {
+	parent, err := d.GetVol(source.Parent)
+	if err != nil {
+		return "", err
+	}
+	// The data of the parent is copied as is, so clones take its spec.
+	if spec != nil && ((spec.Size != 0 && spec.Size != parent.Spec.Size) ||
+		(spec.Format != api.FSType_FS_TYPE_NONE && spec.Format != parent.Spec.Format)) {
+		return "", fmt.Errorf("Clones of volume %v must have its size and format", parent.Id)
+	}
+	cloneSpec := *parent.Spec
+	volumeID := uuid.New()
+	size := int64(cloneSpec.Size)
+
+	d.devLock.Lock()
+	pbd, attached := d.buseDevices[parent.Id]
+	d.devLock.Unlock()
+	if !attached {
+		if err := d.cow.snapshot(parent.Id, volumeID); err != nil {
+			return "", err
+		}
+	} else if err := d.cow.create(volumeID, size); err != nil {
+		return "", err
+	}
+
+	v := common.NewVolume(
+		volumeID,
+		cloneSpec.Format,
+		locator,
+		source,
+		&cloneSpec,
+	)
+	v.State = api.VolumeState_VOLUME_STATE_DETACHED
+	if err := d.CreateVol(v); err != nil {
+		d.cow.delete(volumeID)
+		return "", err
+	}
+	if attached {
+		err = d.copyDevice(ctx, progress, volumeID, pbd.nbd.devicePath, size)
+	}
+	if err != nil {
+		d.DeleteVol(volumeID)
+		d.cow.delete(volumeID)
+		return "", err
+	}
+	dlog.Infof("BUSE created clone %v of volume %v", volumeID, parent.Id)
+	return volumeID, nil
+}
+
+// copyDevice copies size bytes of the device src to the new volume
+// volumeID, connected for as long as the copy takes.
+func (d *driver) copyDevice(
+	ctx context.Context,
+	progress func(percent int),
+	volumeID string,
+	src string,
+	size int64,
+) error {
+	bd, err := d.connect(volumeID, size)
+	if err != nil {
+		return err
+	}
+	defer d.disconnect(volumeID)
+
+	// Flush the filesystem of the mounted parent to its device.
+	syscall.Sync()
+	opts := &common.CloneOptions{Zeroed: true, Context: ctx, Progress: progress}
+	return common.CloneDevice(d, volumeID, src, bd.nbd.devicePath, size, opts)
+}
+
 func (d *driver) Delete(volumeID string) error {
 	v, err := d.GetVol(volumeID)
 	if err != nil {
@@ -230,20 +323,18 @@ func (d *driver) Delete(volumeID string) error {
 		return err
 	}
 
-	bd, ok := d.buseDevices[v.DevicePath]
-	if !ok {
-		err = fmt.Errorf("Cannot locate a BUSE device for %s", v.DevicePath)
+	if err := d.detach(v); err != nil {
 		dlog.Println(err)
 		return err
 	}
 
-	// Clean up buse block file and close the NBD connection.
-	os.Remove(bd.file)
-	bd.f.Close()
-	bd.nbd.Disconnect()
+	// Clean up the layers only used by this volume.
+	if err := d.cow.delete(volumeID); err != nil && err != volume.ErrEnoEnt {
+		dlog.Println(err)
+		return err
+	}
 
-	dlog.Infof("BUSE deleted volume %v at NBD device %s", volumeID,
-		v.DevicePath)
+	dlog.Infof("BUSE deleted volume %v", volumeID)
 
 	if err := d.DeleteVol(volumeID); err != nil {
 		dlog.Println(err)
@@ -262,14 +353,25 @@ func (d *driver) Mount(volumeID string, mountpath string, options map[string]str
 	if err != nil {
 		return fmt.Errorf("Failed to locate volume %q", volumeID)
 	}
-	if len(v.AttachPath) > 0 && len(v.AttachPath) > 0 {
+	if len(v.AttachPath) > 0 && len(v.AttachPath[0]) > 0 {
 		return fmt.Errorf("Volume %q already mounted at %q", volumeID, v.AttachPath[0])
 	}
-	if err := syscall.Mount(v.DevicePath, mountpath, v.Spec.Format.SimpleString(), 0, ""); err != nil {
-		return fmt.Errorf("Failed to mount %v at %v: %v", v.DevicePath, mountpath, err)
+	if v.DevicePath == "" {
+		return volume.ErrVolDetached
+	}
+	devicePath := v.DevicePath
+	if v.Spec.Encrypted {
+		// Encrypted volumes are mounted from the mapping set up on Attach.
+		if v.SecureDevicePath == "" {
+			return volume.ErrVolDetached
+		}
+		devicePath = v.SecureDevicePath
+	}
+	if err := syscall.Mount(devicePath, mountpath, v.Spec.Format.SimpleString(), 0, ""); err != nil {
+		return fmt.Errorf("Failed to mount %v at %v: %v", devicePath, mountpath, err)
 	}
 
-	dlog.Infof("BUSE mounted NBD device %s at %s", v.DevicePath, mountpath)
+	dlog.Infof("BUSE mounted NBD device %s at %s", devicePath, mountpath)
 
 	if v.AttachPath == nil {
 		v.AttachPath = make([]string, 1)
@@ -293,65 +395,357 @@ func (d *driver) Unmount(volumeID string, mountpath string, options map[string]s
 	return d.UpdateVol(v)
 }
 
+// Snapshot freezes the current contents of the volume, the snapshot and
+// the volume then each write to their own copy-on-write layer.
 func (d *driver) Snapshot(volumeID string, readonly bool, locator *api.VolumeLocator) (string, error) {
-	volIDs := make([]string, 1)
-	volIDs[0] = volumeID
-	vols, err := d.Inspect(volIDs)
+	v, err := d.GetVol(volumeID)
 	if err != nil {
-		return "", nil
+		return "", err
 	}
 
-	source := &api.Source{Parent: volumeID}
-	newVolumeID, err := d.Create(locator, source, vols[0].Spec)
-	if err != nil {
-		return "", nil
-	}
+	// Flush the filesystem of a mounted volume to its device.
+	syscall.Sync()
 
-	// BUSE does not support snapshots, so just copy the block files.
-	err = copyFile(BuseMountPath+volumeID, BuseMountPath+newVolumeID)
-	if err != nil {
-		d.Delete(newVolumeID)
-		return "", nil
+	snapID := uuid.New()
+	if err := d.cow.snapshot(volumeID, snapID); err != nil {
+		return "", err
+	}
+	spec := *v.Spec
+	snap := common.NewVolume(
+		snapID,
+		v.Format,
+		locator,
+		&api.Source{Parent: volumeID},
+		&spec,
+	)
+	snap.Readonly = readonly
+	snap.State = api.VolumeState_VOLUME_STATE_DETACHED
+	if err := d.CreateVol(snap); err != nil {
+		d.cow.delete(snapID)
+		return "", err
 	}
+	dlog.Infof("BUSE created snapshot %v of volume %v", snapID, volumeID)
+	return snapID, nil
+}
 
-	return newVolumeID, nil
+// SnapshotContext is Snapshot, which shares the layers of the volume with
+// the snapshot instead of copying its data, so it is never slow enough to
+// cancel.
+func (d *driver) SnapshotContext(
+	ctx context.Context,
+	progress func(percent int),
+	volumeID string,
+	readonly bool,
+	locator *api.VolumeLocator,
+) (string, error) {
+	return d.Snapshot(volumeID, readonly, locator)
 }
 
+// RestoreContext is Restore, which switches layers without copying data.
+func (d *driver) RestoreContext(
+	ctx context.Context,
+	progress func(percent int),
+	volumeID string,
+	snapID string,
+) error {
+	return d.Restore(volumeID, snapID)
+}
+
+// Restore switches the volume to the layers of the snapshot.
 func (d *driver) Restore(volumeID string, snapID string) error {
-	if _, err := d.Inspect([]string{volumeID, snapID}); err != nil {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
 		return err
 	}
-
-	// BUSE does not support restore, so just copy the block files.
-	return copyFile(BuseMountPath+snapID, BuseMountPath+volumeID)
+	if _, err := d.GetVol(snapID); err != nil {
+		return err
+	}
+	if len(v.AttachPath) > 0 && len(v.AttachPath[0]) > 0 {
+		return fmt.Errorf("Volume %q is mounted at %q", volumeID, v.AttachPath[0])
+	}
+	return d.cow.restore(volumeID, snapID)
 }
 
 func (d *driver) Set(volumeID string, locator *api.VolumeLocator, spec *api.VolumeSpec) error {
-	if spec != nil {
-		return volume.ErrNotSupported
-	}
 	v, err := d.GetVol(volumeID)
 	if err != nil {
 		return err
 	}
+	if spec != nil {
+		size, err := common.SpecSize(v, spec)
+		if err != nil {
+			return err
+		}
+		if size != v.Spec.Size {
+			if err := d.resize(v, size); err != nil {
+				return err
+			}
+		}
+	}
 	if locator != nil {
 		v.Locator = locator
 	}
 	return d.UpdateVol(v)
 }
 
+// resize grows the block file backing the volume, the NBD device exporting
+// it and, if the volume is mounted, the filesystem on top of it.
+func (d *driver) resize(v *api.Volume, size uint64) (err error) {
+	d.devLock.Lock()
+	bd := d.buseDevices[v.Id]
+	d.devLock.Unlock()
+
+	state := v.State
+	v.State = api.VolumeState_VOLUME_STATE_PENDING
+	if err := d.UpdateVol(v); err != nil {
+		return err
+	}
+	defer func() {
+		v.State = state
+		if err != nil {
+			d.UpdateVol(v)
+		}
+	}()
+
+	dlog.Infof("BUSE resizing volume %v from %v to %v", v.Id, v.Spec.Size, size)
+	if err := d.cow.resize(v.Id, int64(size)); err != nil {
+		return err
+	}
+	if bd == nil {
+		// Detached volumes pick up the new size on Attach.
+		v.Spec.Size = size
+		return nil
+	}
+	if err := bd.nbd.Resize(int64(size)); err != nil {
+		return err
+	}
+	devicePath := v.DevicePath
+	if v.SecureDevicePath != "" {
+		if err := common.CryptResize(common.CryptName(v.Id), bd.key); err != nil {
+			return err
+		}
+		devicePath = v.SecureDevicePath
+	}
+	if len(v.AttachPath) > 0 {
+		if err := common.ResizeFilesystem(
+			v.Spec.Format,
+			devicePath,
+			v.AttachPath[0],
+		); err != nil {
+			return err
+		}
+	}
+	v.Spec.Size = size
+	return nil
+}
+
+// Attach connects the volume to a NBD device and, for encrypted volumes,
+// opens its dm-crypt mapping on top of it.
 func (d *driver) Attach(volumeID string, attachOptions map[string]string) (string, error) {
-	// Nothing to do on attach.
-	return path.Join(BuseMountPath, volumeID), nil
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return "", err
+	}
+	if err := d.attach(v, attachOptions); err != nil {
+		return "", err
+	}
+	if err := d.UpdateVol(v); err != nil {
+		return "", err
+	}
+	if v.SecureDevicePath != "" {
+		return v.SecureDevicePath, nil
+	}
+	return v.DevicePath, nil
 }
 
+// Detach closes the dm-crypt mapping of encrypted volumes and disconnects
+// the volume from its NBD device.
 func (d *driver) Detach(volumeID string, options map[string]string) error {
-	// Nothing to do on detach.
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return err
+	}
+	if err := d.detach(v); err != nil {
+		return err
+	}
+	return d.UpdateVol(v)
+}
+
+func (d *driver) attach(v *api.Volume, options map[string]string) error {
+	bd, err := d.connect(v.Id, int64(v.Spec.Size))
+	if err != nil {
+		return err
+	}
+	v.DevicePath = bd.nbd.devicePath
+	v.State = api.VolumeState_VOLUME_STATE_ATTACHED
+
+	if v.Spec.Encrypted {
+		key, err := common.VolumeKey(v.Spec, options, common.ClusterSecret, common.Secret)
+		if err != nil {
+			d.disconnect(v.Id)
+			return err
+		}
+		secureDevicePath, err := common.CryptOpen(v.DevicePath, common.CryptName(v.Id), key)
+		if err != nil {
+			d.disconnect(v.Id)
+			return err
+		}
+		if passphrase := options[api.SpecPassphrase]; passphrase != "" {
+			if _, err := kvdb.Instance().Put(attachKeyPrefix+v.Id, passphrase, 0); err != nil {
+				common.CryptClose(common.CryptName(v.Id))
+				d.disconnect(v.Id)
+				return err
+			}
+		}
+		bd.key = key
+		v.SecureDevicePath = secureDevicePath
+	}
+	dlog.Infof("BUSE attached volume %v at NBD device %s", v.Id, v.DevicePath)
+	return nil
+}
+
+func (d *driver) detach(v *api.Volume) error {
+	if len(v.AttachPath) > 0 && len(v.AttachPath[0]) > 0 {
+		return fmt.Errorf("Volume %q is mounted at %q", v.Id, v.AttachPath[0])
+	}
+	if v.Spec.Encrypted {
+		if err := common.CryptClose(common.CryptName(v.Id)); err != nil {
+			return err
+		}
+		v.SecureDevicePath = ""
+		if _, err := kvdb.Instance().Delete(attachKeyPrefix + v.Id); err != nil && err != kvdb.ErrNotFound {
+			dlog.Warnf("Failed to delete the attach passphrase of volume %v: %v", v.Id, err)
+		}
+	}
+	d.disconnect(v.Id)
+	if v.State == api.VolumeState_VOLUME_STATE_ATTACHED {
+		dlog.Infof("BUSE detached volume %v from NBD device %s", v.Id, v.DevicePath)
+	}
+	v.DevicePath = ""
+	v.State = api.VolumeState_VOLUME_STATE_DETACHED
+	return nil
+}
+
+// reattach connects a volume that was attached before the driver was
+// restarted, with the passphrase it was attached with, and remounts it if it
+// was mounted.
+func (d *driver) reattach(v *api.Volume) error {
+	// The NBD devices were reset in nbdInit, so any mapping on top of the
+	// old device is stale.
+	var options map[string]string
+	if v.Spec.Encrypted {
+		common.CryptClose(common.CryptName(v.Id))
+		v.SecureDevicePath = ""
+		kvp, err := kvdb.Instance().Get(attachKeyPrefix + v.Id)
+		if err == nil {
+			options = map[string]string{api.SpecPassphrase: string(kvp.Value)}
+		} else if err != kvdb.ErrNotFound {
+			return err
+		}
+	}
+	attachPath := v.AttachPath
+	v.AttachPath = nil
+	if err := d.attach(v, options); err != nil {
+		v.DevicePath = ""
+		v.State = api.VolumeState_VOLUME_STATE_DETACHED
+		d.UpdateVol(v)
+		return err
+	}
+	if err := d.UpdateVol(v); err != nil {
+		return err
+	}
+	for _, mountpath := range attachPath {
+		syscall.Unmount(mountpath, syscall.MNT_DETACH)
+		if err := d.Mount(v.Id, mountpath, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// connect opens the block file of a volume and exports it through a NBD
+// device. Connecting a volume that is already connected is a no-op.
+func (d *driver) connect(volumeID string, size int64) (*buseDev, error) {
+	d.devLock.Lock()
+	defer d.devLock.Unlock()
+
+	if bd, ok := d.buseDevices[volumeID]; ok {
+		return bd, nil
+	}
+	vol, err := d.cow.open(volumeID)
+	if err != nil {
+		return nil, err
+	}
+	bd := &buseDev{vol: vol}
+	if bd.nbd = Create(bd, volumeID, size); bd.nbd == nil {
+		return nil, fmt.Errorf("Failed to create NBD device for %v", volumeID)
+	}
+	dlog.Infof("Connecting to NBD...")
+	if _, err := bd.nbd.Connect(); err != nil {
+		bd.nbd.Disconnect()
+		Destroy(volumeID)
+		return nil, err
+	}
+	d.buseDevices[volumeID] = bd
+	return bd, nil
+}
+
+// disconnect tears down the NBD device of a volume if it is connected.
+func (d *driver) disconnect(volumeID string) {
+	d.devLock.Lock()
+	defer d.devLock.Unlock()
+
+	bd, ok := d.buseDevices[volumeID]
+	if !ok {
+		return
+	}
+	bd.nbd.Disconnect()
+	Destroy(volumeID)
+	delete(d.buseDevices, volumeID)
+}
+
+// format creates the filesystem of a new volume on dev, inside a LUKS
+// container if the volume is encrypted.
+func (d *driver) format(volumeID string, dev string, spec *api.VolumeSpec) error {
+	fsDev := dev
+	if spec.Encrypted {
+		key, err := common.VolumeKey(spec, nil, common.ClusterSecret, common.Secret)
+		if err != nil {
+			return err
+		}
+		if err := common.CryptFormat(dev, key); err != nil {
+			return err
+		}
+		if fsDev, err = common.CryptOpen(dev, common.CryptName(volumeID), key); err != nil {
+			return err
+		}
+		defer common.CryptClose(common.CryptName(volumeID))
+	}
+
+	dlog.Infof("Formatting %s with %v", fsDev, spec.Format)
+	cmd := "/sbin/mkfs." + spec.Format.SimpleString()
+	o, err := exec.Command(cmd, fsDev).CombinedOutput()
+	if err != nil {
+		dlog.Warnf("Failed to run command %v %v: %s", cmd, fsDev, o)
+		return err
+	}
 	return nil
 }
 
 func (d *driver) Shutdown() {
 	dlog.Printf("%s Shutting down", Name)
+	d.stopScan()
+	// Volumes stay attached in kvdb and are reconnected on the next Init.
+	d.devLock.Lock()
+	ids := make([]string, 0, len(d.buseDevices))
+	for id := range d.buseDevices {
+		ids = append(ids, id)
+	}
+	d.devLock.Unlock()
+	for _, id := range ids {
+		d.disconnect(id)
+	}
+	d.cow.close()
 	syscall.Unmount(BuseMountPath, 0)
 }
 
@@ -0,0 +1,694 @@
+package buse
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pborman/uuid"
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/volume"
+	"github.com/libopenstorage/openstorage/volume/drivers/common"
+)
+
+const (
+	// extentSize is the granularity at which extents are copied up into
+	// the top layer of a volume.
+	extentSize = 64 * 1024
+	layerDir   = "layers"
+	headDir    = "heads"
+)
+
+// layerMeta is stored next to the data of each layer.
+type layerMeta struct {
+	// Parent is the layer extents missing from this layer are read from.
+	Parent string `json:"parent,omitempty"`
+	// Full layers hold every extent and have no extent map.
+	Full bool `json:"full,omitempty"`
+}
+
+// layer is a sparse data file holding some extents of a volume, and a
+// bitmap of the extents it holds. Layers with children are never written.
+type layer struct {
+	id      string
+	meta    layerMeta
+	parent  *layer
+	data    *os.File
+	extMap  *os.File
+	extents []byte
+	// refs counts the volume heads and child layers using this layer.
+	refs int
+}
+
+// cowVolume is the device of a volume, reads go down its chain of layers
+// and writes go to its top layer.
+type cowVolume struct {
+	id   string
+	size int64
+	top  *layer
+	lock sync.Mutex
+}
+
+// cowStore keeps the layers of all volumes under a directory. Each volume
+// has a head file naming its top layer.
+type cowStore struct {
+	dir     string
+	layers  map[string]*layer
+	volumes map[string]*cowVolume
+	lock    sync.Mutex
+}
+
+// newCowStore loads the volumes and layers kept under dir and removes the
+// layers that no volume uses.
+func newCowStore(dir string) (*cowStore, error) {
+	s := &cowStore{
+		dir:     dir,
+		layers:  make(map[string]*layer),
+		volumes: make(map[string]*cowVolume),
+	}
+	for _, d := range []string{layerDir, headDir} {
+		if err := os.MkdirAll(filepath.Join(dir, d), 0744); err != nil {
+			return nil, err
+		}
+	}
+	heads, err := ioutil.ReadDir(filepath.Join(dir, headDir))
+	if err != nil {
+		return nil, err
+	}
+	for _, head := range heads {
+		if strings.HasPrefix(head.Name(), ".") {
+			continue
+		}
+		id, err := ioutil.ReadFile(s.headPath(head.Name()))
+		if err != nil {
+			return nil, err
+		}
+		top, err := s.load(string(id))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to load volume %v: %v", head.Name(), err)
+		}
+		info, err := top.data.Stat()
+		if err != nil {
+			return nil, err
+		}
+		top.refs++
+		s.volumes[head.Name()] = &cowVolume{
+			id:   head.Name(),
+			size: info.Size(),
+			top:  top,
+		}
+	}
+
+	files, err := ioutil.ReadDir(filepath.Join(dir, layerDir))
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		id := strings.TrimSuffix(f.Name(), filepath.Ext(f.Name()))
+		if _, ok := s.layers[id]; !ok {
+			dlog.Infof("Removing unused BUSE layer file %v", f.Name())
+			os.Remove(filepath.Join(dir, layerDir, f.Name()))
+		}
+	}
+	return s, nil
+}
+
+// exists returns true if the store has the volume volumeID.
+func (s *cowStore) exists(volumeID string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, ok := s.volumes[volumeID]
+	return ok
+}
+
+// open returns the device of volumeID.
+func (s *cowStore) open(volumeID string) (*cowVolume, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, ok := s.volumes[volumeID]
+	if !ok {
+		return nil, volume.ErrEnoEnt
+	}
+	return v, nil
+}
+
+// create adds an empty volume of size bytes.
+func (s *cowStore) create(volumeID string, size int64) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.volumes[volumeID]; ok {
+		return volume.ErrExist
+	}
+	top, err := s.newLayer(nil, size)
+	if err != nil {
+		return err
+	}
+	if err := s.setHead(volumeID, top); err != nil {
+		s.release(top)
+		return err
+	}
+	s.volumes[volumeID] = &cowVolume{id: volumeID, size: size, top: top}
+	return nil
+}
+
+// importFile adds a volume whose contents are the flat file at path, as
+// created by versions of the driver without snapshot layers. The file is
+// moved into the store.
+func (s *cowStore) importFile(volumeID string, path string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.volumes[volumeID]; ok {
+		return volume.ErrExist
+	}
+	id := uuid.New()
+	if err := os.Rename(path, s.layerPath(id, ".data")); err != nil {
+		return err
+	}
+	if err := writeFile(s.layerPath(id, ".json"), layerMeta{Full: true}); err != nil {
+		return err
+	}
+	top, err := s.load(id)
+	if err != nil {
+		return err
+	}
+	info, err := top.data.Stat()
+	if err != nil {
+		return err
+	}
+	top.refs++
+	if err := s.setHead(volumeID, top); err != nil {
+		s.release(top)
+		return err
+	}
+	s.volumes[volumeID] = &cowVolume{id: volumeID, size: info.Size(), top: top}
+	return nil
+}
+
+// delete removes volumeID and the layers only it used.
+func (s *cowStore) delete(volumeID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, ok := s.volumes[volumeID]
+	if !ok {
+		return volume.ErrEnoEnt
+	}
+	if err := os.Remove(s.headPath(volumeID)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	delete(s.volumes, volumeID)
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	s.release(v.top)
+	v.top = nil
+	return nil
+}
+
+// usage returns the bytes allocated to the layers of volumeID, including
+// the layers it shares with its snapshots.
+func (s *cowStore) usage(volumeID string) (uint64, error) {
+	v, err := s.open(volumeID)
+	if err != nil {
+		return 0, err
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	var used uint64
+	for l := v.top; l != nil; l = l.parent {
+		for _, f := range []*os.File{l.data, l.extMap} {
+			if f == nil {
+				continue
+			}
+			fileUsed, err := common.FileUsage(f.Name())
+			if err != nil {
+				return 0, err
+			}
+			used += fileUsed
+		}
+	}
+	return used, nil
+}
+
+// resize grows volumeID to size bytes.
+func (s *cowStore) resize(volumeID string, size int64) error {
+	v, err := s.open(volumeID)
+	if err != nil {
+		return err
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if size < v.size {
+		return volume.ErrVolShrink
+	}
+	if err := v.top.data.Truncate(size); err != nil {
+		return err
+	}
+	v.size = size
+	return nil
+}
+
+// snapshot creates snapID from the current contents of volumeID. The top
+// layer of volumeID is frozen and both volumes get a new empty top layer
+// on top of it.
+func (s *cowStore) snapshot(volumeID string, snapID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, ok := s.volumes[volumeID]
+	if !ok {
+		return volume.ErrEnoEnt
+	}
+	if _, ok := s.volumes[snapID]; ok {
+		return volume.ErrExist
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	frozen := v.top
+	snapTop, volTop, err := s.branch(frozen, v.size)
+	if err != nil {
+		return err
+	}
+	if err := s.setHead(snapID, snapTop); err != nil {
+		s.release(snapTop)
+		s.release(volTop)
+		return err
+	}
+	if err := s.setHead(volumeID, volTop); err != nil {
+		os.Remove(s.headPath(snapID))
+		s.release(snapTop)
+		s.release(volTop)
+		return err
+	}
+	v.top = volTop
+	s.release(frozen)
+	s.volumes[snapID] = &cowVolume{id: snapID, size: v.size, top: snapTop}
+	return nil
+}
+
+// restore switches volumeID to the contents of snapID. The top layer of
+// snapID is frozen and both volumes get a new empty top layer on top of
+// it, the layers only used by volumeID are released.
+func (s *cowStore) restore(volumeID string, snapID string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	v, ok := s.volumes[volumeID]
+	if !ok {
+		return volume.ErrEnoEnt
+	}
+	snap, ok := s.volumes[snapID]
+	if !ok || snap == v {
+		return volume.ErrEnoEnt
+	}
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	snap.lock.Lock()
+	defer snap.lock.Unlock()
+
+	if snap.size > v.size {
+		return fmt.Errorf("Snapshot %v is larger than volume %v", snapID, volumeID)
+	}
+	frozen := snap.top
+	snapTop, volTop, err := s.branch(frozen, snap.size)
+	if err != nil {
+		return err
+	}
+	if err := volTop.data.Truncate(v.size); err != nil {
+		s.release(snapTop)
+		s.release(volTop)
+		return err
+	}
+	if err := s.setHead(snapID, snapTop); err != nil {
+		s.release(snapTop)
+		s.release(volTop)
+		return err
+	}
+	if err := s.setHead(volumeID, volTop); err != nil {
+		// The snapshot keeps its new top layer, which is equivalent.
+		snap.top = snapTop
+		s.release(frozen)
+		s.release(volTop)
+		return err
+	}
+	snap.top = snapTop
+	s.release(frozen)
+	s.release(v.top)
+	v.top = volTop
+	return nil
+}
+
+// close closes the files of all layers.
+func (s *cowStore) close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, l := range s.layers {
+		l.close()
+	}
+}
+
+// branch returns two new empty layers on top of parent.
+func (s *cowStore) branch(parent *layer, size int64) (*layer, *layer, error) {
+	first, err := s.newLayer(parent, size)
+	if err != nil {
+		return nil, nil, err
+	}
+	second, err := s.newLayer(parent, size)
+	if err != nil {
+		s.release(first)
+		return nil, nil, err
+	}
+	return first, second, nil
+}
+
+// newLayer creates a layer of size bytes with one reference. Layers
+// without a parent are full.
+func (s *cowStore) newLayer(parent *layer, size int64) (l *layer, err error) {
+	id := uuid.New()
+	meta := layerMeta{Full: parent == nil}
+	if parent != nil {
+		meta.Parent = parent.id
+	}
+	defer func() {
+		if err != nil {
+			for _, ext := range []string{".data", ".map", ".json"} {
+				os.Remove(s.layerPath(id, ext))
+			}
+		}
+	}()
+
+	data, err := os.OpenFile(s.layerPath(id, ".data"), os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, err
+	}
+	l = &layer{id: id, meta: meta, parent: parent, data: data, refs: 1}
+	if err := data.Truncate(size); err != nil {
+		l.close()
+		return nil, err
+	}
+	if !meta.Full {
+		if l.extMap, err = os.OpenFile(s.layerPath(id, ".map"),
+			os.O_RDWR|os.O_CREATE|os.O_EXCL, 0644); err != nil {
+			l.close()
+			return nil, err
+		}
+	}
+	// The layer exists once its metadata is written.
+	if err := writeFile(s.layerPath(id, ".json"), meta); err != nil {
+		l.close()
+		return nil, err
+	}
+	if parent != nil {
+		parent.refs++
+	}
+	s.layers[id] = l
+	return l, nil
+}
+
+// load opens layer id and its parents.
+func (s *cowStore) load(id string) (*layer, error) {
+	if l, ok := s.layers[id]; ok {
+		return l, nil
+	}
+	l := &layer{id: id}
+	data, err := ioutil.ReadFile(s.layerPath(id, ".json"))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &l.meta); err != nil {
+		return nil, err
+	}
+	if l.data, err = os.OpenFile(s.layerPath(id, ".data"), os.O_RDWR, 0); err != nil {
+		return nil, err
+	}
+	if !l.meta.Full {
+		if l.extMap, err = os.OpenFile(s.layerPath(id, ".map"), os.O_RDWR, 0); err != nil {
+			l.close()
+			return nil, err
+		}
+		if l.extents, err = ioutil.ReadAll(l.extMap); err != nil {
+			l.close()
+			return nil, err
+		}
+	}
+	if l.meta.Parent != "" {
+		if l.parent, err = s.load(l.meta.Parent); err != nil {
+			l.close()
+			return nil, err
+		}
+		l.parent.refs++
+	}
+	s.layers[id] = l
+	return l, nil
+}
+
+// release drops a reference to l, removing it once it is unused.
+func (s *cowStore) release(l *layer) {
+	for ; l != nil; l = l.parent {
+		l.refs--
+		if l.refs > 0 {
+			return
+		}
+		l.close()
+		delete(s.layers, l.id)
+		for _, ext := range []string{".json", ".map", ".data"} {
+			os.Remove(s.layerPath(l.id, ext))
+		}
+	}
+}
+
+func (s *cowStore) setHead(volumeID string, top *layer) error {
+	tmp := s.headPath("." + volumeID)
+	if err := ioutil.WriteFile(tmp, []byte(top.id), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.headPath(volumeID))
+}
+
+func (s *cowStore) headPath(volumeID string) string {
+	return filepath.Join(s.dir, headDir, volumeID)
+}
+
+func (s *cowStore) layerPath(id string, ext string) string {
+	return filepath.Join(s.dir, layerDir, id+ext)
+}
+
+// Size returns the size of the volume in bytes.
+func (v *cowVolume) Size() int64 {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	return v.size
+}
+
+// ReadAt reads each extent from the first layer holding it, extents no
+// layer holds read as zeroes.
+func (v *cowVolume) ReadAt(b []byte, off int64) (int, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.top == nil {
+		return 0, volume.ErrEnoEnt
+	}
+	if off >= v.size {
+		return 0, io.EOF
+	}
+	n := 0
+	for n < len(b) && off+int64(n) < v.size {
+		pos := off + int64(n)
+		chunk := b[n : n+v.extentLen(pos, len(b)-n)]
+		if err := v.top.read(pos/extentSize, chunk, pos); err != nil {
+			return n, err
+		}
+		n += len(chunk)
+	}
+	if n < len(b) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// WriteAt writes to the top layer. Extents the top layer does not hold yet
+// are first copied up from the layers below.
+func (v *cowVolume) WriteAt(b []byte, off int64) (int, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	if v.top == nil {
+		return 0, volume.ErrEnoEnt
+	}
+	n := 0
+	for n < len(b) {
+		pos := off + int64(n)
+		e := pos / extentSize
+		chunk := b[n : n+v.extentLen(pos, len(b)-n)]
+		if v.top.has(e) {
+			if _, err := v.top.data.WriteAt(chunk, pos); err != nil {
+				return n, err
+			}
+			n += len(chunk)
+			continue
+		}
+
+		start := e * extentSize
+		end := start + extentSize
+		if end > v.size {
+			end = v.size
+		}
+		if end < pos+int64(len(chunk)) {
+			end = pos + int64(len(chunk))
+		}
+		ext := make([]byte, end-start)
+		if err := v.top.read(e, ext, start); err != nil {
+			return n, err
+		}
+		copy(ext[pos-start:], chunk)
+		if _, err := v.top.data.WriteAt(ext, start); err != nil {
+			return n, err
+		}
+		if err := v.top.set(e); err != nil {
+			return n, err
+		}
+		n += len(chunk)
+	}
+	return n, nil
+}
+
+// extentLen returns how many of the remaining bytes at pos fall in the
+// extent holding pos.
+func (v *cowVolume) extentLen(pos int64, remaining int) int {
+	l := extentSize - pos%extentSize
+	if l > int64(remaining) {
+		return remaining
+	}
+	return int(l)
+}
+
+// has returns true if the layer holds extent e.
+func (l *layer) has(e int64) bool {
+	if l.meta.Full {
+		return true
+	}
+	i := e / 8
+	return i < int64(len(l.extents)) && l.extents[i]&(1<<uint(e%8)) != 0
+}
+
+// set records that the layer holds extent e.
+func (l *layer) set(e int64) error {
+	if l.meta.Full {
+		return nil
+	}
+	i := e / 8
+	if i >= int64(len(l.extents)) {
+		extents := make([]byte, i+1)
+		copy(extents, l.extents)
+		l.extents = extents
+	}
+	l.extents[i] |= 1 << uint(e%8)
+	_, err := l.extMap.WriteAt(l.extents[i:i+1], i)
+	return err
+}
+
+// read fills b with the data at off of extent e from the first layer down
+// from l holding it.
+func (l *layer) read(e int64, b []byte, off int64) error {
+	for ; l != nil; l = l.parent {
+		if l.has(e) {
+			n, err := l.data.ReadAt(b, off)
+			if err == io.EOF {
+				// Layers created before the volume grew are shorter.
+				zero(b[n:])
+				return nil
+			}
+			return err
+		}
+	}
+	zero(b)
+	return nil
+}
+
+func (l *layer) close() {
+	if l.data != nil {
+		l.data.Close()
+	}
+	if l.extMap != nil {
+		l.extMap.Close()
+	}
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// writeFile atomically writes v as JSON to path.
+func writeFile(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// cowSource backs up and restores the contents of buse volumes.
+type cowSource struct {
+	store *cowStore
+}
+
+func (c *cowSource) Open(volumeID string) (io.ReadCloser, error) {
+	v, err := c.store.open(volumeID)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(io.NewSectionReader(v, 0, v.Size())), nil
+}
+
+func (c *cowSource) Catalogue(volumeID string) ([]string, error) {
+	v, err := c.store.open(volumeID)
+	if err != nil {
+		return nil, err
+	}
+	return []string{fmt.Sprintf("%s (%d bytes)", volumeID, v.Size())}, nil
+}
+
+func (c *cowSource) Restore(volumeID string, r io.Reader) error {
+	v, err := c.store.open(volumeID)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, 16*extentSize)
+	var off int64
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if _, werr := v.WriteAt(buf[:n], off); werr != nil {
+				return werr
+			}
+			off += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package buse
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testVolSize = 4 * extentSize
+
+func readVol(t *testing.T, s *cowStore, volumeID string) []byte {
+	v, err := s.open(volumeID)
+	require.NoError(t, err)
+	b := make([]byte, v.Size())
+	_, err = v.ReadAt(b, 0)
+	require.NoError(t, err)
+	return b
+}
+
+func writeVol(t *testing.T, s *cowStore, volumeID string, b []byte, off int64) {
+	v, err := s.open(volumeID)
+	require.NoError(t, err)
+	_, err = v.WriteAt(b, off)
+	require.NoError(t, err)
+}
+
+func layerFiles(t *testing.T, dir string) int {
+	files, err := ioutil.ReadDir(filepath.Join(dir, layerDir))
+	require.NoError(t, err)
+	return len(files)
+}
+
+func TestCowSnapshotRestore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "buse")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s, err := newCowStore(dir)
+	require.NoError(t, err)
+	require.NoError(t, s.create("vol", testVolSize))
+
+	// A write spanning two extents.
+	orig := bytes.Repeat([]byte{1}, extentSize)
+	writeVol(t, s, "vol", orig, extentSize/2)
+	expected := make([]byte, testVolSize)
+	copy(expected[extentSize/2:], orig)
+	assert.Equal(t, expected, readVol(t, s, "vol"))
+
+	require.NoError(t, s.snapshot("vol", "snap"))
+	assert.Equal(t, expected, readVol(t, s, "snap"))
+
+	// Partial writes copy up the rest of the extent.
+	writeVol(t, s, "vol", []byte{2, 2}, extentSize-1)
+	changed := make([]byte, testVolSize)
+	copy(changed, expected)
+	changed[extentSize-1], changed[extentSize] = 2, 2
+	assert.Equal(t, changed, readVol(t, s, "vol"))
+	assert.Equal(t, expected, readVol(t, s, "snap"))
+
+	// Layers and heads survive a reload.
+	s.close()
+	s, err = newCowStore(dir)
+	require.NoError(t, err)
+	assert.Equal(t, changed, readVol(t, s, "vol"))
+	assert.Equal(t, expected, readVol(t, s, "snap"))
+
+	require.NoError(t, s.restore("vol", "snap"))
+	assert.Equal(t, expected, readVol(t, s, "vol"))
+	writeVol(t, s, "snap", []byte{3}, 0)
+	assert.Equal(t, expected, readVol(t, s, "vol"))
+
+	// Grown volumes read zeroes past the end of their old layers.
+	require.NoError(t, s.resize("vol", 2*testVolSize))
+	grown := make([]byte, 2*testVolSize)
+	copy(grown, expected)
+	assert.Equal(t, grown, readVol(t, s, "vol"))
+	assert.Error(t, s.resize("vol", testVolSize))
+
+	require.NoError(t, s.delete("vol"))
+	require.NoError(t, s.delete("snap"))
+	assert.Equal(t, 0, layerFiles(t, dir))
+	s.close()
+}
+
+func TestCowImport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "buse")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	flat := filepath.Join(dir, "vol")
+	data := bytes.Repeat([]byte{7}, testVolSize)
+	require.NoError(t, ioutil.WriteFile(flat, data, 0644))
+	// Layer files no volume uses are removed on load.
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, layerDir), 0744))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, layerDir, "stale.data"), nil, 0644))
+
+	s, err := newCowStore(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 0, layerFiles(t, dir))
+	assert.False(t, s.exists("vol"))
+	require.NoError(t, s.importFile("vol", flat))
+	assert.True(t, s.exists("vol"))
+	assert.Equal(t, data, readVol(t, s, "vol"))
+
+	source := &cowSource{store: s}
+	require.NoError(t, s.create("copy", testVolSize))
+	r, err := source.Open("vol")
+	require.NoError(t, err)
+	require.NoError(t, source.Restore("copy", r))
+	assert.Equal(t, data, readVol(t, s, "copy"))
+	s.close()
+}
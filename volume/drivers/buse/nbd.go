@@ -124,6 +124,14 @@ func Create(device Device, id string, size int64) *NBD {
 	return nil
 }
 
+// Destroy forgets the NBD created for id. The NBD must be disconnected.
+func Destroy(id string) {
+	globalMutex.Lock()
+	defer globalMutex.Unlock()
+
+	delete(nbdDevices, id)
+}
+
 // IsConnected returns true if connected.
 func (nbd *NBD) IsConnected() bool {
 	return nbd.deviceFile != nil && nbd.socket > 0
@@ -153,6 +161,19 @@ func (nbd *NBD) Size(size int64) (err error) {
 	return err
 }
 
+// Resize changes the size of the NBD. If the device is connected the new
+// size is pushed to the kernel right away.
+func (nbd *NBD) Resize(size int64) error {
+	nbd.mutex.Lock()
+	defer nbd.mutex.Unlock()
+
+	nbd.size = size
+	if !nbd.IsConnected() {
+		return nil
+	}
+	return nbd.Size(size)
+}
+
 // Connect the network block device.
 func (nbd *NBD) Connect() (dev string, err error) {
 	pair, err := syscall.Socketpair(syscall.SOCK_STREAM, syscall.AF_UNIX, 0)
@@ -0,0 +1,118 @@
+// Package cloudbackup implements volume.CloudBackupDriver on top of a
+// generic object store. Volume contents are split into fixed size chunks
+// addressed by their checksum, so chunks already present in the store are
+// not uploaded again and backups after the first one are incremental.
+package cloudbackup
+
+import (
+	"errors"
+	"io"
+
+	"github.com/portworx/kvdb"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/sched"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+const (
+	// StorePathKey is the driver parameter that sets the directory used by
+	// the local object store.
+	StorePathKey = "cloudbackup_path"
+	// DefaultStorePath is the local object store directory used when
+	// StorePathKey is not set.
+	DefaultStorePath = volume.VolumeBase + "cloudbackup"
+)
+
+var (
+	// ErrBackupNotFound returned when a backup does not exist in the store
+	ErrBackupNotFound = errors.New("Backup not found")
+	// ErrBackupActive returned when an operation is already active on a volume
+	ErrBackupActive = errors.New("Backup or restore already active on volume")
+	// ErrNoActiveBackup returned when no operation is active on a volume
+	ErrNoActiveBackup = errors.New("No backup or restore active on volume")
+	// ErrBackupStopped returned when an operation was stopped by the user
+	ErrBackupStopped = errors.New("Backup or restore stopped")
+	// ErrObjectNotFound returned by an ObjectStore when a key does not exist
+	ErrObjectNotFound = errors.New("Object not found")
+)
+
+// ObjectStore is a flat key/value store for backup data.
+// Keys use '/' as a separator.
+type ObjectStore interface {
+	// Put stores the contents of r under key, replacing any previous object.
+	Put(key string, r io.Reader) error
+	// Get returns the contents stored under key or ErrObjectNotFound.
+	Get(key string) (io.ReadCloser, error)
+	// Exists returns true if an object is stored under key.
+	Exists(key string) (bool, error)
+	// Delete removes the object stored under key.
+	Delete(key string) error
+	// List returns the keys that start with prefix.
+	List(prefix string) ([]string, error)
+}
+
+// StoreProvider returns the object store for a cloud credential.
+type StoreProvider func(credentialUUID string) (ObjectStore, error)
+
+// Source reads and writes the contents of volumes.
+type Source interface {
+	// Open returns a reader for the contents of volumeID.
+	Open(volumeID string) (io.ReadCloser, error)
+	// Catalogue lists the contents of volumeID.
+	Catalogue(volumeID string) ([]string, error)
+	// Restore replaces the contents of volumeID with the data read from r.
+	Restore(volumeID string, r io.Reader) error
+}
+
+// Driver is the subset of the volume driver used by backups and restores.
+type Driver interface {
+	// Inspect specified volumes.
+	Inspect(volumeIDs []string) ([]*api.Volume, error)
+	// Create a new volume, used to restore backups.
+	Create(locator *api.VolumeLocator, source *api.Source, spec *api.VolumeSpec) (string, error)
+	// Delete a volume.
+	Delete(volumeID string) error
+	// Snapshot a volume, the snapshot is backed up instead of the live
+	// volume. Backups fail if the volume cannot be snapshotted.
+	Snapshot(volumeID string, readonly bool, locator *api.VolumeLocator) (string, error)
+}
+
+// Config for a CloudBackupDriver.
+type Config struct {
+	// Name of the volume driver, used to namespace state in kvdb.
+	Name string
+	// Driver whose volumes are backed up.
+	Driver Driver
+	// Source of the volume contents.
+	Source Source
+	// Store returns the object store for a credential.
+	Store StoreProvider
+	// Kvdb persists history and schedules. kvdb.Instance() if nil.
+	Kvdb kvdb.Kvdb
+	// Scheduler runs backup schedules. sched.Instance() if nil.
+	Scheduler sched.Scheduler
+	// ClusterID backups are stored under. Looked up from the cluster
+	// manager if empty.
+	ClusterID string
+	// NodeID of this node. Looked up from the cluster manager if empty.
+	NodeID string
+}
+
+// New returns a CloudBackupDriver for the volumes of the driver in cfg.
+func New(cfg *Config) volume.CloudBackupDriver {
+	return newCloudBackup(cfg)
+}
+
+// NewLocalStoreProvider returns a StoreProvider that keeps all backups in
+// the local directory set by StorePathKey in params, regardless of the
+// credential.
+func NewLocalStoreProvider(params map[string]string) StoreProvider {
+	root, ok := params[StorePathKey]
+	if !ok || root == "" {
+		root = DefaultStorePath
+	}
+	return func(credentialUUID string) (ObjectStore, error) {
+		return NewLocalStore(root)
+	}
+}
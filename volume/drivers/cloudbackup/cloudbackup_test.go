@@ -0,0 +1,445 @@
+package cloudbackup
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/portworx/kvdb"
+	"github.com/portworx/kvdb/mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/sched"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+// testDriver keeps volumes as directories under root.
+type testDriver struct {
+	sync.Mutex
+	root    string
+	volumes map[string]*api.Volume
+	// snapErr is returned by Snapshot if set
+	snapErr error
+}
+
+func (d *testDriver) Inspect(volumeIDs []string) ([]*api.Volume, error) {
+	d.Lock()
+	defer d.Unlock()
+	vols := make([]*api.Volume, 0)
+	for _, id := range volumeIDs {
+		if v, ok := d.volumes[id]; ok {
+			vols = append(vols, v)
+		}
+	}
+	return vols, nil
+}
+
+func (d *testDriver) Create(
+	locator *api.VolumeLocator,
+	source *api.Source,
+	spec *api.VolumeSpec,
+) (string, error) {
+	d.Lock()
+	defer d.Unlock()
+	id := uuid.New()
+	if err := os.MkdirAll(d.path(id), 0744); err != nil {
+		return "", err
+	}
+	d.volumes[id] = &api.Volume{Id: id, Locator: locator, Spec: spec}
+	return id, nil
+}
+
+func (d *testDriver) Delete(volumeID string) error {
+	d.Lock()
+	defer d.Unlock()
+	delete(d.volumes, volumeID)
+	return os.RemoveAll(d.path(volumeID))
+}
+
+func (d *testDriver) Snapshot(
+	volumeID string,
+	readonly bool,
+	locator *api.VolumeLocator,
+) (string, error) {
+	d.Lock()
+	defer d.Unlock()
+	if d.snapErr != nil {
+		return "", d.snapErr
+	}
+	// Copy the contents with their times, so that backups of snapshots of
+	// unchanged contents are identical.
+	id := uuid.New()
+	src := d.path(volumeID)
+	copied := make(map[string]os.FileInfo)
+	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		dst := filepath.Join(d.path(id), rel)
+		copied[dst] = info
+		if info.IsDir() {
+			return os.MkdirAll(dst, info.Mode())
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(dst, data, info.Mode())
+	})
+	if err != nil {
+		return "", err
+	}
+	for dst, info := range copied {
+		if err := os.Chtimes(dst, info.ModTime(), info.ModTime()); err != nil {
+			return "", err
+		}
+	}
+	d.volumes[id] = &api.Volume{
+		Id:       id,
+		Locator:  locator,
+		Source:   &api.Source{Parent: volumeID},
+		Readonly: readonly,
+	}
+	return id, nil
+}
+
+func (d *testDriver) path(volumeID string) string {
+	return filepath.Join(d.root, volumeID)
+}
+
+type testEnv struct {
+	root   string
+	driver *testDriver
+	store  ObjectStore
+	sched  sched.Scheduler
+	cb     *cloudBackup
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	root, err := ioutil.TempDir("", "cloudbackup")
+	require.NoError(t, err)
+	kv, err := kvdb.New(mem.Name, "cloudbackup_test", []string{}, nil, dlog.Panicf)
+	require.NoError(t, err)
+
+	e := &testEnv{
+		root:   root,
+		driver: &testDriver{root: filepath.Join(root, "volumes"), volumes: make(map[string]*api.Volume)},
+		sched:  sched.New(time.Second),
+	}
+	e.store, err = NewLocalStore(filepath.Join(root, "store"))
+	require.NoError(t, err)
+	e.cb = newCloudBackup(&Config{
+		Name:   "cloudbackup_test",
+		Driver: e.driver,
+		Source: NewDirSource(func(volumeID string) (string, error) {
+			return e.driver.path(volumeID), nil
+		}),
+		Store: func(credentialUUID string) (ObjectStore, error) {
+			return e.store, nil
+		},
+		Kvdb:      kv,
+		Scheduler: e.sched,
+		ClusterID: "cluster1",
+		NodeID:    "node1",
+	})
+	return e
+}
+
+func (e *testEnv) cleanup() {
+	e.sched.Stop()
+	os.RemoveAll(e.root)
+}
+
+func (e *testEnv) waitFor(t *testing.T, volumeID string) api.BackupStatus {
+	for i := 0; i < 100; i++ {
+		sts := e.cb.BackupStatus(&api.BackupStsRequest{SrcVolumeID: volumeID})
+		require.Empty(t, sts.StsErr)
+		s, ok := sts.Statuses[volumeID]
+		require.True(t, ok, "no status for volume %v", volumeID)
+		if s.Status != api.BackupStatusActive && s.Status != api.BackupStatusPaused {
+			return s
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	require.FailNow(t, "timed out waiting for backup or restore")
+	return api.BackupStatus{}
+}
+
+func (e *testEnv) chunks(t *testing.T) []string {
+	keys, err := e.store.List(chunkPrefix)
+	require.NoError(t, err)
+	return keys
+}
+
+func TestBackupRestore(t *testing.T) {
+	e := newTestEnv(t)
+	defer e.cleanup()
+
+	volumeID, err := e.driver.Create(&api.VolumeLocator{Name: "vol1"}, nil, &api.VolumeSpec{Size: 1024})
+	require.NoError(t, err)
+	data := bytes.Repeat([]byte("openstorage"), chunkSize/4)
+	require.NoError(t, os.MkdirAll(filepath.Join(e.driver.path(volumeID), "dir"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(e.driver.path(volumeID), "dir", "data"), data, 0644))
+
+	// First backup uploads all chunks.
+	require.NoError(t, e.cb.Backup(&api.BackupRequest{VolumeID: volumeID}))
+	s := e.waitFor(t, volumeID)
+	require.Equal(t, api.BackupStatusDone, s.Status)
+	assert.Equal(t, api.BackupOpBackup, s.OpType)
+	assert.Equal(t, "node1", s.NodeID)
+	assert.True(t, s.BytesDone > uint64(len(data)))
+	chunks := e.chunks(t)
+	assert.NotEmpty(t, chunks)
+
+	// Second backup of unchanged contents does not add chunks.
+	require.NoError(t, e.cb.Backup(&api.BackupRequest{VolumeID: volumeID}))
+	require.Equal(t, api.BackupStatusDone, e.waitFor(t, volumeID).Status)
+	assert.Equal(t, chunks, e.chunks(t))
+
+	enumResp := e.cb.BackupEnumerate(&api.BackupEnumerateRequest{
+		BackupGenericRequest: api.BackupGenericRequest{SrcVolumeID: volumeID},
+	})
+	require.Empty(t, enumResp.EnumerateErr)
+	require.Len(t, enumResp.Backups, 2)
+	assert.Equal(t, "vol1", enumResp.Backups[0].SrcVolumeName)
+	backupID := enumResp.Backups[1].BackupID
+
+	enumResp = e.cb.BackupEnumerate(&api.BackupEnumerateRequest{
+		BackupGenericRequest: api.BackupGenericRequest{ClusterID: "cluster2"},
+	})
+	assert.Len(t, enumResp.Backups, 0)
+
+	catResp := e.cb.BackupCatalogue(&api.BackupCatalogueRequest{CloudBackupID: backupID})
+	require.Empty(t, catResp.CatalogueErr)
+	assert.Equal(t, []string{"dir/", "dir/data"}, catResp.Contents)
+
+	restoreResp := e.cb.BackupRestore(&api.BackupRestoreRequest{
+		CloudBackupID:     backupID,
+		RestoreVolumeName: "restored",
+	})
+	require.Empty(t, restoreResp.RestoreErr)
+	s = e.waitFor(t, restoreResp.RestoreVolumeID)
+	require.Equal(t, api.BackupStatusDone, s.Status)
+	assert.Equal(t, api.BackupOpRestore, s.OpType)
+	restored, err := ioutil.ReadFile(
+		filepath.Join(e.driver.path(restoreResp.RestoreVolumeID), "dir", "data"))
+	require.NoError(t, err)
+	assert.Equal(t, data, restored)
+	vols, err := e.driver.Inspect([]string{restoreResp.RestoreVolumeID})
+	require.NoError(t, err)
+	require.Len(t, vols, 1)
+	assert.Equal(t, uint64(1024), vols[0].Spec.Size)
+
+	histResp := e.cb.BackupHistory(&api.BackupHistoryRequest{SrcVolumeID: volumeID})
+	require.Empty(t, histResp.HistoryErr)
+	assert.Len(t, histResp.HistoryList, 2)
+	histResp = e.cb.BackupHistory(&api.BackupHistoryRequest{})
+	assert.Len(t, histResp.HistoryList, 3)
+
+	// Deleting all backups removes the chunks.
+	require.Error(t, e.cb.BackupDelete(&api.BackupDeleteRequest{}))
+	require.NoError(t, e.cb.BackupDelete(&api.BackupDeleteRequest{
+		BackupGenericRequest: api.BackupGenericRequest{SrcVolumeID: volumeID},
+	}))
+	enumResp = e.cb.BackupEnumerate(&api.BackupEnumerateRequest{})
+	assert.Len(t, enumResp.Backups, 0)
+	assert.Empty(t, e.chunks(t))
+
+	restoreResp = e.cb.BackupRestore(&api.BackupRestoreRequest{CloudBackupID: backupID})
+	assert.Equal(t, ErrBackupNotFound.Error(), restoreResp.RestoreErr)
+}
+
+func TestBackupStateChange(t *testing.T) {
+	e := newTestEnv(t)
+	defer e.cleanup()
+
+	err := e.cb.BackupStateChange(&api.BackupStateChangeRequest{
+		SrcVolumeID:    "novol",
+		RequestedState: api.BackupStatePause,
+	})
+	assert.Equal(t, ErrNoActiveBackup, err)
+
+	op := newOperation(api.BackupOpBackup, "node1")
+	require.NoError(t, e.cb.addOperation("vol1", op))
+	assert.Equal(t, ErrBackupActive, e.cb.addOperation("vol1", newOperation(api.BackupOpBackup, "node1")))
+
+	assert.Equal(t, volume.ErrEinval, e.cb.BackupStateChange(&api.BackupStateChangeRequest{
+		SrcVolumeID:    "vol1",
+		RequestedState: "bogus",
+	}))
+	require.NoError(t, e.cb.BackupStateChange(&api.BackupStateChangeRequest{
+		SrcVolumeID:    "vol1",
+		RequestedState: api.BackupStatePause,
+	}))
+	assert.Equal(t, api.BackupStatusPaused, op.getStatus().Status)
+
+	waited := make(chan error)
+	go func() {
+		waited <- op.wait()
+	}()
+	select {
+	case <-waited:
+		require.FailNow(t, "paused operation must block")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	require.NoError(t, e.cb.BackupStateChange(&api.BackupStateChangeRequest{
+		SrcVolumeID:    "vol1",
+		RequestedState: api.BackupStateResume,
+	}))
+	assert.NoError(t, <-waited)
+	assert.Equal(t, api.BackupStatusActive, op.getStatus().Status)
+
+	require.NoError(t, e.cb.BackupStateChange(&api.BackupStateChangeRequest{
+		SrcVolumeID:    "vol1",
+		RequestedState: api.BackupStateStop,
+	}))
+	assert.Equal(t, ErrBackupStopped, op.wait())
+	assert.Equal(t, api.BackupStatusStopped, op.finish(ErrBackupStopped))
+	assert.Equal(t, ErrNoActiveBackup, e.cb.BackupStateChange(&api.BackupStateChangeRequest{
+		SrcVolumeID:    "vol1",
+		RequestedState: api.BackupStateResume,
+	}))
+}
+
+func TestBackupSchedules(t *testing.T) {
+	e := newTestEnv(t)
+	defer e.cleanup()
+
+	resp := e.cb.BackupSchedCreate(&api.BackupScheduleInfo{
+		SrcVolumeID:    "novol",
+		BackupSchedule: "daily=12:00",
+	})
+	assert.NotEmpty(t, resp.SchedCreateErr)
+
+	volumeID, err := e.driver.Create(&api.VolumeLocator{Name: "vol1"}, nil, &api.VolumeSpec{})
+	require.NoError(t, err)
+	resp = e.cb.BackupSchedCreate(&api.BackupScheduleInfo{
+		SrcVolumeID:    volumeID,
+		BackupSchedule: "bogus",
+	})
+	assert.NotEmpty(t, resp.SchedCreateErr)
+
+	resp = e.cb.BackupSchedCreate(&api.BackupScheduleInfo{
+		SrcVolumeID:    volumeID,
+		BackupSchedule: "daily=12:00",
+		MaxBackups:     3,
+	})
+	require.Empty(t, resp.SchedCreateErr)
+	assert.Len(t, e.cb.schedTasks[resp.SchedUUID], 1)
+
+	enumResp := e.cb.BackupSchedEnumerate()
+	require.Empty(t, enumResp.SchedEnumerateErr)
+	require.Contains(t, enumResp.BackupSchedules, resp.SchedUUID)
+	assert.Equal(t, uint(3), enumResp.BackupSchedules[resp.SchedUUID].MaxBackups)
+
+	// Schedules are reloaded by the node that owns them.
+	reloaded := newCloudBackup(&e.cb.cfg)
+	assert.Len(t, reloaded.schedTasks[resp.SchedUUID], 1)
+	other := e.cb.cfg
+	other.NodeID = "node2"
+	assert.Len(t, newCloudBackup(&other).schedTasks, 0)
+
+	// A scheduled run backs up the volume.
+	e.cb.scheduledBackup(resp.SchedUUID)
+	require.Equal(t, api.BackupStatusDone, e.waitFor(t, volumeID).Status)
+
+	require.NoError(t, e.cb.BackupSchedDelete(&api.BackupSchedDeleteRequest{
+		SchedUUID: resp.SchedUUID,
+	}))
+	assert.Len(t, e.cb.BackupSchedEnumerate().BackupSchedules, 0)
+	assert.Error(t, e.cb.BackupSchedDelete(&api.BackupSchedDeleteRequest{
+		SchedUUID: resp.SchedUUID,
+	}))
+}
+
+func TestPrune(t *testing.T) {
+	e := newTestEnv(t)
+	defer e.cleanup()
+
+	volumeID, err := e.driver.Create(&api.VolumeLocator{Name: "vol1"}, nil, &api.VolumeSpec{})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, ioutil.WriteFile(
+			filepath.Join(e.driver.path(volumeID), "data"),
+			[]byte(uuid.New()), 0644))
+		require.NoError(t, e.cb.startBackup(volumeID, "", false, 2))
+		require.Equal(t, api.BackupStatusDone, e.waitFor(t, volumeID).Status)
+	}
+	// Old backups are pruned after the last one completes.
+	for i := 0; i < 100; i++ {
+		if len(e.chunks(t)) == 2 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	resp := e.cb.BackupEnumerate(&api.BackupEnumerateRequest{})
+	assert.Len(t, resp.Backups, 2)
+	assert.Len(t, e.chunks(t), 2)
+}
+
+func TestBackupSnapshotFailure(t *testing.T) {
+	e := newTestEnv(t)
+	defer e.cleanup()
+
+	volumeID, err := e.driver.Create(&api.VolumeLocator{Name: "vol1"}, nil, &api.VolumeSpec{})
+	require.NoError(t, err)
+	e.driver.snapErr = volume.ErrNotSupported
+
+	// The live volume is not backed up instead.
+	require.NoError(t, e.cb.Backup(&api.BackupRequest{VolumeID: volumeID}))
+	require.Equal(t, api.BackupStatusFailed, e.waitFor(t, volumeID).Status)
+	assert.Len(t, e.cb.BackupEnumerate(&api.BackupEnumerateRequest{}).Backups, 0)
+}
+
+func TestGarbageCollectionLease(t *testing.T) {
+	e := newTestEnv(t)
+	defer e.cleanup()
+
+	require.NoError(t, e.store.Put(chunkPrefix+"unreferenced", bytes.NewReader([]byte("data"))))
+
+	// Chunks are kept while an operation of any node holds a lease, as a
+	// backup may reference them.
+	other := &cloudBackup{cfg: e.cb.cfg}
+	other.cfg.NodeID = "node2"
+	release, err := other.leaseChunks(e.store, newOperation(api.BackupOpBackup, "node2"))
+	require.NoError(t, err)
+	require.NoError(t, e.cb.collectGarbage(e.store))
+	assert.Len(t, e.chunks(t), 1)
+
+	// Operations wait for garbage collections in progress.
+	gcRelease, err := e.cb.acquireLease(e.store, gcLeasePrefix+"gc1")
+	require.NoError(t, err)
+	defer func(retry time.Duration) { leaseRetry = retry }(leaseRetry)
+	leaseRetry = 10 * time.Millisecond
+	leased := make(chan struct{})
+	go func() {
+		opRelease, err := e.cb.leaseChunks(e.store, newOperation(api.BackupOpBackup, "node1"))
+		assert.NoError(t, err)
+		opRelease()
+		close(leased)
+	}()
+	select {
+	case <-leased:
+		require.FailNow(t, "operation must wait for garbage collection")
+	case <-time.After(50 * time.Millisecond):
+	}
+	gcRelease()
+	<-leased
+
+	release()
+	require.NoError(t, e.cb.collectGarbage(e.store))
+	assert.Empty(t, e.chunks(t))
+}
@@ -0,0 +1,717 @@
+package cloudbackup
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/portworx/kvdb"
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/cluster"
+	"github.com/libopenstorage/openstorage/pkg/sched"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+const (
+	keyBase = "openstorage"
+	// chunkSize is the size of the chunks volume contents are split into.
+	chunkSize = 1 << 20
+	// maxHistory is the number of history items kept per volume.
+	maxHistory = 100
+	// defaultClusterID is used when the cluster ID cannot be determined.
+	defaultClusterID = "default"
+	// chunkPrefix is the object store prefix of the data chunks.
+	chunkPrefix = "chunks/"
+	// backupPrefix is the object store prefix of the backup manifests.
+	backupPrefix = "backups/"
+	// labelBackupSource is set on the snapshots taken for a backup.
+	labelBackupSource = "cloudbackup_source"
+)
+
+// manifest describes a backup in the object store.
+type manifest struct {
+	BackupID      string
+	ClusterID     string
+	SrcVolumeID   string
+	SrcVolumeName string
+	Spec          *api.VolumeSpec
+	Timestamp     time.Time
+	// Size of the backed up contents in bytes
+	Size uint64
+	// Full is true if all chunks were uploaded
+	Full bool
+	// Chunks are the checksums of the chunks, in order
+	Chunks []string
+	// Catalogue lists the contents of the volume
+	Catalogue []string
+}
+
+// operation is an active or completed backup or restore.
+type operation struct {
+	sync.Mutex
+	cond   *sync.Cond
+	status api.BackupStatus
+	// requested is the last state requested through BackupStateChange
+	requested string
+}
+
+func newOperation(opType string, nodeID string) *operation {
+	op := &operation{
+		status: api.BackupStatus{
+			OpType:    opType,
+			Status:    api.BackupStatusActive,
+			StartTime: time.Now(),
+			NodeID:    nodeID,
+		},
+	}
+	op.cond = sync.NewCond(&op.Mutex)
+	return op
+}
+
+// wait blocks while the operation is paused. It returns ErrBackupStopped
+// once the operation was asked to stop.
+func (o *operation) wait() error {
+	o.Lock()
+	defer o.Unlock()
+	for o.requested == api.BackupStatePause {
+		o.cond.Wait()
+	}
+	if o.requested == api.BackupStateStop {
+		return ErrBackupStopped
+	}
+	return nil
+}
+
+func (o *operation) setState(requested string) error {
+	o.Lock()
+	defer o.Unlock()
+
+	if !o.activeLocked() {
+		return ErrNoActiveBackup
+	}
+	switch requested {
+	case api.BackupStatePause:
+		o.status.Status = api.BackupStatusPaused
+	case api.BackupStateResume:
+		o.status.Status = api.BackupStatusActive
+	case api.BackupStateStop:
+	default:
+		return volume.ErrEinval
+	}
+	o.requested = requested
+	o.cond.Broadcast()
+	return nil
+}
+
+func (o *operation) addBytes(n int) {
+	o.Lock()
+	o.status.BytesDone += uint64(n)
+	o.Unlock()
+}
+
+func (o *operation) setBackupID(backupID string) {
+	o.Lock()
+	o.status.BackupID = backupID
+	o.Unlock()
+}
+
+func (o *operation) finish(err error) string {
+	o.Lock()
+	defer o.Unlock()
+	switch err {
+	case nil:
+		o.status.Status = api.BackupStatusDone
+	case ErrBackupStopped:
+		o.status.Status = api.BackupStatusStopped
+	default:
+		o.status.Status = api.BackupStatusFailed
+	}
+	o.status.CompletedTime = time.Now()
+	return o.status.Status
+}
+
+func (o *operation) active() bool {
+	o.Lock()
+	defer o.Unlock()
+	return o.activeLocked()
+}
+
+func (o *operation) activeLocked() bool {
+	return o.status.Status == api.BackupStatusActive ||
+		o.status.Status == api.BackupStatusPaused
+}
+
+func (o *operation) getStatus() api.BackupStatus {
+	o.Lock()
+	defer o.Unlock()
+	return o.status
+}
+
+type cloudBackup struct {
+	sync.Mutex
+	cfg   Config
+	kv    kvdb.Kvdb
+	sched sched.Scheduler
+	// ops maps volume IDs to their last backup or restore
+	ops map[string]*operation
+	// schedTasks maps schedule UUIDs to the tasks running them
+	schedTasks map[string][]sched.TaskID
+}
+
+func newCloudBackup(cfg *Config) *cloudBackup {
+	c := &cloudBackup{
+		cfg:        *cfg,
+		kv:         cfg.Kvdb,
+		sched:      cfg.Scheduler,
+		ops:        make(map[string]*operation),
+		schedTasks: make(map[string][]sched.TaskID),
+	}
+	if c.kv == nil {
+		c.kv = kvdb.Instance()
+	}
+	if c.sched == nil {
+		c.sched = sched.Instance()
+	}
+	if c.cfg.NodeID == "" {
+		c.cfg.NodeID, _ = os.Hostname()
+	}
+	if err := c.loadSchedules(); err != nil {
+		dlog.Warnf("Failed to load backup schedules for %v: %v", c.cfg.Name, err)
+	}
+	return c
+}
+
+func (c *cloudBackup) Backup(input *api.BackupRequest) error {
+	return c.startBackup(input.VolumeID, input.CredentialUUID, input.Full, 0)
+}
+
+func (c *cloudBackup) BackupRestore(
+	input *api.BackupRestoreRequest,
+) *api.BackupRestoreResponse {
+	resp := &api.BackupRestoreResponse{}
+	if input.NodeID != "" && input.NodeID != c.cfg.NodeID {
+		resp.RestoreErr = fmt.Sprintf("Cannot restore to node %v from node %v",
+			input.NodeID, c.cfg.NodeID)
+		return resp
+	}
+	store, err := c.cfg.Store(input.CredentialUUID)
+	if err != nil {
+		resp.RestoreErr = err.Error()
+		return resp
+	}
+	m, err := loadManifest(store, input.CloudBackupID)
+	if err != nil {
+		resp.RestoreErr = err.Error()
+		return resp
+	}
+
+	name := input.RestoreVolumeName
+	if name == "" {
+		name = fmt.Sprintf("%s-restore-%d", m.SrcVolumeName, time.Now().Unix())
+	}
+	spec := &api.VolumeSpec{}
+	if m.Spec != nil {
+		spec = m.Spec.Copy()
+	}
+	volumeID, err := c.cfg.Driver.Create(
+		&api.VolumeLocator{Name: name},
+		&api.Source{},
+		spec,
+	)
+	if err != nil {
+		resp.RestoreErr = err.Error()
+		return resp
+	}
+
+	op := newOperation(api.BackupOpRestore, c.cfg.NodeID)
+	op.status.BackupID = m.BackupID
+	if err := c.addOperation(volumeID, op); err != nil {
+		c.cfg.Driver.Delete(volumeID)
+		resp.RestoreErr = err.Error()
+		return resp
+	}
+	go func() {
+		err := c.restore(store, m, volumeID, op)
+		if err != nil {
+			dlog.Warnf("Restore of backup %v to volume %v failed: %v",
+				m.BackupID, volumeID, err)
+			c.cfg.Driver.Delete(volumeID)
+		}
+		c.addHistory(volumeID, op.finish(err))
+	}()
+	resp.RestoreVolumeID = volumeID
+	return resp
+}
+
+func (c *cloudBackup) BackupEnumerate(
+	input *api.BackupEnumerateRequest,
+) *api.BackupEnumerateResponse {
+	resp := &api.BackupEnumerateResponse{Backups: make([]api.BackupInfo, 0)}
+	store, err := c.cfg.Store(input.CredentialUUID)
+	if err != nil {
+		resp.EnumerateErr = err.Error()
+		return resp
+	}
+	manifests, err := c.manifests(store, &input.BackupGenericRequest)
+	if err != nil {
+		resp.EnumerateErr = err.Error()
+		return resp
+	}
+	for _, m := range manifests {
+		resp.Backups = append(resp.Backups, api.BackupInfo{
+			SrcVolumeID:   m.SrcVolumeID,
+			SrcVolumeName: m.SrcVolumeName,
+			BackupID:      m.BackupID,
+			Timestamp:     m.Timestamp,
+			Status:        api.BackupStatusDone,
+		})
+	}
+	return resp
+}
+
+func (c *cloudBackup) BackupDelete(input *api.BackupDeleteRequest) error {
+	if input.SrcVolumeID == "" {
+		return fmt.Errorf("Source volume ID must be specified")
+	}
+	store, err := c.cfg.Store(input.CredentialUUID)
+	if err != nil {
+		return err
+	}
+	manifests, err := c.manifests(store, &input.BackupGenericRequest)
+	if err != nil {
+		return err
+	}
+	for _, m := range manifests {
+		if err := store.Delete(manifestKey(m.BackupID)); err != nil {
+			return err
+		}
+	}
+	return c.collectGarbage(store)
+}
+
+func (c *cloudBackup) BackupStatus(input *api.BackupStsRequest) *api.BackupStsResponse {
+	resp := &api.BackupStsResponse{Statuses: make(map[string]api.BackupStatus)}
+
+	c.Lock()
+	defer c.Unlock()
+	for volumeID, op := range c.ops {
+		if input.SrcVolumeID != "" && input.SrcVolumeID != volumeID {
+			continue
+		}
+		resp.Statuses[volumeID] = op.getStatus()
+	}
+	return resp
+}
+
+func (c *cloudBackup) BackupCatalogue(
+	input *api.BackupCatalogueRequest,
+) *api.BackupCatalogueResponse {
+	resp := &api.BackupCatalogueResponse{}
+	store, err := c.cfg.Store(input.CredentialUUID)
+	if err != nil {
+		resp.CatalogueErr = err.Error()
+		return resp
+	}
+	m, err := loadManifest(store, input.CloudBackupID)
+	if err != nil {
+		resp.CatalogueErr = err.Error()
+		return resp
+	}
+	resp.Contents = m.Catalogue
+	return resp
+}
+
+func (c *cloudBackup) BackupHistory(
+	input *api.BackupHistoryRequest,
+) *api.BackupHistoryResponse {
+	resp := &api.BackupHistoryResponse{HistoryList: make([]api.BackupHistoryItem, 0)}
+	if input.SrcVolumeID != "" {
+		items, err := c.history(input.SrcVolumeID)
+		if err != nil {
+			resp.HistoryErr = err.Error()
+			return resp
+		}
+		resp.HistoryList = items
+		return resp
+	}
+
+	kvp, err := c.kv.Enumerate(c.historyKey(""))
+	if err != nil {
+		resp.HistoryErr = err.Error()
+		return resp
+	}
+	for _, v := range kvp {
+		var items []api.BackupHistoryItem
+		if err := json.Unmarshal(v.Value, &items); err != nil {
+			resp.HistoryErr = err.Error()
+			return resp
+		}
+		resp.HistoryList = append(resp.HistoryList, items...)
+	}
+	sort.Slice(resp.HistoryList, func(i, j int) bool {
+		return resp.HistoryList[i].Timestamp.Before(resp.HistoryList[j].Timestamp)
+	})
+	return resp
+}
+
+func (c *cloudBackup) BackupStateChange(input *api.BackupStateChangeRequest) error {
+	c.Lock()
+	op, ok := c.ops[input.SrcVolumeID]
+	c.Unlock()
+	if !ok {
+		return ErrNoActiveBackup
+	}
+	return op.setState(input.RequestedState)
+}
+
+// startBackup starts backing up volumeID in the background. If maxBackups
+// is not zero, older backups of the volume are deleted once it completes.
+func (c *cloudBackup) startBackup(
+	volumeID string,
+	credentialUUID string,
+	full bool,
+	maxBackups uint,
+) error {
+	vols, err := c.cfg.Driver.Inspect([]string{volumeID})
+	if err != nil {
+		return err
+	}
+	if len(vols) == 0 {
+		return volume.ErrEnoEnt
+	}
+	store, err := c.cfg.Store(credentialUUID)
+	if err != nil {
+		return err
+	}
+
+	op := newOperation(api.BackupOpBackup, c.cfg.NodeID)
+	if err := c.addOperation(volumeID, op); err != nil {
+		return err
+	}
+	go func() {
+		err := c.backup(store, vols[0], full, op)
+		if err != nil {
+			dlog.Warnf("Backup of volume %v failed: %v", volumeID, err)
+		}
+		c.addHistory(volumeID, op.finish(err))
+		if err == nil && maxBackups > 0 {
+			if err := c.prune(store, volumeID, maxBackups); err != nil {
+				dlog.Warnf("Failed to delete old backups of volume %v: %v",
+					volumeID, err)
+			}
+		}
+	}()
+	return nil
+}
+
+func (c *cloudBackup) addOperation(volumeID string, op *operation) error {
+	c.Lock()
+	defer c.Unlock()
+	if prev, ok := c.ops[volumeID]; ok && prev.active() {
+		return ErrBackupActive
+	}
+	c.ops[volumeID] = op
+	return nil
+}
+
+// backup uploads the contents of a snapshot of vol.
+func (c *cloudBackup) backup(
+	store ObjectStore,
+	vol *api.Volume,
+	full bool,
+	op *operation,
+) error {
+	release, err := c.leaseChunks(store, op)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	srcID, err := c.cfg.Driver.Snapshot(vol.Id, true, &api.VolumeLocator{
+		VolumeLabels: map[string]string{labelBackupSource: vol.Id},
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to snapshot volume %v: %v", vol.Id, err)
+	}
+	defer c.cfg.Driver.Delete(srcID)
+
+	clusterID := c.clusterID()
+	m := &manifest{
+		BackupID:    fmt.Sprintf("%s/%s-%d", clusterID, vol.Id, time.Now().UnixNano()),
+		ClusterID:   clusterID,
+		SrcVolumeID: vol.Id,
+		Spec:        vol.Spec,
+		Timestamp:   time.Now(),
+		Full:        full,
+		Chunks:      make([]string, 0),
+	}
+	if vol.Locator != nil {
+		m.SrcVolumeName = vol.Locator.Name
+	}
+	op.setBackupID(m.BackupID)
+
+	if m.Catalogue, err = c.cfg.Source.Catalogue(srcID); err != nil {
+		return err
+	}
+	r, err := c.cfg.Source.Open(srcID)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	buf := make([]byte, chunkSize)
+	for {
+		if err := op.wait(); err != nil {
+			return err
+		}
+		n, readErr := io.ReadFull(r, buf)
+		if n > 0 {
+			sum := sha256.Sum256(buf[:n])
+			key := chunkPrefix + hex.EncodeToString(sum[:])
+			exists := false
+			if !full {
+				if exists, err = store.Exists(key); err != nil {
+					return err
+				}
+			}
+			if !exists {
+				if err := store.Put(key, bytes.NewReader(buf[:n])); err != nil {
+					return err
+				}
+			}
+			m.Chunks = append(m.Chunks, hex.EncodeToString(sum[:]))
+			m.Size += uint64(n)
+			op.addBytes(n)
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return store.Put(manifestKey(m.BackupID), bytes.NewReader(data))
+}
+
+// restore writes the chunks of m to volumeID.
+func (c *cloudBackup) restore(
+	store ObjectStore,
+	m *manifest,
+	volumeID string,
+	op *operation,
+) error {
+	release, err := c.leaseChunks(store, op)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(c.readChunks(store, m, pw, op))
+	}()
+	err = c.cfg.Source.Restore(volumeID, pr)
+	pr.CloseWithError(io.ErrClosedPipe)
+	return err
+}
+
+func (c *cloudBackup) readChunks(
+	store ObjectStore,
+	m *manifest,
+	w io.Writer,
+	op *operation,
+) error {
+	for _, chunk := range m.Chunks {
+		if err := op.wait(); err != nil {
+			return err
+		}
+		r, err := store.Get(chunkPrefix + chunk)
+		if err != nil {
+			return fmt.Errorf("Failed to read chunk %v: %v", chunk, err)
+		}
+		h := sha256.New()
+		n, err := io.Copy(io.MultiWriter(w, h), r)
+		r.Close()
+		if err != nil {
+			return err
+		}
+		if hex.EncodeToString(h.Sum(nil)) != chunk {
+			return fmt.Errorf("Chunk %v of backup %v is corrupt", chunk, m.BackupID)
+		}
+		op.addBytes(int(n))
+	}
+	return nil
+}
+
+// manifests returns the backups matching req, oldest first.
+func (c *cloudBackup) manifests(
+	store ObjectStore,
+	req *api.BackupGenericRequest,
+) ([]*manifest, error) {
+	prefix := backupPrefix
+	if !req.All {
+		clusterID := req.ClusterID
+		if clusterID == "" {
+			clusterID = c.clusterID()
+		}
+		prefix += clusterID + "/"
+	}
+	keys, err := store.List(prefix)
+	if err != nil {
+		return nil, err
+	}
+	manifests := make([]*manifest, 0, len(keys))
+	for _, key := range keys {
+		m, err := loadManifest(store, strings.TrimPrefix(key, backupPrefix))
+		if err != nil {
+			return nil, err
+		}
+		if req.SrcVolumeID != "" && req.SrcVolumeID != m.SrcVolumeID {
+			continue
+		}
+		manifests = append(manifests, m)
+	}
+	sort.Slice(manifests, func(i, j int) bool {
+		return manifests[i].Timestamp.Before(manifests[j].Timestamp)
+	})
+	return manifests, nil
+}
+
+// prune deletes the oldest backups of volumeID so that at most maxBackups
+// remain.
+func (c *cloudBackup) prune(store ObjectStore, volumeID string, maxBackups uint) error {
+	manifests, err := c.manifests(store, &api.BackupGenericRequest{SrcVolumeID: volumeID})
+	if err != nil {
+		return err
+	}
+	if uint(len(manifests)) <= maxBackups {
+		return nil
+	}
+	for _, m := range manifests[:uint(len(manifests))-maxBackups] {
+		if err := store.Delete(manifestKey(m.BackupID)); err != nil {
+			return err
+		}
+	}
+	return c.collectGarbage(store)
+}
+
+// collectGarbage deletes the chunks not referenced by any backup. It is
+// skipped while an operation of any node holds a lease on store, since the
+// chunks of a backup in progress are not referenced yet. They are deleted by
+// the next garbage collection.
+func (c *cloudBackup) collectGarbage(store ObjectStore) error {
+	release, err := c.acquireLease(store, gcLeasePrefix+uuid.New())
+	if err != nil {
+		return err
+	}
+	defer release()
+	if busy, err := leased(store, opLeasePrefix); err != nil {
+		return err
+	} else if busy {
+		dlog.Infof("Skipping backup garbage collection, operations are in progress")
+		return nil
+	}
+	manifests, err := c.manifests(store, &api.BackupGenericRequest{All: true})
+	if err != nil {
+		return err
+	}
+	referenced := make(map[string]bool)
+	for _, m := range manifests {
+		for _, chunk := range m.Chunks {
+			referenced[chunk] = true
+		}
+	}
+	keys, err := store.List(chunkPrefix)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if !referenced[strings.TrimPrefix(key, chunkPrefix)] {
+			if err := store.Delete(key); err != nil && err != ErrObjectNotFound {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *cloudBackup) history(volumeID string) ([]api.BackupHistoryItem, error) {
+	items := make([]api.BackupHistoryItem, 0)
+	if _, err := c.kv.GetVal(c.historyKey(volumeID), &items); err != nil &&
+		err != kvdb.ErrNotFound {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (c *cloudBackup) addHistory(volumeID string, status string) {
+	items, err := c.history(volumeID)
+	if err != nil {
+		dlog.Warnf("Failed to read backup history of volume %v: %v", volumeID, err)
+		return
+	}
+	items = append(items, api.BackupHistoryItem{
+		SrcVolumeID: volumeID,
+		Timestamp:   time.Now(),
+		Status:      status,
+	})
+	if len(items) > maxHistory {
+		items = items[len(items)-maxHistory:]
+	}
+	if _, err := c.kv.Put(c.historyKey(volumeID), items, 0); err != nil {
+		dlog.Warnf("Failed to update backup history of volume %v: %v", volumeID, err)
+	}
+}
+
+func (c *cloudBackup) historyKey(volumeID string) string {
+	return fmt.Sprintf("%s/%s/cloudbackup/history/%s", keyBase, c.cfg.Name, volumeID)
+}
+
+// clusterID returns the ID of the cluster backups are stored under.
+func (c *cloudBackup) clusterID() string {
+	if c.cfg.ClusterID != "" {
+		return c.cfg.ClusterID
+	}
+	if cm, err := cluster.Inst(); err == nil {
+		if cl, err := cm.Enumerate(); err == nil && cl.Id != "" {
+			return cl.Id
+		}
+	}
+	return defaultClusterID
+}
+
+func loadManifest(store ObjectStore, backupID string) (*manifest, error) {
+	r, err := store.Get(manifestKey(backupID))
+	if err == ErrObjectNotFound {
+		return nil, ErrBackupNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	m := &manifest{}
+	if err := json.NewDecoder(r).Decode(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func manifestKey(backupID string) string {
+	return backupPrefix + backupID
+}
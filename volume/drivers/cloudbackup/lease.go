@@ -0,0 +1,131 @@
+package cloudbackup
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pborman/uuid"
+	"go.pedge.io/dlog"
+)
+
+// Backups reference the chunks already in the store instead of uploading
+// them again, so garbage collection must not run while a backup has not
+// stored its manifest yet. Operations and garbage collection store leases
+// in the object store, which is shared by all nodes and clusters backing up
+// to it: garbage collection is skipped while an operation holds a lease,
+// and operations wait for the garbage collections in progress.
+const (
+	// opLeasePrefix is the object store prefix of the leases of the
+	// backups and restores in progress.
+	opLeasePrefix = "leases/ops/"
+	// gcLeasePrefix is the object store prefix of the leases of the
+	// garbage collections in progress.
+	gcLeasePrefix = "leases/gc/"
+	// leaseTTL is how long a lease lasts unless it is renewed, so that the
+	// leases of processes that died expire.
+	leaseTTL = 10 * time.Minute
+)
+
+// leaseRetry is how often operations check if garbage collection is done.
+var leaseRetry = time.Second
+
+// lease is an operation or garbage collection in progress.
+type lease struct {
+	// Node holding the lease
+	Node string
+	// Expires is the time the lease expires at unless it is renewed
+	Expires time.Time
+}
+
+// acquireLease stores a lease under key and renews it until the returned
+// function releases it.
+func (c *cloudBackup) acquireLease(store ObjectStore, key string) (func(), error) {
+	put := func() error {
+		data, err := json.Marshal(&lease{Node: c.cfg.NodeID, Expires: time.Now().Add(leaseTTL)})
+		if err != nil {
+			return err
+		}
+		return store.Put(key, bytes.NewReader(data))
+	}
+	if err := put(); err != nil {
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		t := time.NewTicker(leaseTTL / 3)
+		defer t.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-t.C:
+				if err := put(); err != nil {
+					dlog.Warnf("Failed to renew backup lease %v: %v", key, err)
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+		if err := store.Delete(key); err != nil && err != ErrObjectNotFound {
+			dlog.Warnf("Failed to release backup lease %v: %v", key, err)
+		}
+	}, nil
+}
+
+// leaseChunks acquires a lease for op, which reads or writes chunks, once
+// no garbage collection is in progress.
+func (c *cloudBackup) leaseChunks(store ObjectStore, op *operation) (func(), error) {
+	release, err := c.acquireLease(store, opLeasePrefix+uuid.New())
+	if err != nil {
+		return nil, err
+	}
+	for {
+		gc, err := leased(store, gcLeasePrefix)
+		if err != nil {
+			release()
+			return nil, err
+		}
+		if !gc {
+			return release, nil
+		}
+		if err := op.wait(); err != nil {
+			release()
+			return nil, err
+		}
+		time.Sleep(leaseRetry)
+	}
+}
+
+// leased returns true if a lease stored under prefix has not expired.
+func leased(store ObjectStore, prefix string) (bool, error) {
+	keys, err := store.List(prefix)
+	if err != nil {
+		return false, err
+	}
+	for _, key := range keys {
+		r, err := store.Get(key)
+		if err == ErrObjectNotFound {
+			continue
+		} else if err != nil {
+			return false, err
+		}
+		l := &lease{}
+		err = json.NewDecoder(r).Decode(l)
+		r.Close()
+		if err != nil {
+			return false, err
+		}
+		if time.Now().Before(l.Expires) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,100 @@
+package cloudbackup
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type localStore struct {
+	root string
+}
+
+// NewLocalStore returns an ObjectStore that keeps objects as files under
+// root. Objects are written to a temporary file first and renamed in place,
+// so readers never see partial objects.
+func NewLocalStore(root string) (ObjectStore, error) {
+	if err := os.MkdirAll(root, 0700); err != nil {
+		return nil, err
+	}
+	return &localStore{root: root}, nil
+}
+
+func (l *localStore) Put(key string, r io.Reader) error {
+	path := l.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(path), ".put-")
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func (l *localStore) Get(key string) (io.ReadCloser, error) {
+	f, err := os.Open(l.path(key))
+	if os.IsNotExist(err) {
+		return nil, ErrObjectNotFound
+	}
+	return f, err
+}
+
+func (l *localStore) Exists(key string) (bool, error) {
+	_, err := os.Stat(l.path(key))
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+func (l *localStore) Delete(key string) error {
+	err := os.Remove(l.path(key))
+	if os.IsNotExist(err) {
+		return ErrObjectNotFound
+	}
+	return err
+}
+
+func (l *localStore) List(prefix string) ([]string, error) {
+	keys := make([]string, 0)
+	err := filepath.Walk(l.root, func(path string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) {
+			// Deleted while listing.
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".put-") {
+			return nil
+		}
+		rel, err := filepath.Rel(l.root, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	return keys, err
+}
+
+func (l *localStore) path(key string) string {
+	return filepath.Join(l.root, filepath.FromSlash(filepath.Clean("/"+key)))
+}
@@ -0,0 +1,183 @@
+package cloudbackup
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/portworx/kvdb"
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/sched"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+// schedule is a backup schedule as persisted in kvdb.
+type schedule struct {
+	api.BackupScheduleInfo
+	// NodeID is the node that runs the schedule. Volume contents are only
+	// available on the node the schedule was created on.
+	NodeID string
+}
+
+func (c *cloudBackup) BackupSchedCreate(
+	input *api.BackupScheduleInfo,
+) *api.BackupSchedResponse {
+	resp := &api.BackupSchedResponse{}
+	if c.sched == nil {
+		resp.SchedCreateErr = volume.ErrNotSupported.Error()
+		return resp
+	}
+	vols, err := c.cfg.Driver.Inspect([]string{input.SrcVolumeID})
+	if err == nil && len(vols) == 0 {
+		err = volume.ErrEnoEnt
+	}
+	if err != nil {
+		resp.SchedCreateErr = err.Error()
+		return resp
+	}
+	intervals, err := sched.ParseSchedule(input.BackupSchedule)
+	if err == nil && len(intervals) == 0 {
+		err = fmt.Errorf("Empty backup schedule")
+	}
+	if err != nil {
+		resp.SchedCreateErr = err.Error()
+		return resp
+	}
+
+	schedUUID := uuid.New()
+	s := &schedule{BackupScheduleInfo: *input, NodeID: c.cfg.NodeID}
+	if _, err := c.kv.Create(c.scheduleKey(schedUUID), s, 0); err != nil {
+		resp.SchedCreateErr = err.Error()
+		return resp
+	}
+	if err := c.registerSchedule(schedUUID, intervals); err != nil {
+		c.kv.Delete(c.scheduleKey(schedUUID))
+		resp.SchedCreateErr = err.Error()
+		return resp
+	}
+	resp.SchedUUID = schedUUID
+	return resp
+}
+
+func (c *cloudBackup) BackupSchedDelete(input *api.BackupSchedDeleteRequest) error {
+	if _, err := c.kv.Delete(c.scheduleKey(input.SchedUUID)); err != nil {
+		return err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	for _, t := range c.schedTasks[input.SchedUUID] {
+		c.sched.Cancel(t)
+	}
+	delete(c.schedTasks, input.SchedUUID)
+	return nil
+}
+
+func (c *cloudBackup) BackupSchedEnumerate() *api.BackupSchedEnumerateResponse {
+	resp := &api.BackupSchedEnumerateResponse{
+		BackupSchedules: make(map[string]api.BackupScheduleInfo),
+	}
+	schedules, err := c.schedules()
+	if err != nil {
+		resp.SchedEnumerateErr = err.Error()
+		return resp
+	}
+	for schedUUID, s := range schedules {
+		resp.BackupSchedules[schedUUID] = s.BackupScheduleInfo
+	}
+	return resp
+}
+
+// loadSchedules registers the persisted schedules owned by this node.
+func (c *cloudBackup) loadSchedules() error {
+	if c.sched == nil || c.kv == nil {
+		return nil
+	}
+	schedules, err := c.schedules()
+	if err != nil {
+		return err
+	}
+	for schedUUID, s := range schedules {
+		if s.NodeID != c.cfg.NodeID {
+			continue
+		}
+		intervals, err := sched.ParseSchedule(s.BackupSchedule)
+		if err == nil {
+			err = c.registerSchedule(schedUUID, intervals)
+		}
+		if err != nil {
+			dlog.Warnf("Failed to register backup schedule %v: %v", schedUUID, err)
+		}
+	}
+	return nil
+}
+
+func (c *cloudBackup) registerSchedule(
+	schedUUID string,
+	intervals []sched.RetainInterval,
+) error {
+	c.Lock()
+	defer c.Unlock()
+
+	tasks := make([]sched.TaskID, 0, len(intervals))
+	for _, iv := range intervals {
+		taskID, err := c.sched.Schedule(
+			func(sched.Interval) {
+				c.scheduledBackup(schedUUID)
+			},
+			iv,
+			time.Now(),
+			false,
+		)
+		if err != nil {
+			for _, t := range tasks {
+				c.sched.Cancel(t)
+			}
+			return err
+		}
+		tasks = append(tasks, taskID)
+	}
+	c.schedTasks[schedUUID] = tasks
+	return nil
+}
+
+func (c *cloudBackup) scheduledBackup(schedUUID string) {
+	s := &schedule{}
+	if _, err := c.kv.GetVal(c.scheduleKey(schedUUID), s); err != nil {
+		dlog.Warnf("Failed to read backup schedule %v: %v", schedUUID, err)
+		return
+	}
+	if err := c.startBackup(
+		s.SrcVolumeID,
+		s.CredentialUUID,
+		false,
+		s.MaxBackups,
+	); err != nil {
+		dlog.Warnf("Scheduled backup of volume %v failed: %v", s.SrcVolumeID, err)
+	}
+}
+
+func (c *cloudBackup) schedules() (map[string]*schedule, error) {
+	kvp, err := c.kv.Enumerate(c.scheduleKey(""))
+	if err != nil && err != kvdb.ErrNotFound {
+		return nil, err
+	}
+	schedules := make(map[string]*schedule)
+	for _, v := range kvp {
+		s := &schedule{}
+		if err := json.Unmarshal(v.Value, s); err != nil {
+			return nil, err
+		}
+		parts := strings.Split(v.Key, "/")
+		schedules[parts[len(parts)-1]] = s
+	}
+	return schedules, nil
+}
+
+func (c *cloudBackup) scheduleKey(schedUUID string) string {
+	return fmt.Sprintf("%s/%s/cloudbackup/schedules/%s", keyBase, c.cfg.Name, schedUUID)
+}
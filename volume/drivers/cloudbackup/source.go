@@ -0,0 +1,206 @@
+package cloudbackup
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PathFunc returns the local path holding the contents of a volume.
+type PathFunc func(volumeID string) (string, error)
+
+type dirSource struct {
+	path PathFunc
+}
+
+// NewDirSource returns a Source for volumes whose contents are a directory.
+// The directory is backed up as a tar stream.
+func NewDirSource(path PathFunc) Source {
+	return &dirSource{path: path}
+}
+
+func (d *dirSource) Open(volumeID string) (io.ReadCloser, error) {
+	root, err := d.path(volumeID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(root); err != nil {
+		return nil, err
+	}
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeTar(root, pw))
+	}()
+	return pr, nil
+}
+
+func (d *dirSource) Catalogue(volumeID string) ([]string, error) {
+	root, err := d.path(volumeID)
+	if err != nil {
+		return nil, err
+	}
+	contents := make([]string, 0)
+	err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == root {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			rel += "/"
+		}
+		contents = append(contents, rel)
+		return nil
+	})
+	return contents, err
+}
+
+func (d *dirSource) Restore(volumeID string, r io.Reader) error {
+	root, err := d.path(volumeID)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(root, 0744); err != nil {
+		return err
+	}
+	return readTar(root, r)
+}
+
+func writeTar(root string, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == root {
+			return nil
+		}
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(path); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+func readTar(root string, r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := filepath.Clean(filepath.FromSlash(hdr.Name))
+		if filepath.IsAbs(name) || name == ".." ||
+			strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+			return fmt.Errorf("Invalid path %q in backup", hdr.Name)
+		}
+		path := filepath.Join(root, name)
+		mode := os.FileMode(hdr.Mode).Perm()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, mode); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			os.Remove(path)
+			if err := os.Symlink(hdr.Linkname, path); err != nil {
+				return err
+			}
+		case tar.TypeReg, tar.TypeRegA:
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+type fileSource struct {
+	path PathFunc
+}
+
+// NewFileSource returns a Source for volumes whose contents are a single
+// file, such as the backing file of a block device.
+func NewFileSource(path PathFunc) Source {
+	return &fileSource{path: path}
+}
+
+func (f *fileSource) Open(volumeID string) (io.ReadCloser, error) {
+	path, err := f.path(volumeID)
+	if err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+func (f *fileSource) Catalogue(volumeID string) ([]string, error) {
+	path, err := f.path(volumeID)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	return []string{fmt.Sprintf("%s (%d bytes)", filepath.Base(path), info.Size())}, nil
+}
+
+func (f *fileSource) Restore(volumeID string, r io.Reader) error {
+	path, err := f.path(volumeID)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
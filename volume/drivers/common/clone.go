@@ -0,0 +1,384 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+// Keys of the clone progress in api.Volume.RuntimeState.
+const (
+	// RuntimeCloneState is one of the CloneState values
+	RuntimeCloneState = "CloneState"
+	// RuntimeCloneProgress is the percentage of the data copied
+	RuntimeCloneProgress = "CloneProgress"
+	// RuntimeCloneError is the error a clone failed with
+	RuntimeCloneError = "CloneError"
+)
+
+// Values of RuntimeCloneState.
+const (
+	// CloneStateCopying while the data of the parent is copied
+	CloneStateCopying = "Copying"
+	// CloneStateDone once all data is copied
+	CloneStateDone = "Done"
+	// CloneStateFailed if the copy failed
+	CloneStateFailed = "Failed"
+)
+
+// ficlone is the FICLONE ioctl sharing the extents of a file with another
+// on filesystems supporting reflinks, such as btrfs and xfs.
+const ficlone = 0x40049409
+
+// cloneBufSize is the size of the chunks data is copied in.
+const cloneBufSize = 1024 * 1024
+
+// cloneProgressInterval is the minimum interval between updates of the
+// progress of a clone.
+var cloneProgressInterval = time.Second
+
+// CloneOptions control how the data of a clone is copied.
+type CloneOptions struct {
+	// Hardlink links the files of the clone to those of the parent instead
+	// of copying them. It is only safe if neither is ever modified, such
+	// as for a readonly snapshot of a readonly volume.
+	Hardlink bool
+	// Zeroed skips copying chunks of zeros to a device known to only hold
+	// zeros, such as a new volume.
+	Zeroed bool
+	// Context stops the copy early once cancelled.
+	Context context.Context
+	// Progress is called with the percentage of the data copied as it
+	// is recorded in the runtime state of the volume.
+	Progress func(percent int)
+}
+
+func (opts *CloneOptions) context() context.Context {
+	if opts.Context == nil {
+		return context.Background()
+	}
+	return opts.Context
+}
+
+// CloneDir copies the files of the directory src of a parent volume into
+// dst, the directory of volume volumeID. Files are reflinked when the
+// filesystem supports it and copied otherwise. The progress is tracked in
+// the runtime state of the volume if store is not nil.
+func CloneDir(
+	store volume.Store,
+	volumeID string,
+	src string,
+	dst string,
+	opts *CloneOptions,
+) error {
+	if opts == nil {
+		opts = &CloneOptions{}
+	}
+	p := newCloneProgress(store, volumeID, 0, opts.Progress)
+	err := cloneDir(src, dst, opts, p)
+	p.finish(err)
+	return err
+}
+
+// CloneDevice copies size bytes of the block device or file src of a
+// parent volume into dst, the device or file of volume volumeID. Chunks of
+// zeros are skipped if dst is a file, leaving it sparse, or if opts.Zeroed
+// is set. The progress is tracked in the runtime state of the volume if
+// store is not nil. opts.Hardlink is ignored.
+func CloneDevice(
+	store volume.Store,
+	volumeID string,
+	src string,
+	dst string,
+	size int64,
+	opts *CloneOptions,
+) error {
+	if opts == nil {
+		opts = &CloneOptions{}
+	}
+	p := newCloneProgress(store, volumeID, size, opts.Progress)
+	err := cloneDevice(opts.context(), src, dst, size, opts.Zeroed, p)
+	p.finish(err)
+	return err
+}
+
+func cloneDir(src string, dst string, opts *CloneOptions, p *cloneProgress) error {
+	ctx := opts.context()
+	if err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err == nil && info.Mode().IsRegular() {
+			p.total += info.Size()
+		}
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		mode := info.Mode()
+		switch {
+		case mode.IsDir():
+			if err := os.MkdirAll(target, mode.Perm()); err != nil {
+				return err
+			}
+		case mode&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			os.Remove(target)
+			if err := os.Symlink(link, target); err != nil {
+				return err
+			}
+		case mode.IsRegular():
+			if err := cloneFile(ctx, path, target, info, opts, p); err != nil {
+				return err
+			}
+		default:
+			dlog.Warnf("Clone of volume %v skips special file %v", p.volumeID, path)
+			return nil
+		}
+		copyAttributes(target, info)
+		return nil
+	})
+}
+
+func cloneDevice(
+	ctx context.Context,
+	src string,
+	dst string,
+	size int64,
+	zeroed bool,
+	p *cloneProgress,
+) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	st, err := out.Stat()
+	if err != nil {
+		return err
+	}
+	// Skipped chunks of a file must read as zeros, whatever it held.
+	sparse := st.Mode().IsRegular()
+	if sparse {
+		if err := out.Truncate(0); err != nil {
+			return err
+		}
+		if err := out.Truncate(size); err != nil {
+			return err
+		}
+	}
+
+	buf := make([]byte, cloneBufSize)
+	for off := int64(0); off < size; {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n := int64(len(buf))
+		if size-off < n {
+			n = size - off
+		}
+		if _, err := io.ReadFull(in, buf[:n]); err != nil {
+			return fmt.Errorf("Failed to read %v at %d: %v", src, off, err)
+		}
+		if !(sparse || zeroed) || !isZero(buf[:n]) {
+			if _, err := out.WriteAt(buf[:n], off); err != nil {
+				return err
+			}
+		}
+		off += n
+		p.add(n)
+	}
+	return out.Sync()
+}
+
+func cloneFile(
+	ctx context.Context,
+	src string,
+	dst string,
+	info os.FileInfo,
+	opts *CloneOptions,
+	p *cloneProgress,
+) error {
+	defer p.add(info.Size())
+
+	os.Remove(dst)
+	if opts.Hardlink {
+		if err := os.Link(src, dst); err == nil {
+			return nil
+		}
+	}
+
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, out.Fd(), ficlone, in.Fd()); errno == 0 {
+		return nil
+	}
+	if _, err := io.CopyBuffer(out, &contextReader{ctx, in}, make([]byte, cloneBufSize)); err != nil {
+		return err
+	}
+	return out.Close()
+}
+
+// copyAttributes copies the ownership, permissions and times of a file,
+// best effort as they are not needed for the data to be usable.
+func copyAttributes(path string, info os.FileInfo) {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		os.Lchown(path, int(st.Uid), int(st.Gid))
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		return
+	}
+	os.Chmod(path, info.Mode())
+	os.Chtimes(path, info.ModTime(), info.ModTime())
+}
+
+// contextReader fails reads once its context is cancelled.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *contextReader) Read(buf []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(buf)
+}
+
+func isZero(buf []byte) bool {
+	for _, b := range buf {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// GetRuntimeState returns the runtime state of v under key.
+func GetRuntimeState(v *api.Volume, key string) string {
+	for _, m := range v.RuntimeState {
+		if value, ok := m.GetRuntimeState()[key]; ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// SetRuntimeState sets the runtime state of v under key.
+func SetRuntimeState(v *api.Volume, key string, value string) {
+	for _, m := range v.RuntimeState {
+		if _, ok := m.GetRuntimeState()[key]; ok {
+			m.RuntimeState[key] = value
+			return
+		}
+	}
+	if len(v.RuntimeState) == 0 {
+		v.RuntimeState = []*api.RuntimeStateMap{{}}
+	}
+	if v.RuntimeState[0].RuntimeState == nil {
+		v.RuntimeState[0].RuntimeState = make(map[string]string)
+	}
+	v.RuntimeState[0].RuntimeState[key] = value
+}
+
+// cloneProgress records the progress of a clone in the runtime state of
+// the clone.
+type cloneProgress struct {
+	store    volume.Store
+	volumeID string
+	total    int64
+	copied   int64
+	updated  time.Time
+	progress func(percent int)
+}
+
+func newCloneProgress(
+	store volume.Store,
+	volumeID string,
+	total int64,
+	progress func(percent int),
+) *cloneProgress {
+	p := &cloneProgress{store: store, volumeID: volumeID, total: total, progress: progress}
+	p.update(CloneStateCopying, "")
+	return p
+}
+
+func (p *cloneProgress) add(n int64) {
+	p.copied += n
+	if time.Since(p.updated) >= cloneProgressInterval {
+		p.update(CloneStateCopying, "")
+	}
+}
+
+func (p *cloneProgress) finish(err error) {
+	if err != nil {
+		p.update(CloneStateFailed, err.Error())
+		return
+	}
+	p.copied = p.total
+	p.update(CloneStateDone, "")
+}
+
+func (p *cloneProgress) update(state string, cloneErr string) {
+	p.updated = time.Now()
+	percent := int64(0)
+	if p.total > 0 {
+		percent = p.copied * 100 / p.total
+	} else if state == CloneStateDone {
+		percent = 100
+	}
+	if p.progress != nil {
+		p.progress(int(percent))
+	}
+	if p.store == nil {
+		return
+	}
+	v, err := p.store.GetVol(p.volumeID)
+	if err != nil {
+		dlog.Warnf("Failed to update clone progress of volume %v: %v", p.volumeID, err)
+		return
+	}
+	SetRuntimeState(v, RuntimeCloneState, state)
+	SetRuntimeState(v, RuntimeCloneProgress, strconv.FormatInt(percent, 10))
+	SetRuntimeState(v, RuntimeCloneError, cloneErr)
+	if err := p.store.UpdateVol(v); err != nil {
+		dlog.Warnf("Failed to update clone progress of volume %v: %v", p.volumeID, err)
+	}
+}
@@ -0,0 +1,117 @@
+package common
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/portworx/kvdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+)
+
+func TestCloneDir(t *testing.T) {
+	store := NewDefaultStoreEnumerator("clone_test", kvdb.Instance())
+	require.NoError(t, store.CreateVol(newTestVolume("clone")))
+	defer store.DeleteVol("clone")
+
+	src, err := ioutil.TempDir("", "clone_src")
+	require.NoError(t, err)
+	defer os.RemoveAll(src)
+	dst, err := ioutil.TempDir("", "clone_dst")
+	require.NoError(t, err)
+	defer os.RemoveAll(dst)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "dir", "sub"), 0750))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src, "file"), []byte("data"), 0600))
+	require.NoError(t, ioutil.WriteFile(
+		filepath.Join(src, "dir", "sub", "big"), make([]byte, 3*cloneBufSize+1), 0644))
+	require.NoError(t, os.Symlink("dir/sub/big", filepath.Join(src, "link")))
+
+	require.NoError(t, CloneDir(store, "clone", src, dst, nil))
+
+	data, err := ioutil.ReadFile(filepath.Join(dst, "file"))
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(data))
+	info, err := os.Stat(filepath.Join(dst, "file"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	info, err = os.Stat(filepath.Join(dst, "dir", "sub"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0750), info.Mode().Perm())
+	info, err = os.Stat(filepath.Join(dst, "dir", "sub", "big"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3*cloneBufSize+1), info.Size())
+	link, err := os.Readlink(filepath.Join(dst, "link"))
+	require.NoError(t, err)
+	assert.Equal(t, "dir/sub/big", link)
+
+	v, err := store.GetVol("clone")
+	require.NoError(t, err)
+	assert.Equal(t, CloneStateDone, GetRuntimeState(v, RuntimeCloneState))
+	assert.Equal(t, "100", GetRuntimeState(v, RuntimeCloneProgress))
+
+	// Hardlinked files share their inode with the parent.
+	linked, err := ioutil.TempDir("", "clone_link")
+	require.NoError(t, err)
+	defer os.RemoveAll(linked)
+	require.NoError(t, CloneDir(nil, "clone", src, linked, &CloneOptions{Hardlink: true}))
+	srcInfo, err := os.Stat(filepath.Join(src, "file"))
+	require.NoError(t, err)
+	info, err = os.Stat(filepath.Join(linked, "file"))
+	require.NoError(t, err)
+	assert.True(t, os.SameFile(srcInfo, info))
+
+	// A failed clone records its error.
+	assert.Error(t, CloneDir(store, "clone", filepath.Join(src, "missing"), dst, nil))
+	v, err = store.GetVol("clone")
+	require.NoError(t, err)
+	assert.Equal(t, CloneStateFailed, GetRuntimeState(v, RuntimeCloneState))
+	assert.NotEmpty(t, GetRuntimeState(v, RuntimeCloneError))
+}
+
+func TestCloneDevice(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clone_device")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	size := int64(4*cloneBufSize + 512)
+	data := make([]byte, size)
+	copy(data[cloneBufSize:], "first")
+	copy(data[size-5:], "last!")
+	src := filepath.Join(dir, "src")
+	require.NoError(t, ioutil.WriteFile(src, data, 0600))
+	dst := filepath.Join(dir, "dst")
+	require.NoError(t, ioutil.WriteFile(dst, []byte("stale data"), 0600))
+
+	require.NoError(t, CloneDevice(nil, "clone", src, dst, size, nil))
+	cloned, err := ioutil.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, data, cloned)
+
+	assert.Error(t, CloneDevice(nil, "clone", src, dst, size+1, nil))
+
+	// The progress is reported and cancelling stops the copy.
+	var percents []int
+	require.NoError(t, CloneDevice(nil, "clone", src, dst, size, &CloneOptions{
+		Progress: func(percent int) { percents = append(percents, percent) },
+	}))
+	assert.Equal(t, 0, percents[0])
+	assert.Equal(t, 100, percents[len(percents)-1])
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, CloneDevice(nil, "clone", src, dst, size, &CloneOptions{Context: ctx}))
+}
+
+func TestRuntimeState(t *testing.T) {
+	v := &api.Volume{}
+	assert.Equal(t, "", GetRuntimeState(v, RuntimeCloneState))
+	SetRuntimeState(v, RuntimeCloneState, CloneStateCopying)
+	SetRuntimeState(v, RuntimeCloneState, CloneStateDone)
+	assert.Len(t, v.RuntimeState, 1)
+	assert.Equal(t, CloneStateDone, GetRuntimeState(v, RuntimeCloneState))
+}
@@ -1,6 +1,11 @@
 package common
 
 import (
+	"fmt"
+	"os/exec"
+
+	"github.com/golang/protobuf/proto"
+
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/pkg/proto/time"
 	"github.com/libopenstorage/openstorage/volume"
@@ -32,3 +37,51 @@ func NewVolume(
 func NewDefaultStoreEnumerator(driver string, kvdb kvdb.Kvdb) volume.StoreEnumerator {
 	return newDefaultStoreEnumerator(driver, kvdb)
 }
+
+// SpecSize returns the size a Set of spec resizes volume v to. spec must
+// otherwise be the spec of v, volume.ErrNotSupported is returned if any
+// other field differs and volume.ErrVolShrink if the size is reduced. A
+// zero size keeps the size of v.
+func SpecSize(v *api.Volume, spec *api.VolumeSpec) (uint64, error) {
+	cur := v.GetSpec()
+	if cur == nil {
+		cur = &api.VolumeSpec{}
+	}
+	size := spec.Size
+	if size == 0 {
+		size = cur.Size
+	}
+	other := proto.Clone(spec).(*api.VolumeSpec)
+	other.Size = cur.Size
+	if !proto.Equal(other, cur) {
+		return 0, volume.ErrNotSupported
+	}
+	if size < cur.Size {
+		return 0, volume.ErrVolShrink
+	}
+	return size, nil
+}
+
+// ResizeFilesystem grows the filesystem of the given format to fill its
+// underlying device. devicePath is the block device backing the volume and
+// mountPath is where it is currently mounted; ext4 is grown through the
+// device while xfs requires the mount point.
+func ResizeFilesystem(format api.FSType, devicePath string, mountPath string) error {
+	var cmd *exec.Cmd
+	switch format {
+	case api.FSType_FS_TYPE_EXT4:
+		cmd = exec.Command("/sbin/resize2fs", devicePath)
+	case api.FSType_FS_TYPE_XFS:
+		if mountPath == "" {
+			return fmt.Errorf("xfs volume on %s must be mounted to be resized", devicePath)
+		}
+		cmd = exec.Command("/usr/sbin/xfs_growfs", mountPath)
+	default:
+		return volume.ErrNotSupported
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("Failed to resize %v filesystem on %s: %v (%s)",
+			format.SimpleString(), devicePath, err, string(out))
+	}
+	return nil
+}
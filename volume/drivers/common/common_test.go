@@ -0,0 +1,32 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+func TestSpecSize(t *testing.T) {
+	v := &api.Volume{Spec: &api.VolumeSpec{Size: 100, HaLevel: 2, Format: api.FSType_FS_TYPE_EXT4}}
+
+	size, err := SpecSize(v, &api.VolumeSpec{Size: 200, HaLevel: 2, Format: api.FSType_FS_TYPE_EXT4})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(200), size)
+
+	size, err = SpecSize(v, &api.VolumeSpec{HaLevel: 2, Format: api.FSType_FS_TYPE_EXT4})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(100), size)
+
+	_, err = SpecSize(v, &api.VolumeSpec{Size: 50, HaLevel: 2, Format: api.FSType_FS_TYPE_EXT4})
+	assert.Equal(t, volume.ErrVolShrink, err)
+
+	// Only the size may change.
+	_, err = SpecSize(v, &api.VolumeSpec{Size: 200})
+	assert.Equal(t, volume.ErrNotSupported, err)
+	_, err = SpecSize(v, &api.VolumeSpec{Size: 100, HaLevel: 3, Format: api.FSType_FS_TYPE_EXT4})
+	assert.Equal(t, volume.ErrNotSupported, err)
+}
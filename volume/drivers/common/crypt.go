@@ -0,0 +1,102 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/libopenstorage/openstorage/api"
+)
+
+const (
+	cryptsetup = "/sbin/cryptsetup"
+	// CryptMapperPath is where dm-crypt mappings are exposed
+	CryptMapperPath = "/dev/mapper/"
+)
+
+// ErrNoVolumeKey returned when an encrypted volume has no key
+var ErrNoVolumeKey = errors.New("No passphrase or secret for encrypted volume")
+
+// VolumeKey returns the key an encrypted volume is secured with. A
+// passphrase passed in options takes precedence over the one in the spec,
+// then over the secret named in the spec, read with named. The cluster
+// secret is used if none is set.
+func VolumeKey(
+	spec *api.VolumeSpec,
+	options map[string]string,
+	secret SecretFunc,
+	named NamedSecretFunc,
+) (string, error) {
+	if key := options[api.SpecPassphrase]; key != "" {
+		return key, nil
+	}
+	if spec != nil && spec.Passphrase != "" {
+		return spec.Passphrase, nil
+	}
+	if spec != nil && spec.SecretName != "" {
+		key, err := named(spec.SecretName)
+		if err != nil {
+			return "", fmt.Errorf("%v: %v", ErrNoVolumeKey, err)
+		}
+		return key, nil
+	}
+	key, err := secret()
+	if err != nil {
+		return "", fmt.Errorf("%v: %v", ErrNoVolumeKey, err)
+	}
+	if key == "" {
+		return "", ErrNoVolumeKey
+	}
+	return key, nil
+}
+
+// CryptName returns the dm-crypt mapping name for a volume.
+func CryptName(volumeID string) string {
+	return "osd-" + volumeID
+}
+
+// CryptFormat initializes a LUKS header on devicePath keyed with key.
+func CryptFormat(devicePath string, key string) error {
+	return runCrypt(key, "-q", "luksFormat", "--key-file=-", devicePath)
+}
+
+// CryptOpen opens the LUKS device devicePath as the mapping name and
+// returns the path of the decrypted device.
+func CryptOpen(devicePath string, name string, key string) (string, error) {
+	mapperPath := path.Join(CryptMapperPath, name)
+	if _, err := os.Stat(mapperPath); err == nil {
+		return mapperPath, nil
+	}
+	if err := runCrypt(key, "luksOpen", "--key-file=-", devicePath, name); err != nil {
+		return "", err
+	}
+	return mapperPath, nil
+}
+
+// CryptClose closes the mapping name if it is open.
+func CryptClose(name string) error {
+	if _, err := os.Stat(path.Join(CryptMapperPath, name)); os.IsNotExist(err) {
+		return nil
+	}
+	return runCrypt("", "luksClose", name)
+}
+
+// CryptResize grows the mapping name to the size of its backing device.
+func CryptResize(name string, key string) error {
+	return runCrypt(key, "resize", "--key-file=-", name)
+}
+
+func runCrypt(key string, args ...string) error {
+	cmd := exec.Command(cryptsetup, args...)
+	if key != "" {
+		cmd.Stdin = strings.NewReader(key)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("cryptsetup %v failed: %v (%s)",
+			args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
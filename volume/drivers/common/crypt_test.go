@@ -0,0 +1,50 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+)
+
+func TestVolumeKey(t *testing.T) {
+	spec := &api.VolumeSpec{Encrypted: true, Passphrase: "spec"}
+	options := map[string]string{api.SpecPassphrase: "option"}
+
+	key, err := VolumeKey(spec, options, testSecret("cluster"), testNamedSecret)
+	require.NoError(t, err)
+	assert.Equal(t, "option", key)
+
+	key, err = VolumeKey(spec, nil, testSecret("cluster"), testNamedSecret)
+	require.NoError(t, err)
+	assert.Equal(t, "spec", key)
+
+	key, err = VolumeKey(&api.VolumeSpec{Encrypted: true}, nil, testSecret("cluster"), testNamedSecret)
+	require.NoError(t, err)
+	assert.Equal(t, "cluster", key)
+
+	_, err = VolumeKey(&api.VolumeSpec{Encrypted: true}, nil, testSecret(""), testNamedSecret)
+	assert.Equal(t, ErrNoVolumeKey, err)
+	_, err = VolumeKey(&api.VolumeSpec{Encrypted: true}, nil, func() (string, error) {
+		return "", errors.New("unavailable")
+	}, testNamedSecret)
+	assert.Error(t, err)
+
+	key, err = VolumeKey(&api.VolumeSpec{Encrypted: true, SecretName: "key1"}, nil,
+		testSecret("cluster"), testNamedSecret)
+	require.NoError(t, err)
+	assert.Equal(t, "secret-key1", key)
+	_, err = VolumeKey(&api.VolumeSpec{Encrypted: true, SecretName: "missing"}, nil,
+		testSecret("cluster"), testNamedSecret)
+	assert.Error(t, err)
+}
+
+func testNamedSecret(name string) (string, error) {
+	if name == "missing" {
+		return "", errors.New("Secret not found")
+	}
+	return "secret-" + name, nil
+}
@@ -0,0 +1,310 @@
+package common
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/pborman/uuid"
+	"github.com/portworx/kvdb"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/cluster"
+	"github.com/libopenstorage/openstorage/osdconfig"
+	"github.com/libopenstorage/openstorage/pkg/secrets"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+var (
+	// ErrNoClusterSecret returned when no cluster secret is configured
+	ErrNoClusterSecret = errors.New("Cluster secret key is not configured")
+	// ErrNoSecretsStore returned when no secrets store is configured
+	ErrNoSecretsStore = errors.New("Secrets store is not configured")
+	// ErrCredNotFound returned when a credential does not exist
+	ErrCredNotFound = errors.New("Credential not found")
+)
+
+// SecretFunc returns the secret credentials are encrypted with.
+type SecretFunc func() (string, error)
+
+// NamedSecretFunc returns the secret named name.
+type NamedSecretFunc func(name string) (string, error)
+
+// credValidators check the params of each supported credential type.
+var credValidators = map[string]func(params map[string]string) error{
+	api.CredTypeS3:     validateS3Cred,
+	api.CredTypeGoogle: validateGoogleCred,
+	api.CredTypeAzure:  validateAzureCred,
+}
+
+// publicCredParams are returned by CredsEnumerate, all other params are
+// only kept encrypted.
+var publicCredParams = []string{
+	api.OptCredType,
+	api.OptCredRegion,
+	api.OptCredEndpoint,
+	api.OptCredDisableSSL,
+	api.OptCredGoogleProjectID,
+	api.OptCredAzureAccountName,
+}
+
+// credential is the form credentials are stored in kvdb.
+type credential struct {
+	// Public are the params that are safe to list
+	Public map[string]string
+	// Secret is the encrypted JSON of all params
+	Secret string
+}
+
+type defaultCreds struct {
+	driver string
+	kvdb   kvdb.Kvdb
+	secret SecretFunc
+}
+
+// NewDefaultCredsDriver returns a CredsDriver that stores credentials in
+// kvdb, encrypted with the secret returned by secret.
+func NewDefaultCredsDriver(
+	driver string,
+	kv kvdb.Kvdb,
+	secret SecretFunc,
+) volume.CredsDriver {
+	return &defaultCreds{
+		driver: driver,
+		kvdb:   kv,
+		secret: secret,
+	}
+}
+
+// ClusterSecret returns the cluster secret key from the cluster
+// configuration. If a secrets store is configured, the cluster secret key
+// names the secret to read from it, otherwise it is the secret itself.
+func ClusterSecret() (string, error) {
+	conf, err := secretsConf()
+	if err != nil {
+		return "", err
+	}
+	if conf == nil || conf.ClusterSecretKey == "" {
+		return "", ErrNoClusterSecret
+	}
+	if conf.SecretType == "" {
+		return conf.ClusterSecretKey, nil
+	}
+	store, err := secrets.New(conf)
+	if err != nil {
+		return "", err
+	}
+	return store.GetSecret(conf.ClusterSecretKey)
+}
+
+// Secret returns the secret name from the secrets store of the cluster
+// configuration.
+func Secret(name string) (string, error) {
+	conf, err := secretsConf()
+	if err != nil {
+		return "", err
+	}
+	if conf == nil || conf.SecretType == "" {
+		return "", ErrNoSecretsStore
+	}
+	store, err := secrets.New(conf)
+	if err != nil {
+		return "", err
+	}
+	return store.GetSecret(name)
+}
+
+// secretsConf returns the secrets configuration of the cluster, if any.
+func secretsConf() (*osdconfig.SecretsConfig, error) {
+	cm, err := cluster.Inst()
+	if err != nil {
+		return nil, err
+	}
+	conf, err := cm.GetClusterConf()
+	if err != nil {
+		return nil, err
+	}
+	return conf.Secrets, nil
+}
+
+// CredsCreate validates and stores a credential.
+func (c *defaultCreds) CredsCreate(params map[string]string) (string, error) {
+	if err := validateCred(params); err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+	secret, err := c.encrypt(data)
+	if err != nil {
+		return "", err
+	}
+	cred := &credential{
+		Public: make(map[string]string),
+		Secret: secret,
+	}
+	for _, k := range publicCredParams {
+		if v, ok := params[k]; ok {
+			cred.Public[k] = v
+		}
+	}
+
+	credUUID := uuid.New()
+	if _, err := c.kvdb.Create(c.credKey(credUUID), cred, 0); err != nil {
+		return "", err
+	}
+	return credUUID, nil
+}
+
+// CredsEnumerate returns the public params of all credentials.
+func (c *defaultCreds) CredsEnumerate() (map[string]interface{}, error) {
+	kvp, err := c.kvdb.Enumerate(c.credKey(""))
+	if err != nil && err != kvdb.ErrNotFound {
+		return nil, err
+	}
+	creds := make(map[string]interface{})
+	for _, v := range kvp {
+		cred := &credential{}
+		if err := json.Unmarshal(v.Value, cred); err != nil {
+			return nil, err
+		}
+		parts := strings.Split(v.Key, "/")
+		creds[parts[len(parts)-1]] = cred.Public
+	}
+	return creds, nil
+}
+
+// CredsDelete deletes the credential credUUID.
+func (c *defaultCreds) CredsDelete(credUUID string) error {
+	if _, err := c.kvdb.Delete(c.credKey(credUUID)); err != nil {
+		if err == kvdb.ErrNotFound {
+			return ErrCredNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// CredsValidate checks that credUUID can be decrypted and is complete.
+func (c *defaultCreds) CredsValidate(credUUID string) error {
+	cred := &credential{}
+	if _, err := c.kvdb.GetVal(c.credKey(credUUID), cred); err != nil {
+		if err == kvdb.ErrNotFound {
+			return ErrCredNotFound
+		}
+		return err
+	}
+	data, err := c.decrypt(cred.Secret)
+	if err != nil {
+		return err
+	}
+	params := make(map[string]string)
+	if err := json.Unmarshal(data, &params); err != nil {
+		return err
+	}
+	return validateCred(params)
+}
+
+func (c *defaultCreds) credKey(credUUID string) string {
+	return fmt.Sprintf("%s/%s/creds/%s", keyBase, c.driver, credUUID)
+}
+
+// cipher returns an AES-GCM cipher keyed with the SHA-256 of the secret.
+func (c *defaultCreds) cipher() (cipher.AEAD, error) {
+	secret, err := c.secret()
+	if err != nil {
+		return nil, err
+	}
+	if secret == "" {
+		return nil, ErrNoClusterSecret
+	}
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (c *defaultCreds) encrypt(plaintext []byte) (string, error) {
+	gcm, err := c.cipher()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, plaintext, nil)), nil
+}
+
+func (c *defaultCreds) decrypt(ciphertext string) ([]byte, error) {
+	gcm, err := c.cipher()
+	if err != nil {
+		return nil, err
+	}
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("Invalid encrypted credential")
+	}
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decrypt credential, " +
+			"the cluster secret may have changed")
+	}
+	return plaintext, nil
+}
+
+func validateCred(params map[string]string) error {
+	validate, ok := credValidators[params[api.OptCredType]]
+	if !ok {
+		return fmt.Errorf("Unsupported credential type %q", params[api.OptCredType])
+	}
+	return validate(params)
+}
+
+func validateS3Cred(params map[string]string) error {
+	if params[api.OptCredAccessKey] == "" || params[api.OptCredSecretKey] == "" {
+		return fmt.Errorf("Missing s3 access/secret keys")
+	}
+	if params[api.OptCredEndpoint] == "" {
+		return fmt.Errorf("Missing s3 endpoint")
+	}
+	if _, err := url.Parse(params[api.OptCredEndpoint]); err != nil {
+		return fmt.Errorf("Invalid s3 endpoint: %v", err)
+	}
+	return nil
+}
+
+func validateGoogleCred(params map[string]string) error {
+	if params[api.OptCredGoogleProjectID] == "" || params[api.OptCredGoogleJsonKey] == "" {
+		return fmt.Errorf("Missing google project/json key")
+	}
+	var key map[string]interface{}
+	if err := json.Unmarshal([]byte(params[api.OptCredGoogleJsonKey]), &key); err != nil {
+		return fmt.Errorf("Invalid google json key: %v", err)
+	}
+	return nil
+}
+
+func validateAzureCred(params map[string]string) error {
+	if params[api.OptCredAzureAccountName] == "" || params[api.OptCredAzureAccountKey] == "" {
+		return fmt.Errorf("Missing azure account name/key")
+	}
+	if _, err := base64.StdEncoding.DecodeString(params[api.OptCredAzureAccountKey]); err != nil {
+		return fmt.Errorf("Invalid azure account key: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/portworx/kvdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+)
+
+func testSecret(secret string) SecretFunc {
+	return func() (string, error) {
+		return secret, nil
+	}
+}
+
+func TestCredsCreateValidate(t *testing.T) {
+	creds := NewDefaultCredsDriver("creds_test", kvdb.Instance(), testSecret("secret"))
+
+	invalid := []map[string]string{
+		{api.OptCredType: "unknown"},
+		{api.OptCredType: api.CredTypeS3, api.OptCredEndpoint: "s3.url.com"},
+		{api.OptCredType: api.CredTypeS3,
+			api.OptCredAccessKey: "access", api.OptCredSecretKey: "secret"},
+		{api.OptCredType: api.CredTypeGoogle, api.OptCredGoogleProjectID: "project"},
+		{api.OptCredType: api.CredTypeGoogle,
+			api.OptCredGoogleProjectID: "project", api.OptCredGoogleJsonKey: "notjson"},
+		{api.OptCredType: api.CredTypeAzure, api.OptCredAzureAccountName: "account"},
+		{api.OptCredType: api.CredTypeAzure,
+			api.OptCredAzureAccountName: "account", api.OptCredAzureAccountKey: "not base64!"},
+	}
+	for _, params := range invalid {
+		_, err := creds.CredsCreate(params)
+		assert.Error(t, err, "params %v must be rejected", params)
+	}
+
+	valid := []map[string]string{
+		{api.OptCredType: api.CredTypeS3,
+			api.OptCredRegion:    "east",
+			api.OptCredEndpoint:  "s3.url.com",
+			api.OptCredAccessKey: "access",
+			api.OptCredSecretKey: "secret"},
+		{api.OptCredType: api.CredTypeGoogle,
+			api.OptCredGoogleProjectID: "project",
+			api.OptCredGoogleJsonKey:   `{"type": "service_account"}`},
+		{api.OptCredType: api.CredTypeAzure,
+			api.OptCredAzureAccountName: "account",
+			api.OptCredAzureAccountKey:  "YWNjb3VudGtleQ=="},
+	}
+	ids := make([]string, 0)
+	for _, params := range valid {
+		id, err := creds.CredsCreate(params)
+		require.NoError(t, err)
+		require.NoError(t, creds.CredsValidate(id))
+		ids = append(ids, id)
+	}
+
+	list, err := creds.CredsEnumerate()
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	s3 := list[ids[0]].(map[string]string)
+	assert.Equal(t, api.CredTypeS3, s3[api.OptCredType])
+	assert.Equal(t, "east", s3[api.OptCredRegion])
+	assert.NotContains(t, s3, api.OptCredSecretKey)
+	assert.NotContains(t, s3, api.OptCredAccessKey)
+
+	// Secrets are not readable without the cluster secret.
+	other := NewDefaultCredsDriver("creds_test", kvdb.Instance(), testSecret("other"))
+	assert.Error(t, other.CredsValidate(ids[0]))
+	noSecret := NewDefaultCredsDriver("creds_test", kvdb.Instance(), testSecret(""))
+	_, err = noSecret.CredsCreate(valid[0])
+	assert.Equal(t, ErrNoClusterSecret, err)
+
+	for _, id := range ids {
+		require.NoError(t, creds.CredsDelete(id))
+	}
+	assert.Equal(t, ErrCredNotFound, creds.CredsDelete(ids[0]))
+	assert.Equal(t, ErrCredNotFound, creds.CredsValidate(ids[0]))
+	list, err = creds.CredsEnumerate()
+	require.NoError(t, err)
+	assert.Len(t, list, 0)
+}
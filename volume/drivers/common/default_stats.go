@@ -0,0 +1,295 @@
+package common
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/portworx/kvdb"
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/proto/time"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+const (
+	// sectorSize is the unit of the sector counters in /proc/diskstats
+	sectorSize = 512
+	// statsInterval is how long the first non cumulative sample of a
+	// volume is taken over.
+	statsInterval = time.Second
+	// UsageScanInterval is how often the usage of volumes is saved by
+	// StartUsageScanner.
+	UsageScanInterval = 5 * time.Minute
+)
+
+// diskStatsPath is the file block device counters are read from.
+var diskStatsPath = "/proc/diskstats"
+
+// UsageFunc returns the bytes allocated to a volume.
+type UsageFunc func(volumeID string) (uint64, error)
+
+// DeviceFunc returns the block device a volume is attached to, or "" if
+// the volume is not attached to a block device.
+type DeviceFunc func(volumeID string) (string, error)
+
+type statsSample struct {
+	stats *api.Stats
+	time  time.Time
+}
+
+type defaultStats struct {
+	store  volume.StoreEnumerator
+	usage  UsageFunc
+	device DeviceFunc
+	// samples are the last counters returned for each volume
+	samples map[string]*statsSample
+	lock    sync.Mutex
+}
+
+// NewDefaultStatsDriver returns a StatsDriver that reports the usage
+// returned by usage and, if device is not nil, the /proc/diskstats
+// counters of the block device of attached volumes. The usage found is
+// not saved, see StartUsageScanner.
+func NewDefaultStatsDriver(
+	store volume.StoreEnumerator,
+	usage UsageFunc,
+	device DeviceFunc,
+) volume.StatsDriver {
+	return &defaultStats{
+		store:   store,
+		usage:   usage,
+		device:  device,
+		samples: make(map[string]*statsSample),
+	}
+}
+
+// Stats returns the usage of the volume and the IO counters of its block
+// device. Non cumulative stats are counted since the previous call.
+func (s *defaultStats) Stats(volumeID string, cumulative bool) (*api.Stats, error) {
+	used, err := s.UsedSize(volumeID)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.deviceStats(volumeID)
+	if err != nil {
+		return nil, err
+	}
+	stats.BytesUsed = used
+	if cumulative {
+		return stats, nil
+	}
+
+	s.lock.Lock()
+	prev, ok := s.samples[volumeID]
+	s.lock.Unlock()
+	if !ok {
+		prev = &statsSample{stats: stats, time: time.Now()}
+		time.Sleep(statsInterval)
+		if stats, err = s.deviceStats(volumeID); err != nil {
+			return nil, err
+		}
+		stats.BytesUsed = used
+	}
+	now := time.Now()
+	s.lock.Lock()
+	s.samples[volumeID] = &statsSample{stats: stats, time: now}
+	s.lock.Unlock()
+	return diffStats(stats, prev.stats, now.Sub(prev.time)), nil
+}
+
+// UsedSize returns the bytes allocated to the volume.
+func (s *defaultStats) UsedSize(volumeID string) (uint64, error) {
+	if _, err := s.store.GetVol(volumeID); err != nil {
+		if err == kvdb.ErrNotFound {
+			return 0, volume.ErrEnoEnt
+		}
+		return 0, err
+	}
+	return s.usage(volumeID)
+}
+
+// GetActiveRequests is not tracked, no requests are reported.
+func (s *defaultStats) GetActiveRequests() (*api.ActiveRequests, error) {
+	return &api.ActiveRequests{}, nil
+}
+
+func (s *defaultStats) deviceStats(volumeID string) (*api.Stats, error) {
+	if s.device == nil {
+		return &api.Stats{}, nil
+	}
+	dev, err := s.device(volumeID)
+	if err != nil {
+		return nil, err
+	}
+	if dev == "" {
+		return &api.Stats{}, nil
+	}
+	return DiskStats(dev)
+}
+
+// diffStats returns the counters of cur accumulated since prev.
+func diffStats(cur, prev *api.Stats, interval time.Duration) *api.Stats {
+	delta := func(c, p uint64) uint64 {
+		if c < p {
+			// The counters were reset when the device was reattached.
+			return c
+		}
+		return c - p
+	}
+	return &api.Stats{
+		Reads:      delta(cur.Reads, prev.Reads),
+		ReadMs:     delta(cur.ReadMs, prev.ReadMs),
+		ReadBytes:  delta(cur.ReadBytes, prev.ReadBytes),
+		Writes:     delta(cur.Writes, prev.Writes),
+		WriteMs:    delta(cur.WriteMs, prev.WriteMs),
+		WriteBytes: delta(cur.WriteBytes, prev.WriteBytes),
+		IoProgress: cur.IoProgress,
+		IoMs:       delta(cur.IoMs, prev.IoMs),
+		BytesUsed:  cur.BytesUsed,
+		IntervalMs: uint64(interval / time.Millisecond),
+	}
+}
+
+// StartUsageScanner saves the usage of the volumes of store every
+// interval, until the returned function is called.
+func StartUsageScanner(
+	store volume.StoreEnumerator,
+	usage UsageFunc,
+	interval time.Duration,
+) func() {
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				ScanUsage(store, usage)
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// ScanUsage saves the usage of the volumes of store. Volumes whose usage
+// cannot be found, such as local volumes of other nodes, are skipped.
+func ScanUsage(store volume.StoreEnumerator, usage UsageFunc) {
+	vols, err := store.Enumerate(&api.VolumeLocator{}, nil)
+	if err != nil {
+		dlog.Warnf("Failed to enumerate volumes for usage scan: %v", err)
+		return
+	}
+	for _, v := range vols {
+		used, err := usage(v.Id)
+		if err != nil {
+			continue
+		}
+		if err := saveUsage(store, v.Id, used); err != nil {
+			dlog.Warnf("Failed to save usage of volume %v: %v", v.Id, err)
+		}
+	}
+}
+
+// saveUsage updates the usage of the volume under its lock, so updates
+// made while scanning are not overwritten.
+func saveUsage(store volume.StoreEnumerator, volumeID string, used uint64) error {
+	token, err := store.Lock(volumeID)
+	if err != nil {
+		return err
+	}
+	defer store.Unlock(token)
+
+	v, err := store.GetVol(volumeID)
+	if err != nil {
+		return err
+	}
+	v.Usage = used
+	v.LastScan = prototime.Now()
+	return store.UpdateVol(v)
+}
+
+// DiskStats returns the /proc/diskstats counters of a block device.
+func DiskStats(devicePath string) (*api.Stats, error) {
+	f, err := os.Open(diskStatsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	name := filepath.Base(devicePath)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// major minor name, followed by at least 11 counters.
+		if len(fields) < 14 || fields[2] != name {
+			continue
+		}
+		c := make([]uint64, 11)
+		for i := range c {
+			if c[i], err = strconv.ParseUint(fields[3+i], 10, 64); err != nil {
+				return nil, fmt.Errorf("Invalid diskstats for %v: %v", name, err)
+			}
+		}
+		return &api.Stats{
+			Reads:      c[0],
+			ReadBytes:  c[2] * sectorSize,
+			ReadMs:     c[3],
+			Writes:     c[4],
+			WriteBytes: c[6] * sectorSize,
+			WriteMs:    c[7],
+			IoProgress: c[8],
+			IoMs:       c[9],
+		}, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("No diskstats found for %v", devicePath)
+}
+
+// FileUsage returns the bytes allocated to a file, holes in sparse files
+// are not counted.
+func FileUsage(path string) (uint64, error) {
+	var st syscall.Stat_t
+	if err := syscall.Stat(path, &st); err != nil {
+		return 0, err
+	}
+	return uint64(st.Blocks) * 512, nil
+}
+
+// DirUsage returns the bytes allocated to all files under root. Files
+// with several links under root are counted once.
+func DirUsage(root string) (uint64, error) {
+	var used uint64
+	seen := make(map[uint64]bool)
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		st, ok := info.Sys().(*syscall.Stat_t)
+		if !ok {
+			used += uint64(info.Size())
+			return nil
+		}
+		if st.Nlink > 1 && !info.IsDir() {
+			if seen[st.Ino] {
+				return nil
+			}
+			seen[st.Ino] = true
+		}
+		used += uint64(st.Blocks) * 512
+		return nil
+	})
+	return used, err
+}
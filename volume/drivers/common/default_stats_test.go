@@ -0,0 +1,95 @@
+package common
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+const testDiskStats = `   1       0 ram0 0 0 0 0 0 0 0 0 0 0 0
+  43       0 nbd0 10 1 80 5 20 2 160 7 1 12 12
+`
+
+func TestDiskStats(t *testing.T) {
+	f, err := ioutil.TempFile("", "diskstats")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(testDiskStats)
+	require.NoError(t, err)
+	f.Close()
+
+	defer func(path string) { diskStatsPath = path }(diskStatsPath)
+	diskStatsPath = f.Name()
+
+	stats, err := DiskStats("/dev/nbd0")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), stats.Reads)
+	assert.Equal(t, uint64(80*512), stats.ReadBytes)
+	assert.Equal(t, uint64(5), stats.ReadMs)
+	assert.Equal(t, uint64(20), stats.Writes)
+	assert.Equal(t, uint64(160*512), stats.WriteBytes)
+	assert.Equal(t, uint64(7), stats.WriteMs)
+	assert.Equal(t, uint64(1), stats.IoProgress)
+	assert.Equal(t, uint64(12), stats.IoMs)
+
+	_, err = DiskStats("/dev/nbd1")
+	assert.Error(t, err)
+}
+
+func TestUsedSize(t *testing.T) {
+	dir, err := ioutil.TempDir("", "usage")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// A sparse file only counts its allocated blocks.
+	sparse, err := os.Create(filepath.Join(dir, "sparse"))
+	require.NoError(t, err)
+	require.NoError(t, sparse.Truncate(1<<30))
+	_, err = sparse.WriteAt(make([]byte, 4096), 0)
+	require.NoError(t, err)
+	sparse.Close()
+	fileUsed, err := FileUsage(sparse.Name())
+	require.NoError(t, err)
+	assert.True(t, fileUsed >= 4096 && fileUsed < 1<<20, "used %v", fileUsed)
+
+	// Hard links are counted once.
+	require.NoError(t, os.Link(sparse.Name(), filepath.Join(dir, "link")))
+	dirUsed, err := DirUsage(dir)
+	require.NoError(t, err)
+	rootUsed, err := FileUsage(dir)
+	require.NoError(t, err)
+	assert.Equal(t, rootUsed+fileUsed, dirUsed)
+
+	v := newTestVolume("TestUsedSize")
+	require.NoError(t, testEnumerator.CreateVol(v))
+	stats := NewDefaultStatsDriver(testEnumerator, func(volumeID string) (uint64, error) {
+		return DirUsage(dir)
+	}, nil)
+	used, err := stats.UsedSize(v.Id)
+	require.NoError(t, err)
+	assert.Equal(t, dirUsed, used)
+	saved, err := testEnumerator.GetVol(v.Id)
+	require.NoError(t, err)
+	assert.Zero(t, saved.Usage, "usage must only be saved by scans")
+
+	ScanUsage(testEnumerator, func(volumeID string) (uint64, error) {
+		return DirUsage(dir)
+	})
+	saved, err = testEnumerator.GetVol(v.Id)
+	require.NoError(t, err)
+	assert.Equal(t, dirUsed, saved.Usage)
+
+	s, err := stats.Stats(v.Id, true)
+	require.NoError(t, err)
+	assert.Equal(t, dirUsed, s.BytesUsed)
+
+	_, err = stats.UsedSize("missing")
+	assert.Equal(t, volume.ErrEnoEnt, err)
+	require.NoError(t, testEnumerator.DeleteVol(v.Id))
+}
@@ -146,7 +146,13 @@ func (e *defaultStoreEnumerator) lockKeyPrefix() string {
 }
 
 func (e *defaultStoreEnumerator) volKeyPrefix() string {
-	return fmt.Sprintf("%s/%s/volumes/", keyBase, e.driver)
+	return VolumeKeyPrefix(e.driver)
+}
+
+// VolumeKeyPrefix returns the kvdb prefix of the volumes of driver stored
+// by the default store enumerator. Volume locks are stored under it too.
+func VolumeKeyPrefix(driver string) string {
+	return fmt.Sprintf("%s/%s/volumes/", keyBase, driver)
 }
 
 func hasSubset(set map[string]string, subset map[string]string) bool {
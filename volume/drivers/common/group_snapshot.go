@@ -0,0 +1,155 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pborman/uuid"
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+// DefaultQuiesceTimeoutSec bounds how long the volumes of a group
+// snapshot stay quiesced if the request does not.
+const DefaultQuiesceTimeoutSec = 30
+
+// EnforceFunc runs fn, which creates the volumes next, unless they would
+// exceed a quota.
+type EnforceFunc func(next []*api.Volume, fn func() error) error
+
+// GroupSnapshot snapshots the volumes of req at the same point in time.
+// If enforce is not nil, the snapshots are only taken if it allows them
+// all, before any volume is quiesced. Every volume is quiesced with a
+// shared quiesce ID before any snapshot is taken and unquiesced once all
+// are. Volumes of drivers that cannot quiesce are synced by the snapshot
+// only. If a snapshot fails, those already taken are deleted and no
+// snapshot is returned.
+func GroupSnapshot(
+	d volume.VolumeDriver,
+	req *api.GroupSnapCreateRequest,
+	enforce EnforceFunc,
+) (*api.GroupSnapCreateResponse, error) {
+	vols, err := groupVolumes(d, req)
+	if err != nil {
+		return nil, err
+	}
+	id := uuid.New()
+	locators := make([]*api.VolumeLocator, 0, len(vols))
+	snaps := make([]*api.Volume, 0, len(vols))
+	for _, v := range vols {
+		labels := map[string]string{api.LabelGroupSnapID: id}
+		for k, val := range req.Labels {
+			labels[k] = val
+		}
+		locator := &api.VolumeLocator{
+			Name:         fmt.Sprintf("%s.%s", v.GetLocator().GetName(), id),
+			VolumeLabels: labels,
+		}
+		locators = append(locators, locator)
+		snaps = append(snaps, &api.Volume{
+			Locator:  locator,
+			Source:   &api.Source{Parent: v.Id},
+			Readonly: req.Readonly,
+		})
+	}
+	if enforce == nil {
+		return groupSnapshot(d, req, id, vols, locators)
+	}
+
+	var resp *api.GroupSnapCreateResponse
+	err = enforce(snaps, func() (err error) {
+		resp, err = groupSnapshot(d, req, id, vols, locators)
+		return err
+	})
+	return resp, err
+}
+
+// groupSnapshot quiesces vols and snapshots them with locators.
+func groupSnapshot(
+	d volume.VolumeDriver,
+	req *api.GroupSnapCreateRequest,
+	id string,
+	vols []*api.Volume,
+	locators []*api.VolumeLocator,
+) (*api.GroupSnapCreateResponse, error) {
+	timeout := req.QuiesceTimeoutSec
+	if timeout == 0 {
+		timeout = DefaultQuiesceTimeoutSec
+	}
+
+	quiesced := make([]string, 0, len(vols))
+	defer func() {
+		for _, volumeID := range quiesced {
+			if err := d.Unquiesce(volumeID); err != nil {
+				dlog.Warnf("Failed to unquiesce volume %v of group snapshot %v: %v",
+					volumeID, id, err)
+			}
+		}
+	}()
+	for _, v := range vols {
+		err := d.Quiesce(v.Id, timeout, id)
+		if err == volume.ErrNotSupported {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("Failed to quiesce volume %v: %v", v.Id, err)
+		}
+		quiesced = append(quiesced, v.Id)
+	}
+
+	resp := &api.GroupSnapCreateResponse{Id: id, Snapshots: make(map[string]string)}
+	for i, v := range vols {
+		snapID, err := d.Snapshot(v.Id, req.Readonly, locators[i])
+		if err != nil {
+			rollbackGroupSnapshot(d, resp)
+			return nil, fmt.Errorf("Failed to snapshot volume %v: %v", v.Id, err)
+		}
+		resp.Snapshots[v.Id] = snapID
+	}
+	return resp, nil
+}
+
+// groupVolumes returns the volumes of a group snapshot request in ID order.
+func groupVolumes(d volume.VolumeDriver, req *api.GroupSnapCreateRequest) ([]*api.Volume, error) {
+	var (
+		vols []*api.Volume
+		err  error
+	)
+	if len(req.Ids) > 0 {
+		if vols, err = d.Inspect(req.Ids); err != nil {
+			return nil, err
+		}
+		if len(vols) != len(req.Ids) {
+			return nil, fmt.Errorf("Only %d of %d volumes found", len(vols), len(req.Ids))
+		}
+	} else if req.Group != "" {
+		all, err := d.Enumerate(&api.VolumeLocator{}, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range all {
+			// Snapshots inherit the group of their parent.
+			_, groupSnap := v.GetLocator().GetVolumeLabels()[api.LabelGroupSnapID]
+			if v.GetSpec().GetGroup().GetId() == req.Group && !v.Readonly && !groupSnap {
+				vols = append(vols, v)
+			}
+		}
+	} else {
+		return nil, fmt.Errorf("Volume IDs or group must be provided")
+	}
+	if len(vols) == 0 {
+		return nil, fmt.Errorf("No volumes in group %v", req.Group)
+	}
+	sort.Slice(vols, func(i, j int) bool { return vols[i].Id < vols[j].Id })
+	return vols, nil
+}
+
+func rollbackGroupSnapshot(d volume.VolumeDriver, resp *api.GroupSnapCreateResponse) {
+	for volumeID, snapID := range resp.Snapshots {
+		if err := d.Delete(snapID); err != nil {
+			dlog.Warnf("Failed to delete snapshot %v of volume %v of group snapshot %v: %v",
+				snapID, volumeID, resp.Id, err)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package common
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/volume"
+	mockdriver "github.com/libopenstorage/openstorage/volume/drivers/mock"
+)
+
+func groupVolume(id string, group string) *api.Volume {
+	return &api.Volume{
+		Id:      id,
+		Locator: &api.VolumeLocator{Name: "name-" + id},
+		Spec:    &api.VolumeSpec{Group: &api.Group{Id: group}},
+	}
+}
+
+func TestGroupSnapshot(t *testing.T) {
+	mc := gomock.NewController(t)
+	defer mc.Finish()
+	d := mockdriver.NewMockVolumeDriver(mc)
+
+	d.EXPECT().Enumerate(&api.VolumeLocator{}, nil).Return([]*api.Volume{
+		groupVolume("vol2", "db"),
+		groupVolume("vol1", "db"),
+		groupVolume("vol3", "web"),
+	}, nil)
+
+	var quiesceID string
+	gomock.InOrder(
+		d.EXPECT().Quiesce("vol1", uint64(DefaultQuiesceTimeoutSec), gomock.Any()).
+			Do(func(volumeID string, timeout uint64, id string) { quiesceID = id }).
+			Return(nil),
+		d.EXPECT().Quiesce("vol2", uint64(DefaultQuiesceTimeoutSec), gomock.Any()).
+			Do(func(volumeID string, timeout uint64, id string) {
+				assert.Equal(t, quiesceID, id)
+			}).
+			Return(volume.ErrNotSupported),
+		d.EXPECT().Snapshot("vol1", true, gomock.Any()).
+			Do(func(volumeID string, readonly bool, locator *api.VolumeLocator) {
+				assert.Equal(t, quiesceID, locator.VolumeLabels[api.LabelGroupSnapID])
+				assert.Equal(t, "nightly", locator.VolumeLabels["backup"])
+				assert.Equal(t, "name-vol1."+quiesceID, locator.Name)
+			}).
+			Return("snap1", nil),
+		d.EXPECT().Snapshot("vol2", true, gomock.Any()).Return("snap2", nil),
+		d.EXPECT().Unquiesce("vol1").Return(nil),
+	)
+
+	resp, err := GroupSnapshot(d, &api.GroupSnapCreateRequest{
+		Group:    "db",
+		Labels:   map[string]string{"backup": "nightly"},
+		Readonly: true,
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, quiesceID, resp.Id)
+	assert.Equal(t, map[string]string{"vol1": "snap1", "vol2": "snap2"}, resp.Snapshots)
+}
+
+func TestGroupSnapshotRollback(t *testing.T) {
+	mc := gomock.NewController(t)
+	defer mc.Finish()
+	d := mockdriver.NewMockVolumeDriver(mc)
+
+	ids := []string{"vol1", "vol2"}
+	d.EXPECT().Inspect(ids).Return([]*api.Volume{
+		groupVolume("vol1", ""),
+		groupVolume("vol2", ""),
+	}, nil)
+	gomock.InOrder(
+		d.EXPECT().Quiesce("vol1", uint64(5), gomock.Any()).Return(nil),
+		d.EXPECT().Quiesce("vol2", uint64(5), gomock.Any()).Return(nil),
+		d.EXPECT().Snapshot("vol1", false, gomock.Any()).Return("snap1", nil),
+		d.EXPECT().Snapshot("vol2", false, gomock.Any()).Return("", fmt.Errorf("no space")),
+		d.EXPECT().Delete("snap1").Return(nil),
+	)
+	d.EXPECT().Unquiesce("vol1").Return(nil)
+	d.EXPECT().Unquiesce("vol2").Return(nil)
+
+	_, err := GroupSnapshot(d, &api.GroupSnapCreateRequest{Ids: ids, QuiesceTimeoutSec: 5}, nil)
+	assert.Error(t, err)
+
+	// Volumes quiesced before a failure to quiesce are unquiesced.
+	d.EXPECT().Inspect(ids).Return([]*api.Volume{
+		groupVolume("vol1", ""),
+		groupVolume("vol2", ""),
+	}, nil)
+	d.EXPECT().Quiesce("vol1", gomock.Any(), gomock.Any()).Return(nil)
+	d.EXPECT().Quiesce("vol2", gomock.Any(), gomock.Any()).Return(fmt.Errorf("busy"))
+	d.EXPECT().Unquiesce("vol1").Return(nil)
+
+	_, err = GroupSnapshot(d, &api.GroupSnapCreateRequest{Ids: ids}, nil)
+	assert.Error(t, err)
+}
+
+func TestGroupSnapshotEnforce(t *testing.T) {
+	mc := gomock.NewController(t)
+	defer mc.Finish()
+	d := mockdriver.NewMockVolumeDriver(mc)
+
+	// Snapshots denied together are not taken, nor volumes quiesced.
+	ids := []string{"vol1", "vol2"}
+	d.EXPECT().Inspect(ids).Return([]*api.Volume{
+		groupVolume("vol1", ""),
+		groupVolume("vol2", ""),
+	}, nil)
+	_, err := GroupSnapshot(d, &api.GroupSnapCreateRequest{Ids: ids, Readonly: true},
+		func(next []*api.Volume, fn func() error) error {
+			require.Len(t, next, 2)
+			assert.Equal(t, "vol1", next[0].GetSource().GetParent())
+			assert.Equal(t, "vol2", next[1].GetSource().GetParent())
+			assert.True(t, next[0].GetReadonly())
+			return fmt.Errorf("quota exceeded")
+		})
+	assert.EqualError(t, err, "quota exceeded")
+}
@@ -19,25 +19,35 @@ import (
 type Driver struct {
 	DriverType api.DriverType
 	Name       string
+	// Scope is reported to Docker, ScopeLocal if the volumes of the driver
+	// can only be used on the node they are created on.
+	Scope string
 }
 
+const (
+	// ScopeLocal is the scope of the volumes of a node.
+	ScopeLocal = "local"
+	// ScopeGlobal is the scope of the volumes of a cluster.
+	ScopeGlobal = "global"
+)
+
 var (
 	// AllDrivers is a slice of all existing known Drivers.
 	AllDrivers = []Driver{
 		// AWS driver provisions storage from EBS.
-		{DriverType: aws.Type, Name: aws.Name},
+		{DriverType: aws.Type, Name: aws.Name, Scope: ScopeGlobal},
 		// BTRFS driver provisions storage from local btrfs.
-		{DriverType: btrfs.Type, Name: btrfs.Name},
+		{DriverType: btrfs.Type, Name: btrfs.Name, Scope: ScopeLocal},
 		// BUSE driver provisions storage from local volumes and implements block in user space.
-		{DriverType: buse.Type, Name: buse.Name},
+		{DriverType: buse.Type, Name: buse.Name, Scope: ScopeLocal},
 		// COPRHD driver
-		{DriverType: coprhd.Type, Name: coprhd.Name},
+		{DriverType: coprhd.Type, Name: coprhd.Name, Scope: ScopeGlobal},
 		// NFS driver provisions storage from an NFS server.
-		{DriverType: nfs.Type, Name: nfs.Name},
+		{DriverType: nfs.Type, Name: nfs.Name, Scope: ScopeGlobal},
 		// PWX driver provisions storage from PWX cluster.
-		{DriverType: pwx.Type, Name: pwx.Name},
+		{DriverType: pwx.Type, Name: pwx.Name, Scope: ScopeGlobal},
 		// VFS driver provisions storage from local filesystem
-		{DriverType: vfs.Type, Name: vfs.Name},
+		{DriverType: vfs.Type, Name: vfs.Name, Scope: ScopeLocal},
 	}
 
 	volumeDriverRegistry = volume.NewVolumeDriverRegistry(
@@ -53,6 +63,17 @@ var (
 	)
 )
 
+// Scope returns the scope of the volumes of driver name, ScopeGlobal if the
+// driver is not known.
+func Scope(name string) string {
+	for _, d := range AllDrivers {
+		if d.Name == name {
+			return d.Scope
+		}
+	}
+	return ScopeGlobal
+}
+
 // Get returns a VolumeDriver based on input name.
 func Get(name string) (volume.VolumeDriver, error) {
 	return volumeDriverRegistry.Get(name)
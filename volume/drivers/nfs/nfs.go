@@ -1,9 +1,9 @@
 package nfs
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"io"
 	"os"
 	"path"
 	"strconv"
@@ -17,6 +17,7 @@ import (
 	"github.com/libopenstorage/openstorage/pkg/mount"
 	"github.com/libopenstorage/openstorage/pkg/seed"
 	"github.com/libopenstorage/openstorage/volume"
+	"github.com/libopenstorage/openstorage/volume/drivers/cloudbackup"
 	"github.com/libopenstorage/openstorage/volume/drivers/common"
 	"github.com/portworx/kvdb"
 	"math/rand"
@@ -42,6 +43,8 @@ type driver struct {
 	nfsServers []string
 	nfsPath    string
 	mounter    mount.Manager
+	// stopScan stops saving the usage of volumes
+	stopScan func()
 }
 
 func Init(params map[string]string) (volume.VolumeDriver, error) {
@@ -67,16 +70,21 @@ func Init(params map[string]string) (volume.VolumeDriver, error) {
 		return nil, err
 	}
 	inst := &driver{
-		IODriver:          volume.IONotSupported,
-		StoreEnumerator:   common.NewDefaultStoreEnumerator(Name, kvdb.Instance()),
-		StatsDriver:       volume.StatsNotSupported,
-		QuiesceDriver:     volume.QuiesceNotSupported,
-		nfsServers:        servers,
-		CredsDriver:       volume.CredsNotSupported,
-		nfsPath:           path,
-		mounter:           mounter,
-		CloudBackupDriver: volume.CloudBackupNotSupported,
-	}
+		IODriver:        volume.IONotSupported,
+		StoreEnumerator: common.NewDefaultStoreEnumerator(Name, kvdb.Instance()),
+		QuiesceDriver:   volume.QuiesceNotSupported,
+		nfsServers:      servers,
+		CredsDriver:     common.NewDefaultCredsDriver(Name, kvdb.Instance(), common.ClusterSecret),
+		nfsPath:         path,
+		mounter:         mounter,
+	}
+	inst.StatsDriver = common.NewDefaultStatsDriver(inst.StoreEnumerator, inst.usedSize, nil)
+	inst.CloudBackupDriver = cloudbackup.New(&cloudbackup.Config{
+		Name:   Name,
+		Driver: inst,
+		Source: cloudbackup.NewDirSource(inst.getNFSVolumePathById),
+		Store:  cloudbackup.NewLocalStoreProvider(params),
+	})
 
 	//make directory for each nfs server
 	for _, v := range servers {
@@ -126,6 +134,9 @@ func Init(params map[string]string) (volume.VolumeDriver, error) {
 		}
 	}
 
+	inst.stopScan = common.StartUsageScanner(inst.StoreEnumerator, inst.usedSize,
+		common.UsageScanInterval)
+
 	dlog.Println("NFS initialized and driver mounted at: ", nfsMountPath)
 	return inst, nil
 }
@@ -197,6 +208,31 @@ func (d *driver) getNFSVolumePathById(volumeID string) (string, error) {
 	return d.getNFSVolumePath(v)
 }
 
+// usedSize returns the bytes allocated to the volume directory and its
+// simulated block volume.
+func (d *driver) usedSize(volumeID string) (uint64, error) {
+	v, err := d.GetVol(volumeID)
+	if err != nil {
+		return 0, err
+	}
+	volPath, err := d.getNFSVolumePath(v)
+	if err != nil {
+		return 0, err
+	}
+	used, err := common.DirUsage(volPath)
+	if err != nil {
+		return 0, err
+	}
+	if v.DevicePath != "" {
+		blockUsed, err := common.FileUsage(v.DevicePath)
+		if err != nil && !os.IsNotExist(err) {
+			return 0, err
+		}
+		used += blockUsed
+	}
+	return used, nil
+}
+
 //append unix time to volumeID
 func (d *driver) getNewSnapVolID(volumeID string) string {
 	return volumeID + "-" + strconv.FormatUint(uint64(time.Now().Unix()), 10)
@@ -210,11 +246,36 @@ func (d *driver) Create(
 	locator *api.VolumeLocator,
 	source *api.Source,
 	spec *api.VolumeSpec) (string, error) {
+	return d.CreateContext(context.Background(), nil, locator, source, spec)
+}
+
+func (d *driver) CreateContext(
+	ctx context.Context,
+	progress func(percent int),
+	locator *api.VolumeLocator,
+	source *api.Source,
+	spec *api.VolumeSpec,
+) (string, error) {
 
 	volumeID := locator.Name
-	if volumeID == "" && source.Parent != "" {
-		volumeID = d.getNewSnapVolID(source.Parent)
-		dlog.Infof("Creating snap vol id: %s", volumeID)
+	parentPath := ""
+	if source != nil && source.Parent != "" {
+		parent, err := d.GetVol(source.Parent)
+		if err != nil {
+			return "", err
+		}
+		if parentPath, err = d.getNFSVolumePath(parent); err != nil {
+			return "", err
+		}
+		// Clones take the spec of their parent if none is given.
+		if spec == nil && parent.Spec != nil {
+			parentSpec := *parent.Spec
+			spec = &parentSpec
+		}
+		if volumeID == "" {
+			volumeID = d.getNewSnapVolID(source.Parent)
+			dlog.Infof("Creating snap vol id: %s", volumeID)
+		}
 	}
 
 	if _, err := d.GetVol(volumeID); err == nil {
@@ -290,6 +351,14 @@ func (d *driver) Create(
 	if err := d.CreateVol(v); err != nil {
 		return "", err
 	}
+	if parentPath != "" {
+		// NFS does not support clones, so just copy the files.
+		opts := &common.CloneOptions{Context: ctx, Progress: progress}
+		if err := common.CloneDir(d, volumeID, parentPath, volPath, opts); err != nil {
+			d.Delete(volumeID)
+			return "", err
+		}
+	}
 	return v.Id, err
 }
 
@@ -387,36 +456,45 @@ func (d *driver) Unmount(volumeID string, mountpath string, options map[string]s
 }
 
 func (d *driver) Snapshot(volumeID string, readonly bool, locator *api.VolumeLocator) (string, error) {
-	volIDs := []string{volumeID}
-	vols, err := d.Inspect(volIDs)
-	if err != nil {
-		return "", nil
-	}
-	source := &api.Source{Parent: volumeID}
-	newVolumeID, err := d.Create(locator, source, vols[0].Spec)
-	if err != nil {
-		return "", nil
-	}
-
-	nfsVolPath, err := d.getNFSVolumePathById(volumeID)
-	if err != nil {
-		return "", err
-	}
+	return d.SnapshotContext(context.Background(), nil, volumeID, readonly, locator)
+}
 
-	newNfsVolPath, err := d.getNFSVolumePathById(newVolumeID)
+func (d *driver) SnapshotContext(
+	ctx context.Context,
+	progress func(percent int),
+	volumeID string,
+	readonly bool,
+	locator *api.VolumeLocator,
+) (string, error) {
+	// NFS does not support snapshots, so the files are copied to a clone.
+	source := &api.Source{Parent: volumeID}
+	newVolumeID, err := d.CreateContext(ctx, progress, locator, source, nil)
 	if err != nil {
 		return "", err
 	}
-
-	// NFS does not support snapshots, so just copy the files.
-	if err := copyDir(nfsVolPath, newNfsVolPath); err != nil {
-		d.Delete(newVolumeID)
-		return "", nil
+	if readonly {
+		v, err := d.GetVol(newVolumeID)
+		if err != nil {
+			return "", err
+		}
+		v.Readonly = true
+		if err := d.UpdateVol(v); err != nil {
+			return "", err
+		}
 	}
 	return newVolumeID, nil
 }
 
 func (d *driver) Restore(volumeID string, snapID string) error {
+	return d.RestoreContext(context.Background(), nil, volumeID, snapID)
+}
+
+func (d *driver) RestoreContext(
+	ctx context.Context,
+	progress func(percent int),
+	volumeID string,
+	snapID string,
+) error {
 	if _, err := d.Inspect([]string{volumeID, snapID}); err != nil {
 		return err
 	}
@@ -432,7 +510,8 @@ func (d *driver) Restore(volumeID string, snapID string) error {
 	}
 
 	// NFS does not support restore, so just copy the files.
-	if err := copyDir(snapNfsVolPath, nfsVolPath); err != nil {
+	opts := &common.CloneOptions{Context: ctx, Progress: progress}
+	if err := common.CloneDir(d, volumeID, snapNfsVolPath, nfsVolPath, opts); err != nil {
 		return err
 	}
 	return nil
@@ -453,13 +532,23 @@ func (d *driver) Detach(volumeID string, options map[string]string) error {
 }
 
 func (d *driver) Set(volumeID string, locator *api.VolumeLocator, spec *api.VolumeSpec) error {
-	if spec != nil {
-		return volume.ErrNotSupported
-	}
 	v, err := d.GetVol(volumeID)
 	if err != nil {
 		return err
 	}
+	if spec != nil {
+		size, err := common.SpecSize(v, spec)
+		if err != nil {
+			return err
+		}
+		// Grow the simulated block volume, the directory itself is unbounded.
+		if size != v.Spec.Size {
+			if err := os.Truncate(v.DevicePath, int64(size)); err != nil {
+				return err
+			}
+			v.Spec.Size = size
+		}
+	}
 	if locator != nil {
 		v.Locator = locator
 	}
@@ -468,78 +557,10 @@ func (d *driver) Set(volumeID string, locator *api.VolumeLocator, spec *api.Volu
 
 func (d *driver) Shutdown() {
 	dlog.Printf("%s Shutting down", Name)
+	d.stopScan()
 
 	for _, v := range d.nfsServers {
 		dlog.Infof("Umounting: %s", nfsMountPath+v)
 		syscall.Unmount(path.Join(nfsMountPath, v), 0)
 	}
 }
-
-func copyFile(source string, dest string) (err error) {
-	sourcefile, err := os.Open(source)
-	if err != nil {
-		return err
-	}
-
-	defer sourcefile.Close()
-
-	destfile, err := os.Create(dest)
-	if err != nil {
-		return err
-	}
-
-	defer destfile.Close()
-
-	_, err = io.Copy(destfile, sourcefile)
-	if err == nil {
-		sourceinfo, err := os.Stat(source)
-		if err != nil {
-			err = os.Chmod(dest, sourceinfo.Mode())
-		}
-
-	}
-
-	return
-}
-
-func copyDir(source string, dest string) (err error) {
-	// get properties of source dir
-	sourceinfo, err := os.Stat(source)
-	if err != nil {
-		return err
-	}
-
-	// create dest dir
-
-	err = os.MkdirAll(dest, sourceinfo.Mode())
-	if err != nil {
-		return err
-	}
-
-	directory, _ := os.Open(source)
-
-	objects, err := directory.Readdir(-1)
-
-	for _, obj := range objects {
-
-		sourcefilepointer := source + "/" + obj.Name()
-
-		destinationfilepointer := dest + "/" + obj.Name()
-
-		if obj.IsDir() {
-			// create sub-directories - recursively
-			err = copyDir(sourcefilepointer, destinationfilepointer)
-			if err != nil {
-				fmt.Println(err)
-			}
-		} else {
-			// perform copy
-			err = copyFile(sourcefilepointer, destinationfilepointer)
-			if err != nil {
-				fmt.Println(err)
-			}
-		}
-
-	}
-	return
-}
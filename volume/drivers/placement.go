@@ -0,0 +1,51 @@
+package volumedrivers
+
+import (
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/cluster"
+	"github.com/libopenstorage/openstorage/pkg/placement"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+// Place sets the replica set of a new volume of driver name, served by d, to
+// the nodes of c picked by placement.Place, unless the spec already has
+// one. Only the volumes of drivers of global scope are placed, those of
+// local drivers stay on the node creating them. Volumes are not placed
+// either if there is no cluster to place them in.
+func Place(
+	name string,
+	d volume.VolumeDriver,
+	c cluster.Cluster,
+	locator *api.VolumeLocator,
+	spec *api.VolumeSpec,
+) error {
+	if c == nil || spec == nil || len(spec.GetReplicaSet().GetNodes()) > 0 {
+		return nil
+	}
+	if Scope(name) != ScopeGlobal {
+		return nil
+	}
+	cl, err := c.Enumerate()
+	if err != nil {
+		return err
+	}
+	// Other volumes only matter to spread those of a group.
+	var vols []*api.Volume
+	if spec.GetGroup().GetId() != "" {
+		if vols, err = d.Enumerate(&api.VolumeLocator{}, nil); err != nil {
+			return err
+		}
+	}
+	decision, err := placement.Place(&placement.Request{
+		Spec:      spec,
+		Locator:   locator,
+		LocalNode: cl.NodeId,
+		Nodes:     cl.Nodes,
+		Volumes:   vols,
+	})
+	if err != nil {
+		return err
+	}
+	spec.ReplicaSet = decision.ReplicaSet
+	return nil
+}
@@ -0,0 +1,39 @@
+package volumedrivers
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/cluster/mock"
+	"github.com/libopenstorage/openstorage/pkg/placement"
+	"github.com/libopenstorage/openstorage/volume/drivers/vfs"
+)
+
+func TestPlace(t *testing.T) {
+	mc := gomock.NewController(t)
+	defer mc.Finish()
+	c := mock.NewMockCluster(mc)
+	c.EXPECT().
+		Enumerate().
+		Return(api.Cluster{
+			NodeId: "node1",
+			Nodes:  []api.Node{{Id: "node1", Status: api.Status_STATUS_OK}},
+		}, nil).
+		Times(2)
+
+	spec := &api.VolumeSpec{HaLevel: 1}
+	require.NoError(t, Place("global", nil, c, nil, spec))
+	assert.Equal(t, []string{"node1"}, spec.GetReplicaSet().GetNodes())
+	assert.Equal(t, placement.ErrNoCapacity, Place("global", nil, c, nil, &api.VolumeSpec{HaLevel: 2}))
+
+	// The volumes of local drivers are not placed, whatever their spec.
+	spec = &api.VolumeSpec{HaLevel: 2}
+	require.NoError(t, Place(vfs.Name, nil, c, nil, spec))
+	assert.Nil(t, spec.ReplicaSet)
+	require.NoError(t, Place("global", nil, nil, nil, spec))
+	assert.Nil(t, spec.ReplicaSet)
+}
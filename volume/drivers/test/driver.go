@@ -61,6 +61,7 @@ func RunShort(t *testing.T, ctx *Context) {
 	create(t, ctx)
 	inspect(t, ctx)
 	set(t, ctx)
+	resize(t, ctx)
 	enumerate(t, ctx)
 	attach(t, ctx)
 	mount(t, ctx)
@@ -149,6 +150,31 @@ func set(t *testing.T, ctx *Context) {
 	}
 }
 
+func resize(t *testing.T, ctx *Context) {
+	fmt.Println("resize")
+
+	vols, err := ctx.Inspect([]string{ctx.volID})
+	require.NoError(t, err, "Failed in Inspect")
+	require.Equal(t, len(vols), 1, "Expect 1 volume actual %v volumes", len(vols))
+
+	spec := *vols[0].Spec
+	spec.Size = vols[0].Spec.Size * 2
+	size := spec.Size
+	err = ctx.Set(ctx.volID, nil, &spec)
+	if err != volume.ErrNotSupported {
+		require.NoError(t, err, "Failed in Resize")
+		vols, err = ctx.Inspect([]string{ctx.volID})
+		require.NoError(t, err, "Failed in Inspect")
+		require.Equal(t, len(vols), 1, "Expect 1 volume actual %v volumes", len(vols))
+		require.Equal(t, size, vols[0].Spec.Size,
+			"Expect size %v actual %v", size, vols[0].Spec.Size)
+
+		spec.Size = size / 2
+		err = ctx.Set(ctx.volID, nil, &spec)
+		require.Equal(t, volume.ErrVolShrink, err, "Expected shrink to fail")
+	}
+}
+
 func enumerate(t *testing.T, ctx *Context) {
 	fmt.Println("enumerate")
 
@@ -1,6 +1,7 @@
 package vfs
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/libopenstorage/openstorage/api"
 	"github.com/libopenstorage/openstorage/volume"
+	"github.com/libopenstorage/openstorage/volume/drivers/cloudbackup"
 	"github.com/libopenstorage/openstorage/volume/drivers/common"
 	"github.com/pborman/uuid"
 	"github.com/portworx/kvdb"
@@ -35,19 +37,35 @@ type driver struct {
 	volume.StatsDriver
 	volume.CredsDriver
 	volume.CloudBackupDriver
+	// stopScan stops saving the usage of volumes
+	stopScan func()
 }
 
 // Init Driver intialization.
 func Init(params map[string]string) (volume.VolumeDriver, error) {
-	return &driver{
+	store := common.NewDefaultStoreEnumerator(Name, kvdb.Instance())
+	usage := func(volumeID string) (uint64, error) {
+		return common.DirUsage(filepath.Join(volume.VolumeBase, volumeID))
+	}
+	d := &driver{
 		volume.IONotSupported,
 		volume.BlockNotSupported,
 		volume.SnapshotNotSupported,
-		common.NewDefaultStoreEnumerator(Name, kvdb.Instance()),
-		volume.StatsNotSupported,
-		volume.CredsNotSupported,
-		volume.CloudBackupNotSupported,
-	}, nil
+		store,
+		common.NewDefaultStatsDriver(store, usage, nil),
+		common.NewDefaultCredsDriver(Name, kvdb.Instance(), common.ClusterSecret),
+		nil,
+		common.StartUsageScanner(store, usage, common.UsageScanInterval),
+	}
+	d.CloudBackupDriver = cloudbackup.New(&cloudbackup.Config{
+		Name:   Name,
+		Driver: d,
+		Source: cloudbackup.NewDirSource(func(volumeID string) (string, error) {
+			return filepath.Join(volume.VolumeBase, volumeID), nil
+		}),
+		Store: cloudbackup.NewLocalStoreProvider(params),
+	})
+	return d, nil
 }
 
 func (d *driver) Name() string {
@@ -59,6 +77,16 @@ func (d *driver) Type() api.DriverType {
 }
 
 func (d *driver) Create(locator *api.VolumeLocator, source *api.Source, spec *api.VolumeSpec) (string, error) {
+	return d.CreateContext(context.Background(), nil, locator, source, spec)
+}
+
+func (d *driver) CreateContext(
+	ctx context.Context,
+	progress func(percent int),
+	locator *api.VolumeLocator,
+	source *api.Source,
+	spec *api.VolumeSpec,
+) (string, error) {
 	volumeID := strings.TrimSuffix(uuid.New(), "\n")
 	// Create a directory on the Local machine with this UUID.
 	if err := os.MkdirAll(filepath.Join(volume.VolumeBase, string(volumeID)), 0744); err != nil {
@@ -75,9 +103,45 @@ func (d *driver) Create(locator *api.VolumeLocator, source *api.Source, spec *ap
 	if err := d.CreateVol(v); err != nil {
 		return "", err
 	}
+	if source != nil && source.Parent != "" {
+		if _, err := d.GetVol(source.Parent); err != nil {
+			d.Delete(volumeID)
+			return "", err
+		}
+		if err := common.CloneDir(
+			d,
+			volumeID,
+			filepath.Join(volume.VolumeBase, source.Parent),
+			v.DevicePath,
+			&common.CloneOptions{Context: ctx, Progress: progress},
+		); err != nil {
+			d.Delete(volumeID)
+			return "", err
+		}
+		return v.Id, nil
+	}
 	return v.Id, d.UpdateVol(v)
 }
 
+func (d *driver) SnapshotContext(
+	ctx context.Context,
+	progress func(percent int),
+	volumeID string,
+	readonly bool,
+	locator *api.VolumeLocator,
+) (string, error) {
+	return d.Snapshot(volumeID, readonly, locator)
+}
+
+func (d *driver) RestoreContext(
+	ctx context.Context,
+	progress func(percent int),
+	volumeID string,
+	snapshotID string,
+) error {
+	return d.Restore(volumeID, snapshotID)
+}
+
 func (d *driver) Delete(volumeID string) error {
 	if _, err := d.GetVol(volumeID); err != nil {
 		return err
@@ -144,13 +208,20 @@ func (d *driver) Unmount(volumeID string, mountpath string, options map[string]s
 }
 
 func (d *driver) Set(volumeID string, locator *api.VolumeLocator, spec *api.VolumeSpec) error {
-	if spec != nil {
-		return volume.ErrNotSupported
-	}
 	v, err := d.GetVol(volumeID)
 	if err != nil {
 		return err
 	}
+	if spec != nil {
+		// vfs volumes are plain directories, so there is no backing
+		// store to grow and the size is not enforced; just record the
+		// new provisioned size for quotas.
+		size, err := common.SpecSize(v, spec)
+		if err != nil {
+			return err
+		}
+		v.Spec.Size = size
+	}
 	if locator != nil {
 		v.Locator = locator
 	}
@@ -161,7 +232,9 @@ func (d *driver) Status() [][2]string {
 	return [][2]string{}
 }
 
-func (d *driver) Shutdown() {}
+func (d *driver) Shutdown() {
+	d.stopScan()
+}
 
 func (d *driver) fsFreeze(volumeID string, freeze bool) error {
 	v, err := d.GetVol(volumeID)
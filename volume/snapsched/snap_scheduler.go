@@ -0,0 +1,330 @@
+package snapsched
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/portworx/kvdb"
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/proto/time"
+	"github.com/libopenstorage/openstorage/pkg/sched"
+	"github.com/libopenstorage/openstorage/volume"
+)
+
+type volumeSchedule struct {
+	// schedule is the schedule string the tasks were created from
+	schedule string
+	// tasks scheduled for this volume, one per interval
+	tasks []sched.TaskID
+}
+
+type snapScheduler struct {
+	sync.Mutex
+	name   string
+	driver volume.VolumeDriver
+	kv     kvdb.Kvdb
+	sched  sched.Scheduler
+	nodeID string
+	// volumes maps volume IDs to their registered schedule
+	volumes map[string]*volumeSchedule
+	// refreshTask periodically calls Refresh while started
+	refreshTask sched.TaskID
+}
+
+func newSnapScheduler(
+	name string,
+	d volume.VolumeDriver,
+	kv kvdb.Kvdb,
+	s sched.Scheduler,
+	nodeID string,
+) *snapScheduler {
+	return &snapScheduler{
+		name:        name,
+		driver:      d,
+		kv:          kv,
+		sched:       s,
+		nodeID:      nodeID,
+		volumes:     make(map[string]*volumeSchedule),
+		refreshTask: sched.TaskNone,
+	}
+}
+
+func (s *snapScheduler) Start() error {
+	if err := s.Refresh(); err != nil {
+		return err
+	}
+	taskID, err := s.sched.Schedule(
+		func(sched.Interval) {
+			if err := s.Refresh(); err != nil {
+				dlog.Warnf("Failed to refresh snapshot schedules for %v: %v",
+					s.name, err)
+			}
+		},
+		sched.Periodic(refreshInterval),
+		time.Now(),
+		false,
+	)
+	if err != nil {
+		return err
+	}
+	s.Lock()
+	s.refreshTask = taskID
+	s.Unlock()
+	return nil
+}
+
+func (s *snapScheduler) Stop() {
+	s.Lock()
+	defer s.Unlock()
+
+	if sched.ValidTaskID(s.refreshTask) {
+		s.sched.Cancel(s.refreshTask)
+		s.refreshTask = sched.TaskNone
+	}
+	for volumeID, vs := range s.volumes {
+		s.cancel(volumeID, vs)
+	}
+}
+
+func (s *snapScheduler) Refresh() error {
+	vols, err := s.driver.Enumerate(&api.VolumeLocator{}, nil)
+	if err != nil {
+		return err
+	}
+
+	s.Lock()
+	defer s.Unlock()
+
+	seen := make(map[string]bool)
+	for _, v := range vols {
+		// Snapshots and clones inherit the spec of their parent, do not
+		// snapshot them in turn.
+		if fromParent(v) {
+			continue
+		}
+		schedule := scheduleString(v.Spec)
+		if schedule == "" {
+			continue
+		}
+		seen[v.Id] = true
+		if vs, ok := s.volumes[v.Id]; ok {
+			if vs.schedule == schedule {
+				s.lead(v.Id)
+				continue
+			}
+			s.cancel(v.Id, vs)
+		}
+		if err := s.register(v.Id, schedule); err != nil {
+			dlog.Warnf("Invalid snapshot schedule %q for volume %v: %v",
+				schedule, v.Id, err)
+			continue
+		}
+		s.lead(v.Id)
+	}
+
+	for volumeID, vs := range s.volumes {
+		if !seen[volumeID] {
+			s.cancel(volumeID, vs)
+		}
+	}
+	return nil
+}
+
+// register schedules a snapshot task for each interval in schedule.
+// Called with the lock held.
+func (s *snapScheduler) register(volumeID string, schedule string) error {
+	intervals, policies, err := sched.ParseScheduleAndPolicies(schedule)
+	if err != nil {
+		return err
+	}
+	if policies != nil {
+		dlog.Warnf("Ignoring schedule policies %v for volume %v",
+			policies, volumeID)
+	}
+
+	vs := &volumeSchedule{schedule: schedule}
+	for _, iv := range sched.SetupIntvWithDefaults(intervals) {
+		taskID, err := s.sched.Schedule(
+			func(iv sched.Interval) {
+				s.snap(volumeID, iv)
+			},
+			iv,
+			time.Now(),
+			false,
+		)
+		if err != nil {
+			for _, t := range vs.tasks {
+				s.sched.Cancel(t)
+			}
+			return err
+		}
+		vs.tasks = append(vs.tasks, taskID)
+	}
+	s.volumes[volumeID] = vs
+	dlog.Infof("Registered snapshot schedule for volume %v: %v",
+		volumeID, sched.ScheduleSummary(intervals, policies))
+	return nil
+}
+
+// cancel removes the tasks for volumeID and releases its lease.
+// Called with the lock held.
+func (s *snapScheduler) cancel(volumeID string, vs *volumeSchedule) {
+	for _, t := range vs.tasks {
+		if err := s.sched.Cancel(t); err != nil {
+			dlog.Warnf("Failed to cancel snapshot task for volume %v: %v",
+				volumeID, err)
+		}
+	}
+	delete(s.volumes, volumeID)
+	s.release(volumeID)
+}
+
+// snap takes a scheduled snapshot of volumeID and prunes older snapshots
+// taken for the same interval type.
+func (s *snapScheduler) snap(volumeID string, iv sched.Interval) {
+	if !s.lead(volumeID) {
+		return
+	}
+
+	vols, err := s.driver.Inspect([]string{volumeID})
+	if err == nil && len(vols) == 0 {
+		err = volume.ErrEnoEnt
+	}
+	if err != nil {
+		dlog.Warnf("Scheduled snapshot of volume %v failed: %v", volumeID, err)
+		return
+	}
+	name := vols[0].Locator.Name
+	if name == "" {
+		name = volumeID
+	}
+
+	locator := &api.VolumeLocator{
+		Name: fmt.Sprintf("%s.%s.%s",
+			name, iv.IntervalType(), time.Now().UTC().Format("20060102-150405")),
+		VolumeLabels: map[string]string{
+			LabelScheduleParent:   volumeID,
+			LabelScheduleInterval: iv.IntervalType(),
+		},
+	}
+	snapID, err := s.driver.Snapshot(volumeID, true, locator)
+	if err != nil {
+		dlog.Warnf("Scheduled snapshot of volume %v failed: %v", volumeID, err)
+		return
+	}
+	dlog.Infof("Scheduled %v snapshot %v of volume %v",
+		iv.IntervalType(), snapID, volumeID)
+
+	if ri, ok := iv.(sched.RetainInterval); ok {
+		if err := s.prune(volumeID, iv.IntervalType(), ri.RetainNumber()); err != nil {
+			dlog.Warnf("Failed to prune snapshots of volume %v: %v",
+				volumeID, err)
+		}
+	}
+}
+
+// prune deletes the oldest scheduled snapshots of volumeID for the given
+// interval type so that at most retain remain.
+func (s *snapScheduler) prune(volumeID string, intervalType string, retain uint32) error {
+	if retain == 0 {
+		return nil
+	}
+	labels := map[string]string{
+		LabelScheduleParent:   volumeID,
+		LabelScheduleInterval: intervalType,
+	}
+	all, err := s.driver.SnapEnumerate([]string{volumeID}, labels)
+	if err != nil {
+		return err
+	}
+	snaps := make([]*api.Volume, 0, len(all))
+	for _, v := range all {
+		if v.Locator != nil && hasLabels(v.Locator.VolumeLabels, labels) {
+			snaps = append(snaps, v)
+		}
+	}
+	if len(snaps) <= int(retain) {
+		return nil
+	}
+
+	sort.Slice(snaps, func(i, j int) bool {
+		return prototime.TimestampLess(snaps[i].Ctime, snaps[j].Ctime)
+	})
+	for _, v := range snaps[:len(snaps)-int(retain)] {
+		if err := s.driver.Delete(v.Id); err != nil {
+			return err
+		}
+		dlog.Infof("Pruned %v snapshot %v of volume %v",
+			intervalType, v.Id, volumeID)
+	}
+	return nil
+}
+
+// lead returns true if this node owns the schedule of volumeID, acquiring
+// or renewing the lease as needed. The lease is only renewed if it is still
+// held by this node, so that a node whose lease expired never overwrites
+// the lease of the node which took over.
+func (s *snapScheduler) lead(volumeID string) bool {
+	key := s.leaseKey(volumeID)
+	_, err := s.kv.Create(key, s.nodeID, leaseTTL)
+	if err == nil {
+		return true
+	}
+	if err != kvdb.ErrExist {
+		dlog.Warnf("Failed to acquire snapshot lease for volume %v: %v",
+			volumeID, err)
+		return false
+	}
+	kvp := &kvdb.KVPair{Key: key, Value: []byte(s.nodeID), TTL: int64(leaseTTL)}
+	if _, err := s.kv.CompareAndSet(kvp, kvdb.KVTTL, []byte(s.nodeID)); err != nil {
+		if err != kvdb.ErrValueMismatch && err != kvdb.ErrNotFound {
+			dlog.Warnf("Failed to renew snapshot lease for volume %v: %v",
+				volumeID, err)
+		}
+		return false
+	}
+	return true
+}
+
+// release gives up the lease on volumeID if held by this node.
+func (s *snapScheduler) release(volumeID string) {
+	kvp := &kvdb.KVPair{Key: s.leaseKey(volumeID), Value: []byte(s.nodeID)}
+	s.kv.CompareAndDelete(kvp, kvdb.KVFlags(0))
+}
+
+func (s *snapScheduler) leaseKey(volumeID string) string {
+	return fmt.Sprintf("%s/%s/snapsched/%s", keyBase, s.name, volumeID)
+}
+
+// scheduleString returns the snapshot schedule of spec. The legacy
+// snapshot interval, in minutes, is used if no schedule is set.
+func scheduleString(spec *api.VolumeSpec) string {
+	if spec == nil {
+		return ""
+	}
+	if spec.SnapshotSchedule != "" {
+		return spec.SnapshotSchedule
+	}
+	if spec.SnapshotInterval > 0 {
+		return fmt.Sprintf("%s=%d", sched.PeriodicType, spec.SnapshotInterval)
+	}
+	return ""
+}
+
+// fromParent returns true for snapshots and clones, scheduled or not.
+func fromParent(v *api.Volume) bool {
+	return v.GetSource().GetParent() != ""
+}
+
+func hasLabels(set map[string]string, subset map[string]string) bool {
+	for k, v := range subset {
+		if set[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+// Package snapsched takes periodic snapshots of volumes according to the
+// snapshot schedule in their VolumeSpec.
+package snapsched
+
+import (
+	"time"
+
+	"github.com/libopenstorage/openstorage/pkg/sched"
+	"github.com/libopenstorage/openstorage/volume"
+	"github.com/portworx/kvdb"
+)
+
+const (
+	// LabelScheduleParent is set on every scheduled snapshot to the ID of
+	// the volume it was taken from.
+	LabelScheduleParent = "snap_sched_parent"
+	// LabelScheduleInterval is set on every scheduled snapshot to the type
+	// of the interval (periodic, daily, weekly, monthly) that triggered it.
+	// Snapshots are pruned per interval type.
+	LabelScheduleInterval = "snap_sched_interval"
+)
+
+const (
+	keyBase = "openstorage"
+	// refreshInterval is how often volume schedules are re-read and
+	// leases are renewed.
+	refreshInterval = time.Minute
+	// leaseTTL is how long, in seconds, a node keeps ownership of a
+	// volume's schedule without renewing it.
+	leaseTTL = 3 * uint64(refreshInterval/time.Second)
+)
+
+// SnapScheduler snapshots volumes on the schedule set in their spec and
+// prunes scheduled snapshots beyond the retain count of each interval.
+// In a cluster every node may run a SnapScheduler; a lease in kvdb makes
+// sure only one node snapshots a given volume.
+type SnapScheduler interface {
+	// Start registers the schedules of all volumes and keeps them up to
+	// date as volumes are created, updated and deleted.
+	Start() error
+	// Stop cancels all schedules and gives up the leases held by this node.
+	Stop()
+	// Refresh re-reads the schedules of all volumes.
+	Refresh() error
+}
+
+// New returns a SnapScheduler for the volume driver registered as name.
+// Scheduled tasks run on s and nodeID identifies this node in the leases.
+func New(
+	name string,
+	d volume.VolumeDriver,
+	kv kvdb.Kvdb,
+	s sched.Scheduler,
+	nodeID string,
+) SnapScheduler {
+	return newSnapScheduler(name, d, kv, s, nodeID)
+}
@@ -0,0 +1,175 @@
+package snapsched
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/portworx/kvdb"
+	"github.com/portworx/kvdb/mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.pedge.io/dlog"
+
+	"github.com/libopenstorage/openstorage/api"
+	"github.com/libopenstorage/openstorage/pkg/proto/time"
+	"github.com/libopenstorage/openstorage/pkg/sched"
+	mockdriver "github.com/libopenstorage/openstorage/volume/drivers/mock"
+)
+
+const testDriverName = "snapsched_test"
+
+func newTestKvdb(t *testing.T) kvdb.Kvdb {
+	kv, err := kvdb.New(mem.Name, "snapsched_test", []string{}, nil, dlog.Panicf)
+	require.NoError(t, err)
+	return kv
+}
+
+func newTestVolume(id string, schedule string) *api.Volume {
+	return &api.Volume{
+		Id:      id,
+		Locator: &api.VolumeLocator{Name: id},
+		Spec:    &api.VolumeSpec{SnapshotSchedule: schedule},
+	}
+}
+
+func newTestSnap(id string, parent string, ctime time.Time) *api.Volume {
+	return &api.Volume{
+		Id: id,
+		Locator: &api.VolumeLocator{
+			Name: id,
+			VolumeLabels: map[string]string{
+				LabelScheduleParent:   parent,
+				LabelScheduleInterval: sched.PeriodicType,
+			},
+		},
+		Source: &api.Source{Parent: parent},
+		Ctime:  prototime.TimeToTimestamp(ctime),
+	}
+}
+
+func TestScheduleString(t *testing.T) {
+	assert.Equal(t, "", scheduleString(nil))
+	assert.Equal(t, "", scheduleString(&api.VolumeSpec{}))
+	assert.Equal(t, "periodic=30",
+		scheduleString(&api.VolumeSpec{SnapshotInterval: 30}))
+	assert.Equal(t, "daily=12:00,3",
+		scheduleString(&api.VolumeSpec{
+			SnapshotInterval: 30,
+			SnapshotSchedule: "daily=12:00,3",
+		}))
+}
+
+func TestLease(t *testing.T) {
+	kv := newTestKvdb(t)
+	s1 := newSnapScheduler(testDriverName, nil, kv, nil, "node1")
+	s2 := newSnapScheduler(testDriverName, nil, kv, nil, "node2")
+
+	assert.True(t, s1.lead("vol1"))
+	assert.True(t, s1.lead("vol1"), "leader must be able to renew its lease")
+	assert.False(t, s2.lead("vol1"))
+
+	s2.release("vol1")
+	assert.False(t, s2.lead("vol1"), "only the owner can release a lease")
+
+	s1.release("vol1")
+	assert.True(t, s2.lead("vol1"))
+	assert.False(t, s1.lead("vol1"))
+
+	// A node never renews a lease taken over by another node.
+	_, err := kv.Put(s2.leaseKey("vol1"), "node3", 0)
+	require.NoError(t, err)
+	assert.False(t, s2.lead("vol1"))
+	kvp, err := kv.Get(s2.leaseKey("vol1"))
+	require.NoError(t, err)
+	assert.Equal(t, "node3", string(kvp.Value))
+}
+
+func TestRefresh(t *testing.T) {
+	mc := gomock.NewController(t)
+	defer mc.Finish()
+	d := mockdriver.NewMockVolumeDriver(mc)
+	sc := sched.New(time.Second)
+	defer sc.Stop()
+	s := newSnapScheduler(testDriverName, d, newTestKvdb(t), sc, "node1")
+
+	// Snapshots, scheduled or manual, inherit the schedule of their parent
+	// but are not snapshotted.
+	snap := newTestSnap("snap1", "vol1", time.Now())
+	snap.Spec = &api.VolumeSpec{SnapshotSchedule: "periodic=60"}
+	manual := newTestVolume("manual1", "periodic=60,2;daily=12:00")
+	manual.Source = &api.Source{Parent: "vol1"}
+	manual.Readonly = true
+	d.EXPECT().
+		Enumerate(gomock.Any(), nil).
+		Return([]*api.Volume{
+			newTestVolume("vol1", "periodic=60,2;daily=12:00"),
+			newTestVolume("vol2", ""),
+			snap,
+			manual,
+		}, nil)
+	require.NoError(t, s.Refresh())
+	require.Len(t, s.volumes, 1)
+	require.Contains(t, s.volumes, "vol1")
+	assert.Len(t, s.volumes["vol1"].tasks, 2)
+
+	// Changing the schedule replaces the tasks.
+	d.EXPECT().
+		Enumerate(gomock.Any(), nil).
+		Return([]*api.Volume{newTestVolume("vol1", "weekly=sunday@02:00")}, nil)
+	require.NoError(t, s.Refresh())
+	require.Contains(t, s.volumes, "vol1")
+	assert.Len(t, s.volumes["vol1"].tasks, 1)
+	assert.Equal(t, "weekly=sunday@02:00", s.volumes["vol1"].schedule)
+
+	// Deleted volumes are unscheduled and their lease released.
+	d.EXPECT().
+		Enumerate(gomock.Any(), nil).
+		Return([]*api.Volume{}, nil)
+	require.NoError(t, s.Refresh())
+	assert.Len(t, s.volumes, 0)
+	_, err := s.kv.Get(s.leaseKey("vol1"))
+	assert.Equal(t, kvdb.ErrNotFound, err)
+}
+
+func TestSnapAndPrune(t *testing.T) {
+	mc := gomock.NewController(t)
+	defer mc.Finish()
+	d := mockdriver.NewMockVolumeDriver(mc)
+	s := newSnapScheduler(testDriverName, d, newTestKvdb(t), nil, "node1")
+
+	intervals, _, err := sched.ParseScheduleAndPolicies("periodic=60,2")
+	require.NoError(t, err)
+	iv := intervals[0]
+
+	now := time.Now()
+	snaps := []*api.Volume{
+		newTestSnap("snap3", "vol1", now),
+		newTestSnap("snap1", "vol1", now.Add(-2*time.Hour)),
+		newTestSnap("snap2", "vol1", now.Add(-time.Hour)),
+	}
+	gomock.InOrder(
+		d.EXPECT().
+			Inspect([]string{"vol1"}).
+			Return([]*api.Volume{newTestVolume("vol1", "periodic=60,2")}, nil),
+		d.EXPECT().
+			Snapshot("vol1", true, gomock.Any()).
+			Do(func(id string, ro bool, locator *api.VolumeLocator) {
+				assert.Equal(t, "vol1", locator.VolumeLabels[LabelScheduleParent])
+				assert.Equal(t, sched.PeriodicType,
+					locator.VolumeLabels[LabelScheduleInterval])
+			}).
+			Return("snap3", nil),
+		d.EXPECT().
+			SnapEnumerate([]string{"vol1"}, gomock.Any()).
+			Return(snaps, nil),
+		d.EXPECT().
+			Delete("snap1").
+			Return(nil),
+	)
+	s.snap("vol1", iv)
+
+	// Another node holding the lease must not take the snapshot.
+	other := newSnapScheduler(testDriverName, d, s.kv, nil, "node2")
+	other.snap("vol1", iv)
+}
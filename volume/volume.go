@@ -1,6 +1,7 @@
 package volume
 
 import (
+	"context"
 	"errors"
 
 	"github.com/libopenstorage/openstorage/api"
@@ -36,6 +37,8 @@ var (
 	ErrNotSupported = errors.New("Operation not supported")
 	// ErrVolBusy returned when volume is in busy state
 	ErrVolBusy = errors.New("Volume is busy")
+	// ErrVolShrink returned when a resize would reduce the volume size
+	ErrVolShrink = errors.New("Volume size cannot be reduced")
 )
 
 // Constants used by the VolumeDriver
@@ -130,6 +133,35 @@ type QuiesceDriver interface {
 	Unquiesce(volumeID string) error
 }
 
+// ContextDriver is an optional interface of drivers whose operations copying
+// the data of a volume can be cancelled and report their progress. ctx and
+// progress are those of the job running the operation, progress may be nil.
+type ContextDriver interface {
+	// CreateContext is Create, copying the data of source.Parent if set.
+	CreateContext(
+		ctx context.Context,
+		progress func(percent int),
+		locator *api.VolumeLocator,
+		source *api.Source,
+		spec *api.VolumeSpec,
+	) (string, error)
+	// SnapshotContext is Snapshot.
+	SnapshotContext(
+		ctx context.Context,
+		progress func(percent int),
+		volumeID string,
+		readonly bool,
+		locator *api.VolumeLocator,
+	) (string, error)
+	// RestoreContext is Restore.
+	RestoreContext(
+		ctx context.Context,
+		progress func(percent int),
+		volumeID string,
+		snapshotID string,
+	) error
+}
+
 // CloudBackupDriver interface provides Cloud backup features
 type CloudBackupDriver interface {
 	// Backup uploads snapshot of a volume to cloud